@@ -58,7 +58,9 @@ type Config struct {
 
 	// APIOptions provides the set of middleware mutations modify how the API
 	// client requests will be handled. This is useful for adding additional
-	// tracing data to a request, or changing behavior of the SDK's client.
+	// tracing data to a request, or changing behavior of the SDK's client. The
+	// aws/middleware/tracing package provides middleware for tracing the
+	// operations of API clients.
 	APIOptions []func(*middleware.Stack) error
 
 	// The logger writer interface to write logging messages to. Defaults to
@@ -21,6 +21,8 @@ const (
 	LogRequestWithBody
 	LogResponse
 	LogResponseWithBody
+	LogRequestEventMessage
+	LogResponseEventMessage
 )
 
 // IsSigning returns whether the Signing logging mode bit is set
@@ -53,6 +55,16 @@ func (m ClientLogMode) IsResponseWithBody() bool {
 	return m&LogResponseWithBody != 0
 }
 
+// IsRequestEventMessage returns whether the RequestEventMessage logging mode bit is set
+func (m ClientLogMode) IsRequestEventMessage() bool {
+	return m&LogRequestEventMessage != 0
+}
+
+// IsResponseEventMessage returns whether the ResponseEventMessage logging mode bit is set
+func (m ClientLogMode) IsResponseEventMessage() bool {
+	return m&LogResponseEventMessage != 0
+}
+
 // ClearSigning clears the Signing logging mode bit
 func (m *ClientLogMode) ClearSigning() {
 	*m &^= LogSigning
@@ -82,3 +94,13 @@ func (m *ClientLogMode) ClearResponse() {
 func (m *ClientLogMode) ClearResponseWithBody() {
 	*m &^= LogResponseWithBody
 }
+
+// ClearRequestEventMessage clears the RequestEventMessage logging mode bit
+func (m *ClientLogMode) ClearRequestEventMessage() {
+	*m &^= LogRequestEventMessage
+}
+
+// ClearResponseEventMessage clears the ResponseEventMessage logging mode bit
+func (m *ClientLogMode) ClearResponseEventMessage() {
+	*m &^= LogResponseEventMessage
+}
@@ -19,6 +19,8 @@ var config = struct {
 		"RequestWithBody",
 		"Response",
 		"ResponseWithBody",
+		"RequestEventMessage",
+		"ResponseEventMessage",
 	},
 }
 
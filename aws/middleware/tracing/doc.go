@@ -0,0 +1,21 @@
+// Package tracing provides middleware for tracing the operations of API
+// clients with a Tracer, without a dependency on a specific tracing library.
+//
+// The tracing middleware starts a span for each operation, and a child span
+// for each attempt of the operation. Operation spans record the service ID,
+// operation name, region, request ID, retry count, and HTTP status code of the
+// operation. Attempt spans record the attempt number, request ID, and HTTP
+// status code of the attempt.
+//
+// The trace is propagated to the service with the X-Amzn-Trace-Id header of
+// each attempt's request, if the attempt span implements TraceHeaderSpan, or
+// the trace ID of the AWS Lambda function invocation is available. A header
+// already set on the request is not replaced.
+//
+// Add the tracing middleware to all API clients created from a Config with
+// the Config's APIOptions.
+//
+//     cfg.APIOptions = append(cfg.APIOptions, tracing.WithTracer(tracer))
+//
+//     client := s3.NewFromConfig(cfg)
+package tracing
@@ -0,0 +1,236 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// Attribute keys of the values recorded on spans. The keys follow the
+// OpenTelemetry semantic conventions for AWS SDK spans where one exists.
+const (
+	AttributeRPCSystem      = "rpc.system"
+	AttributeServiceID      = "rpc.service"
+	AttributeOperation      = "rpc.method"
+	AttributeRegion         = "aws.region"
+	AttributeRequestID      = "aws.request_id"
+	AttributeRetryCount     = "aws.retry_count"
+	AttributeAttempt        = "aws.attempt"
+	AttributeHTTPStatusCode = "http.status_code"
+)
+
+// rpcSystem is the value of the AttributeRPCSystem attribute of operation
+// spans.
+const rpcSystem = "aws-api"
+
+const (
+	traceIDHeader = "X-Amzn-Trace-Id"
+
+	// The environment variable AWS Lambda sets to the trace ID of the
+	// function invocation.
+	traceIDEnvVar = "_X_AMZN_TRACE_ID"
+)
+
+// Tracer starts spans for the operations, and operation attempts, of API
+// clients. Implement Tracer to adapt a tracing library, such as OpenTelemetry,
+// to the SDK.
+type Tracer interface {
+	// StartSpan starts a span with the name as a child of the span in the
+	// context, if any. Returns the context with the started span.
+	StartSpan(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer.
+type Span interface {
+	// SetAttribute sets the attribute of the span with the key to the value.
+	SetAttribute(key string, value interface{})
+
+	// RecordError records the error the span's operation failed with.
+	RecordError(err error)
+
+	// End ends the span.
+	End()
+}
+
+// TraceHeaderSpan is a span that provides the X-Amzn-Trace-Id header value
+// to propagate the span's trace to the service with. Attempt spans
+// implementing TraceHeaderSpan set the header of the attempt's request.
+type TraceHeaderSpan interface {
+	Span
+
+	// TraceHeader returns the X-Amzn-Trace-Id header value of the span.
+	TraceHeader() string
+}
+
+// AddTracingMiddlewaresOptions provides the options for the
+// AddTracingMiddlewares middleware setup.
+type AddTracingMiddlewaresOptions struct {
+	// The Tracer to start spans with.
+	Tracer Tracer
+}
+
+// WithTracer returns a stack mutator adding the tracing middlewares with the
+// Tracer to the stack. Use with the APIOptions of the aws.Config, or an API
+// client's Options, to trace the operations of API clients.
+//
+//     cfg.APIOptions = append(cfg.APIOptions, tracing.WithTracer(tracer))
+func WithTracer(tracer Tracer) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return AddTracingMiddlewares(stack, AddTracingMiddlewaresOptions{Tracer: tracer})
+	}
+}
+
+// AddTracingMiddlewares adds the middlewares starting a span for the
+// operation, and a span for each attempt of the operation, to the stack.
+func AddTracingMiddlewares(stack *middleware.Stack, options AddTracingMiddlewaresOptions) error {
+	if options.Tracer == nil {
+		return errors.New("tracer is required")
+	}
+
+	operation := &operationSpan{tracer: options.Tracer}
+	if err := stack.Initialize.Insert(operation, (*awsmiddleware.RegisterServiceMetadata)(nil).ID(), middleware.After); err != nil {
+		if err := stack.Initialize.Add(operation, middleware.Before); err != nil {
+			return err
+		}
+	}
+
+	attempt := &attemptSpan{tracer: options.Tracer}
+	if err := stack.Finalize.Insert(attempt, "Retry", middleware.After); err != nil {
+		return stack.Finalize.Add(attempt, middleware.Before)
+	}
+	return nil
+}
+
+// attemptCountKey is the context key of the number of attempts of the
+// operation.
+type attemptCountKey struct{}
+
+// operationSpan starts a span for the operation.
+type operationSpan struct {
+	tracer Tracer
+}
+
+// ID returns the middleware identifier.
+func (*operationSpan) ID() string { return "TracingOperationSpan" }
+
+// HandleInitialize starts a span for the operation, and records the service
+// and operation of the span, and the response of the operation's last
+// attempt.
+func (m *operationSpan) HandleInitialize(
+	ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler,
+) (
+	out middleware.InitializeOutput, metadata middleware.Metadata, err error,
+) {
+	serviceID := awsmiddleware.GetServiceID(ctx)
+	operationName := awsmiddleware.GetOperationName(ctx)
+
+	ctx, span := m.tracer.StartSpan(ctx, serviceID+"."+operationName)
+	defer span.End()
+
+	span.SetAttribute(AttributeRPCSystem, rpcSystem)
+	span.SetAttribute(AttributeServiceID, serviceID)
+	span.SetAttribute(AttributeOperation, operationName)
+	if region := awsmiddleware.GetRegion(ctx); len(region) != 0 {
+		span.SetAttribute(AttributeRegion, region)
+	}
+
+	var attempts int
+	ctx = middleware.WithStackValue(ctx, attemptCountKey{}, &attempts)
+
+	out, metadata, err = next.HandleInitialize(ctx, in)
+
+	if attempts > 0 {
+		span.SetAttribute(AttributeRetryCount, attempts-1)
+	}
+
+	// The response metadata of each attempt is recorded by the retry
+	// middleware in the operation's attempt results.
+	responseMetadata := metadata
+	if results, ok := retry.GetAttemptResults(metadata); ok && len(results.Results) != 0 {
+		responseMetadata = results.Results[len(results.Results)-1].ResponseMetadata
+	}
+	recordResponse(span, responseMetadata, err)
+
+	return out, metadata, err
+}
+
+// attemptSpan starts a span for each attempt of the operation.
+type attemptSpan struct {
+	tracer Tracer
+}
+
+// ID returns the middleware identifier.
+func (*attemptSpan) ID() string { return "TracingAttemptSpan" }
+
+// HandleFinalize starts a span for the attempt, propagates the trace with
+// the X-Amzn-Trace-Id header of the attempt's request, and records the
+// attempt's response.
+func (m *attemptSpan) HandleFinalize(
+	ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler,
+) (
+	out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+) {
+	ctx, span := m.tracer.StartSpan(ctx, "Attempt")
+	defer span.End()
+
+	if attempts, ok := middleware.GetStackValue(ctx, attemptCountKey{}).(*int); ok {
+		*attempts++
+		span.SetAttribute(AttributeAttempt, *attempts)
+	}
+
+	if req, ok := in.Request.(*smithyhttp.Request); ok && len(req.Header.Get(traceIDHeader)) == 0 {
+		if traceID := traceHeader(span); len(traceID) != 0 {
+			req.Header.Set(traceIDHeader, traceID)
+		}
+	}
+
+	out, metadata, err = next.HandleFinalize(ctx, in)
+	recordResponse(span, metadata, err)
+
+	return out, metadata, err
+}
+
+// traceHeader returns the X-Amzn-Trace-Id header value of the span, or the
+// trace ID of the AWS Lambda function invocation if the span does not
+// provide one.
+func traceHeader(span Span) string {
+	if s, ok := span.(TraceHeaderSpan); ok {
+		if v := s.TraceHeader(); len(v) != 0 {
+			return v
+		}
+	}
+	return os.Getenv(traceIDEnvVar)
+}
+
+// recordResponse records the request ID and HTTP status code of the
+// response, and the error the request failed with, on the span.
+func recordResponse(span Span, metadata middleware.Metadata, err error) {
+	requestID, ok := awsmiddleware.GetRequestIDMetadata(metadata)
+	if !ok && err != nil {
+		var reqIDErr interface{ ServiceRequestID() string }
+		if errors.As(err, &reqIDErr) {
+			requestID = reqIDErr.ServiceRequestID()
+		}
+	}
+	if len(requestID) != 0 {
+		span.SetAttribute(AttributeRequestID, requestID)
+	}
+
+	if resp, ok := awsmiddleware.GetRawResponse(metadata).(*smithyhttp.Response); ok && resp != nil {
+		span.SetAttribute(AttributeHTTPStatusCode, resp.StatusCode)
+	} else {
+		var respErr interface{ HTTPStatusCode() int }
+		if errors.As(err, &respErr) {
+			span.SetAttribute(AttributeHTTPStatusCode, respErr.HTTPStatusCode())
+		}
+	}
+
+	if err != nil {
+		span.RecordError(err)
+	}
+}
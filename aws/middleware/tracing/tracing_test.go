@@ -0,0 +1,321 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// memoryTracer is an in-memory Tracer recording the spans it started.
+type memoryTracer struct {
+	mu    sync.Mutex
+	spans []*memorySpan
+
+	traceHeader string
+}
+
+type memorySpanKey struct{}
+
+func (t *memoryTracer) StartSpan(ctx context.Context, name string) (context.Context, Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	span := &memorySpan{
+		name:        name,
+		attributes:  map[string]interface{}{},
+		traceHeader: t.traceHeader,
+	}
+	if parent, ok := ctx.Value(memorySpanKey{}).(*memorySpan); ok {
+		span.parent = parent.name
+	}
+	t.spans = append(t.spans, span)
+
+	return context.WithValue(ctx, memorySpanKey{}, span), span
+}
+
+func (t *memoryTracer) Spans() []*memorySpan {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]*memorySpan(nil), t.spans...)
+}
+
+type memorySpan struct {
+	name        string
+	parent      string
+	attributes  map[string]interface{}
+	errs        []error
+	ended       bool
+	traceHeader string
+}
+
+func (s *memorySpan) SetAttribute(key string, value interface{}) { s.attributes[key] = value }
+func (s *memorySpan) RecordError(err error)                      { s.errs = append(s.errs, err) }
+func (s *memorySpan) End()                                       { s.ended = true }
+
+// memoryTraceHeaderSpan is a memorySpan that propagates its trace header.
+type memoryTraceHeaderSpan struct {
+	*memorySpan
+}
+
+func (s memoryTraceHeaderSpan) TraceHeader() string { return s.traceHeader }
+
+type traceHeaderTracer struct {
+	*memoryTracer
+}
+
+func (t traceHeaderTracer) StartSpan(ctx context.Context, name string) (context.Context, Span) {
+	ctx, span := t.memoryTracer.StartSpan(ctx, name)
+	return ctx, memoryTraceHeaderSpan{span.(*memorySpan)}
+}
+
+type testResponse struct {
+	statusCode int
+	requestID  string
+}
+
+// invokeTestOperation invokes an operation with the tracing middleware,
+// responding to each attempt with the next response.
+func invokeTestOperation(t *testing.T, tracer Tracer, responses []testResponse) ([]http.Header, error) {
+	t.Helper()
+
+	stack := middleware.NewStack("TestOperation", smithyhttp.NewStackRequest)
+	stack.Initialize.Add(&awsmiddleware.RegisterServiceMetadata{
+		ServiceID:     "Test Service",
+		Region:        "us-west-2",
+		OperationName: "TestOperation",
+	}, middleware.Before)
+	stack.Deserialize.Add(middleware.DeserializeMiddlewareFunc("OperationDeserializer",
+		func(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+			out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+		) {
+			out, metadata, err = next.HandleDeserialize(ctx, in)
+			if err != nil {
+				return out, metadata, err
+			}
+			resp := out.RawResponse.(*smithyhttp.Response)
+			if resp.StatusCode >= 300 {
+				return out, metadata, &smithy.GenericAPIError{Code: "InternalFailure"}
+			}
+			return out, metadata, err
+		}), middleware.After)
+
+	retryer := retry.NewStandard(func(o *retry.StandardOptions) {
+		o.Backoff = retry.BackoffDelayerFunc(func(int, error) (time.Duration, error) {
+			return 0, nil
+		})
+	})
+	for _, fn := range []func(*middleware.Stack) error{
+		func(s *middleware.Stack) error {
+			return retry.AddRetryMiddlewares(s, retry.AddRetryMiddlewaresOptions{Retryer: retryer})
+		},
+		awsmiddleware.AddRequestIDRetrieverMiddleware,
+		awsmiddleware.AddRawResponseToMetadata,
+		awshttp.AddResponseErrorMiddleware,
+		WithTracer(tracer),
+	} {
+		if err := fn(stack); err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+	}
+
+	var headers []http.Header
+	handler := middleware.DecorateHandler(smithyhttp.NewClientHandler(smithyhttp.ClientDoFunc(
+		func(r *http.Request) (*http.Response, error) {
+			headers = append(headers, r.Header.Clone())
+			resp := responses[len(headers)-1]
+			return &http.Response{
+				StatusCode: resp.statusCode,
+				Header:     http.Header{"X-Amzn-Requestid": []string{resp.requestID}},
+				Body:       ioutil.NopCloser(strings.NewReader("")),
+			}, nil
+		})), stack)
+
+	_, _, err := handler.Handle(context.Background(), struct{}{})
+	return headers, err
+}
+
+func TestTracing(t *testing.T) {
+	tracer := &memoryTracer{}
+	_, err := invokeTestOperation(t, tracer, []testResponse{
+		{statusCode: 500, requestID: "request-1"},
+		{statusCode: 200, requestID: "request-2"},
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	spans := tracer.Spans()
+	if e, a := 3, len(spans); e != a {
+		t.Fatalf("expect %v spans, got %v", e, a)
+	}
+
+	operation := spans[0]
+	if e, a := "Test Service.TestOperation", operation.name; e != a {
+		t.Errorf("expect %v operation span, got %v", e, a)
+	}
+	expectAttributes := map[string]interface{}{
+		AttributeRPCSystem:      "aws-api",
+		AttributeServiceID:      "Test Service",
+		AttributeOperation:      "TestOperation",
+		AttributeRegion:         "us-west-2",
+		AttributeRetryCount:     1,
+		AttributeRequestID:      "request-2",
+		AttributeHTTPStatusCode: 200,
+	}
+	if e, a := expectAttributes, operation.attributes; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v operation attributes, got %v", e, a)
+	}
+	if len(operation.errs) != 0 {
+		t.Errorf("expect no operation errors, got %v", operation.errs)
+	}
+
+	for i, attempt := range spans[1:] {
+		if e, a := "Attempt", attempt.name; e != a {
+			t.Errorf("expect %v attempt span, got %v", e, a)
+		}
+		if e, a := operation.name, attempt.parent; e != a {
+			t.Errorf("expect %v parent span, got %v", e, a)
+		}
+		if e, a := i+1, attempt.attributes[AttributeAttempt]; e != a {
+			t.Errorf("expect %v attempt, got %v", e, a)
+		}
+		if e, a := fmt.Sprintf("request-%d", i+1), attempt.attributes[AttributeRequestID]; e != a {
+			t.Errorf("expect %v request ID, got %v", e, a)
+		}
+	}
+	if e, a := 500, spans[1].attributes[AttributeHTTPStatusCode]; e != a {
+		t.Errorf("expect %v first attempt status code, got %v", e, a)
+	}
+	if e, a := 1, len(spans[1].errs); e != a {
+		t.Errorf("expect %v first attempt errors, got %v", e, a)
+	}
+	if e, a := 200, spans[2].attributes[AttributeHTTPStatusCode]; e != a {
+		t.Errorf("expect %v second attempt status code, got %v", e, a)
+	}
+
+	for _, span := range spans {
+		if !span.ended {
+			t.Errorf("expect %v span ended", span.name)
+		}
+	}
+}
+
+func TestTracing_Error(t *testing.T) {
+	tracer := &memoryTracer{}
+	_, err := invokeTestOperation(t, tracer, []testResponse{
+		{statusCode: 400, requestID: "request-1"},
+	})
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+
+	spans := tracer.Spans()
+	if e, a := 2, len(spans); e != a {
+		t.Fatalf("expect %v spans, got %v", e, a)
+	}
+
+	operation := spans[0]
+	if e, a := 0, operation.attributes[AttributeRetryCount]; e != a {
+		t.Errorf("expect %v retry count, got %v", e, a)
+	}
+	if e, a := "request-1", operation.attributes[AttributeRequestID]; e != a {
+		t.Errorf("expect %v request ID, got %v", e, a)
+	}
+	if e, a := 400, operation.attributes[AttributeHTTPStatusCode]; e != a {
+		t.Errorf("expect %v status code, got %v", e, a)
+	}
+	if e, a := 1, len(operation.errs); e != a {
+		t.Fatalf("expect %v operation errors, got %v", e, a)
+	}
+	var apiErr smithy.APIError
+	if !errors.As(operation.errs[0], &apiErr) {
+		t.Errorf("expect API error, got %v", operation.errs[0])
+	}
+}
+
+func TestTracing_TraceHeader(t *testing.T) {
+	defer func(v string, ok bool) {
+		if ok {
+			os.Setenv(traceIDEnvVar, v)
+		} else {
+			os.Unsetenv(traceIDEnvVar)
+		}
+	}(os.LookupEnv(traceIDEnvVar))
+
+	cases := map[string]struct {
+		tracer Tracer
+		env    string
+		expect string
+	}{
+		"none": {
+			tracer: &memoryTracer{},
+		},
+		"span": {
+			tracer: traceHeaderTracer{&memoryTracer{traceHeader: "Root=1-span"}},
+			env:    "Root=1-lambda",
+			expect: "Root=1-span",
+		},
+		"lambda": {
+			tracer: &memoryTracer{},
+			env:    "Root=1-lambda",
+			expect: "Root=1-lambda",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			os.Unsetenv(traceIDEnvVar)
+			if len(c.env) != 0 {
+				os.Setenv(traceIDEnvVar, c.env)
+			}
+
+			headers, err := invokeTestOperation(t, c.tracer, []testResponse{
+				{statusCode: 500, requestID: "request-1"},
+				{statusCode: 200, requestID: "request-2"},
+			})
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			for _, header := range headers {
+				if e, a := c.expect, header.Get("X-Amzn-Trace-Id"); e != a {
+					t.Errorf("expect %q trace header, got %q", e, a)
+				}
+			}
+		})
+	}
+}
+
+func TestAddTracingMiddlewares(t *testing.T) {
+	stack := middleware.NewStack("TestOperation", smithyhttp.NewStackRequest)
+	if err := AddTracingMiddlewares(stack, AddTracingMiddlewaresOptions{}); err == nil {
+		t.Errorf("expect error without tracer, got none")
+	}
+
+	// Without the service metadata and retry middleware, the tracing
+	// middleware are added first in their steps.
+	if err := AddTracingMiddlewares(stack, AddTracingMiddlewaresOptions{Tracer: &memoryTracer{}}); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if _, ok := stack.Initialize.Get((*operationSpan)(nil).ID()); !ok {
+		t.Errorf("expect operation span middleware")
+	}
+	if _, ok := stack.Finalize.Get((*attemptSpan)(nil).ID()); !ok {
+		t.Errorf("expect attempt span middleware")
+	}
+}
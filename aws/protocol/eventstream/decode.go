@@ -0,0 +1,141 @@
+package eventstream
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"io"
+
+	"github.com/aws/smithy-go/logging"
+)
+
+// DecoderOptions is the Decoder configuration options.
+type DecoderOptions struct {
+	Logger      logging.Logger
+	LogMessages bool
+}
+
+// Decoder provides decoding of an Event Stream messages.
+type Decoder struct {
+	options DecoderOptions
+}
+
+// NewDecoder initializes and returns a Decoder for decoding event
+// stream messages from the reader provided.
+func NewDecoder(optFns ...func(*DecoderOptions)) *Decoder {
+	options := DecoderOptions{}
+
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
+	return &Decoder{
+		options: options,
+	}
+}
+
+// Decode attempts to decode a single message from the event stream reader.
+// Will return the event stream message, or error if the message could not be
+// read from the stream. If the reader has no more messages, io.EOF will be
+// returned.
+//
+// Both the prelude and message CRC32 checksums are validated. A ChecksumError
+// is returned if either does not match the bytes read.
+//
+// payloadBuf is a byte slice that will be used in the returned Message.Payload.
+// Callers must ensure that the Message.Payload from a previous decode has been
+// consumed before passing in the same underlying payloadBuf byte slice.
+func (d *Decoder) Decode(reader io.Reader, payloadBuf []byte) (m Message, err error) {
+	if d.options.Logger != nil && d.options.LogMessages {
+		debugMsgBuf := bytes.NewBuffer(nil)
+		reader = io.TeeReader(reader, debugMsgBuf)
+		defer func() {
+			logMessageDecode(d.options.Logger, debugMsgBuf, m, err)
+		}()
+	}
+
+	m, err = decodeMessage(reader, payloadBuf)
+
+	return m, err
+}
+
+// decodeMessage attempts to decode a single message from the event stream
+// reader. Will return the event stream message, or error if decodeMessage
+// fails to read the message from the reader.
+func decodeMessage(reader io.Reader, payloadBuf []byte) (m Message, err error) {
+	var preludeBuf [preludeLen + preludeCRCLen]byte
+	if _, err := io.ReadFull(reader, preludeBuf[:]); err != nil {
+		return Message{}, err
+	}
+
+	prelude := decodePrelude(preludeBuf[:])
+	if err := validatePreludeCRC(preludeBuf[:preludeLen], prelude.PreludeCRC); err != nil {
+		return Message{}, err
+	}
+	if err := prelude.ValidateLens(); err != nil {
+		return Message{}, err
+	}
+
+	// The remainder of the message, including headers, payload, and the
+	// trailing message CRC.
+	remaining := int(prelude.Length) - len(preludeBuf)
+
+	var msgBuf []byte
+	if cap(payloadBuf) >= remaining {
+		msgBuf = payloadBuf[:remaining]
+	} else {
+		msgBuf = make([]byte, remaining)
+	}
+	if _, err := io.ReadFull(reader, msgBuf); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return Message{}, err
+	}
+
+	crcOffset := len(msgBuf) - msgCRCLen
+	crc := crc32.Update(crc32.Checksum(preludeBuf[:], crc32IEEETable), crc32IEEETable, msgBuf[:crcOffset])
+	if expect := binary.BigEndian.Uint32(msgBuf[crcOffset:]); crc != expect {
+		return Message{}, ChecksumError{Part: "message", Expect: expect, Actual: crc}
+	}
+
+	headersBuf := msgBuf[:prelude.HeadersLen]
+	m.Headers, err = decodeHeaders(bytes.NewReader(headersBuf))
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to decode message headers, %w", err)
+	}
+
+	if payloadLen := prelude.PayloadLen(); payloadLen > 0 {
+		m.Payload = msgBuf[prelude.HeadersLen : prelude.HeadersLen+payloadLen]
+	}
+
+	return m, nil
+}
+
+func validatePreludeCRC(prelude []byte, expect uint32) error {
+	if crc := crc32.Checksum(prelude, crc32IEEETable); crc != expect {
+		return ChecksumError{Part: "prelude", Expect: expect, Actual: crc}
+	}
+	return nil
+}
+
+func logMessageDecode(logger logging.Logger, msgBuf *bytes.Buffer, msg Message, decodeErr error) {
+	w := bytes.NewBuffer(nil)
+	defer func() { logger.Logf(logging.Debug, w.String()) }()
+
+	fmt.Fprintf(w, "Raw message:\n%s\n",
+		hex.Dump(msgBuf.Bytes()))
+
+	if decodeErr != nil {
+		fmt.Fprintf(w, "decodeMessage error: %v\n", decodeErr)
+		return
+	}
+
+	fmt.Fprintf(w, "Decoded message headers:\n")
+	for _, h := range msg.Headers {
+		fmt.Fprintf(w, "  %s: %s\n", h.Name, h.Value.String())
+	}
+	fmt.Fprintf(w, "Decoded message payload length: %d\n", len(msg.Payload))
+}
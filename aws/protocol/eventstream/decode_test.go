@@ -0,0 +1,144 @@
+package eventstream
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	for _, c := range testCases {
+		t.Run(c.Name, func(t *testing.T) {
+			decoder := NewDecoder()
+
+			msg, err := decoder.Decode(bytes.NewReader(c.Encoded), nil)
+			if err != nil {
+				t.Fatalf("expect no decode error, got %v", err)
+			}
+
+			if e, a := c.Decoded, msg; !reflect.DeepEqual(e, a) {
+				t.Errorf("expect %v message, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestDecode_MultipleMessages(t *testing.T) {
+	var stream bytes.Buffer
+	for _, c := range testCases {
+		stream.Write(c.Encoded)
+	}
+
+	decoder := NewDecoder()
+	payloadBuf := make([]byte, 0, 1024)
+	for _, c := range testCases {
+		msg, err := decoder.Decode(&stream, payloadBuf)
+		if err != nil {
+			t.Fatalf("%s, expect no decode error, got %v", c.Name, err)
+		}
+		if e, a := c.Decoded, msg; !reflect.DeepEqual(e, a) {
+			t.Errorf("%s, expect %v message, got %v", c.Name, e, a)
+		}
+	}
+
+	if _, err := decoder.Decode(&stream, payloadBuf); err != io.EOF {
+		t.Errorf("expect EOF after last message, got %v", err)
+	}
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	valid := testCases[1].Encoded
+
+	corrupt := func(offset int) []byte {
+		b := append([]byte{}, valid...)
+		b[offset] ^= 0xff
+		return b
+	}
+
+	cases := map[string]struct {
+		Encoded   []byte
+		ExpectErr interface{}
+		ErrSubstr string
+	}{
+		"prelude checksum": {
+			Encoded:   corrupt(9),
+			ExpectErr: &ChecksumError{},
+			ErrSubstr: "prelude checksum invalid",
+		},
+		"header length": {
+			Encoded:   corrupt(5),
+			ErrSubstr: "prelude checksum invalid",
+		},
+		"payload corrupt": {
+			Encoded:   corrupt(len(valid) - 6),
+			ExpectErr: &ChecksumError{},
+			ErrSubstr: "message checksum invalid",
+		},
+		"message checksum": {
+			Encoded:   corrupt(len(valid) - 1),
+			ExpectErr: &ChecksumError{},
+			ErrSubstr: "message checksum invalid",
+		},
+		"truncated prelude": {
+			Encoded:   valid[:6],
+			ErrSubstr: io.ErrUnexpectedEOF.Error(),
+		},
+		"truncated message": {
+			Encoded:   valid[:len(valid)-10],
+			ErrSubstr: io.ErrUnexpectedEOF.Error(),
+		},
+		"message length too small": {
+			Encoded: []byte{
+				0x00, 0x00, 0x00, 0x0f, 0x00, 0x00, 0x00, 0x00, 0xe7, 0x72, 0x48, 0xb8,
+			},
+			ExpectErr: &LengthError{},
+			ErrSubstr: "message prelude length invalid",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewDecoder().Decode(bytes.NewReader(c.Encoded), nil)
+			if err == nil {
+				t.Fatalf("expect error, got none")
+			}
+			if e, a := c.ErrSubstr, err.Error(); !strings.Contains(a, e) {
+				t.Errorf("expect error to contain %q, got %q", e, a)
+			}
+			switch c.ExpectErr.(type) {
+			case *ChecksumError:
+				var v ChecksumError
+				if !errors.As(err, &v) {
+					t.Errorf("expect %T error, got %T", v, err)
+				}
+			case *LengthError:
+				var v LengthError
+				if !errors.As(err, &v) {
+					t.Errorf("expect %T error, got %T", v, err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	var stream bytes.Buffer
+	encoder := NewEncoder()
+	msg := testCases[2].Decoded
+	for i := 0; i < b.N; i++ {
+		encoder.Encode(&stream, msg)
+	}
+
+	decoder := NewDecoder()
+	payloadBuf := make([]byte, 0, 1024)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := decoder.Decode(&stream, payloadBuf); err != nil {
+			b.Fatalf("expect no error, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+// Package eventstream provides an encoder and decoder for the AWS event stream
+// binary format, "application/vnd.amazon.eventstream".
+//
+// An event stream is a sequence of messages, each framed with a prelude
+// describing the total message and header lengths, a CRC32 checksum of the
+// prelude, a set of typed headers, an opaque payload, and a trailing CRC32
+// checksum of the entire message. The Decoder validates both checksums of every
+// message it reads, and the Encoder computes them for every message written.
+//
+// The eventstreamapi package provides the header names and message types used
+// by AWS services on top of this framing.
+package eventstream
@@ -0,0 +1,82 @@
+package eventstream
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	for _, c := range testCases {
+		t.Run(c.Name, func(t *testing.T) {
+			var w bytes.Buffer
+			encoder := NewEncoder()
+
+			if err := encoder.Encode(&w, c.Decoded); err != nil {
+				t.Fatalf("expect no encode error, got %v", err)
+			}
+
+			if e, a := c.Encoded, w.Bytes(); !bytes.Equal(e, a) {
+				t.Errorf("expect encoded bytes\n%#v\ngot\n%#v", e, a)
+			}
+		})
+	}
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	var w bytes.Buffer
+	encoder := NewEncoder()
+	for _, c := range testCases {
+		if err := encoder.Encode(&w, c.Decoded); err != nil {
+			t.Fatalf("%s, expect no encode error, got %v", c.Name, err)
+		}
+	}
+
+	decoder := NewDecoder()
+	for _, c := range testCases {
+		msg, err := decoder.Decode(&w, nil)
+		if err != nil {
+			t.Fatalf("%s, expect no decode error, got %v", c.Name, err)
+		}
+		if e, a := c.Decoded, msg; !reflect.DeepEqual(e, a) {
+			t.Errorf("%s, expect %v message, got %v", c.Name, e, a)
+		}
+	}
+}
+
+func TestEncode_HeaderLengthLimits(t *testing.T) {
+	cases := map[string]Message{
+		"header name": {
+			Headers: Headers{
+				{Name: strings.Repeat("a", maxHeaderNameLen+1), Value: BoolValue(true)},
+			},
+		},
+		"header value": {
+			Headers: Headers{
+				{Name: "value", Value: StringValue(strings.Repeat("a", maxHeaderValueLen+1))},
+			},
+		},
+		"payload": {
+			Payload: make([]byte, maxPayloadLen+1),
+		},
+	}
+
+	for name, msg := range cases {
+		t.Run(name, func(t *testing.T) {
+			var w bytes.Buffer
+			err := NewEncoder().Encode(&w, msg)
+			if err == nil {
+				t.Fatalf("expect error, got none")
+			}
+			var v LengthError
+			if !errors.As(err, &v) {
+				t.Errorf("expect %T error, got %T", v, err)
+			}
+			if w.Len() != 0 {
+				t.Errorf("expect nothing written, got %d bytes", w.Len())
+			}
+		})
+	}
+}
@@ -0,0 +1,4 @@
+// Package eventstreamapi provides the header names, message types, and helpers
+// shared by AWS service clients that read and write event stream messages using
+// the eventstream package.
+package eventstreamapi
@@ -0,0 +1,34 @@
+package eventstreamapi
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws/protocol/eventstream"
+)
+
+// GetHeaderString returns the string value of the named message header. An
+// error is returned if the header is not present, or is not a string value.
+func GetHeaderString(msg eventstream.Message, name string) (string, error) {
+	v := msg.Headers.Get(name)
+	if v == nil {
+		return "", fmt.Errorf("%s header not present", name)
+	}
+
+	sv, ok := v.(eventstream.StringValue)
+	if !ok {
+		return "", fmt.Errorf("%s header expected string, got %T", name, v)
+	}
+
+	return string(sv), nil
+}
+
+// UnknownMessageTypeError provides the error for an event stream message whose
+// message type is not known to the client.
+type UnknownMessageTypeError struct {
+	Type    string
+	Message eventstream.Message
+}
+
+func (e *UnknownMessageTypeError) Error() string {
+	return fmt.Sprintf("unknown eventstream message type, %v", e.Type)
+}
@@ -0,0 +1,54 @@
+package eventstreamapi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws/protocol/eventstream"
+)
+
+func TestGetHeaderString(t *testing.T) {
+	cases := map[string]struct {
+		Headers   eventstream.Headers
+		Expect    string
+		ExpectErr string
+	}{
+		"string value": {
+			Headers: eventstream.Headers{
+				{Name: EventTypeHeader, Value: eventstream.StringValue("Records")},
+			},
+			Expect: "Records",
+		},
+		"missing header": {
+			Headers:   eventstream.Headers{},
+			ExpectErr: "not present",
+		},
+		"not string": {
+			Headers: eventstream.Headers{
+				{Name: EventTypeHeader, Value: eventstream.Int32Value(1)},
+			},
+			ExpectErr: "expected string",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			v, err := GetHeaderString(eventstream.Message{Headers: c.Headers}, EventTypeHeader)
+			if len(c.ExpectErr) != 0 {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				if e, a := c.ExpectErr, err.Error(); !strings.Contains(a, e) {
+					t.Fatalf("expect error to contain %v, got %v", e, a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.Expect, v; e != a {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
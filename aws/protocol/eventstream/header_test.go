@@ -0,0 +1,90 @@
+package eventstream
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHeaders_Set(t *testing.T) {
+	expect := Headers{
+		{Name: "ABC", Value: StringValue("123")},
+		{Name: "EFG", Value: TimestampValue(timeFromEpochMilli(123))},
+	}
+
+	var actual Headers
+	actual.Set("ABC", Int32Value(123))
+	actual.Set("ABC", StringValue("123")) // replace case
+	actual.Set("EFG", TimestampValue(timeFromEpochMilli(123)))
+
+	if e, a := expect, actual; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v headers, got %v", e, a)
+	}
+}
+
+func TestHeaders_Get(t *testing.T) {
+	headers := Headers{
+		{Name: "ABC", Value: StringValue("123")},
+		{Name: "EFG", Value: TimestampValue(timeFromEpochMilli(123))},
+	}
+
+	cases := []struct {
+		Name  string
+		Value Value
+	}{
+		{Name: "ABC", Value: StringValue("123")},
+		{Name: "EFG", Value: TimestampValue(timeFromEpochMilli(123))},
+		{Name: "NotFound"},
+	}
+
+	for i, c := range cases {
+		actual := headers.Get(c.Name)
+		if e, a := c.Value, actual; !reflect.DeepEqual(e, a) {
+			t.Errorf("%d, expect %v value, got %v", i, e, a)
+		}
+	}
+}
+
+func TestHeaders_Del(t *testing.T) {
+	headers := Headers{
+		{Name: "ABC", Value: StringValue("123")},
+		{Name: "EFG", Value: TimestampValue(timeFromEpochMilli(123))},
+		{Name: "HIJ", Value: StringValue("123")},
+		{Name: "KML", Value: TimestampValue(timeFromEpochMilli(123))},
+	}
+	expectAfterDel := Headers{
+		{Name: "EFG", Value: TimestampValue(timeFromEpochMilli(123))},
+	}
+
+	headers.Del("HIJ")
+	headers.Del("ABC")
+	headers.Del("KML")
+
+	if e, a := expectAfterDel, headers; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v headers, got %v", e, a)
+	}
+}
+
+func TestHeaderValue_String(t *testing.T) {
+	cases := []struct {
+		Value  Value
+		Expect string
+	}{
+		{Value: BoolValue(true), Expect: "true"},
+		{Value: Int8Value(127), Expect: "0x7f"},
+		{Value: Int16Value(255), Expect: "0x00ff"},
+		{Value: Int32Value(4096), Expect: "0x00001000"},
+		{Value: BytesValue([]byte{1, 2, 3}), Expect: "AQID"},
+		{Value: StringValue("abc"), Expect: "abc"},
+		{Value: TimestampValue(timeFromEpochMilli(1468432425000)), Expect: "1468432425000"},
+		{
+			Value:  UUIDValue{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+			Expect: "00010203-0405-0607-0809-0A0B0C0D0E0F",
+		},
+	}
+
+	for i, c := range cases {
+		if e, a := c.Expect, c.Value.String(); e != a {
+			t.Errorf("%d, expect %v, got %v", i, e, a)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package eventstream
+
+import (
+	"time"
+)
+
+type testCase struct {
+	Name    string
+	Encoded []byte
+	Decoded Message
+}
+
+// testCases are encoded messages and their decoded representation, following
+// the examples in the event stream specification's test suite.
+var testCases = []testCase{
+	{
+		Name: "empty_message",
+		Encoded: []byte{
+			0x00, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00, 0x00, 0x05, 0xc2, 0x48, 0xeb,
+			0x7d, 0x98, 0xc8, 0xff,
+		},
+		Decoded: Message{
+			Headers: Headers{},
+		},
+	},
+	{
+		Name: "payload_one_str_header",
+		Encoded: []byte{
+			0x00, 0x00, 0x00, 0x3d, 0x00, 0x00, 0x00, 0x20, 0x07, 0xfd, 0x83, 0x96,
+			0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x2d, 0x74, 0x79, 0x70,
+			0x65, 0x07, 0x00, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+			0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x7b, 0x27, 0x66, 0x6f,
+			0x6f, 0x27, 0x3a, 0x27, 0x62, 0x61, 0x72, 0x27, 0x7d, 0x8d, 0x9c, 0x08,
+			0xb1,
+		},
+		Decoded: Message{
+			Headers: Headers{
+				{Name: "content-type", Value: StringValue("application/json")},
+			},
+			Payload: []byte(`{'foo':'bar'}`),
+		},
+	},
+	{
+		Name: "all_headers",
+		Encoded: []byte{
+			0x00, 0x00, 0x00, 0xa6, 0x00, 0x00, 0x00, 0x89, 0x0e, 0x21, 0x8e, 0xdb,
+			0x09, 0x62, 0x6f, 0x6f, 0x6c, 0x2d, 0x74, 0x72, 0x75, 0x65, 0x00, 0x0a,
+			0x62, 0x6f, 0x6f, 0x6c, 0x2d, 0x66, 0x61, 0x6c, 0x73, 0x65, 0x01, 0x04,
+			0x62, 0x79, 0x74, 0x65, 0x02, 0xcf, 0x05, 0x73, 0x68, 0x6f, 0x72, 0x74,
+			0x03, 0xa0, 0x23, 0x03, 0x69, 0x6e, 0x74, 0x04, 0x00, 0x00, 0xa0, 0x0c,
+			0x04, 0x6c, 0x6f, 0x6e, 0x67, 0x05, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+			0xf3, 0x35, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x06, 0x00, 0x03, 0x01,
+			0x02, 0x03, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x07, 0x00, 0x0c,
+			0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x20, 0x76, 0x61, 0x6c, 0x75, 0x65,
+			0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x08, 0x00,
+			0x00, 0x01, 0x55, 0xe5, 0x64, 0xa0, 0x28, 0x04, 0x75, 0x75, 0x69, 0x64,
+			0x09, 0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a,
+			0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x7b, 0x27, 0x66, 0x6f, 0x6f, 0x27, 0x3a,
+			0x27, 0x62, 0x61, 0x72, 0x27, 0x7d, 0x83, 0xd7, 0x10, 0x10,
+		},
+		Decoded: Message{
+			Headers: Headers{
+				{Name: "bool-true", Value: BoolValue(true)},
+				{Name: "bool-false", Value: BoolValue(false)},
+				{Name: "byte", Value: Int8Value(-49)},
+				{Name: "short", Value: Int16Value(-24541)},
+				{Name: "int", Value: Int32Value(40972)},
+				{Name: "long", Value: Int64Value(-3275)},
+				{Name: "bytes", Value: BytesValue([]byte{1, 2, 3})},
+				{Name: "string", Value: StringValue("string value")},
+				{Name: "timestamp", Value: TimestampValue(time.Unix(1468432425, 0).UTC())},
+				{Name: "uuid", Value: UUIDValue{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}},
+			},
+			Payload: []byte(`{'foo':'bar'}`),
+		},
+	},
+}
@@ -0,0 +1,185 @@
+package retry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/internal/sdk"
+)
+
+const (
+	// DefaultRequestCost is the cost of a single request from the adaptive
+	// rate limited token bucket.
+	DefaultRequestCost uint = 1
+)
+
+// DefaultThrottleErrorCodes provides the set of API error codes that are
+// considered throttle errors.
+var DefaultThrottleErrorCodes = map[string]struct{}{
+	"Throttling":                             {},
+	"ThrottlingException":                    {},
+	"ThrottledException":                     {},
+	"RequestThrottledException":              {},
+	"TooManyRequestsException":               {},
+	"ProvisionedThroughputExceededException": {},
+	"TransactionInProgressException":         {},
+	"RequestLimitExceeded":                   {},
+	"BandwidthLimitExceeded":                 {},
+	"LimitExceededException":                 {},
+	"RequestThrottled":                       {},
+	"SlowDown":                               {},
+	"PriorRequestNotComplete":                {},
+	"EC2ThrottledException":                  {},
+}
+
+// DefaultThrottleHTTPStatusCodes is the default set of HTTP status codes the
+// SDK should consider as throttle errors.
+var DefaultThrottleHTTPStatusCodes = map[int]struct{}{
+	429: {},
+}
+
+// DefaultThrottles provides the set of errors considered throttle errors that
+// are checked by default.
+var DefaultThrottles = []IsErrorThrottle{
+	ThrottleErrorCode{
+		Codes: DefaultThrottleErrorCodes,
+	},
+	ThrottleHTTPStatusCode{
+		Codes: DefaultThrottleHTTPStatusCodes,
+	},
+}
+
+// AdaptiveOptions provides the functional options for configuring the
+// adaptive retry mode, and delay behavior.
+type AdaptiveOptions struct {
+	// If the adaptive token bucket is empty, when an attempt will be made
+	// Adaptive retryer will sleep until a token is available. This can occur
+	// when attempts fail with throttle errors. Use this option to disable the
+	// sleep until token is available, and return error immediately.
+	FailOnNoAttemptTokens bool
+
+	// The cost of an attempt from the adaptive token bucket.
+	RequestCost uint
+
+	// Set of strategies to determine if the attempt failed due to a throttle
+	// error.
+	//
+	// It is safe to append to this list in NewAdaptive's functional options.
+	Throttles []IsErrorThrottle
+
+	// Set of options for standard retry mode that Adaptive is built on top of.
+	// Adaptive mode may apply its own defaults to Standard retry mode that are
+	// different than the defaults of NewStandard. Use these options to
+	// override the default options.
+	StandardOptions []func(*StandardOptions)
+}
+
+// Adaptive provides an experimental retry strategy that expands on the
+// Standard retry strategy, adding client attempt rate limits. The attempt rate
+// limit is initially unrestricted, but becomes restricted when the attempt
+// fails with a throttle error. When restricted Adaptive may need to sleep
+// before an attempt is made, if too many throttles have been received.
+// Adaptive's sleep can be configured with FailOnNoAttemptTokens to fail
+// instead of sleeping.
+//
+// Adaptive shares the same retry quota and backoff delay as Standard. Use the
+// StandardOptions of AdaptiveOptions to configure them.
+type Adaptive struct {
+	options AdaptiveOptions
+
+	throttles IsErrorThrottle
+	retryer   *Standard
+	rateLimit *adaptiveRateLimit
+}
+
+// NewAdaptive returns an initialized Adaptive retry strategy with defaults
+// that can be overridden via functional options.
+func NewAdaptive(optFns ...func(*AdaptiveOptions)) *Adaptive {
+	o := AdaptiveOptions{
+		RequestCost: DefaultRequestCost,
+		Throttles:   append([]IsErrorThrottle{}, DefaultThrottles...),
+	}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+
+	ts := make([]IsErrorThrottle, len(o.Throttles))
+	copy(ts, o.Throttles)
+
+	return &Adaptive{
+		options:   o,
+		throttles: IsErrorThrottles(ts),
+		retryer:   NewStandard(o.StandardOptions...),
+		rateLimit: newAdaptiveRateLimit(),
+	}
+}
+
+// IsErrorRetryable returns if the failed attempt is retryable. This check
+// should determine if the error can be retried, or if the error is
+// terminal.
+func (a *Adaptive) IsErrorRetryable(err error) bool {
+	return a.retryer.IsErrorRetryable(err)
+}
+
+// MaxAttempts returns the maximum number of attempts that can be made for
+// an attempt before failing. A value of 0 implies that the attempt should
+// be retried until it succeeds if the errors are retryable.
+func (a *Adaptive) MaxAttempts() int {
+	return a.retryer.MaxAttempts()
+}
+
+// RetryDelay returns the delay that should be used before retrying the
+// attempt. Will return error if the if the delay could not be determined.
+func (a *Adaptive) RetryDelay(attempt int, opErr error) (time.Duration, error) {
+	return a.retryer.RetryDelay(attempt, opErr)
+}
+
+// GetRetryToken attempts to deduct the retry cost from the retry token pool.
+// Returning the token release function, or error.
+func (a *Adaptive) GetRetryToken(ctx context.Context, opErr error) (releaseToken func(error) error, err error) {
+	return a.retryer.GetRetryToken(ctx, opErr)
+}
+
+// GetInitialToken returns the initial attempt token that can increment the
+// retry token pool if the attempt is successful.
+func (a *Adaptive) GetInitialToken() (releaseToken func(error) error) {
+	return a.retryer.GetInitialToken()
+}
+
+// GetAttemptToken returns the attempt token that can be used to rate limit
+// attempt calls. Will be used by the SDK's retry package's Attempt
+// middleware to get an attempt token prior to making the attempt, and releasing
+// the attempt token after the attempt has been made.
+//
+// If the attempt rate limit is restricted, GetAttemptToken will sleep until
+// a token is available, or the context is canceled. If FailOnNoAttemptTokens
+// is set an error is returned instead.
+func (a *Adaptive) GetAttemptToken(ctx context.Context) (func(error) error, error) {
+	for {
+		acquiredToken, waitTryAgain := a.rateLimit.AcquireToken(a.options.RequestCost)
+		if acquiredToken {
+			break
+		}
+		if a.options.FailOnNoAttemptTokens {
+			return nil, fmt.Errorf(
+				"unable to get attempt token, and FailOnNoAttemptTokens enabled")
+		}
+
+		if err := sdk.SleepWithContext(ctx, waitTryAgain); err != nil {
+			return nil, fmt.Errorf(
+				"failed to wait for token to be available, %w", err)
+		}
+	}
+
+	return a.handleResponse, nil
+}
+
+// handleResponse updates the adaptive rate limit with the result of the
+// attempt.
+func (a *Adaptive) handleResponse(opErr error) error {
+	throttled := a.throttles.IsErrorThrottle(opErr).Bool()
+
+	a.rateLimit.Update(throttled)
+	return nil
+}
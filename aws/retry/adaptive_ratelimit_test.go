@@ -0,0 +1,165 @@
+package retry
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/internal/sdk"
+)
+
+func TestAdaptiveRateLimit_CubicSuccess(t *testing.T) {
+	a := newAdaptiveRateLimit()
+	a.lastMaxRate = 10
+	a.lastThrottleTime = time.Unix(5, 0)
+	a.calculateTimeWindow()
+
+	cases := []struct {
+		Timestamp    float64
+		ExpectedRate float64
+	}{
+		{Timestamp: 5, ExpectedRate: 7.0},
+		{Timestamp: 6, ExpectedRate: 9.64893600966},
+		{Timestamp: 7, ExpectedRate: 10.000030849917364},
+		{Timestamp: 8, ExpectedRate: 10.453284520772092},
+		{Timestamp: 9, ExpectedRate: 13.408697022224185},
+		{Timestamp: 10, ExpectedRate: 21.26626835427364},
+		{Timestamp: 11, ExpectedRate: 36.425998516920465},
+	}
+
+	for _, c := range cases {
+		rate := a.cubicSuccess(time.Unix(0, 0).Add(float64Seconds(c.Timestamp)))
+		if e, a := c.ExpectedRate, rate; !float64Equal(e, a, 1e-9) {
+			t.Errorf("%v: expect %v rate, got %v", c.Timestamp, e, a)
+		}
+	}
+}
+
+func TestAdaptiveRateLimit_CubicThrottle(t *testing.T) {
+	a := newAdaptiveRateLimit()
+
+	if e, a := 7.0, a.cubicThrottle(10); !float64Equal(e, a, 1e-9) {
+		t.Errorf("expect %v rate, got %v", e, a)
+	}
+}
+
+func TestAdaptiveRateLimit_Update(t *testing.T) {
+	origNowTime := sdk.NowTime
+	defer func() { sdk.NowTime = origNowTime }()
+
+	var now time.Time
+	sdk.NowTime = func() time.Time { return now }
+
+	now = time.Unix(0, 0)
+	a := newAdaptiveRateLimit()
+
+	cases := []struct {
+		Throttled      bool
+		Timestamp      float64
+		MeasuredTxRate float64
+		FillRate       float64
+	}{
+		{Throttled: false, Timestamp: 0.2, MeasuredTxRate: 0.000000, FillRate: 0.500000},
+		{Throttled: false, Timestamp: 0.4, MeasuredTxRate: 0.000000, FillRate: 0.500000},
+		{Throttled: false, Timestamp: 0.6, MeasuredTxRate: 4.800000, FillRate: 0.500000},
+		{Throttled: false, Timestamp: 0.8, MeasuredTxRate: 4.800000, FillRate: 0.500000},
+		{Throttled: false, Timestamp: 1.0, MeasuredTxRate: 4.160000, FillRate: 0.500000},
+		{Throttled: false, Timestamp: 1.2, MeasuredTxRate: 4.160000, FillRate: 0.691200},
+		{Throttled: false, Timestamp: 1.4, MeasuredTxRate: 4.160000, FillRate: 1.097600},
+		{Throttled: false, Timestamp: 1.6, MeasuredTxRate: 5.632000, FillRate: 1.638400},
+		{Throttled: false, Timestamp: 1.8, MeasuredTxRate: 5.632000, FillRate: 2.332800},
+		{Throttled: true, Timestamp: 2.0, MeasuredTxRate: 4.326400, FillRate: 3.028480},
+		{Throttled: false, Timestamp: 2.2, MeasuredTxRate: 4.326400, FillRate: 3.486639},
+		{Throttled: false, Timestamp: 2.4, MeasuredTxRate: 4.326400, FillRate: 3.821874},
+		{Throttled: false, Timestamp: 2.6, MeasuredTxRate: 5.665280, FillRate: 4.053386},
+		{Throttled: false, Timestamp: 2.8, MeasuredTxRate: 5.665280, FillRate: 4.200373},
+		{Throttled: false, Timestamp: 3.0, MeasuredTxRate: 4.333056, FillRate: 4.282037},
+		{Throttled: false, Timestamp: 3.2, MeasuredTxRate: 4.333056, FillRate: 4.317576},
+		{Throttled: false, Timestamp: 3.4, MeasuredTxRate: 4.333056, FillRate: 4.326192},
+		{Throttled: false, Timestamp: 3.6, MeasuredTxRate: 5.666611, FillRate: 4.327083},
+		{Throttled: true, Timestamp: 3.8, MeasuredTxRate: 5.666611, FillRate: 3.028958},
+		{Throttled: false, Timestamp: 4.0, MeasuredTxRate: 4.333322, FillRate: 3.487169},
+	}
+
+	for _, c := range cases {
+		now = time.Unix(0, 0).Add(float64Seconds(c.Timestamp))
+		a.Update(c.Throttled)
+
+		if e, a := c.MeasuredTxRate, a.measuredTxRate; !float64Equal(e, a, 1e-6) {
+			t.Errorf("%v: expect %v measured rate, got %v", c.Timestamp, e, a)
+		}
+		if e, a := c.FillRate, a.fillRate; !float64Equal(e, a, 1e-6) {
+			t.Errorf("%v: expect %v fill rate, got %v", c.Timestamp, e, a)
+		}
+	}
+}
+
+func TestAdaptiveRateLimit_AcquireToken(t *testing.T) {
+	origNowTime := sdk.NowTime
+	defer func() { sdk.NowTime = origNowTime }()
+
+	var now time.Time
+	sdk.NowTime = func() time.Time { return now }
+
+	now = time.Unix(0, 0)
+	a := newAdaptiveRateLimit()
+
+	// Unrestricted until the first throttle.
+	for i := 0; i < 100; i++ {
+		if ok, _ := a.AcquireToken(1); !ok {
+			t.Fatalf("%d: expect token acquired before throttle", i)
+		}
+	}
+
+	a.Update(true)
+	if !a.tokenBucketEnabled {
+		t.Fatalf("expect token bucket enabled after throttle")
+	}
+
+	// The bucket is empty immediately after being enabled.
+	ok, wait := a.AcquireToken(1)
+	if ok {
+		t.Fatalf("expect token not acquired")
+	}
+	if e, a := 2*time.Second, wait; e != a {
+		t.Fatalf("expect %v wait, got %v", e, a)
+	}
+
+	now = now.Add(wait)
+	if ok, _ := a.AcquireToken(1); !ok {
+		t.Fatalf("expect token acquired after wait")
+	}
+}
+
+func TestAdaptiveTokenBucket(t *testing.T) {
+	b := newAdaptiveTokenBucket(10)
+
+	if avail, ok := b.Retrieve(4); !ok || avail != 6 {
+		t.Errorf("expect 6 tokens retrieved, got %v, %v", avail, ok)
+	}
+	if avail, ok := b.Retrieve(7); ok || avail != 6 {
+		t.Errorf("expect tokens not retrieved with 6 available, got %v, %v", avail, ok)
+	}
+
+	b.Refund(10)
+	if e, a := 10., b.Remaining(); e != a {
+		t.Errorf("expect %v remaining, got %v", e, a)
+	}
+
+	b.Resize(5)
+	if e, a := 5., b.Capacity(); e != a {
+		t.Errorf("expect %v capacity, got %v", e, a)
+	}
+	if e, a := 5., b.Remaining(); e != a {
+		t.Errorf("expect %v remaining, got %v", e, a)
+	}
+
+	b.Resize(0)
+	if e, a := 1., b.Capacity(); e != a {
+		t.Errorf("expect %v minimum capacity, got %v", e, a)
+	}
+}
+
+func float64Equal(a, b, tolerance float64) bool {
+	return math.Abs(a-b) <= tolerance
+}
@@ -0,0 +1,117 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go"
+)
+
+var _ aws.RetryerV2 = (*retry.Adaptive)(nil)
+
+func TestAdaptive_StandardOptions(t *testing.T) {
+	r := retry.NewAdaptive(func(o *retry.AdaptiveOptions) {
+		o.StandardOptions = append(o.StandardOptions, func(so *retry.StandardOptions) {
+			so.MaxAttempts = 7
+		})
+	})
+
+	if e, a := 7, r.MaxAttempts(); e != a {
+		t.Errorf("expect %v max attempts, got %v", e, a)
+	}
+	if !r.IsErrorRetryable(&smithy.GenericAPIError{Code: "ThrottlingException"}) {
+		t.Errorf("expect throttle error to be retryable")
+	}
+}
+
+func TestAdaptive_GetAttemptToken(t *testing.T) {
+	throttleErr := &smithy.GenericAPIError{Code: "ThrottlingException"}
+
+	cases := map[string]struct {
+		Responses []error
+		Attempts  int
+		ExpectErr string
+	}{
+		"no throttles": {
+			Responses: []error{nil, fmt.Errorf("some error"), nil},
+			Attempts:  10,
+		},
+		"throttled": {
+			Responses: []error{throttleErr},
+			Attempts:  10,
+			ExpectErr: "unable to get attempt token",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := retry.NewAdaptive(func(o *retry.AdaptiveOptions) {
+				o.FailOnNoAttemptTokens = true
+			})
+
+			for _, resp := range c.Responses {
+				release, err := r.GetAttemptToken(context.Background())
+				if err != nil {
+					t.Fatalf("expect no error, got %v", err)
+				}
+				if err := release(resp); err != nil {
+					t.Fatalf("expect no release error, got %v", err)
+				}
+			}
+
+			var err error
+			for i := 0; i < c.Attempts && err == nil; i++ {
+				var release func(error) error
+				release, err = r.GetAttemptToken(context.Background())
+				if err == nil {
+					release(nil)
+				}
+			}
+
+			if len(c.ExpectErr) == 0 {
+				if err != nil {
+					t.Fatalf("expect no error, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expect error, got none")
+			}
+			if e, a := c.ExpectErr, err.Error(); !strings.Contains(a, e) {
+				t.Errorf("expect error to contain %q, got %q", e, a)
+			}
+		})
+	}
+}
+
+func TestAdaptive_GetAttemptToken_Canceled(t *testing.T) {
+	r := retry.NewAdaptive()
+
+	release, err := r.GetAttemptToken(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	release(&smithy.GenericAPIError{Code: "SlowDown"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var attemptErr error
+	for i := 0; i < 10 && attemptErr == nil; i++ {
+		release, attemptErr = r.GetAttemptToken(ctx)
+		if attemptErr == nil {
+			release(nil)
+		}
+	}
+	if attemptErr == nil {
+		t.Fatalf("expect error, got none")
+	}
+	if e, a := context.Canceled, attemptErr; !errors.Is(a, e) {
+		t.Errorf("expect %v error, got %v", e, a)
+	}
+}
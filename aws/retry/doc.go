@@ -3,8 +3,8 @@
 // Retryer Interface and Implementations
 //
 // This packages defines Retryer interface that is used to either implement custom retry behavior
-// or to extend the existing retry implementations provided by the SDK. This packages provides a single
-// retry implementations: Standard.
+// or to extend the existing retry implementations provided by the SDK. This packages provides two
+// retry implementations: Standard, and Adaptive.
 //
 // Standard
 //
@@ -49,6 +49,27 @@
 //      o.Retryer = customRetry
 //  })
 //
+// Adaptive
+//
+// Adaptive is a retryer implementation that builds on the Standard retryer, adding client side rate limiting of the
+// request attempts. The attempt rate is initially unrestricted, but becomes restricted when an attempt fails with a
+// throttle error. When restricted a CUBIC-style token bucket measures the rate requests are sent, reduces the allowed
+// rate on throttle responses, and grows the rate back on successful responses. If no token is available the retryer
+// will delay the attempt until one is, or the request's context is canceled.
+//
+// By default the DefaultThrottles slice of IsErrorThrottle types determines whether a given error is a throttle
+// error. Adaptive's underlying standard retryer can be configured with the StandardOptions member of AdaptiveOptions.
+//
+//  // configure the adaptive retryer
+//  customRetry := retry.NewAdaptive(func(o *retry.AdaptiveOptions) {
+//      o.StandardOptions = append(o.StandardOptions, func(so *retry.StandardOptions) {
+//          so.MaxAttempts = 5
+//      })
+//  })
+//
+// The adaptive retryer can also be selected for all API clients created from a configuration with the RetryMode of
+// config.LoadOptions, or with the "retry_mode = adaptive" shared config file setting.
+//
 // Utilities
 //
 // A number of package functions have been provided to easily wrap retryer implementations in an implementation agnostic
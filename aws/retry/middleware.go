@@ -132,10 +132,21 @@ func (r Attempt) handleAttempt(ctx context.Context, in smithymiddle.FinalizeInpu
 		r.logf(logger, logging.Debug, "retrying request %s/%s, attempt %d", service, operation, attemptNum)
 	}
 
+	relAttemptToken, err := getAttemptToken(ctx, r.retryer)
+	if err != nil {
+		err = fmt.Errorf("failed to get retry send token, %w", err)
+		return out, attemptResult, err
+	}
+
 	var metadata smithymiddle.Metadata
 	out, metadata, err = next.HandleFinalize(ctx, in)
 	attemptResult.ResponseMetadata = metadata
 
+	if releaseError := relAttemptToken(err); releaseError != nil {
+		err = fmt.Errorf("failed to release send token after request, %w", releaseError)
+		return out, attemptResult, err
+	}
+
 	if releaseError := relRetryToken(err); releaseError != nil && err != nil {
 		err = fmt.Errorf("failed to release token after request error, %w", err)
 		return out, attemptResult, err
@@ -183,6 +194,16 @@ func (r Attempt) handleAttempt(ctx context.Context, in smithymiddle.FinalizeInpu
 	return out, attemptResult, err
 }
 
+// getAttemptToken returns the send token of the retryer if it implements
+// RetryerV2, otherwise a nop release function.
+func getAttemptToken(ctx context.Context, retryer aws.Retryer) (func(error) error, error) {
+	v, ok := retryer.(aws.RetryerV2)
+	if !ok {
+		return nopTokenRelease, nil
+	}
+	return v.GetAttemptToken(ctx)
+}
+
 // MetricsHeader attaches SDK request metric header for retries to the transport
 type MetricsHeader struct{}
 
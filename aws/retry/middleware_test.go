@@ -446,3 +446,105 @@ type mockRawResponseKey struct{}
 func setMockRawResponse(m *middleware.Metadata, v interface{}) {
 	m.Set(mockRawResponseKey{}, v)
 }
+
+type mockRetryerV2 struct {
+	aws.Retryer
+
+	attemptTokenErr error
+	released        []error
+}
+
+func (m *mockRetryerV2) GetAttemptToken(context.Context) (func(error) error, error) {
+	if m.attemptTokenErr != nil {
+		return nil, m.attemptTokenErr
+	}
+	return func(err error) error {
+		m.released = append(m.released, err)
+		return nil
+	}, nil
+}
+
+func TestAttemptMiddleware_RetryerV2(t *testing.T) {
+	restoreSleep := sdk.TestingUseNopSleep()
+	defer restoreSleep()
+
+	cases := map[string]struct {
+		Retryer      aws.Retryer
+		ExpectErr    string
+		ExpectCalls  int
+		ExpectTokens []error
+	}{
+		"attempt tokens released": {
+			Retryer:     &mockRetryerV2{Retryer: NewStandard()},
+			ExpectCalls: 3,
+			ExpectTokens: []error{
+				mockRetryableError{b: true},
+				mockRetryableError{b: true},
+				nil,
+			},
+		},
+		"wrapped retryer": {
+			Retryer:     AddWithMaxAttempts(&mockRetryerV2{Retryer: NewStandard()}, 5),
+			ExpectCalls: 3,
+			ExpectTokens: []error{
+				mockRetryableError{b: true},
+				mockRetryableError{b: true},
+				nil,
+			},
+		},
+		"attempt token error": {
+			Retryer: &mockRetryerV2{
+				Retryer:         NewStandard(),
+				attemptTokenErr: fmt.Errorf("no attempt token"),
+			},
+			ExpectErr: "no attempt token",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			reqsErrs := []error{
+				mockRetryableError{b: true},
+				mockRetryableError{b: true},
+				nil,
+			}
+			var calls int
+
+			am := NewAttemptMiddleware(c.Retryer, func(i interface{}) interface{} { return i })
+			_, _, err := am.HandleFinalize(context.Background(), middleware.FinalizeInput{Request: testRequest{}},
+				middleware.FinalizeHandlerFunc(func(ctx context.Context, in middleware.FinalizeInput) (
+					out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+				) {
+					err = reqsErrs[calls]
+					calls++
+					return out, metadata, err
+				}))
+
+			if len(c.ExpectErr) != 0 {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				if e, a := c.ExpectErr, err.Error(); !strings.Contains(a, e) {
+					t.Fatalf("expect error to contain %q, got %q", e, a)
+				}
+			} else if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			if e, a := c.ExpectCalls, calls; e != a {
+				t.Errorf("expect %v calls, got %v", e, a)
+			}
+
+			var mock *mockRetryerV2
+			switch v := c.Retryer.(type) {
+			case *mockRetryerV2:
+				mock = v
+			case *withMaxAttempts:
+				mock = v.Retryer.(*mockRetryerV2)
+			}
+			if diff := cmp.Diff(c.ExpectTokens, mock.released, cmp.AllowUnexported(mockRetryableError{})); len(diff) != 0 {
+				t.Errorf("expect released tokens match\n%s", diff)
+			}
+		})
+	}
+}
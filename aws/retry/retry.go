@@ -1,6 +1,7 @@
 package retry
 
 import (
+	"context"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -34,6 +35,10 @@ func (r *withIsErrorRetryable) IsErrorRetryable(err error) bool {
 	return r.Retryer.IsErrorRetryable(err)
 }
 
+func (r *withIsErrorRetryable) GetAttemptToken(ctx context.Context) (func(error) error, error) {
+	return getAttemptToken(ctx, r.Retryer)
+}
+
 // AddWithMaxAttempts returns a Retryer with MaxAttempts set to the value
 // specified.
 func AddWithMaxAttempts(r aws.Retryer, max int) aws.Retryer {
@@ -52,6 +57,10 @@ func (w *withMaxAttempts) MaxAttempts() int {
 	return w.Max
 }
 
+func (w *withMaxAttempts) GetAttemptToken(ctx context.Context) (func(error) error, error) {
+	return getAttemptToken(ctx, w.Retryer)
+}
+
 // AddWithMaxBackoffDelay returns a retryer wrapping the passed in retryer
 // overriding the RetryDelay behavior for a alternate minimum initial backoff
 // delay.
@@ -70,3 +79,7 @@ type withMaxBackoffDelay struct {
 func (r *withMaxBackoffDelay) RetryDelay(attempt int, err error) (time.Duration, error) {
 	return r.backoff.BackoffDelay(attempt, err)
 }
+
+func (r *withMaxBackoffDelay) GetAttemptToken(ctx context.Context) (func(error) error, error) {
+	return getAttemptToken(ctx, r.Retryer)
+}
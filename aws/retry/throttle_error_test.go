@@ -0,0 +1,59 @@
+package retry
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/smithy-go"
+)
+
+type mockHTTPStatusCodeError struct{ code int }
+
+func (e mockHTTPStatusCodeError) HTTPStatusCode() int { return e.code }
+func (e mockHTTPStatusCodeError) Error() string {
+	return fmt.Sprintf("status code error, %v", e.code)
+}
+
+func TestIsErrorThrottles(t *testing.T) {
+	cases := map[string]struct {
+		Err    error
+		Expect aws.Ternary
+	}{
+		"nil error": {
+			Expect: aws.UnknownTernary,
+		},
+		"error code": {
+			Err:    &smithy.GenericAPIError{Code: "ThrottlingException"},
+			Expect: aws.TrueTernary,
+		},
+		"wrapped error code": {
+			Err:    fmt.Errorf("some error, %w", &smithy.GenericAPIError{Code: "SlowDown"}),
+			Expect: aws.TrueTernary,
+		},
+		"unknown error code": {
+			Err:    &smithy.GenericAPIError{Code: "AccessDenied"},
+			Expect: aws.UnknownTernary,
+		},
+		"status code": {
+			Err:    mockHTTPStatusCodeError{code: 429},
+			Expect: aws.TrueTernary,
+		},
+		"unknown status code": {
+			Err:    mockHTTPStatusCodeError{code: 500},
+			Expect: aws.UnknownTernary,
+		},
+		"other error": {
+			Err:    fmt.Errorf("some error"),
+			Expect: aws.UnknownTernary,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if e, a := c.Expect, IsErrorThrottles(DefaultThrottles).IsErrorThrottle(c.Err); e != a {
+				t.Errorf("expect %v throttle, got %v", e, a)
+			}
+		})
+	}
+}
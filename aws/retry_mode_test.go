@@ -0,0 +1,47 @@
+package aws
+
+import (
+	"testing"
+)
+
+func TestParseRetryMode(t *testing.T) {
+	cases := map[string]struct {
+		Value       string
+		Expect      RetryMode
+		ExpectError bool
+	}{
+		"standard": {
+			Value:  "standard",
+			Expect: RetryModeStandard,
+		},
+		"adaptive": {
+			Value:  "adaptive",
+			Expect: RetryModeAdaptive,
+		},
+		"empty": {
+			ExpectError: true,
+		},
+		"unknown": {
+			Value:       "legacy",
+			ExpectError: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			mode, err := ParseRetryMode(c.Value)
+			if c.ExpectError {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.Expect, mode; e != a {
+				t.Errorf("expect %v mode, got %v", e, a)
+			}
+		})
+	}
+}
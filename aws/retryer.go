@@ -34,6 +34,20 @@ type Retryer interface {
 	GetInitialToken() (releaseToken func(error) error)
 }
 
+// RetryerV2 is an interface to determine if a given error from a request
+// should be retried, and if so what backoff delay to apply. Extends the
+// Retryer interface with the ability to delay, or fail, each request attempt
+// before it is sent.
+type RetryerV2 interface {
+	Retryer
+
+	// GetAttemptToken returns the send token that must be acquired before an
+	// attempt is made. Implementations may block until the attempt is allowed
+	// to be sent. The returned release function is called with the attempt's
+	// error, or nil, after the attempt has completed.
+	GetAttemptToken(context.Context) (releaseToken func(error) error, err error)
+}
+
 // NopRetryer provides a RequestRetryDecider implementation that will flag
 // all attempt errors as not retryable, with a max attempts of 1.
 type NopRetryer struct{}
@@ -59,4 +73,9 @@ func (NopRetryer) GetInitialToken() func(error) error {
 	return nopReleaseToken
 }
 
+// GetAttemptToken returns a stub function that does nothing.
+func (NopRetryer) GetAttemptToken(context.Context) (func(error) error, error) {
+	return nopReleaseToken, nil
+}
+
 func nopReleaseToken(error) error { return nil }
@@ -0,0 +1,173 @@
+package v4
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	crlf = "\r\n"
+
+	chunkSignatureExtension = ";chunk-signature="
+	trailerSignatureHeader  = "x-amz-trailer-signature"
+
+	// length of a hex encoded SigV4 signature
+	signatureLength = 64
+)
+
+// trailingChecksum is the checksum of the decoded payload sent as a trailing
+// header after the final chunk of an aws-chunked encoded payload.
+type trailingChecksum struct {
+	header string
+	hash   hash.Hash
+}
+
+// awsChunkedEncoder is an io.Reader that encodes the payload stream with the
+// aws-chunked encoding, signing each chunk with the StreamSigner.
+type awsChunkedEncoder struct {
+	stream        io.Reader
+	signer        *StreamSigner
+	chunk         []byte
+	decodedLength int64
+	trailer       *trailingChecksum
+
+	buf       bytes.Buffer
+	readBytes int64
+	done      bool
+}
+
+func newAWSChunkedEncoder(
+	stream io.Reader, signer *StreamSigner, chunkSize int, decodedLength int64, trailer *trailingChecksum,
+) *awsChunkedEncoder {
+	return &awsChunkedEncoder{
+		stream:        stream,
+		signer:        signer,
+		chunk:         make([]byte, chunkSize),
+		decodedLength: decodedLength,
+		trailer:       trailer,
+	}
+}
+
+// Read reads the aws-chunked encoded payload into p.
+func (e *awsChunkedEncoder) Read(p []byte) (n int, err error) {
+	for e.buf.Len() == 0 {
+		if e.done {
+			return 0, io.EOF
+		}
+		if err := e.encodeNextChunk(); err != nil {
+			return 0, err
+		}
+	}
+
+	return e.buf.Read(p)
+}
+
+func (e *awsChunkedEncoder) encodeNextChunk() error {
+	n, err := io.ReadFull(e.stream, e.chunk)
+	switch {
+	case err == io.EOF || err == io.ErrUnexpectedEOF:
+		e.done = true
+	case err != nil:
+		return err
+	}
+
+	e.readBytes += int64(n)
+	if e.readBytes > e.decodedLength || (e.done && e.readBytes != e.decodedLength) {
+		return fmt.Errorf("aws-chunked payload length does not match the decoded content length, %d, expected %d",
+			e.readBytes, e.decodedLength)
+	}
+
+	if n != 0 {
+		e.writeChunk(e.chunk[:n])
+		if e.trailer != nil {
+			e.trailer.hash.Write(e.chunk[:n])
+		}
+	}
+
+	// The payload is complete once the decoded length has been read, without
+	// waiting for the stream to return EOF.
+	if e.readBytes == e.decodedLength {
+		e.done = true
+	}
+
+	if e.done {
+		e.writeChunk(nil)
+		e.writeTrailer()
+	}
+
+	return nil
+}
+
+func (e *awsChunkedEncoder) writeChunk(chunk []byte) {
+	e.buf.WriteString(strconv.FormatInt(int64(len(chunk)), 16))
+	e.buf.WriteString(chunkSignatureExtension)
+	e.buf.WriteString(e.signer.GetChunkSignature(chunk))
+	e.buf.WriteString(crlf)
+	e.buf.Write(chunk)
+	if len(chunk) != 0 || e.trailer == nil {
+		e.buf.WriteString(crlf)
+	}
+}
+
+func (e *awsChunkedEncoder) writeTrailer() {
+	if e.trailer == nil {
+		return
+	}
+
+	trailer := e.trailer.header + ":" + base64.StdEncoding.EncodeToString(e.trailer.hash.Sum(nil))
+	signature := e.signer.GetTrailerSignature([]byte(trailer + "\n"))
+
+	e.buf.WriteString(trailer)
+	e.buf.WriteString(crlf)
+	e.buf.WriteString(trailerSignatureHeader)
+	e.buf.WriteString(":")
+	e.buf.WriteString(signature)
+	e.buf.WriteString(crlf)
+	e.buf.WriteString(crlf)
+}
+
+// awsChunkedEncodedLength returns the length of the aws-chunked encoding of a
+// payload of the decoded length.
+func awsChunkedEncodedLength(decodedLength int64, chunkSize int, trailer *trailingChecksum) int64 {
+	chunkLength := func(size int64) int64 {
+		return int64(len(strconv.FormatInt(size, 16))) + int64(len(chunkSignatureExtension)) +
+			signatureLength + int64(len(crlf)) + size + int64(len(crlf))
+	}
+
+	fullChunks := decodedLength / int64(chunkSize)
+	length := fullChunks * chunkLength(int64(chunkSize))
+	if remainder := decodedLength % int64(chunkSize); remainder != 0 {
+		length += chunkLength(remainder)
+	}
+
+	if trailer == nil {
+		return length + chunkLength(0)
+	}
+
+	// final chunk does not end with a CRLF when followed by trailing headers.
+	length += chunkLength(0) - int64(len(crlf))
+	length += int64(len(trailer.header)) + 1 +
+		int64(base64.StdEncoding.EncodedLen(trailer.hash.Size())) + int64(len(crlf))
+	length += int64(len(trailerSignatureHeader)) + 1 + signatureLength + int64(len(crlf))
+	length += int64(len(crlf))
+
+	return length
+}
+
+// getSeedSignature returns the signature of the request from the SigV4
+// Authorization header value.
+func getSeedSignature(authorization string) (string, error) {
+	const signature = "Signature="
+
+	i := strings.LastIndex(authorization, signature)
+	if i < 0 {
+		return "", fmt.Errorf("request signature not found")
+	}
+
+	return authorization[i+len(signature):], nil
+}
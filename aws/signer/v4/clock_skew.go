@@ -0,0 +1,120 @@
+package v4
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/internal/sdk"
+)
+
+// clockSkewRetryThreshold is the minimum difference between the clock skew
+// measured from a response, and the clock offset a request was signed with,
+// for a request failing with a clock skew error to be retried with the
+// corrected offset.
+const clockSkewRetryThreshold = 4 * time.Minute
+
+// clockSkewErrorCodes are the API error codes of requests that may have
+// failed because the request was signed with a time skewed from the service's
+// clock.
+var clockSkewErrorCodes = map[string]struct{}{
+	"RequestTimeTooSkewed":      {},
+	"RequestExpired":            {},
+	"RequestInTheFuture":        {},
+	"InvalidSignatureException": {},
+	"SignatureDoesNotMatch":     {},
+	"AuthFailure":               {},
+}
+
+// ClockOffsetTracker tracks the offset of the local clock from the clock of a
+// service, as measured from the Date header of the service's responses. The
+// SignHTTPRequestMiddleware signs requests with the local time corrected by
+// the offset, and updates the offset when a request fails with a clock skew
+// error.
+//
+// The ClockOffsetTracker is safe to use concurrently. The zero value is a
+// tracker with no offset.
+type ClockOffsetTracker struct {
+	mu     sync.RWMutex
+	offset time.Duration
+	set    bool
+}
+
+// Offset returns the current offset of the local clock from the service's
+// clock.
+func (t *ClockOffsetTracker) Offset() time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.offset
+}
+
+// Now returns the local time corrected by the current offset.
+func (t *ClockOffsetTracker) Now() time.Time {
+	return sdk.NowTime().Add(t.Offset())
+}
+
+// Update updates the offset with the clock skew measured from a response. The
+// first skew measured sets the offset. Later measurements are smoothed with
+// the current offset, so a single response does not move the offset to an
+// outlying value.
+func (t *ClockOffsetTracker) Update(skew time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.set {
+		t.offset = skew
+		t.set = true
+		return
+	}
+	t.offset += (skew - t.offset) / 2
+}
+
+// updateClockOffset updates the tracker with the clock skew measured from the
+// response of a request signed with the offset, if the request failed with a
+// clock skew error. Returns if the request should be retried with the
+// corrected offset.
+func updateClockOffset(tracker *ClockOffsetTracker, err error, offset, skew time.Duration) bool {
+	var apiErr interface{ ErrorCode() string }
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	if _, ok := clockSkewErrorCodes[apiErr.ErrorCode()]; !ok {
+		return false
+	}
+
+	tracker.Update(skew)
+
+	diff := skew - offset
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff >= clockSkewRetryThreshold
+}
+
+// ClockSkewError is returned by the SignHTTPRequestMiddleware when a request
+// failed because it was signed with a time skewed from the service's clock.
+// The request is retryable, and will be signed with the corrected clock offset
+// when retried.
+type ClockSkewError struct {
+	// The clock skew measured from the service's response.
+	Skew time.Duration
+
+	Err error
+}
+
+// Error returns the error message.
+func (e *ClockSkewError) Error() string {
+	return fmt.Sprintf("request signed with clock skewed %v from service, %v", e.Skew, e.Err)
+}
+
+// Unwrap returns the error of the failed request.
+func (e *ClockSkewError) Unwrap() error {
+	return e.Err
+}
+
+// RetryableError returns true, as the request will be signed with the
+// corrected clock offset when retried.
+func (e *ClockSkewError) RetryableError() bool {
+	return true
+}
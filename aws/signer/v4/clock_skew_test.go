@@ -0,0 +1,205 @@
+package v4
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/internal/awstesting/unit"
+	"github.com/aws/aws-sdk-go-v2/internal/sdk"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+func TestClockOffsetTracker_Update(t *testing.T) {
+	var tracker ClockOffsetTracker
+	if e, a := time.Duration(0), tracker.Offset(); e != a {
+		t.Errorf("expect %v offset, got %v", e, a)
+	}
+
+	tracker.Update(10 * time.Minute)
+	if e, a := 10*time.Minute, tracker.Offset(); e != a {
+		t.Errorf("expect %v offset, got %v", e, a)
+	}
+
+	tracker.Update(20 * time.Minute)
+	if e, a := 15*time.Minute, tracker.Offset(); e != a {
+		t.Errorf("expect %v offset, got %v", e, a)
+	}
+
+	tracker.Update(-5 * time.Minute)
+	if e, a := 5*time.Minute, tracker.Offset(); e != a {
+		t.Errorf("expect %v offset, got %v", e, a)
+	}
+}
+
+func TestClockOffsetTracker_Now(t *testing.T) {
+	now := time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)
+	defer func(fn func() time.Time) { sdk.NowTime = fn }(sdk.NowTime)
+	sdk.NowTime = func() time.Time { return now }
+
+	var tracker ClockOffsetTracker
+	tracker.Update(-time.Hour)
+	if e, a := now.Add(-time.Hour), tracker.Now(); !e.Equal(a) {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
+
+func TestSignHTTPRequestMiddleware_ClockSkew(t *testing.T) {
+	localTime := time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)
+	defer func(fn func() time.Time) { sdk.NowTime = fn }(sdk.NowTime)
+	sdk.NowTime = func() time.Time { return localTime }
+
+	cases := map[string]struct {
+		offset          time.Duration
+		serverTime      time.Time
+		err             error
+		expectSignedAt  time.Time
+		expectOffset    time.Duration
+		expectSkewError bool
+	}{
+		"no error": {
+			serverTime:     localTime.Add(10 * time.Minute),
+			expectSignedAt: localTime,
+		},
+		"skewed": {
+			serverTime:      localTime.Add(10 * time.Minute),
+			err:             &smithy.GenericAPIError{Code: "RequestTimeTooSkewed"},
+			expectSignedAt:  localTime,
+			expectOffset:    10 * time.Minute,
+			expectSkewError: true,
+		},
+		"signature mismatch": {
+			serverTime:      localTime.Add(-10 * time.Minute),
+			err:             &smithy.GenericAPIError{Code: "SignatureDoesNotMatch"},
+			expectSignedAt:  localTime,
+			expectOffset:    -10 * time.Minute,
+			expectSkewError: true,
+		},
+		"signed with offset": {
+			offset:         10 * time.Minute,
+			serverTime:     localTime.Add(10 * time.Minute),
+			expectSignedAt: localTime.Add(10 * time.Minute),
+			expectOffset:   10 * time.Minute,
+		},
+		"offset within threshold": {
+			offset:         10 * time.Minute,
+			serverTime:     localTime.Add(12 * time.Minute),
+			err:            &smithy.GenericAPIError{Code: "RequestTimeTooSkewed"},
+			expectSignedAt: localTime.Add(10 * time.Minute),
+			expectOffset:   11 * time.Minute,
+		},
+		"not clock skew error": {
+			serverTime:     localTime.Add(10 * time.Minute),
+			err:            &smithy.GenericAPIError{Code: "AccessDenied"},
+			expectSignedAt: localTime,
+		},
+		"not API error": {
+			serverTime:     localTime.Add(10 * time.Minute),
+			err:            errors.New("some error"),
+			expectSignedAt: localTime,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			tracker := &ClockOffsetTracker{}
+			if c.offset != 0 {
+				tracker.Update(c.offset)
+			}
+
+			var signedAt time.Time
+			m := NewSignHTTPRequestMiddleware(SignHTTPRequestMiddlewareOptions{
+				CredentialsProvider: unit.StubCredentialsProvider{},
+				Signer: httpSignerFunc(
+					func(ctx context.Context,
+						credentials aws.Credentials, r *http.Request, payloadHash string,
+						service string, region string, signingTime time.Time,
+						optFns ...func(*SignerOptions),
+					) error {
+						signedAt = signingTime
+						return nil
+					}),
+				ClockOffset: tracker,
+			})
+
+			next := middleware.FinalizeHandlerFunc(func(ctx context.Context, in middleware.FinalizeInput) (
+				out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+			) {
+				_, metadata, err = awsmiddleware.RecordResponseTiming{}.HandleDeserialize(ctx, middleware.DeserializeInput{},
+					middleware.DeserializeHandlerFunc(func(ctx context.Context, in middleware.DeserializeInput) (
+						out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+					) {
+						resp := &http.Response{Header: http.Header{}}
+						resp.Header.Set("Date", c.serverTime.Format(http.TimeFormat))
+						out.RawResponse = &smithyhttp.Response{Response: resp}
+						return out, metadata, c.err
+					}))
+				return out, metadata, err
+			})
+
+			ctx := SetPayloadHash(context.Background(), "0123456789abcdef")
+			_, _, err := m.HandleFinalize(ctx, middleware.FinalizeInput{
+				Request: &smithyhttp.Request{Request: &http.Request{}},
+			}, next)
+
+			if e, a := c.expectSignedAt, signedAt; !e.Equal(a) {
+				t.Errorf("expect signed at %v, got %v", e, a)
+			}
+			if e, a := c.expectOffset, tracker.Offset(); e != a {
+				t.Errorf("expect %v offset, got %v", e, a)
+			}
+
+			if c.err == nil {
+				if err != nil {
+					t.Fatalf("expect no error, got %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, c.err) {
+				t.Errorf("expect %v error, got %v", c.err, err)
+			}
+
+			var skewErr *ClockSkewError
+			if e, a := c.expectSkewError, errors.As(err, &skewErr); e != a {
+				t.Fatalf("expect %v clock skew error, got %v, %v", e, a, err)
+			}
+			if c.expectSkewError {
+				if e, a := c.serverTime.Sub(localTime), skewErr.Skew; e != a {
+					t.Errorf("expect %v skew, got %v", e, a)
+				}
+			}
+			if e, a := c.expectSkewError, retry.IsErrorRetryables(retry.DefaultRetryables).IsErrorRetryable(err).Bool(); e != a {
+				t.Errorf("expect %v retryable, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestSignHTTPRequestMiddleware_SignerClockOffset(t *testing.T) {
+	signer := NewSigner()
+	m := NewSignHTTPRequestMiddleware(SignHTTPRequestMiddlewareOptions{
+		Signer: signer,
+	})
+	if m.clockOffset == nil {
+		t.Fatalf("expect clock offset tracker, got none")
+	}
+	if e, a := signer.ClockOffset(), m.clockOffset; e != a {
+		t.Errorf("expect signer's clock offset tracker, got %p", a)
+	}
+
+	tracker := &ClockOffsetTracker{}
+	m = NewSignHTTPRequestMiddleware(SignHTTPRequestMiddlewareOptions{
+		Signer:      signer,
+		ClockOffset: tracker,
+	})
+	if e, a := tracker, m.clockOffset; e != a {
+		t.Errorf("expect configured clock offset tracker, got %p", a)
+	}
+}
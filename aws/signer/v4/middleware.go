@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
@@ -200,6 +201,12 @@ type SignHTTPRequestMiddlewareOptions struct {
 	CredentialsProvider aws.CredentialsProvider
 	Signer              HTTPSigner
 	LogSigning          bool
+
+	// The tracker of the clock offset requests are signed with, shared by
+	// the requests of a client. If nil, the ClockOffsetTracker of the Signer
+	// is used, if the Signer provides one, such as the Signer returned by
+	// NewSigner.
+	ClockOffset *ClockOffsetTracker
 }
 
 // SignHTTPRequestMiddleware is a `FinalizeMiddleware` implementation for SigV4 HTTP Signing
@@ -207,14 +214,23 @@ type SignHTTPRequestMiddleware struct {
 	credentialsProvider aws.CredentialsProvider
 	signer              HTTPSigner
 	logSigning          bool
+	clockOffset         *ClockOffsetTracker
 }
 
 // NewSignHTTPRequestMiddleware constructs a SignHTTPRequestMiddleware using the given Signer for signing requests
 func NewSignHTTPRequestMiddleware(options SignHTTPRequestMiddlewareOptions) *SignHTTPRequestMiddleware {
+	clockOffset := options.ClockOffset
+	if clockOffset == nil {
+		if p, ok := options.Signer.(interface{ ClockOffset() *ClockOffsetTracker }); ok {
+			clockOffset = p.ClockOffset()
+		}
+	}
+
 	return &SignHTTPRequestMiddleware{
 		credentialsProvider: options.CredentialsProvider,
 		signer:              options.Signer,
 		logSigning:          options.LogSigning,
+		clockOffset:         clockOffset,
 	}
 }
 
@@ -223,7 +239,13 @@ func (s *SignHTTPRequestMiddleware) ID() string {
 	return "Signing"
 }
 
-// HandleFinalize will take the provided input and sign the request using the SigV4 authentication scheme
+// HandleFinalize will take the provided input and sign the request using the SigV4 authentication scheme.
+//
+// If the middleware has a ClockOffsetTracker, the request is signed with the
+// local time corrected by the tracker's offset. If the request fails with a
+// clock skew error, the offset is updated with the clock skew measured from
+// the response, and a retryable ClockSkewError is returned if the request was
+// signed with a skewed time, so the retry is signed with the corrected offset.
 func (s *SignHTTPRequestMiddleware) HandleFinalize(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
 	out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
 ) {
@@ -247,7 +269,14 @@ func (s *SignHTTPRequestMiddleware) HandleFinalize(ctx context.Context, in middl
 		return out, metadata, &SigningError{Err: fmt.Errorf("failed to retrieve credentials: %w", err)}
 	}
 
-	err = s.signer.SignHTTP(ctx, credentials, req.Request, payloadHash, signingName, signingRegion, sdk.NowTime(),
+	signingTime := sdk.NowTime()
+	var clockOffset time.Duration
+	if s.clockOffset != nil {
+		clockOffset = s.clockOffset.Offset()
+		signingTime = signingTime.Add(clockOffset)
+	}
+
+	err = s.signer.SignHTTP(ctx, credentials, req.Request, payloadHash, signingName, signingRegion, signingTime,
 		func(o *SignerOptions) {
 			o.Logger = middleware.GetLogger(ctx)
 			o.LogSigning = s.logSigning
@@ -256,7 +285,24 @@ func (s *SignHTTPRequestMiddleware) HandleFinalize(ctx context.Context, in middl
 		return out, metadata, &SigningError{Err: fmt.Errorf("failed to sign http request, %w", err)}
 	}
 
-	return next.HandleFinalize(ctx, in)
+	// The chunks of a streaming payload are signed as the payload is sent,
+	// chained to the signature of the request.
+	if isStreamingPayload(payloadHash) {
+		in.Request, err = encodeStreamingPayload(ctx, req, credentials, signingName, signingRegion, signingTime)
+		if err != nil {
+			return out, metadata, &SigningError{Err: fmt.Errorf("failed to sign streaming payload, %w", err)}
+		}
+	}
+
+	out, metadata, err = next.HandleFinalize(ctx, in)
+	if err != nil && s.clockOffset != nil {
+		if skew, ok := awsmiddleware.GetAttemptSkew(metadata); ok &&
+			updateClockOffset(s.clockOffset, err, clockOffset, skew) {
+			err = &ClockSkewError{Skew: skew, Err: err}
+		}
+	}
+
+	return out, metadata, err
 }
 
 func haveCredentialProvider(p aws.CredentialsProvider) bool {
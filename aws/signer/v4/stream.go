@@ -0,0 +1,96 @@
+package v4
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4Internal "github.com/aws/aws-sdk-go-v2/aws/signer/internal/v4"
+)
+
+const (
+	// StreamingPayload is the payload hash of a request whose payload is
+	// sent with aws-chunked encoding, and each chunk of the payload is signed.
+	StreamingPayload = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
+
+	// StreamingPayloadTrailer is the payload hash of a request whose payload
+	// is sent with aws-chunked encoding, each chunk of the payload is signed,
+	// and the payload is followed by signed trailing headers.
+	StreamingPayloadTrailer = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD-TRAILER"
+
+	chunkSigningAlgorithm   = "AWS4-HMAC-SHA256-PAYLOAD"
+	trailerSigningAlgorithm = "AWS4-HMAC-SHA256-TRAILER"
+)
+
+// StreamSigner signs the chunks, and trailing headers of a payload sent with
+// aws-chunked encoding. Each signature is chained to the previous signature,
+// starting with the seed signature of the request's headers.
+//
+// A StreamSigner is not safe for concurrent use, and the chunks must be
+// signed in the order they are sent.
+type StreamSigner struct {
+	credentials     aws.Credentials
+	service         string
+	region          string
+	signingTime     v4Internal.SigningTime
+	keyDerivator    keyDerivator
+	credentialScope string
+
+	prevSignature string
+}
+
+// NewStreamSigner returns a StreamSigner for the payload of a request signed
+// with the credentials, service, region, and signing time, where the seed
+// signature is the hex encoded signature of the request.
+func NewStreamSigner(credentials aws.Credentials, service, region string, signingTime time.Time, seedSignature string) *StreamSigner {
+	t := v4Internal.NewSigningTime(signingTime.UTC())
+
+	return &StreamSigner{
+		credentials:  credentials,
+		service:      service,
+		region:       region,
+		signingTime:  t,
+		keyDerivator: v4Internal.NewSigningKeyDeriver(),
+		credentialScope: strings.Join([]string{
+			t.ShortTimeFormat(),
+			region,
+			service,
+			"aws4_request",
+		}, "/"),
+		prevSignature: seedSignature,
+	}
+}
+
+// GetChunkSignature returns the hex encoded signature of the payload chunk.
+// The final zero length chunk of the payload must also be signed.
+func (s *StreamSigner) GetChunkSignature(chunk []byte) string {
+	return s.sign(chunkSigningAlgorithm,
+		v4Internal.EmptyStringSHA256,
+		hex.EncodeToString(makeHash(sha256.New(), chunk)),
+	)
+}
+
+// GetTrailerSignature returns the hex encoded signature of the trailing
+// headers sent after the final chunk of the payload. The trailing headers
+// must be formatted as lines of "name:value\n".
+func (s *StreamSigner) GetTrailerSignature(trailer []byte) string {
+	return s.sign(trailerSigningAlgorithm,
+		hex.EncodeToString(makeHash(sha256.New(), trailer)),
+	)
+}
+
+func (s *StreamSigner) sign(algorithm string, hashes ...string) string {
+	strToSign := strings.Join(append([]string{
+		algorithm,
+		s.signingTime.TimeFormat(),
+		s.credentialScope,
+		s.prevSignature,
+	}, hashes...), "\n")
+
+	key := s.keyDerivator.DeriveKey(s.credentials, s.service, s.region, s.signingTime)
+	s.prevSignature = hex.EncodeToString(v4Internal.HMACSHA256(key, []byte(strToSign)))
+
+	return s.prevSignature
+}
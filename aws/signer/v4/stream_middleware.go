@@ -0,0 +1,246 @@
+package v4
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hash"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/smithy-go/middleware"
+	smithyHTTP "github.com/aws/smithy-go/transport/http"
+)
+
+const (
+	// DefaultStreamingPayloadChunkSize is the size of the chunks of an
+	// aws-chunked encoded payload, if a chunk size is not provided.
+	DefaultStreamingPayloadChunkSize = 64 * 1024
+
+	// MinStreamingPayloadChunkSize is the minimum size of the chunks of an
+	// aws-chunked encoded payload. Only the final chunk of the payload may be
+	// smaller.
+	MinStreamingPayloadChunkSize = 8 * 1024
+
+	awsChunkedContentEncoding  = "aws-chunked"
+	decodedContentLengthHeader = "X-Amz-Decoded-Content-Length"
+	trailerHeader              = "X-Amz-Trailer"
+)
+
+// StreamingPayloadOptions is the options for signing the request payload
+// with aws-chunked encoding.
+type StreamingPayloadOptions struct {
+	// The size of the chunks the payload is split into, and signed. Defaults
+	// to DefaultStreamingPayloadChunkSize. Must not be smaller than
+	// MinStreamingPayloadChunkSize.
+	ChunkSize int
+
+	// The name of the header the checksum of the payload is sent as after the
+	// final chunk of the payload, e.g. "x-amz-checksum-crc32". The checksum is
+	// only sent if TrailingChecksum is also set.
+	TrailingChecksumHeader string
+
+	// Returns the hash the trailing checksum of the payload is computed with.
+	// The checksum is base64 encoded.
+	TrailingChecksum func() hash.Hash
+}
+
+// streamingPayload sets the SigV4 request payload hash to sign the payload
+// with aws-chunked encoding, where each chunk of the payload is signed as the
+// payload is sent, instead of computing the hash of the payload before the
+// request is signed.
+//
+// This allows signed requests to be made with payloads that are not
+// seekable, such as pipes, without buffering the payload. The length of the
+// payload must be known.
+type streamingPayload struct {
+	options StreamingPayloadOptions
+}
+
+// AddStreamingPayloadMiddleware replaces the payload hash middleware of the
+// operation middleware stack, to sign the request payload with aws-chunked
+// encoding. The SignHTTPRequestMiddleware encodes, and signs each chunk of the
+// payload as it is sent.
+//
+// Add the middleware to an operation's API options to sign the payload of
+// that operation with aws-chunked encoding. The length of the payload must be
+// provided, such as the ContentLength member of the Amazon S3 PutObject input.
+//
+//  out, err := client.PutObject(context.TODO(), &s3.PutObjectInput{
+//      Bucket:        aws.String("mybucket"),
+//      Key:           aws.String("mykey"),
+//      Body:          pipeReader,
+//      ContentLength: length,
+//  }, s3.WithAPIOptions(func(stack *middleware.Stack) error {
+//      return v4.AddStreamingPayloadMiddleware(stack)
+//  }))
+func AddStreamingPayloadMiddleware(stack *middleware.Stack, optFns ...func(*StreamingPayloadOptions)) error {
+	options := StreamingPayloadOptions{
+		ChunkSize: DefaultStreamingPayloadChunkSize,
+	}
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
+	m := &streamingPayload{options: options}
+	if _, ok := stack.Build.Get(computePayloadHashMiddlewareID); ok {
+		if _, err := stack.Build.Swap(computePayloadHashMiddlewareID, m); err != nil {
+			return err
+		}
+	} else if err := stack.Build.Add(m, middleware.After); err != nil {
+		return err
+	}
+
+	if _, ok := stack.Build.Get((*contentSHA256Header)(nil).ID()); ok {
+		return nil
+	}
+	return AddContentSHA256HeaderMiddleware(stack)
+}
+
+// ID returns the streamingPayload identifier
+func (m *streamingPayload) ID() string {
+	return computePayloadHashMiddlewareID
+}
+
+// HandleBuild sets the payload hash to be a streaming payload, and the
+// aws-chunked encoding headers of the request.
+func (m *streamingPayload) HandleBuild(
+	ctx context.Context, in middleware.BuildInput, next middleware.BuildHandler,
+) (
+	out middleware.BuildOutput, metadata middleware.Metadata, err error,
+) {
+	req, ok := in.Request.(*smithyHTTP.Request)
+	if !ok {
+		return out, metadata, &HashComputationError{
+			Err: fmt.Errorf("unexpected request middleware type %T", in.Request),
+		}
+	}
+
+	// An earlier middleware may have already determined the request's
+	// payload must be streamed, (e.g. to send a trailing checksum).
+	if isStreamingPayload(GetPayloadHash(ctx)) {
+		return next.HandleBuild(ctx, in)
+	}
+
+	ctx, err = useStreamingPayload(ctx, req, m.options)
+	if err != nil {
+		return out, metadata, err
+	}
+
+	return next.HandleBuild(ctx, in)
+}
+
+// UseStreamingPayload updates the request, and returns a context, for the
+// request's payload to be signed with aws-chunked encoding by the
+// SignHTTPRequestMiddleware. The length of the payload must be known.
+//
+// UseStreamingPayload is for Build step middleware that determine when the
+// request is built that its payload needs to be streamed, such as sending a
+// trailing checksum of a payload that is not seekable. The middleware must be
+// run before the stack's payload hash middleware. Use
+// AddStreamingPayloadMiddleware to always stream an operation's payload.
+func UseStreamingPayload(
+	ctx context.Context, req *smithyHTTP.Request, optFns ...func(*StreamingPayloadOptions),
+) (context.Context, error) {
+	options := StreamingPayloadOptions{
+		ChunkSize: DefaultStreamingPayloadChunkSize,
+	}
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
+	return useStreamingPayload(ctx, req, options)
+}
+
+func useStreamingPayload(
+	ctx context.Context, req *smithyHTTP.Request, options StreamingPayloadOptions,
+) (context.Context, error) {
+	if options.ChunkSize < MinStreamingPayloadChunkSize {
+		return ctx, &HashComputationError{
+			Err: fmt.Errorf("streaming payload chunk size must be at least %d, %d",
+				MinStreamingPayloadChunkSize, options.ChunkSize),
+		}
+	}
+
+	decodedLength := req.ContentLength
+	if decodedLength < 0 {
+		return ctx, &HashComputationError{
+			Err: fmt.Errorf("streaming payload requires the length of the payload to be known"),
+		}
+	}
+
+	var trailer *trailingChecksum
+	payloadHash := StreamingPayload
+	if len(options.TrailingChecksumHeader) != 0 && options.TrailingChecksum != nil {
+		trailer = &trailingChecksum{
+			header: options.TrailingChecksumHeader,
+			hash:   options.TrailingChecksum(),
+		}
+		payloadHash = StreamingPayloadTrailer
+		req.Header.Set(trailerHeader, trailer.header)
+	}
+
+	contentEncoding := awsChunkedContentEncoding
+	if v := req.Header.Get("Content-Encoding"); len(v) != 0 {
+		contentEncoding += "," + v
+	}
+	req.Header.Set("Content-Encoding", contentEncoding)
+	req.Header.Set(decodedContentLengthHeader, strconv.FormatInt(decodedLength, 10))
+	req.ContentLength = awsChunkedEncodedLength(decodedLength, options.ChunkSize, trailer)
+
+	ctx = SetPayloadHash(ctx, payloadHash)
+	ctx = middleware.WithStackValue(ctx, streamingPayloadOptionsKey{}, options)
+
+	return ctx, nil
+}
+
+type streamingPayloadOptionsKey struct{}
+
+func getStreamingPayloadOptions(ctx context.Context) (v StreamingPayloadOptions, ok bool) {
+	v, ok = middleware.GetStackValue(ctx, streamingPayloadOptionsKey{}).(StreamingPayloadOptions)
+	return v, ok
+}
+
+func isStreamingPayload(payloadHash string) bool {
+	return payloadHash == StreamingPayload || payloadHash == StreamingPayloadTrailer
+}
+
+// encodeStreamingPayload returns a copy of the signed request with its
+// payload wrapped in an aws-chunked encoder, signing each chunk of the
+// payload chained to the request's signature.
+func encodeStreamingPayload(
+	ctx context.Context, req *smithyHTTP.Request, credentials aws.Credentials,
+	service, region string, signingTime time.Time,
+) (*smithyHTTP.Request, error) {
+	options, ok := getStreamingPayloadOptions(ctx)
+	if !ok {
+		return nil, fmt.Errorf("streaming payload options missing from context")
+	}
+
+	seedSignature, err := getSeedSignature(req.Header.Get(authorizationHeader))
+	if err != nil {
+		return nil, err
+	}
+
+	decodedLength, err := strconv.ParseInt(req.Header.Get(decodedContentLengthHeader), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid decoded content length, %w", err)
+	}
+
+	var trailer *trailingChecksum
+	if len(req.Header.Get(trailerHeader)) != 0 {
+		trailer = &trailingChecksum{
+			header: options.TrailingChecksumHeader,
+			hash:   options.TrailingChecksum(),
+		}
+	}
+
+	stream := req.GetStream()
+	if stream == nil {
+		stream = bytes.NewReader(nil)
+	}
+
+	signer := NewStreamSigner(credentials, service, region, signingTime, seedSignature)
+	return req.SetStream(newAWSChunkedEncoder(stream, signer, options.ChunkSize, decodedLength, trailer))
+}
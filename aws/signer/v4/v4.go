@@ -108,6 +108,7 @@ type SignerOptions struct {
 type Signer struct {
 	options      SignerOptions
 	keyDerivator keyDerivator
+	clockOffset  *ClockOffsetTracker
 }
 
 // NewSigner returns a new SigV4 Signer
@@ -118,7 +119,18 @@ func NewSigner(optFns ...func(signer *SignerOptions)) *Signer {
 		fn(&options)
 	}
 
-	return &Signer{options: options, keyDerivator: v4Internal.NewSigningKeyDeriver()}
+	return &Signer{
+		options:      options,
+		keyDerivator: v4Internal.NewSigningKeyDeriver(),
+		clockOffset:  &ClockOffsetTracker{},
+	}
+}
+
+// ClockOffset returns the tracker of the clock offset requests signed by the
+// SignHTTPRequestMiddleware with the Signer are corrected by. The tracker is
+// shared by the requests of the client the Signer was created for.
+func (s *Signer) ClockOffset() *ClockOffsetTracker {
+	return s.clockOffset
 }
 
 type httpSigner struct {
@@ -358,6 +370,34 @@ func (s *Signer) PresignHTTP(
 	return signedRequest.Request.URL.String(), signedHeaders, nil
 }
 
+// SignString signs an arbitrary string with the signing key derived from the
+// credentials, service name, region, and signing time. Returns the hex encoded
+// signature of the string.
+//
+// This is used to sign content that is not an HTTP request, such as the base64
+// encoded policy document of an Amazon S3 browser-based POST upload.
+func (s *Signer) SignString(
+	ctx context.Context, credentials aws.Credentials, stringToSign string,
+	service string, region string, signingTime time.Time,
+	optFns ...func(*SignerOptions),
+) (string, error) {
+	options := s.options
+
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
+	key := s.keyDerivator.DeriveKey(credentials, service, region, v4Internal.NewSigningTime(signingTime.UTC()))
+	signature := hex.EncodeToString(v4Internal.HMACSHA256(key, []byte(stringToSign)))
+
+	if options.LogSigning {
+		logger := logging.WithContext(ctx, options.Logger)
+		logger.Logf(logging.Debug, logSignStringMsg, stringToSign)
+	}
+
+	return signature, nil
+}
+
 func (s *httpSigner) buildCredentialScope() string {
 	return strings.Join([]string{
 		s.Time.ShortTimeFormat(),
@@ -511,6 +551,10 @@ const logSignInfoMsg = `Request Signature:
 ---[ STRING TO SIGN ]--------------------------------
 %s%s
 -----------------------------------------------------`
+const logSignStringMsg = `String Signature:
+---[ STRING TO SIGN ]--------------------------------
+%s
+-----------------------------------------------------`
 const logSignedURLMsg = `
 ---[ SIGNED URL ]------------------------------------
 %s`
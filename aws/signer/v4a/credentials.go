@@ -0,0 +1,128 @@
+package v4a
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/internal/sdk"
+)
+
+// Credentials is the type to represent the asymmetric credentials used to
+// sign requests with SigV4a.
+type Credentials struct {
+	// Context is the access key ID the private key was derived from, and is
+	// included in the credential scope of the signed request.
+	Context string
+
+	// PrivateKey is the ECDSA P-256 private key requests are signed with.
+	PrivateKey *ecdsa.PrivateKey
+
+	// SessionToken is the AWS session token of temporary credentials.
+	SessionToken string
+
+	// Time the credentials will expire.
+	CanExpire bool
+	Expires   time.Time
+}
+
+// Expired returns if the credentials have expired.
+func (v Credentials) Expired() bool {
+	if v.CanExpire {
+		return !v.Expires.After(sdk.NowTime())
+	}
+
+	return false
+}
+
+// HasKeys returns if the credentials keys are set.
+func (v Credentials) HasKeys() bool {
+	return len(v.Context) > 0 && v.PrivateKey != nil
+}
+
+// CredentialsProvider is the interface for a provider to retrieve the
+// asymmetric credentials used to sign requests with SigV4a.
+type CredentialsProvider interface {
+	RetrievePrivateKey(context.Context) (Credentials, error)
+}
+
+// SymmetricCredentialAdaptor wraps a SigV4 symmetric credentials provider,
+// deriving the SigV4a asymmetric credentials from the access key pair it
+// retrieves.
+//
+// The derived private key is cached, and is only derived again when the
+// access key pair retrieved from the symmetric provider changes. Wrap the
+// symmetric provider with aws.CredentialsCache to avoid retrieving the
+// symmetric credentials each time a request is signed.
+type SymmetricCredentialAdaptor struct {
+	SymmetricProvider aws.CredentialsProvider
+
+	m       sync.Mutex
+	derived *derivedCredentials
+}
+
+type derivedCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	PrivateKey      *ecdsa.PrivateKey
+}
+
+// NewSymmetricCredentialAdaptor returns a SymmetricCredentialAdaptor
+// deriving asymmetric credentials from the symmetric credentials provider.
+func NewSymmetricCredentialAdaptor(provider aws.CredentialsProvider) *SymmetricCredentialAdaptor {
+	return &SymmetricCredentialAdaptor{SymmetricProvider: provider}
+}
+
+// Retrieve retrieves the symmetric credentials from the wrapped provider.
+func (s *SymmetricCredentialAdaptor) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	return s.SymmetricProvider.Retrieve(ctx)
+}
+
+// RetrievePrivateKey retrieves the symmetric credentials from the wrapped
+// provider, and returns the asymmetric credentials derived from them.
+func (s *SymmetricCredentialAdaptor) RetrievePrivateKey(ctx context.Context) (Credentials, error) {
+	symCreds, err := s.SymmetricProvider.Retrieve(ctx)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	privateKey, err := s.getPrivateKey(symCreds)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	return Credentials{
+		Context:      symCreds.AccessKeyID,
+		PrivateKey:   privateKey,
+		SessionToken: symCreds.SessionToken,
+		CanExpire:    symCreds.CanExpire,
+		Expires:      symCreds.Expires,
+	}, nil
+}
+
+func (s *SymmetricCredentialAdaptor) getPrivateKey(symCreds aws.Credentials) (*ecdsa.PrivateKey, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if d := s.derived; d != nil &&
+		d.AccessKeyID == symCreds.AccessKeyID &&
+		d.SecretAccessKey == symCreds.SecretAccessKey {
+		return d.PrivateKey, nil
+	}
+
+	privateKey, err := deriveKeyFromAccessKeyPair(symCreds.AccessKeyID, symCreds.SecretAccessKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive asymmetric key from credentials, %w", err)
+	}
+
+	s.derived = &derivedCredentials{
+		AccessKeyID:     symCreds.AccessKeyID,
+		SecretAccessKey: symCreds.SecretAccessKey,
+		PrivateKey:      privateKey,
+	}
+
+	return privateKey, nil
+}
@@ -0,0 +1,65 @@
+package v4a
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+func TestSymmetricCredentialAdaptor(t *testing.T) {
+	var retrieveCalls int
+	creds := aws.Credentials{
+		AccessKeyID:     accessKey,
+		SecretAccessKey: secretKey,
+		SessionToken:    "SESSION",
+	}
+
+	adaptor := NewSymmetricCredentialAdaptor(aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+		retrieveCalls++
+		return creds, nil
+	}))
+
+	first, err := adaptor.RetrievePrivateKey(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := accessKey, first.Context; e != a {
+		t.Errorf("expect %v context, got %v", e, a)
+	}
+	if e, a := "SESSION", first.SessionToken; e != a {
+		t.Errorf("expect %v session token, got %v", e, a)
+	}
+
+	expectKey, err := deriveKeyFromAccessKeyPair(accessKey, secretKey)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if first.PrivateKey.D.Cmp(expectKey.D) != 0 {
+		t.Errorf("expect derived private key to match")
+	}
+
+	second, err := adaptor.RetrievePrivateKey(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if first.PrivateKey != second.PrivateKey {
+		t.Errorf("expect cached private key to be reused")
+	}
+
+	creds.AccessKeyID = "AKIDROTATED"
+	third, err := adaptor.RetrievePrivateKey(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if first.PrivateKey == third.PrivateKey {
+		t.Errorf("expect private key to be derived for rotated credentials")
+	}
+	if e, a := "AKIDROTATED", third.Context; e != a {
+		t.Errorf("expect %v context, got %v", e, a)
+	}
+
+	if e, a := 3, retrieveCalls; e != a {
+		t.Errorf("expect %v retrieve calls, got %v", e, a)
+	}
+}
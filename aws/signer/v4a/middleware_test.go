@@ -0,0 +1,209 @@
+package v4a
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+type credentialsProviderFunc func(context.Context) (Credentials, error)
+
+func (f credentialsProviderFunc) RetrievePrivateKey(ctx context.Context) (Credentials, error) {
+	return f(ctx)
+}
+
+type httpSignerFunc func(ctx context.Context, credentials Credentials, r *http.Request, payloadHash string, service string, regionSet []string, signingTime time.Time, optFns ...func(*SignerOptions)) error
+
+func (f httpSignerFunc) SignHTTP(ctx context.Context, credentials Credentials, r *http.Request, payloadHash string, service string, regionSet []string, signingTime time.Time, optFns ...func(*SignerOptions)) error {
+	return f(ctx, credentials, r, payloadHash, service, regionSet, signingTime, optFns...)
+}
+
+func TestSignHTTPRequestMiddleware(t *testing.T) {
+	stubCredentials := credentialsProviderFunc(func(context.Context) (Credentials, error) {
+		return Credentials{Context: "AKID"}, nil
+	})
+
+	cases := map[string]struct {
+		creds           CredentialsProvider
+		hash            string
+		regionSet       []string
+		expectRegionSet []string
+		expectSigned    bool
+		expectedErr     error
+	}{
+		"region set": {
+			creds:           stubCredentials,
+			hash:            "0123456789abcdef",
+			regionSet:       []string{"us-east-1", "us-west-2"},
+			expectRegionSet: []string{"us-east-1", "us-west-2"},
+			expectSigned:    true,
+		},
+		"signing region": {
+			creds:           stubCredentials,
+			hash:            "0123456789abcdef",
+			expectRegionSet: []string{"regionName"},
+			expectSigned:    true,
+		},
+		"missing hash": {
+			creds:       stubCredentials,
+			expectedErr: &v4.SigningError{},
+		},
+		"credentials error": {
+			creds: credentialsProviderFunc(func(context.Context) (Credentials, error) {
+				return Credentials{}, errors.New("credentials error")
+			}),
+			hash:        "0123456789abcdef",
+			expectedErr: &v4.SigningError{},
+		},
+		"nil creds": {
+			creds: nil,
+		},
+	}
+
+	const (
+		signingName   = "serviceId"
+		signingRegion = "regionName"
+	)
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			var signed bool
+			c := NewSignHTTPRequestMiddleware(SignHTTPRequestMiddlewareOptions{
+				CredentialsProvider: tt.creds,
+				Signer: httpSignerFunc(
+					func(ctx context.Context,
+						credentials Credentials, r *http.Request, payloadHash string,
+						service string, regionSet []string, signingTime time.Time,
+						optFns ...func(*SignerOptions),
+					) error {
+						signed = true
+						if e, a := "AKID", credentials.Context; e != a {
+							t.Errorf("expected %v, got %v", e, a)
+						}
+						if e, a := tt.hash, payloadHash; e != a {
+							t.Errorf("expected %v, got %v", e, a)
+						}
+						if e, a := signingName, service; e != a {
+							t.Errorf("expected %v, got %v", e, a)
+						}
+						if e, a := tt.expectRegionSet, regionSet; !reflect.DeepEqual(e, a) {
+							t.Errorf("expected %v, got %v", e, a)
+						}
+						return nil
+					}),
+			})
+
+			next := middleware.FinalizeHandlerFunc(func(ctx context.Context, in middleware.FinalizeInput) (out middleware.FinalizeOutput, metadata middleware.Metadata, err error) {
+				return out, metadata, err
+			})
+
+			ctx := awsmiddleware.SetSigningRegion(
+				awsmiddleware.SetSigningName(context.Background(), signingName),
+				signingRegion)
+			if len(tt.regionSet) != 0 {
+				ctx = SetSigningRegionSet(ctx, tt.regionSet)
+			}
+			if len(tt.hash) != 0 {
+				ctx = v4.SetPayloadHash(ctx, tt.hash)
+			}
+
+			_, _, err := c.HandleFinalize(ctx, middleware.FinalizeInput{
+				Request: &smithyhttp.Request{Request: &http.Request{}},
+			}, next)
+			if err != nil && tt.expectedErr == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err != nil && tt.expectedErr != nil {
+				var e *v4.SigningError
+				if !errors.As(err, &e) {
+					t.Errorf("expected error type %T, got %T", tt.expectedErr, err)
+				}
+			} else if err == nil && tt.expectedErr != nil {
+				t.Errorf("expected error, got nil")
+			}
+
+			if e, a := tt.expectSigned, signed; e != a {
+				t.Errorf("expect signed %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestSwapSignHTTPRequestMiddleware(t *testing.T) {
+	stack := middleware.NewStack("test", smithyhttp.NewStackRequest)
+	err := stack.Finalize.Add(v4.NewSignHTTPRequestMiddleware(v4.SignHTTPRequestMiddlewareOptions{}), middleware.After)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	err = SwapSignHTTPRequestMiddleware(stack, SignHTTPRequestMiddlewareOptions{})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	m, ok := stack.Finalize.Get("Signing")
+	if !ok {
+		t.Fatalf("expect signing middleware")
+	}
+	if _, ok := m.(*SignHTTPRequestMiddleware); !ok {
+		t.Errorf("expect %T middleware, got %T", (*SignHTTPRequestMiddleware)(nil), m)
+	}
+	if e, a := 1, len(stack.Finalize.List()); e != a {
+		t.Errorf("expect %v finalize middleware, got %v", e, a)
+	}
+}
+
+func TestPresignHTTPRequestMiddleware(t *testing.T) {
+	privateKey, err := deriveKeyFromAccessKeyPair(accessKey, secretKey)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	m := NewPresignHTTPRequestMiddleware(PresignHTTPRequestMiddlewareOptions{
+		CredentialsProvider: credentialsProviderFunc(func(context.Context) (Credentials, error) {
+			return Credentials{Context: accessKey, PrivateKey: privateKey}, nil
+		}),
+		Presigner: NewSigner(),
+	})
+
+	ctx := awsmiddleware.SetSigningName(context.Background(), "s3")
+	ctx = SetSigningRegionSet(ctx, []string{"*"})
+	ctx = v4.SetPayloadHash(ctx, "UNSIGNED-PAYLOAD")
+
+	req := smithyhttp.NewStackRequest().(*smithyhttp.Request)
+	req.Method = http.MethodGet
+	req.URL, _ = url.Parse("https://mfzwi23gnjvgw.mrap.accesspoint.s3-global.amazonaws.com/key")
+
+	out, _, err := m.HandleFinalize(ctx, middleware.FinalizeInput{Request: req},
+		middleware.FinalizeHandlerFunc(func(context.Context, middleware.FinalizeInput) (
+			out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+		) {
+			t.Fatalf("expect next handler not to be called")
+			return out, metadata, err
+		}))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	result, ok := out.Result.(*v4.PresignedHTTPRequest)
+	if !ok {
+		t.Fatalf("expect %T result, got %T", result, out.Result)
+	}
+	if e, a := http.MethodGet, result.Method; e != a {
+		t.Errorf("expect %v method, got %v", e, a)
+	}
+	for _, k := range []string{"X-Amz-Algorithm=AWS4-ECDSA-P256-SHA256", "X-Amz-Region-Set=%2A", "X-Amz-Signature="} {
+		if !strings.Contains(result.URL, k) {
+			t.Errorf("expect %v in presigned URL, got %v", k, result.URL)
+		}
+	}
+}
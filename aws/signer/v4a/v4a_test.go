@@ -0,0 +1,327 @@
+package v4a
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	v4Internal "github.com/aws/aws-sdk-go-v2/aws/signer/internal/v4"
+	"github.com/aws/smithy-go/logging"
+)
+
+const (
+	accessKey = "AKISORANDOMAASORANDOM"
+	secretKey = "q+jcrXGc+0zWN6uzclKVhvMmUsIfRPa4rlRandom"
+)
+
+func TestDeriveECDSAKeyPairFromSecret(t *testing.T) {
+	privateKey, err := deriveKeyFromAccessKeyPair(accessKey, secretKey)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expectedX := func() *big.Int {
+		t.Helper()
+		b, ok := new(big.Int).SetString("15D242CEEBF8D8169FD6A8B5A746C41140414C3B07579038DA06AF89190FFFCB", 16)
+		if !ok {
+			t.Fatalf("failed to parse big integer")
+		}
+		return b
+	}()
+	expectedY := func() *big.Int {
+		t.Helper()
+		b, ok := new(big.Int).SetString("515242CEDD82E94799482E4C0514B505AFCCF2C0C98D6A553BF539F424C5EC0", 16)
+		if !ok {
+			t.Fatalf("failed to parse big integer")
+		}
+		return b
+	}()
+
+	if privateKey.X.Cmp(expectedX) != 0 {
+		t.Errorf("expected % X, got % X", expectedX, privateKey.X)
+	}
+	if privateKey.Y.Cmp(expectedY) != 0 {
+		t.Errorf("expected % X, got % X", expectedY, privateKey.Y)
+	}
+}
+
+func TestConstantTimeByteCompare(t *testing.T) {
+	cases := []struct {
+		x, y      []byte
+		expect    int
+		expectErr bool
+	}{
+		{x: []byte{}, y: []byte{}, expect: 0},
+		{x: []byte{40}, y: []byte{30}, expect: 1},
+		{x: []byte{30}, y: []byte{40}, expect: -1},
+		{x: []byte{60, 40, 30, 10, 20}, y: []byte{50, 30, 20, 0, 10}, expect: 1},
+		{x: []byte{50, 30, 20, 0, 10}, y: []byte{60, 40, 30, 10, 20}, expect: -1},
+		{x: []byte{60, 40, 30, 10, 20}, y: []byte{60, 40, 30, 10, 20}, expect: 0},
+		{x: []byte{0, 0, 0, 0, 1}, y: []byte{0, 0, 0, 0, 0}, expect: 1},
+		{x: []byte{255, 0, 0}, y: []byte{0, 255, 255}, expect: 1},
+		{x: []byte{0, 255}, y: []byte{1, 0}, expect: -1},
+		{x: []byte{0}, y: []byte{0, 0}, expectErr: true},
+	}
+
+	for i, tt := range cases {
+		actual, err := constantTimeByteCompare(tt.x, tt.y)
+		if (err != nil) != tt.expectErr {
+			t.Fatalf("%d, expect error %v, got %v", i, tt.expectErr, err)
+		}
+		if e, a := tt.expect, actual; e != a {
+			t.Errorf("%d, expect %v, got %v", i, e, a)
+		}
+	}
+}
+
+func buildRequest(serviceName, body string) (*http.Request, string) {
+	reader := strings.NewReader(body)
+
+	endpoint := "https://" + serviceName + ".amazonaws.com"
+	req, _ := http.NewRequest("POST", endpoint, reader)
+	req.URL.Opaque = "//example.org/bucket/key-._~,!@#$%^&*()"
+	req.Header.Set("X-Amz-Target", "prefix.Operation")
+	req.Header.Set("Content-Type", "application/x-amz-json-1.0")
+	req.ContentLength = int64(reader.Len())
+	req.Header.Set("X-Amz-Meta-Other-Header", "some-value=!@#$%^&* (+)")
+
+	h := sha256.New()
+	_, _ = io.Copy(h, reader)
+	payloadHash := hex.EncodeToString(h.Sum(nil))
+
+	return req, payloadHash
+}
+
+func testCredentials(t *testing.T) Credentials {
+	t.Helper()
+
+	privateKey, err := deriveKeyFromAccessKeyPair(accessKey, secretKey)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	return Credentials{
+		Context:      accessKey,
+		PrivateKey:   privateKey,
+		SessionToken: "SESSION",
+	}
+}
+
+func verifySignature(t *testing.T, key *ecdsa.PublicKey, stringToSign, signature string) {
+	t.Helper()
+
+	sig, err := hex.DecodeString(signature)
+	if err != nil {
+		t.Fatalf("expect hex encoded signature, got %v", err)
+	}
+	hash := sha256.Sum256([]byte(stringToSign))
+	if !ecdsa.VerifyASN1(key, hash[:], sig) {
+		t.Errorf("expect signature to be valid for string to sign\n%v", stringToSign)
+	}
+}
+
+func TestSignHTTP(t *testing.T) {
+	req, payloadHash := buildRequest("dynamodb", "{}")
+	credentials := testCredentials(t)
+
+	var logs bytes.Buffer
+	signer := NewSigner(func(o *SignerOptions) {
+		o.Logger = logging.NewStandardLogger(&logs)
+		o.LogSigning = true
+	})
+
+	err := signer.SignHTTP(context.Background(), credentials, req, payloadHash, "dynamodb",
+		[]string{"us-east-1", "us-west-2"}, time.Unix(0, 0))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expectCanonicalString := strings.Join([]string{
+		"POST",
+		"/bucket/key-._~%2C%21%40%23%24%25%5E%26%2A%28%29",
+		"",
+		"content-length:2",
+		"content-type:application/x-amz-json-1.0",
+		"host:dynamodb.amazonaws.com",
+		"x-amz-date:19700101T000000Z",
+		"x-amz-meta-other-header:some-value=!@#$%^&* (+)",
+		"x-amz-region-set:us-east-1,us-west-2",
+		"x-amz-security-token:SESSION",
+		"x-amz-target:prefix.Operation",
+		"",
+		"content-length;content-type;host;x-amz-date;x-amz-meta-other-header;x-amz-region-set;x-amz-security-token;x-amz-target",
+		"44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a",
+	}, "\n")
+	expectStringToSign := strings.Join([]string{
+		"AWS4-ECDSA-P256-SHA256",
+		"19700101T000000Z",
+		"19700101/dynamodb/aws4_request",
+		hex.EncodeToString(makeHash(sha256.New(), []byte(expectCanonicalString))),
+	}, "\n")
+
+	if !strings.Contains(logs.String(), expectCanonicalString) {
+		t.Errorf("expect canonical string logged\n%v\ngot\n%v", expectCanonicalString, logs.String())
+	}
+	if !strings.Contains(logs.String(), expectStringToSign) {
+		t.Errorf("expect string to sign logged\n%v\ngot\n%v", expectStringToSign, logs.String())
+	}
+
+	if e, a := "us-east-1,us-west-2", req.Header.Get(AmzRegionSetKey); e != a {
+		t.Errorf("expect %v region set, got %v", e, a)
+	}
+	if e, a := "19700101T000000Z", req.Header.Get(v4Internal.AmzDateKey); e != a {
+		t.Errorf("expect %v date, got %v", e, a)
+	}
+	if e, a := "SESSION", req.Header.Get(v4Internal.AmzSecurityTokenKey); e != a {
+		t.Errorf("expect %v security token, got %v", e, a)
+	}
+
+	const expectPrefix = "AWS4-ECDSA-P256-SHA256 Credential=AKISORANDOMAASORANDOM/19700101/dynamodb/aws4_request, " +
+		"SignedHeaders=content-length;content-type;host;x-amz-date;x-amz-meta-other-header;x-amz-region-set;x-amz-security-token;x-amz-target, " +
+		"Signature="
+	authorization := req.Header.Get(authorizationHeader)
+	if !strings.HasPrefix(authorization, expectPrefix) {
+		t.Fatalf("expect authorization header prefix\n%v\ngot\n%v", expectPrefix, authorization)
+	}
+	verifySignature(t, &credentials.PrivateKey.PublicKey, expectStringToSign, strings.TrimPrefix(authorization, expectPrefix))
+}
+
+func TestPresignHTTP(t *testing.T) {
+	req, payloadHash := buildRequest("dynamodb", "{}")
+	credentials := testCredentials(t)
+
+	query := req.URL.Query()
+	query.Set("X-Amz-Expires", "300")
+	req.URL.RawQuery = query.Encode()
+
+	signer := NewSigner()
+	signed, headers, err := signer.PresignHTTP(context.Background(), credentials, req, payloadHash, "dynamodb",
+		[]string{"*"}, time.Unix(0, 0))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	q, err := url.ParseQuery(signed[strings.Index(signed, "?")+1:])
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	expectQuery := map[string]string{
+		"X-Amz-Algorithm":      "AWS4-ECDSA-P256-SHA256",
+		"X-Amz-Credential":     "AKISORANDOMAASORANDOM/19700101/dynamodb/aws4_request",
+		"X-Amz-Date":           "19700101T000000Z",
+		"X-Amz-Expires":        "300",
+		"X-Amz-Region-Set":     "*",
+		"X-Amz-Security-Token": "SESSION",
+		"X-Amz-SignedHeaders":  "content-length;content-type;host;x-amz-meta-other-header",
+		"X-Amz-Target":         "prefix.Operation",
+	}
+	for k, e := range expectQuery {
+		if a := q.Get(k); e != a {
+			t.Errorf("expect %v to be %v, got %v", k, e, a)
+		}
+	}
+
+	expectCanonicalString := strings.Join([]string{
+		"POST",
+		"/bucket/key-._~%2C%21%40%23%24%25%5E%26%2A%28%29",
+		"X-Amz-Algorithm=AWS4-ECDSA-P256-SHA256" +
+			"&X-Amz-Credential=AKISORANDOMAASORANDOM%2F19700101%2Fdynamodb%2Faws4_request" +
+			"&X-Amz-Date=19700101T000000Z" +
+			"&X-Amz-Expires=300" +
+			"&X-Amz-Region-Set=%2A" +
+			"&X-Amz-Security-Token=SESSION" +
+			"&X-Amz-SignedHeaders=content-length%3Bcontent-type%3Bhost%3Bx-amz-meta-other-header" +
+			"&X-Amz-Target=prefix.Operation",
+		"content-length:2",
+		"content-type:application/x-amz-json-1.0",
+		"host:dynamodb.amazonaws.com",
+		"x-amz-meta-other-header:some-value=!@#$%^&* (+)",
+		"",
+		"content-length;content-type;host;x-amz-meta-other-header",
+		"44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a",
+	}, "\n")
+	expectStringToSign := strings.Join([]string{
+		"AWS4-ECDSA-P256-SHA256",
+		"19700101T000000Z",
+		"19700101/dynamodb/aws4_request",
+		hex.EncodeToString(makeHash(sha256.New(), []byte(expectCanonicalString))),
+	}, "\n")
+	verifySignature(t, &credentials.PrivateKey.PublicKey, expectStringToSign, q.Get("X-Amz-Signature"))
+
+	expectHeaders := http.Header{
+		"Content-Length":          {"2"},
+		"Content-Type":            {"application/x-amz-json-1.0"},
+		"Host":                    {"dynamodb.amazonaws.com"},
+		"X-Amz-Meta-Other-Header": {"some-value=!@#$%^&* (+)"},
+	}
+	for k, e := range expectHeaders {
+		if a := headers.Get(k); e[0] != a {
+			t.Errorf("expect %v header to be %v, got %v", k, e[0], a)
+		}
+	}
+	if e, a := len(expectHeaders), len(headers); e != a {
+		t.Errorf("expect %v signed headers, got %v", e, a)
+	}
+
+	if len(req.URL.Query().Get("X-Amz-Signature")) != 0 {
+		t.Errorf("expect request to not be modified")
+	}
+}
+
+func TestSignHTTP_InvalidInput(t *testing.T) {
+	cases := map[string]struct {
+		Credentials Credentials
+		RegionSet   []string
+		ExpectErr   string
+	}{
+		"no private key": {
+			Credentials: Credentials{Context: accessKey},
+			RegionSet:   []string{"*"},
+			ExpectErr:   "private key",
+		},
+		"no region set": {
+			Credentials: testCredentials(t),
+			ExpectErr:   "region set",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			req, payloadHash := buildRequest("dynamodb", "{}")
+
+			err := NewSigner().SignHTTP(context.Background(), tt.Credentials, req, payloadHash, "dynamodb",
+				tt.RegionSet, time.Unix(0, 0))
+			if err == nil {
+				t.Fatalf("expect error, got none")
+			}
+			if e, a := tt.ExpectErr, err.Error(); !strings.Contains(a, e) {
+				t.Errorf("expect error to contain %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func BenchmarkSignHTTP(b *testing.B) {
+	privateKey, err := deriveKeyFromAccessKeyPair(accessKey, secretKey)
+	if err != nil {
+		b.Fatalf("expected no error, got %v", err)
+	}
+	credentials := Credentials{Context: accessKey, PrivateKey: privateKey}
+	signer := NewSigner()
+	req, payloadHash := buildRequest("dynamodb", "{}")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		signer.SignHTTP(context.Background(), credentials, req, payloadHash, "dynamodb", []string{"*"}, time.Now())
+	}
+}
@@ -36,6 +36,7 @@ import software.amazon.smithy.go.codegen.SmithyGoDependency;
 import software.amazon.smithy.go.codegen.SymbolUtils;
 import software.amazon.smithy.go.codegen.integration.GoIntegration;
 import software.amazon.smithy.model.Model;
+import software.amazon.smithy.model.knowledge.TopDownIndex;
 import software.amazon.smithy.model.shapes.OperationShape;
 import software.amazon.smithy.model.shapes.ServiceShape;
 import software.amazon.smithy.model.shapes.Shape;
@@ -89,6 +90,12 @@ public class AwsHttpPresignURLClientGenerator implements GoIntegration {
                     ShapeId.from("com.amazonaws.sts#GetCallerIdentity"))
     );
 
+    // constant set of services for which presignedURL operations must be generated for every operation of the
+    // service.
+    private static final Set<ShapeId> presignAllOperationsSet = SetUtils.of(
+            ShapeId.from("com.amazonaws.s3#AmazonS3")
+    );
+
     // map of service to list of operations for which presignedURL client and operation should
     // be generated.
     private final Map<ShapeId, Set<ShapeId>> PRESIGNER_MAP = new TreeMap<>();
@@ -143,6 +150,20 @@ public class AwsHttpPresignURLClientGenerator implements GoIntegration {
                 PRESIGNER_MAP.put(service, operations);
             }
         }
+
+        // update map for presign client/operation generation to include all operations of
+        // services for which every operation can be presigned.
+        ServiceShape serviceShape = settings.getService(model);
+        if (presignAllOperationsSet.contains(serviceShape.getId())) {
+            Set<ShapeId> operations = new TreeSet<>();
+            if (PRESIGNER_MAP.containsKey(serviceShape.getId())) {
+                operations.addAll(PRESIGNER_MAP.get(serviceShape.getId()));
+            }
+            for (OperationShape operation : TopDownIndex.of(model).getContainedOperations(serviceShape)) {
+                operations.add(operation.getId());
+            }
+            PRESIGNER_MAP.put(serviceShape.getId(), operations);
+        }
     }
 
     @Override
@@ -180,6 +201,9 @@ public class AwsHttpPresignURLClientGenerator implements GoIntegration {
             // generate Presign client per service
             writePresignClientType(writer, model, symbolProvider, serviceShape);
 
+            // generate generic presign operation function dispatching on the operation input
+            writePresignOperationDispatcher(writer, model, symbolProvider, serviceShape, validOperations);
+
             // generate client helpers such as copyAPIClient, GetAPIClientOptions()
             writePresignClientHelpers(writer, model, symbolProvider, serviceShape);
 
@@ -273,6 +297,53 @@ public class AwsHttpPresignURLClientGenerator implements GoIntegration {
         writer.write("");
     }
 
+    /**
+     * Writes the generic PresignOperation function, which generates the presigned HTTP request for the operation
+     * of the provided operation input.
+     *
+     * @param writer          the writer to write to
+     * @param model           the service model
+     * @param symbolProvider  the symbol provider
+     * @param serviceShape    the service for which the function is generated
+     * @param validOperations the operations that can be presigned
+     */
+    private void writePresignOperationDispatcher(
+            GoWriter writer,
+            Model model,
+            SymbolProvider symbolProvider,
+            ServiceShape serviceShape,
+            Set<ShapeId> validOperations
+    ) {
+        writer.addUseImports(SmithyGoDependency.CONTEXT);
+        writer.addUseImports(SmithyGoDependency.FMT);
+
+        writer.writeDocs("PresignOperation is used to generate a presigned HTTP Request for the operation of the "
+                + "provided operation input parameters, such as *GetObjectInput. The presigned request is built the "
+                + "same as the operation's Presign method. Returns an error if the operation of the input cannot be "
+                + "presigned.");
+        writer.openBlock("func (c *$T) PresignOperation(ctx context.Context, params interface{}, "
+                        + "optFns ...func($P)) ($P, error) {", "}",
+                presignClientSymbol, presignOptionsSymbol, v4PresignedHTTPRequestSymbol, () -> {
+                    writer.openBlock("switch v := params.(type) {", "}", () -> {
+                        for (OperationShape operationShape : TopDownIndex.of(model)
+                                .getContainedOperations(serviceShape)) {
+                            if (!validOperations.contains(operationShape.getId())) {
+                                continue;
+                            }
+                            Symbol operationSymbol = symbolProvider.toSymbol(operationShape);
+                            Symbol operationInputSymbol = symbolProvider.toSymbol(
+                                    model.expectShape(operationShape.getInput().get()));
+
+                            writer.write("case $P:", operationInputSymbol);
+                            writer.write("return c.Presign$T(ctx, v, optFns...)", operationSymbol);
+                        }
+                        writer.write("default:");
+                        writer.write("return nil, fmt.Errorf(\"presign not supported for %T input\", params)");
+                    });
+                });
+        writer.write("");
+    }
+
     private void writeS3AddAsUnsignedPayloadHelper(
             GoWriter writer,
             Model model,
@@ -28,6 +28,9 @@ import software.amazon.smithy.model.transform.ModelTransformer;
 
 /**
  * Filters out event stream operations.
+ *
+ * Operations whose event streams are supported by customizations are not
+ * filtered, see {@link software.amazon.smithy.aws.go.codegen.customization.EventStreamCustomizations}.
  * TODO: implement event streams
  */
 public final class FilterStreamingOperations implements GoIntegration {
@@ -36,12 +36,16 @@ public final class AwsCustomGoDependency extends AwsGoDependency {
             "service/internal/accept-encoding", null, Versions.INTERNAL_ACCEPTENCODING, "acceptencodingcust");
     public static final GoDependency KINESIS_CUSTOMIZATION = aws(
             "service/kinesis/internal/customizations", "kinesiscust");
+    public static final GoDependency SQS_CUSTOMIZATION = aws(
+            "service/sqs/internal/customizations", "sqscust");
     public static final GoDependency MACHINE_LEARNING_CUSTOMIZATION = aws(
             "service/machinelearning/internal/customizations", "mlcust");
     public static final GoDependency ROUTE53_CUSTOMIZATION = aws(
             "service/route53/internal/customizations", "route53cust");
     public static final GoDependency PRESIGNEDURL_CUSTOMIZATION = awsModuleDep(
             "service/internal/presigned-url", null, Versions.INTERNAL_PRESIGNURL, "presignedurlcust");
+    public static final GoDependency CHECKSUM_CUSTOMIZATION = awsModuleDep(
+            "service/internal/checksum", null, Versions.INTERNAL_CHECKSUM, "internalChecksum");
 
     private AwsCustomGoDependency() {
         super();
@@ -51,5 +55,6 @@ public final class AwsCustomGoDependency extends AwsGoDependency {
         private static final String INTERNAL_S3SHARED = "v1.0.0";
         private static final String INTERNAL_ACCEPTENCODING = "v1.0.0";
         private static final String INTERNAL_PRESIGNURL = "v1.0.0";
+        private static final String INTERNAL_CHECKSUM = "v1.0.0";
     }
 }
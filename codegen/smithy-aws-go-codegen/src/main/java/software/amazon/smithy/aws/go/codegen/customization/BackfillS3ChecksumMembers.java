@@ -0,0 +1,188 @@
+/*
+ * Copyright 2021 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License").
+ * You may not use this file except in compliance with the License.
+ * A copy of the License is located at
+ *
+ *  http://aws.amazon.com/apache2.0
+ *
+ * or in the "license" file accompanying this file. This file is distributed
+ * on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+ * express or implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package software.amazon.smithy.aws.go.codegen.customization;
+
+import java.util.LinkedHashMap;
+import java.util.List;
+import java.util.Locale;
+import java.util.Map;
+import java.util.logging.Logger;
+import software.amazon.smithy.go.codegen.GoSettings;
+import software.amazon.smithy.go.codegen.integration.GoIntegration;
+import software.amazon.smithy.model.Model;
+import software.amazon.smithy.model.shapes.MemberShape;
+import software.amazon.smithy.model.shapes.ServiceShape;
+import software.amazon.smithy.model.shapes.ShapeId;
+import software.amazon.smithy.model.shapes.StringShape;
+import software.amazon.smithy.model.shapes.StructureShape;
+import software.amazon.smithy.model.traits.DocumentationTrait;
+import software.amazon.smithy.model.traits.EnumDefinition;
+import software.amazon.smithy.model.traits.EnumTrait;
+import software.amazon.smithy.model.traits.HttpHeaderTrait;
+import software.amazon.smithy.utils.ListUtils;
+
+/**
+ * Integration that back fills the flexible checksum members to the S3 API model's operation input and output
+ * shapes, that were not modeled. The members are used by the {@link S3FlexibleChecksums} customization.
+ */
+public class BackfillS3ChecksumMembers implements GoIntegration {
+    private static final Logger LOGGER = Logger.getLogger(BackfillS3ChecksumMembers.class.getName());
+
+    private static final String NAMESPACE = "com.amazonaws.s3";
+    private static final ShapeId CHECKSUM_ALGORITHM = ShapeId.fromParts(NAMESPACE, "ChecksumAlgorithm");
+    private static final ShapeId CHECKSUM_MODE = ShapeId.fromParts(NAMESPACE, "ChecksumMode");
+
+    private static final List<String> ALGORITHMS = ListUtils.of("CRC32", "CRC32C", "SHA1", "SHA256");
+
+    private static final String INTEGRITY_DOCS = " This header can be used as a data integrity check to verify that "
+            + "the data received is the same data that was originally sent. For more information, see "
+            + "<a href=\"https://docs.aws.amazon.com/AmazonS3/latest/userguide/checking-object-integrity.html\">"
+            + "Checking object integrity</a> in the <i>Amazon S3 User Guide</i>.";
+
+    private static final String REQUEST_ALGORITHM_DOCS = "<p>Indicates the algorithm used to create the checksum "
+            + "for the %s when using the SDK. The SDK computes the checksum, and sends it as the "
+            + "<code>x-amz-checksum-<i>algorithm</i></code> header, or as a trailing header of the payload when the "
+            + "payload is not seekable. If you provide a checksum header for any algorithm, the SDK does not compute "
+            + "a checksum.</p>";
+
+    @Override
+    public byte getOrder() {
+        // This integration should happen before other integrations that rely on the presence of these members
+        return -60;
+    }
+
+    @Override
+    public Model preprocessModel(Model model, GoSettings settings) {
+        ServiceShape service = settings.getService(model);
+        if (!S3ModelUtils.isServiceS3(model, service)) {
+            return model;
+        }
+        if (model.getShape(CHECKSUM_ALGORITHM).isPresent()) {
+            LOGGER.warning("checksum members are present in model and do not require backfill");
+            return model;
+        }
+
+        Model.Builder builder = model.toBuilder();
+
+        builder.addShape(enumShape(CHECKSUM_ALGORITHM, ALGORITHMS));
+        builder.addShape(enumShape(CHECKSUM_MODE, ListUtils.of("ENABLED")));
+        for (String algorithm : ALGORITHMS) {
+            builder.addShape(StringShape.builder().id(checksumShapeId(algorithm)).build());
+        }
+
+        // Structures are updated in place, as some have more than one member added.
+        Map<String, StructureShape.Builder> structures = new LinkedHashMap<>();
+
+        // Request checksums of the object and part payloads.
+        addAlgorithmMember(model, structures, "PutObjectRequest", "x-amz-sdk-checksum-algorithm",
+                String.format(REQUEST_ALGORITHM_DOCS, "object"));
+        addChecksumMembers(model, structures, "PutObjectRequest", true,
+                "<p>The base64-encoded %s checksum of the object." + INTEGRITY_DOCS + "</p>");
+        addAlgorithmMember(model, structures, "UploadPartRequest", "x-amz-sdk-checksum-algorithm",
+                String.format(REQUEST_ALGORITHM_DOCS, "part"));
+        addChecksumMembers(model, structures, "UploadPartRequest", true,
+                "<p>The base64-encoded %s checksum of the part." + INTEGRITY_DOCS + "</p>");
+
+        // Response checksums of the object and part payloads.
+        addChecksumMembers(model, structures, "PutObjectOutput", true,
+                "<p>The base64-encoded %s checksum of the object.</p>");
+        addChecksumMembers(model, structures, "UploadPartOutput", true,
+                "<p>The base64-encoded %s checksum of the part.</p>");
+        addChecksumMembers(model, structures, "GetObjectOutput", true,
+                "<p>The base64-encoded %s checksum of the object.</p>");
+        structure(model, structures, "GetObjectRequest").addMember(member("GetObjectRequest", "ChecksumMode",
+                CHECKSUM_MODE, "<p>To retrieve the checksum of the object, set <code>ChecksumMode</code> to "
+                        + "<code>ENABLED</code>. When enabled, the SDK validates the checksum of the object's "
+                        + "payload as it is read.</p>",
+                "x-amz-checksum-mode"));
+
+        // Multipart upload checksums.
+        addAlgorithmMember(model, structures, "CreateMultipartUploadRequest", "x-amz-checksum-algorithm",
+                "<p>Indicates the algorithm you want Amazon S3 to use to create the checksum of each part of the "
+                        + "object. Each part must be uploaded with a checksum of the same algorithm.</p>");
+        addAlgorithmMember(model, structures, "CreateMultipartUploadOutput", "x-amz-checksum-algorithm",
+                "<p>The algorithm that was used to create a checksum of the object.</p>");
+        addChecksumMembers(model, structures, "CompletedPart", false,
+                "<p>The base64-encoded %1$s checksum of the part. Required if the multipart upload was created "
+                        + "with the %1$s checksum algorithm.</p>");
+        addChecksumMembers(model, structures, "CompleteMultipartUploadOutput", false,
+                "<p>The base64-encoded %s checksum of the object. The checksum of a multipart upload is the "
+                        + "checksum of the checksums of each part, followed by a hyphen and the number of parts.</p>");
+
+        for (StructureShape.Builder structure : structures.values()) {
+            StructureShape shape = structure.build();
+            builder.addShape(shape);
+            shape.members().forEach(builder::addShape);
+        }
+        return builder.build();
+    }
+
+    private static ShapeId checksumShapeId(String algorithm) {
+        return ShapeId.fromParts(NAMESPACE, "Checksum" + algorithm);
+    }
+
+    private static StringShape enumShape(ShapeId id, List<String> values) {
+        EnumTrait.Builder trait = EnumTrait.builder();
+        for (String value : values) {
+            trait.addEnum(EnumDefinition.builder().value(value).name(value).build());
+        }
+        return StringShape.builder().id(id).addTrait(trait.build()).build();
+    }
+
+    private static StructureShape.Builder structure(
+            Model model, Map<String, StructureShape.Builder> structures, String structureName
+    ) {
+        return structures.computeIfAbsent(structureName, name -> model.expectShape(
+                ShapeId.fromParts(NAMESPACE, name), StructureShape.class).toBuilder());
+    }
+
+    private static void addAlgorithmMember(
+            Model model, Map<String, StructureShape.Builder> structures, String structureName, String header,
+            String docs
+    ) {
+        structure(model, structures, structureName).addMember(
+                member(structureName, "ChecksumAlgorithm", CHECKSUM_ALGORITHM, docs, header));
+    }
+
+    /**
+     * Adds a checksum member for each algorithm to the structure. The documentation is formatted with the
+     * algorithm's name. Members bound to headers are bound to the algorithm's x-amz-checksum header.
+     */
+    private static void addChecksumMembers(
+            Model model, Map<String, StructureShape.Builder> structures, String structureName, boolean bindHeader,
+            String docs
+    ) {
+        StructureShape.Builder structure = structure(model, structures, structureName);
+        for (String algorithm : ALGORITHMS) {
+            String header = bindHeader ? "x-amz-checksum-" + algorithm.toLowerCase(Locale.US) : null;
+            structure.addMember(member(structureName, "Checksum" + algorithm, checksumShapeId(algorithm),
+                    String.format(docs, algorithm), header));
+        }
+    }
+
+    private static MemberShape member(
+            String structureName, String memberName, ShapeId target, String docs, String header
+    ) {
+        MemberShape.Builder member = MemberShape.builder()
+                .id(ShapeId.fromParts(NAMESPACE, structureName, memberName))
+                .target(target)
+                .addTrait(new DocumentationTrait(docs));
+        if (header != null) {
+            member.addTrait(new HttpHeaderTrait(header));
+        }
+        return member.build();
+    }
+}
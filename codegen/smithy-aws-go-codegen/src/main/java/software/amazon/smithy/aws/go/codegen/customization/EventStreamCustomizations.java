@@ -0,0 +1,248 @@
+/*
+ * Copyright 2021 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License").
+ * You may not use this file except in compliance with the License.
+ * A copy of the License is located at
+ *
+ *  http://aws.amazon.com/apache2.0
+ *
+ * or in the "license" file accompanying this file. This file is distributed
+ * on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+ * express or implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package software.amazon.smithy.aws.go.codegen.customization;
+
+import java.util.ArrayList;
+import java.util.Collections;
+import java.util.List;
+import java.util.Map;
+import java.util.Optional;
+import java.util.Set;
+import java.util.TreeSet;
+import java.util.stream.Collectors;
+import software.amazon.smithy.codegen.core.Symbol;
+import software.amazon.smithy.codegen.core.SymbolProvider;
+import software.amazon.smithy.go.codegen.GoCodegenPlugin;
+import software.amazon.smithy.go.codegen.GoDelegator;
+import software.amazon.smithy.go.codegen.GoDependency;
+import software.amazon.smithy.go.codegen.GoSettings;
+import software.amazon.smithy.go.codegen.GoWriter;
+import software.amazon.smithy.go.codegen.SmithyGoDependency;
+import software.amazon.smithy.go.codegen.StructureGenerator;
+import software.amazon.smithy.go.codegen.SymbolUtils;
+import software.amazon.smithy.go.codegen.UnionGenerator;
+import software.amazon.smithy.go.codegen.integration.GoIntegration;
+import software.amazon.smithy.go.codegen.integration.MiddlewareRegistrar;
+import software.amazon.smithy.go.codegen.integration.RuntimeClientPlugin;
+import software.amazon.smithy.model.Model;
+import software.amazon.smithy.model.knowledge.EventStreamIndex;
+import software.amazon.smithy.model.knowledge.EventStreamInfo;
+import software.amazon.smithy.model.neighbor.Walker;
+import software.amazon.smithy.model.shapes.OperationShape;
+import software.amazon.smithy.model.shapes.Shape;
+import software.amazon.smithy.model.shapes.ShapeId;
+import software.amazon.smithy.model.shapes.StructureShape;
+import software.amazon.smithy.model.shapes.UnionShape;
+import software.amazon.smithy.model.transform.ModelTransformer;
+import software.amazon.smithy.utils.MapUtils;
+
+/**
+ * EventStreamCustomizations adds support for the output event streams of
+ * operations whose event stream handling is implemented by the service's
+ * customization package, instead of being generated.
+ *
+ * The output's event stream member is removed from the model, so that the
+ * operation is generated as an operation without an event stream, and is not
+ * filtered out by {@link software.amazon.smithy.aws.go.codegen.FilterStreamingOperations}.
+ * The event stream's union, and the shapes only referenced by it, are
+ * generated into the service's types package. The operation's output is given
+ * a GetStream method returning the event stream read by the customization's
+ * middleware.
+ */
+public class EventStreamCustomizations implements GoIntegration {
+    // operations with output event streams, and the customization package
+    // reading the event stream
+    private static final Map<ShapeId, GoDependency> OPERATIONS = MapUtils.of(
+            ShapeId.from("com.amazonaws.s3#SelectObjectContent"), AwsCustomGoDependency.S3_CUSTOMIZATION,
+            ShapeId.from("com.amazonaws.kinesis#SubscribeToShard"), AwsCustomGoDependency.KINESIS_CUSTOMIZATION
+    );
+
+    private final List<UnionShape> eventStreams = new ArrayList<>();
+    private Model eventStreamModel;
+
+    /**
+     * Gets the sort order of the customization from -128 to 127, with lowest
+     * executed first.
+     *
+     * @return Returns the sort order, -20 so that event stream members are
+     * removed before streaming operations are filtered out.
+     */
+    @Override
+    public byte getOrder() {
+        return -20;
+    }
+
+    private static String streamAdderFuncName(String operationName) {
+        return String.format("add%sEventStreamMiddleware", operationName);
+    }
+
+    @Override
+    public Model preprocessModel(Model model, GoSettings settings) {
+        EventStreamIndex index = EventStreamIndex.of(model);
+        List<Shape> eventStreamMembers = new ArrayList<>();
+
+        for (ShapeId operationId : settings.getService(model).getAllOperations()) {
+            if (!OPERATIONS.containsKey(operationId)) {
+                continue;
+            }
+            OperationShape operation = model.expectShape(operationId, OperationShape.class);
+            Optional<EventStreamInfo> info = index.getOutputInfo(operation);
+            if (!info.isPresent()) {
+                continue;
+            }
+
+            eventStreamMembers.add(info.get().getEventStreamMember());
+            eventStreams.add(info.get().getEventStreamTarget().asUnionShape().get());
+        }
+
+        if (eventStreamMembers.isEmpty()) {
+            return model;
+        }
+
+        // The event stream's shapes are generated from the model including
+        // them, as they are removed with the event stream member.
+        eventStreamModel = model;
+        return ModelTransformer.create().removeShapes(model, eventStreamMembers);
+    }
+
+    @Override
+    public List<RuntimeClientPlugin> getClientPlugins() {
+        List<RuntimeClientPlugin> plugins = new ArrayList<>();
+        for (ShapeId operationId : OPERATIONS.keySet()) {
+            plugins.add(RuntimeClientPlugin.builder()
+                    .operationPredicate((m, s, o) -> o.getId().equals(operationId))
+                    .registerMiddleware(MiddlewareRegistrar.builder()
+                            .resolvedFunction(SymbolUtils.createValueSymbolBuilder(
+                                    streamAdderFuncName(operationId.getName())).build())
+                            .useClientOptions()
+                            .build())
+                    .build());
+        }
+        return plugins;
+    }
+
+    @Override
+    public void writeAdditionalFiles(
+            GoSettings settings,
+            Model model,
+            SymbolProvider symbolProvider,
+            GoDelegator goDelegator
+    ) {
+        if (eventStreamModel == null) {
+            return;
+        }
+
+        for (ShapeId operationId : settings.getService(model).getAllOperations()) {
+            if (!OPERATIONS.containsKey(operationId)) {
+                continue;
+            }
+            OperationShape operation = model.expectShape(operationId, OperationShape.class);
+            goDelegator.useShapeWriter(operation, writer -> writeOperationEventStream(
+                    writer, symbolProvider, model, operation, OPERATIONS.get(operationId)));
+        }
+
+        SymbolProvider eventStreamSymbolProvider = GoCodegenPlugin.createSymbolProvider(
+                eventStreamModel, settings.getModuleName());
+        for (UnionShape eventStream : eventStreams) {
+            writeEventStreamShapes(goDelegator, eventStreamSymbolProvider, model, eventStream);
+        }
+    }
+
+    private void writeOperationEventStream(
+            GoWriter writer,
+            SymbolProvider symbolProvider,
+            Model model,
+            OperationShape operation,
+            GoDependency customization
+    ) {
+        String operationName = symbolProvider.toSymbol(operation).getName();
+        String outputName = symbolProvider.toSymbol(model.expectShape(operation.getOutput().get())).getName();
+        String streamName = operationName + "EventStream";
+
+        Symbol getStream = SymbolUtils.createValueSymbolBuilder("Get" + streamName, customization).build();
+        Symbol stream = SymbolUtils.createPointableSymbolBuilder(streamName, customization).build();
+        Symbol streamReader = SymbolUtils.createValueSymbolBuilder(streamName + "Reader", customization).build();
+        Symbol newStream = SymbolUtils.createValueSymbolBuilder("New" + streamName, customization).build();
+        Symbol addStream = SymbolUtils.createValueSymbolBuilder("Add" + streamName, customization).build();
+        Symbol addStreamOptions = SymbolUtils.createValueSymbolBuilder("Add" + streamName + "Options",
+                customization).build();
+
+        writer.writeDocs("GetStream returns the type to interact with the event stream.");
+        writer.openBlock("func (o *$L) GetStream() *$L {", "}", outputName, streamName, () -> {
+            writer.write("return $T(o.ResultMetadata)", getStream);
+        });
+        writer.write("");
+
+        writer.writeDocs(String.format("%sReader provides the interface for reading events from a stream.",
+                streamName));
+        writer.write("type $LReader = $T", streamName, streamReader);
+        writer.write("");
+
+        writer.writeDocs(String.format("%s provides the event stream handling for the %s operation.",
+                streamName, operationName));
+        writer.write("type $L = $T", streamName, stream);
+        writer.write("");
+
+        writer.writeDocs(String.format("New%1$s initializes an %1$s. This function should only be used "
+                + "for testing and mocking the %1$s stream within your application.", streamName));
+        writer.openBlock("func New$1L(optFns ...func(*$1L)) *$1L {", "}", streamName, () -> {
+            writer.write("return $T(optFns...)", newStream);
+        });
+        writer.write("");
+
+        writer.addUseImports(SmithyGoDependency.SMITHY_MIDDLEWARE);
+        writer.openBlock("func $L(stack *middleware.Stack, options Options) error {", "}",
+                streamAdderFuncName(operationName), () -> {
+                    writer.openBlock("return $T(stack, $T{", "})", addStream, addStreamOptions, () -> {
+                        writer.write("LogEventStreamReads: options.ClientLogMode.IsResponseEventMessage(),");
+                    });
+                });
+        writer.write("");
+    }
+
+    private void writeEventStreamShapes(
+            GoDelegator goDelegator,
+            SymbolProvider symbolProvider,
+            Model model,
+            UnionShape eventStream
+    ) {
+        goDelegator.useShapeWriter(eventStream, writer -> {
+            new UnionGenerator(eventStreamModel, symbolProvider, eventStream).generateUnion(writer);
+            // The UnknownUnionMember type is only generated for models with
+            // unions.
+            if (!model.shapes(UnionShape.class).findAny().isPresent()) {
+                UnionGenerator.generateUnknownUnion(writer, Collections.singleton(eventStream), symbolProvider);
+            } else {
+                writer.write("func (*$L) is$L() {}", UnionGenerator.UNKNOWN_MEMBER_NAME,
+                        symbolProvider.toSymbol(eventStream).getName());
+            }
+            writer.write("");
+        });
+
+        // The shapes of the event stream not referenced by other operations
+        // are removed with the event stream member.
+        Set<StructureShape> shapes = new Walker(eventStreamModel).walkShapes(eventStream).stream()
+                .filter(Shape::isStructureShape)
+                .filter(shape -> !model.getShape(shape.getId()).isPresent())
+                .map(shape -> shape.asStructureShape().get())
+                .collect(Collectors.toCollection(TreeSet::new));
+        for (StructureShape shape : shapes) {
+            goDelegator.useShapeWriter(shape, writer -> {
+                new StructureGenerator(eventStreamModel, symbolProvider, writer, shape).run();
+            });
+        }
+    }
+}
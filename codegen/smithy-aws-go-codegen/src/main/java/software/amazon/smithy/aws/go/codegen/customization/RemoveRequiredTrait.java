@@ -0,0 +1,77 @@
+/*
+ * Copyright 2021 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License").
+ * You may not use this file except in compliance with the License.
+ * A copy of the License is located at
+ *
+ *  http://aws.amazon.com/apache2.0
+ *
+ * or in the "license" file accompanying this file. This file is distributed
+ * on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+ * express or implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package software.amazon.smithy.aws.go.codegen.customization;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import java.util.Set;
+import java.util.logging.Logger;
+import software.amazon.smithy.go.codegen.GoSettings;
+import software.amazon.smithy.go.codegen.integration.GoIntegration;
+import software.amazon.smithy.model.Model;
+import software.amazon.smithy.model.shapes.MemberShape;
+import software.amazon.smithy.model.shapes.Shape;
+import software.amazon.smithy.model.shapes.ShapeId;
+import software.amazon.smithy.model.traits.RequiredTrait;
+import software.amazon.smithy.model.transform.ModelTransformer;
+import software.amazon.smithy.utils.MapUtils;
+import software.amazon.smithy.utils.SetUtils;
+
+/**
+ * Removes the Smithy required traits from members of AWS models that are modeled as required, but are not required
+ * by the service for all requests.
+ */
+public class RemoveRequiredTrait implements GoIntegration {
+    private static final Logger LOGGER = Logger.getLogger(RemoveRequiredTrait.class.getName());
+
+    /**
+     * Map of service shape to Set of member shapes that are not required.
+     */
+    private static final Map<ShapeId, Set<ShapeId>> SERVICE_TO_MEMBER_MAP = MapUtils.of(
+            // DeviceCode is only used by the device code grant type, and must not be sent when refreshing a token.
+            ShapeId.from("com.amazonaws.ssooidc#AWSSSOOIDCService"), SetUtils.of(
+                    ShapeId.from("com.amazonaws.ssooidc#CreateTokenRequest$deviceCode")));
+
+    @Override
+    public byte getOrder() {
+        // This integration should happen before other integrations that rely on the presence of this trait
+        return -60;
+    }
+
+    @Override
+    public Model preprocessModel(Model model, GoSettings settings) {
+        ShapeId serviceId = settings.getService();
+        if (!SERVICE_TO_MEMBER_MAP.containsKey(serviceId)) {
+            return model;
+        }
+
+        List<Shape> updates = new ArrayList<>();
+        for (ShapeId memberId : SERVICE_TO_MEMBER_MAP.get(serviceId)) {
+            MemberShape member = model.expectShape(memberId, MemberShape.class);
+            if (!member.getTrait(RequiredTrait.class).isPresent()) {
+                LOGGER.warning("required trait is not present in model and does not require removal");
+                continue;
+            }
+            updates.add(member.toBuilder()
+                    .removeTrait(RequiredTrait.ID)
+                    .build());
+        }
+
+        // Replacing the members also updates the structures containing them.
+        return ModelTransformer.create().replaceShapes(model, updates);
+    }
+}
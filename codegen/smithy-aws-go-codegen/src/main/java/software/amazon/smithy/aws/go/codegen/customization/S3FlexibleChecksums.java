@@ -0,0 +1,217 @@
+/*
+ * Copyright 2021 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License").
+ * You may not use this file except in compliance with the License.
+ * A copy of the License is located at
+ *
+ *  http://aws.amazon.com/apache2.0
+ *
+ * or in the "license" file accompanying this file. This file is distributed
+ * on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+ * express or implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ *
+ *
+ */
+
+package software.amazon.smithy.aws.go.codegen.customization;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import software.amazon.smithy.codegen.core.SymbolProvider;
+import software.amazon.smithy.go.codegen.GoDelegator;
+import software.amazon.smithy.go.codegen.GoSettings;
+import software.amazon.smithy.go.codegen.GoWriter;
+import software.amazon.smithy.go.codegen.SmithyGoDependency;
+import software.amazon.smithy.go.codegen.SymbolUtils;
+import software.amazon.smithy.go.codegen.integration.GoIntegration;
+import software.amazon.smithy.go.codegen.integration.MiddlewareRegistrar;
+import software.amazon.smithy.go.codegen.integration.RuntimeClientPlugin;
+import software.amazon.smithy.model.Model;
+import software.amazon.smithy.model.shapes.OperationShape;
+import software.amazon.smithy.model.shapes.ServiceShape;
+import software.amazon.smithy.model.shapes.ShapeId;
+import software.amazon.smithy.utils.MapUtils;
+
+/**
+ * S3FlexibleChecksums adds the middleware for computing the checksum of the
+ * request payload, and validating the checksum of the response payload, to
+ * the S3 operations that support flexible checksums.
+ *
+ * The checksum algorithm of the request payload is selected by the
+ * operation's input ChecksumAlgorithm member, and the validation of the
+ * response payload's checksum is enabled by the input ChecksumMode member.
+ */
+public class S3FlexibleChecksums implements GoIntegration {
+    private static final String INPUT_ADDER = "AddInputMiddleware";
+    private static final String OUTPUT_ADDER = "AddOutputMiddleware";
+
+    // operations, and the input member selecting the request checksum algorithm
+    private static final Map<String, String> REQUEST_ALGORITHM_MEMBER = MapUtils.of(
+            "PutObject", "ChecksumAlgorithm",
+            "UploadPart", "ChecksumAlgorithm"
+    );
+
+    // operations, and the input member enabling response checksum validation
+    private static final Map<String, String> RESPONSE_VALIDATION_MODE_MEMBER = MapUtils.of(
+            "GetObject", "ChecksumMode"
+    );
+
+    private final List<RuntimeClientPlugin> runtimeClientPlugins = new ArrayList<>();
+
+    /**
+     * Gets the sort order of the customization from -128 to 127, with lowest
+     * executed first.
+     *
+     * @return Returns the sort order, defaults to 127.
+     */
+    @Override
+    public byte getOrder() {
+        return 127;
+    }
+
+    private static String inputAdderFuncName(String operationName) {
+        return String.format("add%sInputChecksumMiddlewares", operationName);
+    }
+
+    private static String outputAdderFuncName(String operationName) {
+        return String.format("add%sOutputChecksumMiddlewares", operationName);
+    }
+
+    @Override
+    public void processFinalizedModel(GoSettings settings, Model model) {
+        ServiceShape service = settings.getService(model);
+        if (!S3ModelUtils.isServiceS3(model, service)) {
+            return;
+        }
+
+        for (ShapeId operationId : service.getAllOperations()) {
+            final OperationShape operation = model.expectShape(operationId, OperationShape.class);
+            String name = operation.getId().getName();
+
+            if (REQUEST_ALGORITHM_MEMBER.containsKey(name)) {
+                runtimeClientPlugins.add(operationPlugin(operation, inputAdderFuncName(name)));
+            }
+            if (RESPONSE_VALIDATION_MODE_MEMBER.containsKey(name)) {
+                runtimeClientPlugins.add(operationPlugin(operation, outputAdderFuncName(name)));
+            }
+        }
+    }
+
+    private static RuntimeClientPlugin operationPlugin(OperationShape operation, String funcName) {
+        return RuntimeClientPlugin.builder()
+                .operationPredicate((m, s, o) -> S3ModelUtils.isServiceS3(m, s) && o.equals(operation))
+                .registerMiddleware(MiddlewareRegistrar.builder()
+                        .resolvedFunction(SymbolUtils.createValueSymbolBuilder(funcName).build())
+                        .useClientOptions()
+                        .build())
+                .build();
+    }
+
+    @Override
+    public List<RuntimeClientPlugin> getClientPlugins() {
+        return runtimeClientPlugins;
+    }
+
+    @Override
+    public void writeAdditionalFiles(
+            GoSettings settings,
+            Model model,
+            SymbolProvider symbolProvider,
+            GoDelegator goDelegator
+    ) {
+        ServiceShape service = settings.getService(model);
+        if (!S3ModelUtils.isServiceS3(model, service)) {
+            return;
+        }
+
+        for (ShapeId operationId : service.getAllOperations()) {
+            OperationShape operation = model.expectShape(operationId, OperationShape.class);
+            String name = operation.getId().getName();
+
+            if (REQUEST_ALGORITHM_MEMBER.containsKey(name)) {
+                goDelegator.useShapeWriter(operation, writer -> writeInputMiddlewareHelper(
+                        writer, symbolProvider, operation, model, REQUEST_ALGORITHM_MEMBER.get(name)));
+            }
+            if (RESPONSE_VALIDATION_MODE_MEMBER.containsKey(name)) {
+                goDelegator.useShapeWriter(operation, writer -> writeOutputMiddlewareHelper(
+                        writer, symbolProvider, operation, model, RESPONSE_VALIDATION_MODE_MEMBER.get(name)));
+            }
+        }
+    }
+
+    private void writeInputMiddlewareHelper(
+            GoWriter writer,
+            SymbolProvider symbolProvider,
+            OperationShape operation,
+            Model model,
+            String memberName
+    ) {
+        String operationName = symbolProvider.toSymbol(operation).getName();
+        String getterName = String.format("get%sRequestAlgorithmMember", operationName);
+        String inputName = symbolProvider.toSymbol(model.expectShape(operation.getInput().get())).getName();
+
+        writer.write("// $L gets the request checksum algorithm value provided as input.", getterName);
+        writer.openBlock("func $L(input interface{}) (string, bool) {", "}", getterName, () -> {
+            writer.write("in := input.(*$L)", inputName);
+            writer.openBlock("if len(in.$L) == 0 {", "}", memberName, () -> writer.write("return \"\", false"));
+            writer.write("return string(in.$L), true", memberName);
+        });
+        writer.write("");
+
+        writer.addUseImports(SmithyGoDependency.SMITHY_MIDDLEWARE);
+        writer.openBlock("func $L(stack *middleware.Stack, options Options) error {", "}",
+                inputAdderFuncName(operationName), () -> {
+                    writer.openBlock("return $T(stack, $T{", "})",
+                            SymbolUtils.createValueSymbolBuilder(INPUT_ADDER,
+                                    AwsCustomGoDependency.CHECKSUM_CUSTOMIZATION).build(),
+                            SymbolUtils.createValueSymbolBuilder("InputMiddlewareOptions",
+                                    AwsCustomGoDependency.CHECKSUM_CUSTOMIZATION).build(), () -> {
+                                writer.write("GetAlgorithm: $L,", getterName);
+                                writer.write("EnableTrailingChecksum: true,");
+                                writer.write("EnableComputeSHA256PayloadHash: true,");
+                            });
+                });
+        writer.write("");
+    }
+
+    private void writeOutputMiddlewareHelper(
+            GoWriter writer,
+            SymbolProvider symbolProvider,
+            OperationShape operation,
+            Model model,
+            String memberName
+    ) {
+        String operationName = symbolProvider.toSymbol(operation).getName();
+        String getterName = String.format("get%sRequestValidationModeMember", operationName);
+        String inputName = symbolProvider.toSymbol(model.expectShape(operation.getInput().get())).getName();
+
+        writer.write("// $L gets the request checksum validation mode provided as input.", getterName);
+        writer.openBlock("func $L(input interface{}) (string, bool) {", "}", getterName, () -> {
+            writer.write("in := input.(*$L)", inputName);
+            writer.openBlock("if len(in.$L) == 0 {", "}", memberName, () -> writer.write("return \"\", false"));
+            writer.write("return string(in.$L), true", memberName);
+        });
+        writer.write("");
+
+        writer.addUseImports(SmithyGoDependency.SMITHY_MIDDLEWARE);
+        writer.openBlock("func $L(stack *middleware.Stack, options Options) error {", "}",
+                outputAdderFuncName(operationName), () -> {
+                    writer.openBlock("return $T(stack, $T{", "})",
+                            SymbolUtils.createValueSymbolBuilder(OUTPUT_ADDER,
+                                    AwsCustomGoDependency.CHECKSUM_CUSTOMIZATION).build(),
+                            SymbolUtils.createValueSymbolBuilder("OutputMiddlewareOptions",
+                                    AwsCustomGoDependency.CHECKSUM_CUSTOMIZATION).build(), () -> {
+                                writer.write("GetValidationMode: $L,", getterName);
+                                writer.write("ValidationAlgorithms: []string{\"CRC32\", \"CRC32C\", \"SHA256\", "
+                                        + "\"SHA1\"},");
+                                writer.write("IgnoreMultipartValidation: true,");
+                                writer.write("LogValidationSkipped: true,");
+                                writer.write("LogMultipartValidationSkipped: true,");
+                            });
+                });
+        writer.write("");
+    }
+}
@@ -0,0 +1,257 @@
+/*
+ * Copyright 2021 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License").
+ * You may not use this file except in compliance with the License.
+ * A copy of the License is located at
+ *
+ *  http://aws.amazon.com/apache2.0
+ *
+ * or in the "license" file accompanying this file. This file is distributed
+ * on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+ * express or implied. See the License for the specific language governing
+ * permissions and limitations under the License.
+ */
+
+package software.amazon.smithy.aws.go.codegen.customization;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import software.amazon.smithy.aws.traits.ServiceTrait;
+import software.amazon.smithy.codegen.core.Symbol;
+import software.amazon.smithy.codegen.core.SymbolProvider;
+import software.amazon.smithy.go.codegen.GoDelegator;
+import software.amazon.smithy.go.codegen.GoSettings;
+import software.amazon.smithy.go.codegen.GoWriter;
+import software.amazon.smithy.go.codegen.SymbolUtils;
+import software.amazon.smithy.go.codegen.integration.ConfigField;
+import software.amazon.smithy.go.codegen.integration.GoIntegration;
+import software.amazon.smithy.go.codegen.integration.MiddlewareRegistrar;
+import software.amazon.smithy.go.codegen.integration.RuntimeClientPlugin;
+import software.amazon.smithy.model.Model;
+import software.amazon.smithy.model.shapes.OperationShape;
+import software.amazon.smithy.model.shapes.ServiceShape;
+import software.amazon.smithy.model.shapes.ShapeId;
+import software.amazon.smithy.utils.ListUtils;
+import software.amazon.smithy.utils.MapUtils;
+
+/**
+ * Adds the validation of the MD5 checksums of the body and attributes of the
+ * messages sent, and received, by SQS operations. The validation middleware is
+ * implemented by the SQS customization package, with each operation given an
+ * accessor returning the messages of its input and output to validate.
+ */
+public class SQSValidateMessageChecksum implements GoIntegration {
+    private static final String CLIENT_OPTION = "DisableMessageChecksumValidation";
+    private static final String ADDER = "addValidateMessageChecksum";
+    private static final String INTERNAL_ADDER = "AddValidateMessageChecksum";
+    private static final String CHECKSUM_ERROR = "MessageChecksumError";
+
+    private static final Symbol MESSAGE = SymbolUtils.createValueSymbolBuilder("Message",
+            AwsCustomGoDependency.SQS_CUSTOMIZATION).build();
+
+    // operations with messages to validate, and the writer of the operation's
+    // accessor returning the messages.
+    private final Map<ShapeId, AccessorWriter> operations = MapUtils.of(
+            ShapeId.from("com.amazonaws.sqs#ReceiveMessage"), this::writeReceiveMessageAccessor,
+            ShapeId.from("com.amazonaws.sqs#SendMessage"), this::writeSendMessageAccessor,
+            ShapeId.from("com.amazonaws.sqs#SendMessageBatch"), this::writeSendMessageBatchAccessor
+    );
+
+    /**
+     * Gets the sort order of the customization from -128 to 127, with lowest
+     * executed first.
+     *
+     * @return Returns the sort order, 127 so that the middleware is registered
+     * after the response error middleware.
+     */
+    @Override
+    public byte getOrder() {
+        return 127;
+    }
+
+    private static String accessorName(String operationName) {
+        return String.format("get%sChecksumMessages", operationName);
+    }
+
+    @Override
+    public void writeAdditionalFiles(
+            GoSettings settings,
+            Model model,
+            SymbolProvider symbolProvider,
+            GoDelegator goDelegator
+    ) {
+        ServiceShape service = settings.getService(model);
+        if (!isSQSService(model, service)) {
+            return;
+        }
+
+        goDelegator.useShapeWriter(service, this::writeMiddlewareHelper);
+
+        for (ShapeId operationId : service.getAllOperations()) {
+            if (!operations.containsKey(operationId)) {
+                continue;
+            }
+            OperationShape operation = model.expectShape(operationId, OperationShape.class);
+            String operationName = symbolProvider.toSymbol(operation).getName();
+            goDelegator.useShapeWriter(operation, writer -> operations.get(operationId)
+                    .write(writer, model, symbolProvider, operationName));
+        }
+    }
+
+    private void writeMiddlewareHelper(GoWriter writer) {
+        Symbol checksumError = SymbolUtils.createPointableSymbolBuilder(CHECKSUM_ERROR,
+                AwsCustomGoDependency.SQS_CUSTOMIZATION).build();
+        writer.writeDocs("MessageChecksumError is returned by SendMessage, SendMessageBatch, and ReceiveMessage "
+                + "when the MD5 checksums of the messages sent, or received, do not match the checksums "
+                + "returned by the service. Lists the IDs of the messages whose checksums did not match.");
+        writer.write("type $L = $T", CHECKSUM_ERROR, checksumError);
+        writer.write("");
+
+        writer.openBlock("func $L(stack *middleware.Stack, options Options, "
+                + "getMessages func(input, output interface{}) []$T) error {", "}", ADDER, MESSAGE, () -> {
+            writer.openBlock("return $T(stack, $T{", "})",
+                    SymbolUtils.createValueSymbolBuilder(INTERNAL_ADDER,
+                            AwsCustomGoDependency.SQS_CUSTOMIZATION).build(),
+                    SymbolUtils.createValueSymbolBuilder(INTERNAL_ADDER + "Options",
+                            AwsCustomGoDependency.SQS_CUSTOMIZATION).build(),
+                    () -> {
+                        writer.write("GetMessages: getMessages,");
+                        writer.write("Disable:     options.$L,", CLIENT_OPTION);
+                    });
+        });
+        writer.write("");
+    }
+
+    private void writeReceiveMessageAccessor(
+            GoWriter writer,
+            Model model,
+            SymbolProvider symbolProvider,
+            String operationName
+    ) {
+        writer.writeDocs(String.format("%s returns the messages received, and the checksums of the messages "
+                + "returned by the service.", accessorName(operationName)));
+        writer.openBlock("func $L(input, output interface{}) []$T {", "}", accessorName(operationName), MESSAGE,
+                () -> {
+                    writer.write("out := output.(*$LOutput)", operationName);
+                    writer.write("");
+                    writer.write("messages := make([]$T, 0, len(out.Messages))", MESSAGE);
+                    writer.openBlock("for _, msg := range out.Messages {", "}", () -> {
+                        writer.openBlock("messages = append(messages, $T{", "})", MESSAGE, () -> {
+                            writer.write("MessageID:       msg.MessageId,");
+                            writer.write("Body:            msg.Body,");
+                            writer.write("MD5OfBody:       msg.MD5OfBody,");
+                            writer.write("Attributes:      msg.MessageAttributes,");
+                            writer.write("MD5OfAttributes: msg.MD5OfMessageAttributes,");
+                        });
+                    });
+                    writer.write("return messages");
+                });
+    }
+
+    private void writeSendMessageAccessor(
+            GoWriter writer,
+            Model model,
+            SymbolProvider symbolProvider,
+            String operationName
+    ) {
+        writer.writeDocs(String.format("%s returns the message sent, and the checksums of the message "
+                + "returned by the service.", accessorName(operationName)));
+        writer.openBlock("func $L(input, output interface{}) []$T {", "}", accessorName(operationName), MESSAGE,
+                () -> {
+                    writer.write("in := input.(*$LInput)", operationName);
+                    writer.write("out := output.(*$LOutput)", operationName);
+                    writer.openBlock("return []$T{{", "}}", MESSAGE, () -> {
+                        writer.write("MessageID:       out.MessageId,");
+                        writer.write("Body:            in.MessageBody,");
+                        writer.write("MD5OfBody:       out.MD5OfMessageBody,");
+                        writer.write("Attributes:      in.MessageAttributes,");
+                        writer.write("MD5OfAttributes: out.MD5OfMessageAttributes,");
+                    });
+                });
+    }
+
+    private void writeSendMessageBatchAccessor(
+            GoWriter writer,
+            Model model,
+            SymbolProvider symbolProvider,
+            String operationName
+    ) {
+        Symbol entry = symbolProvider.toSymbol(model.expectShape(
+                ShapeId.from("com.amazonaws.sqs#SendMessageBatchRequestEntry")));
+        writer.writeDocs(String.format("%s returns the messages sent successfully, and the checksums of "
+                + "the messages returned by the service.", accessorName(operationName)));
+        writer.openBlock("func $L(input, output interface{}) []$T {", "}", accessorName(operationName), MESSAGE,
+                () -> {
+                    writer.write("in := input.(*$LInput)", operationName);
+                    writer.write("out := output.(*$LOutput)", operationName);
+                    writer.write("");
+                    writer.write("entries := make(map[string]$T, len(in.Entries))", entry);
+                    writer.openBlock("for _, entry := range in.Entries {", "}", () -> {
+                        writer.openBlock("if entry.Id != nil {", "}", () -> {
+                            writer.write("entries[*entry.Id] = entry");
+                        });
+                    });
+                    writer.write("");
+                    writer.write("var messages []$T", MESSAGE);
+                    writer.openBlock("for _, result := range out.Successful {", "}", () -> {
+                        writer.openBlock("if result.Id == nil {", "}", () -> writer.write("continue"));
+                        writer.write("entry, ok := entries[*result.Id]");
+                        writer.openBlock("if !ok {", "}", () -> writer.write("continue"));
+                        writer.openBlock("messages = append(messages, $T{", "})", MESSAGE, () -> {
+                            writer.write("MessageID:       result.MessageId,");
+                            writer.write("Body:            entry.MessageBody,");
+                            writer.write("MD5OfBody:       result.MD5OfMessageBody,");
+                            writer.write("Attributes:      entry.MessageAttributes,");
+                            writer.write("MD5OfAttributes: result.MD5OfMessageAttributes,");
+                        });
+                    });
+                    writer.write("return messages");
+                });
+    }
+
+    @Override
+    public List<RuntimeClientPlugin> getClientPlugins() {
+        List<RuntimeClientPlugin> plugins = new ArrayList<>();
+        plugins.add(RuntimeClientPlugin.builder()
+                .servicePredicate(SQSValidateMessageChecksum::isSQSService)
+                .configFields(ListUtils.of(
+                        ConfigField.builder()
+                                .name(CLIENT_OPTION)
+                                .type(SymbolUtils.createValueSymbolBuilder("bool")
+                                        .putProperty(SymbolUtils.GO_UNIVERSE_TYPE, true)
+                                        .build())
+                                .documentation("Allows you to disable the client's validation of the MD5 "
+                                        + "checksums of the body and attributes of messages sent, and received. "
+                                        + "Enabled by default.")
+                                .build()
+                ))
+                .build());
+
+        // Each operation's middleware is registered with the operation's
+        // accessor of its messages.
+        for (ShapeId operationId : operations.keySet()) {
+            plugins.add(RuntimeClientPlugin.builder()
+                    .operationPredicate((m, s, o) -> o.getId().equals(operationId))
+                    .registerMiddleware(MiddlewareRegistrar.builder()
+                            .resolvedFunction(SymbolUtils.createValueSymbolBuilder(ADDER).build())
+                            .functionArguments(ListUtils.of(
+                                    SymbolUtils.createValueSymbolBuilder("options").build(),
+                                    SymbolUtils.createValueSymbolBuilder(
+                                            accessorName(operationId.getName())).build()))
+                            .build())
+                    .build());
+        }
+        return plugins;
+    }
+
+    @FunctionalInterface
+    private interface AccessorWriter {
+        void write(GoWriter writer, Model model, SymbolProvider symbolProvider, String operationName);
+    }
+
+    private static boolean isSQSService(Model model, ServiceShape service) {
+        return service.expectTrait(ServiceTrait.class).getSdkId().equalsIgnoreCase("SQS");
+    }
+}
@@ -37,6 +37,8 @@ var implAsserts = map[string][]string{
 	"clientLogModeProvider":                    {loadOptionsType},
 	"logConfigurationWarningsProvider":         {loadOptionsType},
 	"ec2IMDSRegionProvider":                    {loadOptionsType},
+	"retryModeProvider":                        {envConfigType, sharedConfigType, loadOptionsType},
+	"retryMaxAttemptsProvider":                 {envConfigType, sharedConfigType, loadOptionsType},
 }
 
 var tplProviderTests = template.Must(template.New("tplProviderTests").Funcs(map[string]interface{}{
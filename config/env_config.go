@@ -7,6 +7,7 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -50,6 +51,9 @@ const (
 	awsEnableEndpointDiscoveryEnvKey = "AWS_ENABLE_ENDPOINT_DISCOVERY"
 
 	awsS3UseARNRegionEnvVar = "AWS_S3_USE_ARN_REGION"
+
+	awsRetryModeEnvVar   = "AWS_RETRY_MODE"
+	awsMaxAttemptsEnvVar = "AWS_MAX_ATTEMPTS"
 )
 
 var (
@@ -180,6 +184,18 @@ type EnvConfig struct {
 	//
 	// AWS_S3_USE_ARN_REGION=true
 	S3UseARNRegion *bool
+
+	// Specifies the retry mode API clients should use to create their
+	// retryer. Must be one of "standard", or "adaptive".
+	//
+	//	AWS_RETRY_MODE=adaptive
+	RetryMode aws.RetryMode
+
+	// Specifies the maximum number attempts an API client will call an
+	// operation that fails with a retryable error. Must be greater than zero.
+	//
+	//	AWS_MAX_ATTEMPTS=5
+	RetryMaxAttempts int
 }
 
 // loadEnvConfig reads configuration values from the OS's environment variables.
@@ -228,6 +244,13 @@ func NewEnvConfig() (EnvConfig, error) {
 		return cfg, err
 	}
 
+	if err := setRetryModeFromEnvVal(&cfg.RetryMode, awsRetryModeEnvVar); err != nil {
+		return cfg, err
+	}
+	if err := setRetryMaxAttemptsFromEnvVal(&cfg.RetryMaxAttempts, awsMaxAttemptsEnvVar); err != nil {
+		return cfg, err
+	}
+
 	return cfg, nil
 }
 
@@ -304,6 +327,23 @@ func (c EnvConfig) GetS3UseARNRegion(ctx context.Context) (value, ok bool, err e
 	return *c.S3UseARNRegion, true, nil
 }
 
+// getRetryMode returns the retry mode if set in the environment.
+func (c EnvConfig) getRetryMode(ctx context.Context) (aws.RetryMode, bool, error) {
+	if len(c.RetryMode) == 0 {
+		return "", false, nil
+	}
+	return c.RetryMode, true, nil
+}
+
+// getRetryMaxAttempts returns the retry max attempts if set in the
+// environment.
+func (c EnvConfig) getRetryMaxAttempts(ctx context.Context) (int, bool, error) {
+	if c.RetryMaxAttempts == 0 {
+		return 0, false, nil
+	}
+	return c.RetryMaxAttempts, true, nil
+}
+
 func setStringFromEnvVal(dst *string, keys []string) {
 	for _, k := range keys {
 		if v := os.Getenv(k); len(v) > 0 {
@@ -339,3 +379,46 @@ func setBoolPtrFromEnvVal(dst **bool, keys []string) error {
 
 	return nil
 }
+
+func setRetryModeFromEnvVal(dst *aws.RetryMode, key string) error {
+	value := os.Getenv(key)
+	if len(value) == 0 {
+		return nil
+	}
+
+	mode, err := aws.ParseRetryMode(value)
+	if err != nil {
+		return RetryConfigLoadError{Key: key, Value: value, Err: err}
+	}
+
+	*dst = mode
+	return nil
+}
+
+func setRetryMaxAttemptsFromEnvVal(dst *int, key string) error {
+	value := os.Getenv(key)
+	if len(value) == 0 {
+		return nil
+	}
+
+	maxAttempts, err := parseRetryMaxAttempts(value)
+	if err != nil {
+		return RetryConfigLoadError{Key: key, Value: value, Err: err}
+	}
+
+	*dst = maxAttempts
+	return nil
+}
+
+// parseRetryMaxAttempts parses the retry max attempts value, which must be an
+// integer greater than zero.
+func parseRetryMaxAttempts(value string) (int, error) {
+	maxAttempts, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("max attempts must be an integer, %w", err)
+	}
+	if maxAttempts < 1 {
+		return 0, fmt.Errorf("max attempts must be greater than zero, %d", maxAttempts)
+	}
+	return maxAttempts, nil
+}
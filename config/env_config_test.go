@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"reflect"
 	"strconv"
@@ -230,6 +231,16 @@ func TestNewEnvConfig(t *testing.T) {
 				EnableEndpointDiscovery: ptr.Bool(true),
 			},
 		},
+		13: {
+			Env: map[string]string{
+				"AWS_RETRY_MODE":   "adaptive",
+				"AWS_MAX_ATTEMPTS": "5",
+			},
+			Config: EnvConfig{
+				RetryMode:        aws.RetryModeAdaptive,
+				RetryMaxAttempts: 5,
+			},
+		},
 	}
 
 	for i, c := range cases {
@@ -270,3 +281,55 @@ func TestSetEnvValue(t *testing.T) {
 		t.Errorf("expect %s value from environment, got %s", e, a)
 	}
 }
+
+func TestNewEnvConfig_InvalidRetry(t *testing.T) {
+	restoreEnv := awstesting.StashEnv()
+	defer awstesting.PopEnv(restoreEnv)
+
+	cases := map[string]struct {
+		Env       map[string]string
+		ExpectKey string
+	}{
+		"unknown retry mode": {
+			Env:       map[string]string{"AWS_RETRY_MODE": "legacy"},
+			ExpectKey: "AWS_RETRY_MODE",
+		},
+		"max attempts not integer": {
+			Env:       map[string]string{"AWS_MAX_ATTEMPTS": "three"},
+			ExpectKey: "AWS_MAX_ATTEMPTS",
+		},
+		"max attempts zero": {
+			Env:       map[string]string{"AWS_MAX_ATTEMPTS": "0"},
+			ExpectKey: "AWS_MAX_ATTEMPTS",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			os.Clearenv()
+
+			for k, v := range c.Env {
+				os.Setenv(k, v)
+			}
+
+			_, err := NewEnvConfig()
+			if err == nil {
+				t.Fatalf("expect error, got none")
+			}
+
+			var loadErr RetryConfigLoadError
+			if !errors.As(err, &loadErr) {
+				t.Fatalf("expect %T error, got %T, %v", loadErr, err, err)
+			}
+			if e, a := c.ExpectKey, loadErr.Key; e != a {
+				t.Errorf("expect %v key, got %v", e, a)
+			}
+			if e, a := c.Env[c.ExpectKey], loadErr.Value; e != a {
+				t.Errorf("expect %v value, got %v", e, a)
+			}
+			if len(loadErr.Profile) != 0 {
+				t.Errorf("expect no profile, got %v", loadErr.Profile)
+			}
+		})
+	}
+}
@@ -38,6 +38,16 @@ type LoadOptions struct {
 	// retried in case of recoverable failures.
 	Retryer func() aws.Retryer
 
+	// RetryMode specifies the retry mode the API clients will use to create
+	// their Retryer with, if a Retryer is not provided. Use
+	// aws.RetryModeAdaptive to rate limit requests on throttle responses.
+	RetryMode aws.RetryMode
+
+	// RetryMaxAttempts specifies the maximum number attempts an API client
+	// will call an operation that fails with a retryable error, if a Retryer
+	// is not provided.
+	RetryMaxAttempts int
+
 	// APIOptions provides the set of middleware mutations modify how the API
 	// client requests will be handled. This is useful for adding additional
 	// tracing data to a request, or changing behavior of the SDK's client.
@@ -501,6 +511,45 @@ func WithRetryer(v func() aws.Retryer) LoadOptionsFunc {
 	}
 }
 
+func (o LoadOptions) getRetryMode(ctx context.Context) (aws.RetryMode, bool, error) {
+	if len(o.RetryMode) == 0 {
+		return "", false, nil
+	}
+
+	return o.RetryMode, true, nil
+}
+
+// WithRetryMode is a helper function to construct functional options
+// that sets RetryMode on LoadOptions. If RetryMode is set to an empty
+// value, the RetryMode value is ignored. If multiple WithRetryMode calls
+// are made, the last call overrides the previous call values.
+func WithRetryMode(v aws.RetryMode) LoadOptionsFunc {
+	return func(o *LoadOptions) error {
+		o.RetryMode = v
+		return nil
+	}
+}
+
+func (o LoadOptions) getRetryMaxAttempts(ctx context.Context) (int, bool, error) {
+	if o.RetryMaxAttempts == 0 {
+		return 0, false, nil
+	}
+
+	return o.RetryMaxAttempts, true, nil
+}
+
+// WithRetryMaxAttempts is a helper function to construct functional options
+// that sets RetryMaxAttempts on LoadOptions. If RetryMaxAttempts is set to
+// zero, the RetryMaxAttempts value is ignored. If multiple
+// WithRetryMaxAttempts calls are made, the last call overrides the previous
+// call values.
+func WithRetryMaxAttempts(v int) LoadOptionsFunc {
+	return func(o *LoadOptions) error {
+		o.RetryMaxAttempts = v
+		return nil
+	}
+}
+
 func (o LoadOptions) getEndpointResolver(ctx context.Context) (aws.EndpointResolver, bool, error) {
 	if o.EndpointResolver == nil {
 		return nil, false, nil
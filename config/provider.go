@@ -389,6 +389,42 @@ func getRetryer(ctx context.Context, configs configs) (v func() aws.Retryer, fou
 	return
 }
 
+// retryModeProvider is an configuration provider for the retry mode used to
+// create the API clients' Retryer.
+type retryModeProvider interface {
+	getRetryMode(ctx context.Context) (aws.RetryMode, bool, error)
+}
+
+func getRetryMode(ctx context.Context, configs configs) (v aws.RetryMode, found bool, err error) {
+	for _, c := range configs {
+		if p, ok := c.(retryModeProvider); ok {
+			v, found, err = p.getRetryMode(ctx)
+			if err != nil || found {
+				break
+			}
+		}
+	}
+	return
+}
+
+// retryMaxAttemptsProvider is an configuration provider for the maximum
+// number of attempts the API clients' Retryer will make.
+type retryMaxAttemptsProvider interface {
+	getRetryMaxAttempts(ctx context.Context) (int, bool, error)
+}
+
+func getRetryMaxAttempts(ctx context.Context, configs configs) (v int, found bool, err error) {
+	for _, c := range configs {
+		if p, ok := c.(retryMaxAttemptsProvider); ok {
+			v, found, err = p.getRetryMaxAttempts(ctx)
+			if err != nil || found {
+				break
+			}
+		}
+	}
+	return
+}
+
 // logConfigurationWarningsProvider is an configuration provider for
 // retrieving a boolean indicating whether configuration issues should
 // be logged when loading from config sources
@@ -10,6 +10,7 @@ import (
 	"os"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
 	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/smithy-go/logging"
 )
@@ -199,11 +200,46 @@ func resolveRetryer(ctx context.Context, cfg *aws.Config, configs configs) error
 	if err != nil {
 		return err
 	}
-	if !found {
+	if found {
+		cfg.Retryer = retryer
 		return nil
 	}
 
-	cfg.Retryer = retryer
+	mode, modeFound, err := getRetryMode(ctx, configs)
+	if err != nil {
+		return err
+	}
+	maxAttempts, maxAttemptsFound, err := getRetryMaxAttempts(ctx, configs)
+	if err != nil {
+		return err
+	}
+	if !modeFound && !maxAttemptsFound {
+		return nil
+	}
+	if maxAttemptsFound && maxAttempts < 1 {
+		return fmt.Errorf("retry max attempts must be greater than zero, %d", maxAttempts)
+	}
+
+	standardOptions := func(o *retry.StandardOptions) {
+		if maxAttemptsFound {
+			o.MaxAttempts = maxAttempts
+		}
+	}
+
+	switch mode {
+	case aws.RetryModeStandard, "":
+		cfg.Retryer = func() aws.Retryer {
+			return retry.NewStandard(standardOptions)
+		}
+	case aws.RetryModeAdaptive:
+		cfg.Retryer = func() aws.Retryer {
+			return retry.NewAdaptive(func(o *retry.AdaptiveOptions) {
+				o.StandardOptions = append(o.StandardOptions, standardOptions)
+			})
+		}
+	default:
+		return fmt.Errorf("unknown retry mode, %v", mode)
+	}
 
 	return nil
 }
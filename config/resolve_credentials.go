@@ -15,6 +15,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
 	"github.com/aws/aws-sdk-go-v2/service/sso"
+	"github.com/aws/aws-sdk-go-v2/service/ssooidc"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 )
 
@@ -164,7 +165,14 @@ func resolveSSOCredentials(ctx context.Context, cfg *aws.Config, sharedConfig *S
 		return err
 	}
 
-	var options []func(*ssocreds.Options)
+	cfgCopy := cfg.Copy()
+	cfgCopy.Region = sharedConfig.SSORegion
+
+	options := []func(*ssocreds.Options){
+		func(o *ssocreds.Options) {
+			o.OIDCClient = ssooidc.NewFromConfig(cfgCopy)
+		},
+	}
 	v, found, err := getSSOProviderOptions(ctx, configs)
 	if err != nil {
 		return err
@@ -173,9 +181,6 @@ func resolveSSOCredentials(ctx context.Context, cfg *aws.Config, sharedConfig *S
 		options = append(options, v)
 	}
 
-	cfgCopy := cfg.Copy()
-	cfgCopy.Region = sharedConfig.SSORegion
-
 	cfg.Credentials = ssocreds.New(sso.NewFromConfig(cfgCopy), sharedConfig.SSOAccountID, sharedConfig.SSORoleName, sharedConfig.SSOStartURL, options...)
 
 	return nil
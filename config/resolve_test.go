@@ -5,9 +5,12 @@ import (
 	"context"
 	"io/ioutil"
 	"net/http"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
 	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/internal/awstesting"
@@ -211,3 +214,116 @@ func TestResolveLogger(t *testing.T) {
 		t.Error("unexpected logger type")
 	}
 }
+
+func TestResolveRetryer(t *testing.T) {
+	cases := map[string]struct {
+		Configs           configs
+		ExpectNil         bool
+		ExpectType        aws.Retryer
+		ExpectMaxAttempts int
+		ExpectErrMsg      string
+	}{
+		"none": {
+			Configs:   configs{LoadOptions{}, SharedConfig{}},
+			ExpectNil: true,
+		},
+		"load options retryer": {
+			Configs: configs{
+				LoadOptions{
+					Retryer: func() aws.Retryer {
+						return aws.NopRetryer{}
+					},
+					RetryMode: aws.RetryModeAdaptive,
+				},
+			},
+			ExpectType: aws.NopRetryer{},
+		},
+		"load options retry mode": {
+			Configs: configs{
+				LoadOptions{RetryMode: aws.RetryModeAdaptive},
+				SharedConfig{RetryMode: aws.RetryModeStandard},
+			},
+			ExpectType:        &retry.Adaptive{},
+			ExpectMaxAttempts: retry.DefaultMaxAttempts,
+		},
+		"shared config adaptive retry mode": {
+			Configs:           configs{LoadOptions{}, SharedConfig{RetryMode: aws.RetryModeAdaptive}},
+			ExpectType:        &retry.Adaptive{},
+			ExpectMaxAttempts: retry.DefaultMaxAttempts,
+		},
+		"shared config standard retry mode": {
+			Configs:           configs{LoadOptions{}, SharedConfig{RetryMode: aws.RetryModeStandard}},
+			ExpectType:        &retry.Standard{},
+			ExpectMaxAttempts: retry.DefaultMaxAttempts,
+		},
+		"max attempts only": {
+			Configs:           configs{LoadOptions{}, EnvConfig{}, SharedConfig{RetryMaxAttempts: 7}},
+			ExpectType:        &retry.Standard{},
+			ExpectMaxAttempts: 7,
+		},
+		"env config precedence": {
+			Configs: configs{
+				LoadOptions{},
+				EnvConfig{RetryMode: aws.RetryModeAdaptive, RetryMaxAttempts: 2},
+				SharedConfig{RetryMode: aws.RetryModeStandard, RetryMaxAttempts: 7},
+			},
+			ExpectType:        &retry.Adaptive{},
+			ExpectMaxAttempts: 2,
+		},
+		"mixed sources": {
+			Configs: configs{
+				LoadOptions{RetryMaxAttempts: 10},
+				EnvConfig{},
+				SharedConfig{RetryMode: aws.RetryModeAdaptive, RetryMaxAttempts: 7},
+			},
+			ExpectType:        &retry.Adaptive{},
+			ExpectMaxAttempts: 10,
+		},
+		"invalid max attempts": {
+			Configs:      configs{LoadOptions{RetryMaxAttempts: -1}},
+			ExpectErrMsg: "retry max attempts must be greater than zero",
+		},
+		"unknown retry mode": {
+			Configs:      configs{LoadOptions{RetryMode: "unknown"}},
+			ExpectErrMsg: "unknown retry mode",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			var cfg aws.Config
+			err := resolveRetryer(context.Background(), &cfg, c.Configs)
+			if len(c.ExpectErrMsg) != 0 {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				if e, a := c.ExpectErrMsg, err.Error(); !strings.Contains(a, e) {
+					t.Fatalf("expect %q error, got %q", e, a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			if c.ExpectNil {
+				if cfg.Retryer != nil {
+					t.Fatalf("expect no retryer, got %T", cfg.Retryer())
+				}
+				return
+			}
+			if cfg.Retryer == nil {
+				t.Fatalf("expect retryer, got none")
+			}
+			retryer := cfg.Retryer()
+			if e, a := reflect.TypeOf(c.ExpectType), reflect.TypeOf(retryer); e != a {
+				t.Errorf("expect %v retryer, got %v", e, a)
+			}
+			if c.ExpectMaxAttempts != 0 {
+				if e, a := c.ExpectMaxAttempts, retryer.MaxAttempts(); e != a {
+					t.Errorf("expect %v max attempts, got %v", e, a)
+				}
+			}
+		})
+	}
+}
@@ -54,6 +54,10 @@ const (
 	// S3 ARN Region Usage
 	s3UseARNRegionKey = "s3_use_arn_region"
 
+	// Retry options
+	retryModeKey        = "retry_mode"
+	retryMaxAttemptsKey = "max_attempts"
+
 	// DefaultSharedConfigProfile is the default profile to be used when
 	// loading configuration from the config files if another profile name
 	// is not provided.
@@ -147,6 +151,18 @@ type SharedConfig struct {
 	//
 	// s3_use_arn_region=true
 	S3UseARNRegion *bool
+
+	// Specifies the retry mode API clients should use to create their
+	// retryer. If not set the API client's default retryer is used.
+	//
+	//	retry_mode=adaptive
+	RetryMode aws.RetryMode
+
+	// Specifies the maximum number attempts an API client will call an
+	// operation that fails with a retryable error. Must be greater than zero.
+	//
+	//	max_attempts=5
+	RetryMaxAttempts int
 }
 
 // GetS3UseARNRegion returns if the S3 service should allow ARNs to direct the region
@@ -159,6 +175,23 @@ func (c SharedConfig) GetS3UseARNRegion(ctx context.Context) (value, ok bool, er
 	return *c.S3UseARNRegion, true, nil
 }
 
+// getRetryMode returns the retry mode for the profile if one is set.
+func (c SharedConfig) getRetryMode(ctx context.Context) (aws.RetryMode, bool, error) {
+	if len(c.RetryMode) == 0 {
+		return "", false, nil
+	}
+	return c.RetryMode, true, nil
+}
+
+// getRetryMaxAttempts returns the retry max attempts for the profile if it is
+// set.
+func (c SharedConfig) getRetryMaxAttempts(ctx context.Context) (int, bool, error) {
+	if c.RetryMaxAttempts == 0 {
+		return 0, false, nil
+	}
+	return c.RetryMaxAttempts, true, nil
+}
+
 // GetRegion returns the region for the profile if a region is set.
 func (c SharedConfig) getRegion(ctx context.Context) (string, bool, error) {
 	if len(c.Region) == 0 {
@@ -863,6 +896,27 @@ func (c *SharedConfig) setFromIniSection(profile string, section ini.Section) er
 	updateBoolPtr(&c.EnableEndpointDiscovery, section, enableEndpointDiscoveryKey)
 	updateBoolPtr(&c.S3UseARNRegion, section, s3UseARNRegionKey)
 
+	if section.Has(retryModeKey) {
+		value := section.String(retryModeKey)
+		mode, err := aws.ParseRetryMode(value)
+		if err != nil {
+			return RetryConfigLoadError{
+				Profile: profile, Key: retryModeKey, Value: value, Err: err,
+			}
+		}
+		c.RetryMode = mode
+	}
+	if section.Has(retryMaxAttemptsKey) {
+		value := section.String(retryMaxAttemptsKey)
+		maxAttempts, err := parseRetryMaxAttempts(value)
+		if err != nil {
+			return RetryConfigLoadError{
+				Profile: profile, Key: retryMaxAttemptsKey, Value: value, Err: err,
+			}
+		}
+		c.RetryMaxAttempts = maxAttempts
+	}
+
 	// Shared Credentials
 	creds := aws.Credentials{
 		AccessKeyID:     section.String(accessKeyIDKey),
@@ -1046,6 +1100,36 @@ func (e SharedConfigAssumeRoleError) Error() string {
 		e.RoleARN, e.Profile, e.Err)
 }
 
+// RetryConfigLoadError is the error for a retry configuration value loaded
+// from the environment, or shared config file, that is not valid.
+type RetryConfigLoadError struct {
+	// Profile the value was loaded from. Empty if the value was loaded from
+	// the environment.
+	Profile string
+
+	// Key is the name of the environment variable or shared config key.
+	Key string
+
+	// Value is the invalid value that was loaded.
+	Value string
+
+	Err error
+}
+
+// Unwrap returns the underlying error that caused the failure.
+func (e RetryConfigLoadError) Unwrap() error {
+	return e.Err
+}
+
+func (e RetryConfigLoadError) Error() string {
+	if len(e.Profile) == 0 {
+		return fmt.Sprintf("failed to load %s from environment, %q, %v",
+			e.Key, e.Value, e.Err)
+	}
+	return fmt.Sprintf("failed to load %s from shared config profile %s, %q, %v",
+		e.Key, e.Profile, e.Value, e.Err)
+}
+
 // CredentialRequiresARNError provides the error for shared config credentials
 // that are incorrectly configured in the shared config or credentials file.
 type CredentialRequiresARNError struct {
@@ -155,6 +155,43 @@ func TestNewSharedConfig(t *testing.T) {
 				EnableEndpointDiscovery: ptr.Bool(true),
 			},
 		},
+		"RetryMode property on profile": {
+			Profile:   "retry_mode_adaptive",
+			Filenames: []string{testConfigFilename},
+			Expected: SharedConfig{
+				Profile:   "retry_mode_adaptive",
+				RetryMode: aws.RetryModeAdaptive,
+			},
+		},
+		"Invalid RetryMode property on profile": {
+			Profile:   "retry_mode_invalid",
+			Filenames: []string{testConfigFilename},
+			Err: RetryConfigLoadError{
+				Profile: "retry_mode_invalid",
+				Key:     "retry_mode",
+				Value:   "unknown",
+				Err:     fmt.Errorf("unknown RetryMode, unknown"),
+			},
+		},
+		"RetryMaxAttempts property on profile": {
+			Profile:   "retry_max_attempts",
+			Filenames: []string{testConfigFilename},
+			Expected: SharedConfig{
+				Profile:          "retry_max_attempts",
+				RetryMode:        aws.RetryModeStandard,
+				RetryMaxAttempts: 5,
+			},
+		},
+		"Invalid RetryMaxAttempts property on profile": {
+			Profile:   "retry_max_attempts_invalid",
+			Filenames: []string{testConfigFilename},
+			Err: RetryConfigLoadError{
+				Profile: "retry_max_attempts_invalid",
+				Key:     "max_attempts",
+				Value:   "0",
+				Err:     fmt.Errorf("max attempts must be greater than zero, 0"),
+			},
+		},
 		"Assume role with credential source Ec2Metadata": {
 			Filenames: []string{testConfigOtherFilename, testConfigFilename},
 			Profile:   "assume_role_with_credential_source",
@@ -7,6 +7,7 @@ require (
 	github.com/aws/aws-sdk-go-v2/feature/ec2/imds v1.0.2
 	github.com/aws/aws-sdk-go-v2/service/internal/presigned-url v1.0.2 // indirect
 	github.com/aws/aws-sdk-go-v2/service/sso v1.1.1
+	github.com/aws/aws-sdk-go-v2/service/ssooidc v1.1.1
 	github.com/aws/aws-sdk-go-v2/service/sts v1.1.1
 	github.com/aws/smithy-go v1.1.0
 	github.com/google/go-cmp v0.5.4
@@ -21,3 +22,5 @@ replace (
 replace github.com/aws/aws-sdk-go-v2/service/internal/presigned-url => ../service/internal/presigned-url/
 
 replace github.com/aws/aws-sdk-go-v2/service/sso => ../service/sso/
+
+replace github.com/aws/aws-sdk-go-v2/service/ssooidc => ../service/ssooidc/
@@ -1,9 +1,27 @@
 // Package ssocreds provides a credential provider for retrieving temporary AWS credentials using an SSO access token.
 //
-// IMPORTANT: The provider in this package does not initiate or perform the AWS SSO login flow. The SDK provider
-// expects that you have already performed the SSO login flow using AWS CLI using the "aws sso login" command, or by
-// some other mechanism. The provider must find a valid non-expired access token for the AWS SSO user portal URL in
-// ~/.aws/sso/cache. If a cached token is not found, it is expired, or the file is malformed an error will be returned.
+// The provider expects that you have already performed the SSO login flow using AWS CLI using the "aws sso login"
+// command, or with the Login function of this package. The provider must find a valid non-expired access token for
+// the AWS SSO user portal URL in ~/.aws/sso/cache. If a cached token is not found, it is expired, or the file is
+// malformed an error will be returned.
+//
+// If the provider is configured with an AWS SSO OIDC client, cached access tokens that include a refresh token are
+// refreshed before they expire, and the refreshed token is written back to ~/.aws/sso/cache. If a PromptUser function
+// is also configured, the provider will perform the device authorization login flow when a valid access token cannot
+// be found or refreshed.
+//
+// Performing the AWS SSO login flow
+//
+// The Login function performs the AWS SSO OIDC device authorization flow, and writes the access token to
+// ~/.aws/sso/cache in the same format as the AWS CLI. The user must complete the device authorization in a browser.
+//
+//  err := ssocreds.Login(context.TODO(), ssooidc.NewFromConfig(cfg), "https://my-sso-portal.awsapps.com/start",
+//      func(o *ssocreds.LoginOptions) {
+//          o.PromptUser = func(ctx context.Context, auth ssocreds.DeviceAuthorization) error {
+//              fmt.Printf("Open %s and enter the code %s\n", auth.VerificationURI, auth.UserCode)
+//              return nil
+//          }
+//      })
 //
 // Loading AWS SSO credentials with the AWS shared configuration file
 //
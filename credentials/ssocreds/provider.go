@@ -2,23 +2,21 @@ package ssocreds
 
 import (
 	"context"
-	"crypto/sha1"
-	"encoding/hex"
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/internal/sdk"
 	"github.com/aws/aws-sdk-go-v2/service/sso"
 )
 
 // ProviderName is the name of the provider used to specify the source of credentials.
 const ProviderName = "SSOProvider"
 
+// tokenRefreshWindow is the duration before the cached access token expires that the provider will attempt to
+// refresh the token.
+const tokenRefreshWindow = 5 * time.Minute
+
 var defaultCacheLocation func() string
 
 func defaultCacheLocationImpl() string {
@@ -47,6 +45,16 @@ type Options struct {
 
 	// The URL that points to the organization's AWS Single Sign-On (AWS SSO) user portal.
 	StartURL string
+
+	// The AWS SSO OIDC client used to refresh the cached access token when it has expired, or is about to expire,
+	// and the token cache contains a refresh token for it. The client must be configured for the AWS Region where
+	// the AWS SSO user portal is located. If not set the cached access token is not refreshed.
+	OIDCClient OIDCAPIClient
+
+	// If set, enables the provider to perform the device authorization login flow when the cached access token is
+	// missing, or has expired and cannot be refreshed. Requires OIDCClient to be set. See the Login function for
+	// more information.
+	PromptUser func(context.Context, DeviceAuthorization) error
 }
 
 // Provider is an AWS credential provider that retrieves temporary AWS credentials by exchanging an SSO login token.
@@ -76,13 +84,13 @@ func New(client GetRoleCredentialsAPIClient, accountID, roleName, startURL strin
 // Retrieve retrieves temporary AWS credentials from the configured Amazon Single Sign-On (AWS SSO) user portal
 // by exchanging the accessToken present in ~/.aws/sso/cache.
 func (p *Provider) Retrieve(ctx context.Context) (aws.Credentials, error) {
-	tokenFile, err := loadTokenFile(p.options.StartURL)
+	accessToken, err := p.getAccessToken(ctx)
 	if err != nil {
 		return aws.Credentials{}, err
 	}
 
 	output, err := p.options.Client.GetRoleCredentials(ctx, &sso.GetRoleCredentialsInput{
-		AccessToken: &tokenFile.AccessToken,
+		AccessToken: &accessToken,
 		AccountId:   &p.options.AccountID,
 		RoleName:    &p.options.RoleName,
 	})
@@ -100,43 +108,47 @@ func (p *Provider) Retrieve(ctx context.Context) (aws.Credentials, error) {
 	}, nil
 }
 
-func getCacheFileName(url string) (string, error) {
-	hash := sha1.New()
-	_, err := hash.Write([]byte(url))
-	if err != nil {
-		return "", err
+// getAccessToken returns the cached access token for the start URL. If the
+// cached token is stale, it will be refreshed, or a new token created by
+// login, if the provider is configured to do so.
+func (p *Provider) getAccessToken(ctx context.Context) (string, error) {
+	t, err := loadCachedToken(p.options.StartURL)
+	if err == nil {
+		refresh := p.options.OIDCClient != nil && t.canRefresh() && t.expiresWithin(tokenRefreshWindow)
+		switch {
+		case refresh:
+			refreshed, refreshErr := refreshToken(ctx, p.options.OIDCClient, p.options.StartURL, t)
+			if refreshErr == nil {
+				return refreshed.AccessToken, nil
+			}
+			if !t.Expired() {
+				// The token is still valid even though it could not be
+				// refreshed.
+				return t.AccessToken, nil
+			}
+			err = &InvalidTokenError{Err: refreshErr}
+		case !t.Expired():
+			return t.AccessToken, nil
+		default:
+			err = &InvalidTokenError{Err: fmt.Errorf("access token is expired")}
+		}
 	}
-	return strings.ToLower(hex.EncodeToString(hash.Sum(nil))) + ".json", nil
-}
-
-type rfc3339 time.Time
-
-func (r *rfc3339) UnmarshalJSON(bytes []byte) error {
-	var value string
 
-	if err := json.Unmarshal(bytes, &value); err != nil {
-		return err
+	if p.options.OIDCClient == nil || p.options.PromptUser == nil {
+		return "", err
 	}
 
-	parse, err := time.Parse(time.RFC3339, value)
+	t, err = login(ctx, LoginOptions{
+		Client:     p.options.OIDCClient,
+		StartURL:   p.options.StartURL,
+		PromptUser: p.options.PromptUser,
+		ClientName: DefaultLoginClientName,
+	})
 	if err != nil {
-		return fmt.Errorf("expected RFC3339 timestamp: %w", err)
+		return "", &InvalidTokenError{Err: err}
 	}
 
-	*r = rfc3339(parse)
-
-	return nil
-}
-
-type token struct {
-	AccessToken string  `json:"accessToken"`
-	ExpiresAt   rfc3339 `json:"expiresAt"`
-	Region      string  `json:"region,omitempty"`
-	StartURL    string  `json:"startUrl,omitempty"`
-}
-
-func (t token) Expired() bool {
-	return sdk.NowTime().Round(0).After(time.Time(t.ExpiresAt))
+	return t.AccessToken, nil
 }
 
 // InvalidTokenError is the error type that is returned if loaded token has expired or is otherwise invalid.
@@ -156,29 +168,3 @@ func (i *InvalidTokenError) Error() string {
 	}
 	return msg + ": " + i.Err.Error()
 }
-
-func loadTokenFile(startURL string) (t token, err error) {
-	key, err := getCacheFileName(startURL)
-	if err != nil {
-		return token{}, &InvalidTokenError{Err: err}
-	}
-
-	fileBytes, err := ioutil.ReadFile(filepath.Join(defaultCacheLocation(), key))
-	if err != nil {
-		return token{}, &InvalidTokenError{Err: err}
-	}
-
-	if err := json.Unmarshal(fileBytes, &t); err != nil {
-		return token{}, &InvalidTokenError{Err: err}
-	}
-
-	if len(t.AccessToken) == 0 {
-		return token{}, &InvalidTokenError{}
-	}
-
-	if t.Expired() {
-		return token{}, &InvalidTokenError{Err: fmt.Errorf("access token is expired")}
-	}
-
-	return t, nil
-}
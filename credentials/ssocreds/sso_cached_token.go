@@ -0,0 +1,170 @@
+package ssocreds
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/internal/sdk"
+)
+
+func getCacheFileName(url string) (string, error) {
+	hash := sha1.New()
+	_, err := hash.Write([]byte(url))
+	if err != nil {
+		return "", err
+	}
+	return strings.ToLower(hex.EncodeToString(hash.Sum(nil))) + ".json", nil
+}
+
+type rfc3339 time.Time
+
+func (r *rfc3339) UnmarshalJSON(bytes []byte) error {
+	var value string
+
+	if err := json.Unmarshal(bytes, &value); err != nil {
+		return err
+	}
+
+	parse, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return fmt.Errorf("expected RFC3339 timestamp: %w", err)
+	}
+
+	*r = rfc3339(parse)
+
+	return nil
+}
+
+func (r rfc3339) MarshalJSON() ([]byte, error) {
+	value := time.Time(r).UTC().Format(time.RFC3339)
+
+	return json.Marshal(value)
+}
+
+// token is the AWS SSO access token stored in the SSO token cache. The file
+// format is compatible with the token cache of the AWS CLI.
+type token struct {
+	AccessToken string  `json:"accessToken"`
+	ExpiresAt   rfc3339 `json:"expiresAt"`
+	Region      string  `json:"region,omitempty"`
+	StartURL    string  `json:"startUrl,omitempty"`
+
+	// The refresh token, and the registered OIDC client the access token
+	// was created for. Only present if the token can be refreshed. The
+	// registration's expiry is not present if the client secret does not
+	// expire.
+	RefreshToken          string   `json:"refreshToken,omitempty"`
+	ClientID              string   `json:"clientId,omitempty"`
+	ClientSecret          string   `json:"clientSecret,omitempty"`
+	RegistrationExpiresAt *rfc3339 `json:"registrationExpiresAt,omitempty"`
+}
+
+func (t token) Expired() bool {
+	return sdk.NowTime().Round(0).After(time.Time(t.ExpiresAt))
+}
+
+// expiresWithin returns if the access token will expire within the duration.
+func (t token) expiresWithin(d time.Duration) bool {
+	return sdk.NowTime().Round(0).Add(d).After(time.Time(t.ExpiresAt))
+}
+
+// hasValidRegistration returns if the token includes an OIDC client
+// registration that has not expired. A registration without an expiry does not
+// expire.
+func (t token) hasValidRegistration() bool {
+	if len(t.ClientID) == 0 || len(t.ClientSecret) == 0 {
+		return false
+	}
+	if t.RegistrationExpiresAt == nil {
+		return true
+	}
+	return sdk.NowTime().Round(0).Before(time.Time(*t.RegistrationExpiresAt))
+}
+
+// canRefresh returns if the access token can be refreshed with the token's
+// refresh token and client registration.
+func (t token) canRefresh() bool {
+	return len(t.RefreshToken) != 0 && t.hasValidRegistration()
+}
+
+func getCacheFilePath(startURL string) (string, error) {
+	key, err := getCacheFileName(startURL)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(defaultCacheLocation(), key), nil
+}
+
+// loadCachedToken loads the cached token for the start URL. The token is
+// not checked for expiration.
+func loadCachedToken(startURL string) (t token, err error) {
+	filename, err := getCacheFilePath(startURL)
+	if err != nil {
+		return token{}, &InvalidTokenError{Err: err}
+	}
+
+	fileBytes, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return token{}, &InvalidTokenError{Err: err}
+	}
+
+	if err := json.Unmarshal(fileBytes, &t); err != nil {
+		return token{}, &InvalidTokenError{Err: err}
+	}
+
+	if len(t.AccessToken) == 0 {
+		return token{}, &InvalidTokenError{}
+	}
+
+	return t, nil
+}
+
+// storeCachedToken writes the token to the cache file of the start URL. The
+// file is replaced atomically, and only readable by the current user.
+func storeCachedToken(startURL string, t token) (err error) {
+	filename, err := getCacheFilePath(startURL)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(filename)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create SSO token cache directory, %w", err)
+	}
+
+	fileBytes, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode SSO token, %w", err)
+	}
+
+	tmpFile, err := ioutil.TempFile(dir, filepath.Base(filename)+".tmp-")
+	if err != nil {
+		return fmt.Errorf("failed to create SSO token cache file, %w", err)
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(tmpFile.Name())
+		}
+	}()
+
+	if _, err = tmpFile.Write(fileBytes); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("failed to write SSO token cache file, %w", err)
+	}
+	if err = tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to write SSO token cache file, %w", err)
+	}
+
+	if err = os.Rename(tmpFile.Name(), filename); err != nil {
+		return fmt.Errorf("failed to replace SSO token cache file, %w", err)
+	}
+
+	return nil
+}
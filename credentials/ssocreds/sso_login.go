@@ -0,0 +1,244 @@
+package ssocreds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/internal/sdk"
+	"github.com/aws/aws-sdk-go-v2/service/ssooidc"
+	"github.com/aws/aws-sdk-go-v2/service/ssooidc/types"
+)
+
+// DefaultLoginClientName is the name of the client registered with AWS SSO
+// OIDC when performing a login, if another name is not provided.
+const DefaultLoginClientName = "aws-sdk-go-v2"
+
+const (
+	deviceCodeGrantType   = "urn:ietf:params:oauth:grant-type:device_code"
+	refreshTokenGrantType = "refresh_token"
+	publicClientType      = "public"
+
+	// defaultPollInterval is the interval to poll for the access token if
+	// the device authorization does not specify one.
+	defaultPollInterval = 5 * time.Second
+
+	// slowDownIntervalIncrease is the duration the poll interval is
+	// increased by each time the service requests the client slow down.
+	slowDownIntervalIncrease = 5 * time.Second
+)
+
+// OIDCAPIClient is a API client that implements the AWS SSO OIDC operations
+// used to login, and refresh access tokens.
+type OIDCAPIClient interface {
+	RegisterClient(ctx context.Context, params *ssooidc.RegisterClientInput, optFns ...func(*ssooidc.Options)) (*ssooidc.RegisterClientOutput, error)
+	StartDeviceAuthorization(ctx context.Context, params *ssooidc.StartDeviceAuthorizationInput, optFns ...func(*ssooidc.Options)) (*ssooidc.StartDeviceAuthorizationOutput, error)
+	CreateToken(ctx context.Context, params *ssooidc.CreateTokenInput, optFns ...func(*ssooidc.Options)) (*ssooidc.CreateTokenOutput, error)
+}
+
+// DeviceAuthorization is the verification the user must complete in a browser
+// to authorize a login.
+type DeviceAuthorization struct {
+	// The code the user must enter at the verification URI.
+	UserCode string
+
+	// The URI the user must visit to authorize the login.
+	VerificationURI string
+
+	// The verification URI including the user code, so that the user does not
+	// need to enter it.
+	VerificationURIComplete string
+
+	// The time the device authorization expires, and the login fails if the
+	// user has not completed the verification.
+	ExpiresAt time.Time
+}
+
+// LoginOptions is the Login options structure.
+type LoginOptions struct {
+	// The AWS SSO OIDC client which is configured for the AWS Region where the
+	// AWS SSO user portal is located.
+	Client OIDCAPIClient
+
+	// The URL that points to the organization's AWS Single Sign-On (AWS SSO)
+	// user portal.
+	StartURL string
+
+	// PromptUser is called with the device authorization the user must
+	// complete in a browser for the login to succeed. For example by printing
+	// the verification URI and user code, or opening the URI in a browser.
+	//
+	// This member is required.
+	PromptUser func(context.Context, DeviceAuthorization) error
+
+	// The AWS Region of the AWS SSO user portal, recorded in the token cache.
+	Region string
+
+	// The name of the client registered with AWS SSO OIDC. Defaults to
+	// DefaultLoginClientName.
+	ClientName string
+
+	// The scopes the registered client is restricted to.
+	Scopes []string
+}
+
+// Login performs the AWS SSO OIDC device authorization login flow for the
+// AWS SSO user portal, writing the access token to the SSO token cache,
+// ~/.aws/sso/cache, where it will be used by the Provider.
+//
+// The login registers a client with AWS SSO OIDC, starts a device
+// authorization, and calls PromptUser with the verification the user must
+// complete in a browser. The access token is polled for until the user has
+// authorized the login, the device authorization expires, or the context is
+// canceled. The client registration is cached with the access token, and
+// reused by subsequent logins until it expires.
+func Login(ctx context.Context, client OIDCAPIClient, startURL string, optFns ...func(*LoginOptions)) error {
+	options := LoginOptions{
+		Client:     client,
+		StartURL:   startURL,
+		ClientName: DefaultLoginClientName,
+	}
+
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
+	_, err := login(ctx, options)
+	return err
+}
+
+func login(ctx context.Context, options LoginOptions) (token, error) {
+	if options.Client == nil {
+		return token{}, fmt.Errorf("SSO login requires an AWS SSO OIDC client")
+	}
+	if options.PromptUser == nil {
+		return token{}, fmt.Errorf("SSO login requires a PromptUser function")
+	}
+
+	t := token{
+		StartURL: options.StartURL,
+		Region:   options.Region,
+	}
+
+	// Reuse the client registration of the previously cached token if it has
+	// not expired.
+	if cached, err := loadCachedToken(options.StartURL); err == nil && cached.hasValidRegistration() {
+		t.ClientID = cached.ClientID
+		t.ClientSecret = cached.ClientSecret
+		t.RegistrationExpiresAt = cached.RegistrationExpiresAt
+		if len(t.Region) == 0 {
+			t.Region = cached.Region
+		}
+	} else {
+		registration, err := options.Client.RegisterClient(ctx, &ssooidc.RegisterClientInput{
+			ClientName: aws.String(options.ClientName),
+			ClientType: aws.String(publicClientType),
+			Scopes:     options.Scopes,
+		})
+		if err != nil {
+			return token{}, fmt.Errorf("failed to register SSO OIDC client, %w", err)
+		}
+
+		t.ClientID = aws.ToString(registration.ClientId)
+		t.ClientSecret = aws.ToString(registration.ClientSecret)
+		// A zero ClientSecretExpiresAt is a client secret without an expiry.
+		if registration.ClientSecretExpiresAt != 0 {
+			expiresAt := rfc3339(time.Unix(registration.ClientSecretExpiresAt, 0).UTC())
+			t.RegistrationExpiresAt = &expiresAt
+		}
+	}
+
+	authorization, err := options.Client.StartDeviceAuthorization(ctx, &ssooidc.StartDeviceAuthorizationInput{
+		ClientId:     aws.String(t.ClientID),
+		ClientSecret: aws.String(t.ClientSecret),
+		StartUrl:     aws.String(options.StartURL),
+	})
+	if err != nil {
+		return token{}, fmt.Errorf("failed to start SSO device authorization, %w", err)
+	}
+
+	deadline := sdk.NowTime().Add(time.Duration(authorization.ExpiresIn) * time.Second)
+	err = options.PromptUser(ctx, DeviceAuthorization{
+		UserCode:                aws.ToString(authorization.UserCode),
+		VerificationURI:         aws.ToString(authorization.VerificationUri),
+		VerificationURIComplete: aws.ToString(authorization.VerificationUriComplete),
+		ExpiresAt:               deadline,
+	})
+	if err != nil {
+		return token{}, fmt.Errorf("failed to prompt user for SSO login, %w", err)
+	}
+
+	interval := time.Duration(authorization.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	var output *ssooidc.CreateTokenOutput
+	for {
+		if err := sdk.SleepWithContext(ctx, interval); err != nil {
+			return token{}, &aws.RequestCanceledError{Err: err}
+		}
+
+		output, err = options.Client.CreateToken(ctx, &ssooidc.CreateTokenInput{
+			ClientId:     aws.String(t.ClientID),
+			ClientSecret: aws.String(t.ClientSecret),
+			GrantType:    aws.String(deviceCodeGrantType),
+			DeviceCode:   authorization.DeviceCode,
+		})
+		if err == nil {
+			break
+		}
+
+		var pendingErr *types.AuthorizationPendingException
+		var slowDownErr *types.SlowDownException
+		switch {
+		case errors.As(err, &pendingErr):
+		case errors.As(err, &slowDownErr):
+			interval += slowDownIntervalIncrease
+		default:
+			return token{}, fmt.Errorf("failed to create SSO access token, %w", err)
+		}
+
+		if !sdk.NowTime().Before(deadline) {
+			return token{}, fmt.Errorf("SSO device authorization expired before the login was authorized")
+		}
+	}
+
+	updateTokenFromOutput(&t, output)
+	if err := storeCachedToken(options.StartURL, t); err != nil {
+		return token{}, err
+	}
+
+	return t, nil
+}
+
+// refreshToken refreshes the access token of the cached token with the
+// token's refresh token, writing the refreshed token to the token cache.
+func refreshToken(ctx context.Context, client OIDCAPIClient, startURL string, t token) (token, error) {
+	output, err := client.CreateToken(ctx, &ssooidc.CreateTokenInput{
+		ClientId:     aws.String(t.ClientID),
+		ClientSecret: aws.String(t.ClientSecret),
+		GrantType:    aws.String(refreshTokenGrantType),
+		RefreshToken: aws.String(t.RefreshToken),
+	})
+	if err != nil {
+		return token{}, fmt.Errorf("failed to refresh SSO access token, %w", err)
+	}
+
+	updateTokenFromOutput(&t, output)
+	if err := storeCachedToken(startURL, t); err != nil {
+		return token{}, err
+	}
+
+	return t, nil
+}
+
+func updateTokenFromOutput(t *token, output *ssooidc.CreateTokenOutput) {
+	t.AccessToken = aws.ToString(output.AccessToken)
+	t.ExpiresAt = rfc3339(sdk.NowTime().Round(0).Add(time.Duration(output.ExpiresIn) * time.Second).UTC())
+	if output.RefreshToken != nil {
+		t.RefreshToken = aws.ToString(output.RefreshToken)
+	}
+}
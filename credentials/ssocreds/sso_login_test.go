@@ -0,0 +1,570 @@
+package ssocreds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/internal/sdk"
+	"github.com/aws/aws-sdk-go-v2/service/sso"
+	ssotypes "github.com/aws/aws-sdk-go-v2/service/sso/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssooidc"
+	"github.com/aws/aws-sdk-go-v2/service/ssooidc/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+type mockOIDCClient struct {
+	registerClient           func(*ssooidc.RegisterClientInput) (*ssooidc.RegisterClientOutput, error)
+	startDeviceAuthorization func(*ssooidc.StartDeviceAuthorizationInput) (*ssooidc.StartDeviceAuthorizationOutput, error)
+	createToken              func(*ssooidc.CreateTokenInput) (*ssooidc.CreateTokenOutput, error)
+}
+
+func (m *mockOIDCClient) RegisterClient(ctx context.Context, params *ssooidc.RegisterClientInput, optFns ...func(*ssooidc.Options)) (*ssooidc.RegisterClientOutput, error) {
+	if m.registerClient == nil {
+		return nil, fmt.Errorf("unexpected RegisterClient call")
+	}
+	return m.registerClient(params)
+}
+
+func (m *mockOIDCClient) StartDeviceAuthorization(ctx context.Context, params *ssooidc.StartDeviceAuthorizationInput, optFns ...func(*ssooidc.Options)) (*ssooidc.StartDeviceAuthorizationOutput, error) {
+	if m.startDeviceAuthorization == nil {
+		return nil, fmt.Errorf("unexpected StartDeviceAuthorization call")
+	}
+	return m.startDeviceAuthorization(params)
+}
+
+func (m *mockOIDCClient) CreateToken(ctx context.Context, params *ssooidc.CreateTokenInput, optFns ...func(*ssooidc.Options)) (*ssooidc.CreateTokenOutput, error) {
+	if m.createToken == nil {
+		return nil, fmt.Errorf("unexpected CreateToken call")
+	}
+	return m.createToken(params)
+}
+
+const testStartURL = "https://example.awsapps.com/start"
+
+func newTempCacheLocation(t *testing.T) (string, func()) {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "ssocreds")
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	restoreCache := swapCacheLocation(dir)
+
+	return dir, func() {
+		restoreCache()
+		os.RemoveAll(dir)
+	}
+}
+
+func readCachedTokenFile(t *testing.T, dir string) map[string]interface{} {
+	t.Helper()
+
+	fileName, err := getCacheFileName(testStartURL)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(dir, fileName))
+	if err != nil {
+		t.Fatalf("expect cached token file, got %v", err)
+	}
+
+	var v map[string]interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		t.Fatalf("expect valid JSON cached token, got %v", err)
+	}
+	return v
+}
+
+func writeCachedTokenFile(t *testing.T, v token) {
+	t.Helper()
+
+	if err := storeCachedToken(testStartURL, v); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+}
+
+func TestLogin(t *testing.T) {
+	restoreTime := sdk.TestingUseReferenceTime(time.Date(2021, 01, 19, 19, 50, 0, 0, time.UTC))
+	defer restoreTime()
+	restoreSleep := sdk.TestingUseNopSleep()
+	defer restoreSleep()
+
+	cases := map[string]struct {
+		CachedToken      *token
+		CreateTokenErrs  []error
+		ExpectRegister   bool
+		ExpectCreateCall int
+		ExpectErr        string
+		ExpectCache      map[string]interface{}
+	}{
+		"authorization pending": {
+			CreateTokenErrs: []error{
+				&types.AuthorizationPendingException{},
+				&types.SlowDownException{},
+			},
+			ExpectRegister:   true,
+			ExpectCreateCall: 3,
+			ExpectCache: map[string]interface{}{
+				"accessToken":           "access token",
+				"expiresAt":             "2021-01-19T20:50:00Z",
+				"region":                "us-west-2",
+				"startUrl":              testStartURL,
+				"refreshToken":          "refresh token",
+				"clientId":              "client id",
+				"clientSecret":          "client secret",
+				"registrationExpiresAt": "2021-04-19T19:50:00Z",
+			},
+		},
+		"reuse registration": {
+			CachedToken: &token{
+				AccessToken:           "expired token",
+				ExpiresAt:             rfc3339(time.Date(2021, 01, 19, 19, 00, 0, 0, time.UTC)),
+				Region:                "us-west-2",
+				StartURL:              testStartURL,
+				ClientID:              "cached client id",
+				ClientSecret:          "cached client secret",
+				RegistrationExpiresAt: rfc3339Ptr(time.Date(2021, 02, 19, 19, 50, 0, 0, time.UTC)),
+			},
+			ExpectCreateCall: 1,
+			ExpectCache: map[string]interface{}{
+				"accessToken":           "access token",
+				"expiresAt":             "2021-01-19T20:50:00Z",
+				"region":                "us-west-2",
+				"startUrl":              testStartURL,
+				"refreshToken":          "refresh token",
+				"clientId":              "cached client id",
+				"clientSecret":          "cached client secret",
+				"registrationExpiresAt": "2021-02-19T19:50:00Z",
+			},
+		},
+		"expired registration": {
+			CachedToken: &token{
+				AccessToken:           "expired token",
+				ExpiresAt:             rfc3339(time.Date(2021, 01, 19, 19, 00, 0, 0, time.UTC)),
+				StartURL:              testStartURL,
+				ClientID:              "cached client id",
+				ClientSecret:          "cached client secret",
+				RegistrationExpiresAt: rfc3339Ptr(time.Date(2021, 01, 19, 19, 00, 0, 0, time.UTC)),
+			},
+			ExpectRegister:   true,
+			ExpectCreateCall: 1,
+			ExpectCache: map[string]interface{}{
+				"accessToken":           "access token",
+				"expiresAt":             "2021-01-19T20:50:00Z",
+				"region":                "us-west-2",
+				"startUrl":              testStartURL,
+				"refreshToken":          "refresh token",
+				"clientId":              "client id",
+				"clientSecret":          "client secret",
+				"registrationExpiresAt": "2021-04-19T19:50:00Z",
+			},
+		},
+		"access denied": {
+			CreateTokenErrs: []error{
+				&types.AuthorizationPendingException{},
+				&types.AccessDeniedException{},
+			},
+			ExpectRegister:   true,
+			ExpectCreateCall: 2,
+			ExpectErr:        "failed to create SSO access token",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			dir, cleanup := newTempCacheLocation(t)
+			defer cleanup()
+
+			if tt.CachedToken != nil {
+				writeCachedTokenFile(t, *tt.CachedToken)
+			}
+
+			var registered bool
+			var createCalls int
+			var prompted DeviceAuthorization
+			client := &mockOIDCClient{
+				registerClient: func(params *ssooidc.RegisterClientInput) (*ssooidc.RegisterClientOutput, error) {
+					registered = true
+					if e, a := DefaultLoginClientName, aws.ToString(params.ClientName); e != a {
+						t.Errorf("expect %v client name, got %v", e, a)
+					}
+					return &ssooidc.RegisterClientOutput{
+						ClientId:              aws.String("client id"),
+						ClientSecret:          aws.String("client secret"),
+						ClientSecretExpiresAt: time.Date(2021, 04, 19, 19, 50, 0, 0, time.UTC).Unix(),
+					}, nil
+				},
+				startDeviceAuthorization: func(params *ssooidc.StartDeviceAuthorizationInput) (*ssooidc.StartDeviceAuthorizationOutput, error) {
+					if e, a := testStartURL, aws.ToString(params.StartUrl); e != a {
+						t.Errorf("expect %v start URL, got %v", e, a)
+					}
+					return &ssooidc.StartDeviceAuthorizationOutput{
+						DeviceCode:              aws.String("device code"),
+						UserCode:                aws.String("user code"),
+						VerificationUri:         aws.String("https://device.sso.us-west-2.amazonaws.com/"),
+						VerificationUriComplete: aws.String("https://device.sso.us-west-2.amazonaws.com/?user_code=user+code"),
+						ExpiresIn:               600,
+						Interval:                1,
+					}, nil
+				},
+				createToken: func(params *ssooidc.CreateTokenInput) (*ssooidc.CreateTokenOutput, error) {
+					createCalls++
+					if e, a := deviceCodeGrantType, aws.ToString(params.GrantType); e != a {
+						t.Errorf("expect %v grant type, got %v", e, a)
+					}
+					if e, a := "device code", aws.ToString(params.DeviceCode); e != a {
+						t.Errorf("expect %v device code, got %v", e, a)
+					}
+					if createCalls <= len(tt.CreateTokenErrs) {
+						return nil, tt.CreateTokenErrs[createCalls-1]
+					}
+					return &ssooidc.CreateTokenOutput{
+						AccessToken:  aws.String("access token"),
+						ExpiresIn:    3600,
+						RefreshToken: aws.String("refresh token"),
+					}, nil
+				},
+			}
+
+			err := Login(context.Background(), client, testStartURL, func(o *LoginOptions) {
+				o.Region = "us-west-2"
+				o.PromptUser = func(ctx context.Context, auth DeviceAuthorization) error {
+					prompted = auth
+					return nil
+				}
+			})
+			if len(tt.ExpectErr) != 0 {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				if e, a := tt.ExpectErr, err.Error(); !strings.Contains(a, e) {
+					t.Errorf("expect error to contain %v, got %v", e, a)
+				}
+			} else if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			if e, a := tt.ExpectRegister, registered; e != a {
+				t.Errorf("expect register %v, got %v", e, a)
+			}
+			if e, a := tt.ExpectCreateCall, createCalls; e != a {
+				t.Errorf("expect %v CreateToken calls, got %v", e, a)
+			}
+			if e, a := "user code", prompted.UserCode; e != a {
+				t.Errorf("expect %v user code, got %v", e, a)
+			}
+
+			if tt.ExpectCache == nil {
+				return
+			}
+			if diff := cmp.Diff(tt.ExpectCache, readCachedTokenFile(t, dir)); len(diff) != 0 {
+				t.Errorf("expect cached token match\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLogin_DeviceAuthorizationExpired(t *testing.T) {
+	restoreSleep := sdk.TestingUseNopSleep()
+	defer restoreSleep()
+
+	_, cleanup := newTempCacheLocation(t)
+	defer cleanup()
+
+	now := time.Date(2021, 01, 19, 19, 50, 0, 0, time.UTC)
+	defer func() { sdk.NowTime = time.Now }()
+	sdk.NowTime = func() time.Time { return now }
+
+	client := &mockOIDCClient{
+		registerClient: func(*ssooidc.RegisterClientInput) (*ssooidc.RegisterClientOutput, error) {
+			return &ssooidc.RegisterClientOutput{
+				ClientId:              aws.String("client id"),
+				ClientSecret:          aws.String("client secret"),
+				ClientSecretExpiresAt: now.Add(24 * time.Hour).Unix(),
+			}, nil
+		},
+		startDeviceAuthorization: func(*ssooidc.StartDeviceAuthorizationInput) (*ssooidc.StartDeviceAuthorizationOutput, error) {
+			return &ssooidc.StartDeviceAuthorizationOutput{
+				DeviceCode: aws.String("device code"),
+				ExpiresIn:  10,
+			}, nil
+		},
+		createToken: func(*ssooidc.CreateTokenInput) (*ssooidc.CreateTokenOutput, error) {
+			now = now.Add(defaultPollInterval)
+			return nil, &types.AuthorizationPendingException{}
+		},
+	}
+
+	err := Login(context.Background(), client, testStartURL, func(o *LoginOptions) {
+		o.PromptUser = func(context.Context, DeviceAuthorization) error { return nil }
+	})
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+	if e, a := "device authorization expired", err.Error(); !strings.Contains(a, e) {
+		t.Errorf("expect error to contain %v, got %v", e, a)
+	}
+}
+
+func TestLogin_RegistrationWithoutExpiry(t *testing.T) {
+	restoreTime := sdk.TestingUseReferenceTime(time.Date(2021, 01, 19, 19, 50, 0, 0, time.UTC))
+	defer restoreTime()
+	restoreSleep := sdk.TestingUseNopSleep()
+	defer restoreSleep()
+
+	dir, cleanup := newTempCacheLocation(t)
+	defer cleanup()
+
+	var registerCalls int
+	client := &mockOIDCClient{
+		registerClient: func(*ssooidc.RegisterClientInput) (*ssooidc.RegisterClientOutput, error) {
+			registerCalls++
+			return &ssooidc.RegisterClientOutput{
+				ClientId:     aws.String("client id"),
+				ClientSecret: aws.String("client secret"),
+			}, nil
+		},
+		startDeviceAuthorization: func(*ssooidc.StartDeviceAuthorizationInput) (*ssooidc.StartDeviceAuthorizationOutput, error) {
+			return &ssooidc.StartDeviceAuthorizationOutput{
+				DeviceCode: aws.String("device code"),
+				ExpiresIn:  600,
+			}, nil
+		},
+		createToken: func(*ssooidc.CreateTokenInput) (*ssooidc.CreateTokenOutput, error) {
+			return &ssooidc.CreateTokenOutput{
+				AccessToken:  aws.String("access token"),
+				ExpiresIn:    3600,
+				RefreshToken: aws.String("refresh token"),
+			}, nil
+		},
+	}
+
+	for i := 0; i < 2; i++ {
+		err := Login(context.Background(), client, testStartURL, func(o *LoginOptions) {
+			o.Region = "us-west-2"
+			o.PromptUser = func(context.Context, DeviceAuthorization) error { return nil }
+		})
+		if err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+	}
+
+	if e, a := 1, registerCalls; e != a {
+		t.Errorf("expect %v RegisterClient calls, got %v", e, a)
+	}
+
+	expectCache := map[string]interface{}{
+		"accessToken":  "access token",
+		"expiresAt":    "2021-01-19T20:50:00Z",
+		"region":       "us-west-2",
+		"startUrl":     testStartURL,
+		"refreshToken": "refresh token",
+		"clientId":     "client id",
+		"clientSecret": "client secret",
+	}
+	if diff := cmp.Diff(expectCache, readCachedTokenFile(t, dir)); len(diff) != 0 {
+		t.Errorf("expect cached token match\n%s", diff)
+	}
+}
+
+func TestProvider_RefreshToken(t *testing.T) {
+	restoreTime := sdk.TestingUseReferenceTime(time.Date(2021, 01, 19, 19, 50, 0, 0, time.UTC))
+	defer restoreTime()
+
+	cases := map[string]struct {
+		ExpiresAt        time.Time
+		RefreshErr       error
+		ExpectRefresh    bool
+		ExpectToken      string
+		ExpectErr        bool
+		ExpectCachedFile string
+	}{
+		"not within refresh window": {
+			ExpiresAt:   time.Date(2021, 01, 19, 20, 50, 0, 0, time.UTC),
+			ExpectToken: "cached token",
+		},
+		"within refresh window": {
+			ExpiresAt:        time.Date(2021, 01, 19, 19, 52, 0, 0, time.UTC),
+			ExpectRefresh:    true,
+			ExpectToken:      "refreshed token",
+			ExpectCachedFile: "refreshed token",
+		},
+		"expired": {
+			ExpiresAt:        time.Date(2021, 01, 19, 19, 00, 0, 0, time.UTC),
+			ExpectRefresh:    true,
+			ExpectToken:      "refreshed token",
+			ExpectCachedFile: "refreshed token",
+		},
+		"refresh failed within window": {
+			ExpiresAt:        time.Date(2021, 01, 19, 19, 52, 0, 0, time.UTC),
+			RefreshErr:       &types.InvalidGrantException{},
+			ExpectRefresh:    true,
+			ExpectToken:      "cached token",
+			ExpectCachedFile: "cached token",
+		},
+		"refresh failed expired": {
+			ExpiresAt:     time.Date(2021, 01, 19, 19, 00, 0, 0, time.UTC),
+			RefreshErr:    &types.InvalidGrantException{},
+			ExpectRefresh: true,
+			ExpectErr:     true,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			dir, cleanup := newTempCacheLocation(t)
+			defer cleanup()
+
+			writeCachedTokenFile(t, token{
+				AccessToken:           "cached token",
+				ExpiresAt:             rfc3339(tt.ExpiresAt),
+				Region:                "us-west-2",
+				StartURL:              testStartURL,
+				RefreshToken:          "refresh token",
+				ClientID:              "client id",
+				ClientSecret:          "client secret",
+				RegistrationExpiresAt: rfc3339Ptr(time.Date(2021, 04, 19, 19, 50, 0, 0, time.UTC)),
+			})
+
+			var refreshed bool
+			oidcClient := &mockOIDCClient{
+				createToken: func(params *ssooidc.CreateTokenInput) (*ssooidc.CreateTokenOutput, error) {
+					refreshed = true
+					if e, a := refreshTokenGrantType, aws.ToString(params.GrantType); e != a {
+						t.Errorf("expect %v grant type, got %v", e, a)
+					}
+					if e, a := "refresh token", aws.ToString(params.RefreshToken); e != a {
+						t.Errorf("expect %v refresh token, got %v", e, a)
+					}
+					if tt.RefreshErr != nil {
+						return nil, tt.RefreshErr
+					}
+					return &ssooidc.CreateTokenOutput{
+						AccessToken: aws.String("refreshed token"),
+						ExpiresIn:   3600,
+					}, nil
+				},
+			}
+
+			provider := New(mockClient{
+				t:                   t,
+				ExpectedAccessToken: tt.ExpectToken,
+				Response: func(mockClient) (*sso.GetRoleCredentialsOutput, error) {
+					return &sso.GetRoleCredentialsOutput{
+						RoleCredentials: &ssotypes.RoleCredentials{
+							AccessKeyId:     aws.String("AccessKey"),
+							SecretAccessKey: aws.String("SecretKey"),
+							SessionToken:    aws.String("SessionToken"),
+							Expiration:      1611177743123,
+						},
+					}, nil
+				},
+			}, "012345678901", "TestRole", testStartURL, func(o *Options) {
+				o.OIDCClient = oidcClient
+			})
+
+			_, err := provider.Retrieve(context.Background())
+			if tt.ExpectErr {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+			} else if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			if e, a := tt.ExpectRefresh, refreshed; e != a {
+				t.Errorf("expect refresh %v, got %v", e, a)
+			}
+
+			if len(tt.ExpectCachedFile) == 0 {
+				return
+			}
+			cached := readCachedTokenFile(t, dir)
+			if e, a := tt.ExpectCachedFile, cached["accessToken"]; e != a {
+				t.Errorf("expect %v cached access token, got %v", e, a)
+			}
+			if e, a := "refresh token", cached["refreshToken"]; e != a {
+				t.Errorf("expect %v cached refresh token, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestProvider_Login(t *testing.T) {
+	restoreTime := sdk.TestingUseReferenceTime(time.Date(2021, 01, 19, 19, 50, 0, 0, time.UTC))
+	defer restoreTime()
+	restoreSleep := sdk.TestingUseNopSleep()
+	defer restoreSleep()
+
+	_, cleanup := newTempCacheLocation(t)
+	defer cleanup()
+
+	oidcClient := &mockOIDCClient{
+		registerClient: func(*ssooidc.RegisterClientInput) (*ssooidc.RegisterClientOutput, error) {
+			return &ssooidc.RegisterClientOutput{
+				ClientId:              aws.String("client id"),
+				ClientSecret:          aws.String("client secret"),
+				ClientSecretExpiresAt: time.Date(2021, 04, 19, 19, 50, 0, 0, time.UTC).Unix(),
+			}, nil
+		},
+		startDeviceAuthorization: func(*ssooidc.StartDeviceAuthorizationInput) (*ssooidc.StartDeviceAuthorizationOutput, error) {
+			return &ssooidc.StartDeviceAuthorizationOutput{
+				DeviceCode: aws.String("device code"),
+				UserCode:   aws.String("user code"),
+				ExpiresIn:  600,
+			}, nil
+		},
+		createToken: func(*ssooidc.CreateTokenInput) (*ssooidc.CreateTokenOutput, error) {
+			return &ssooidc.CreateTokenOutput{
+				AccessToken: aws.String("login token"),
+				ExpiresIn:   3600,
+			}, nil
+		},
+	}
+
+	var prompted bool
+	provider := New(mockClient{
+		t:                   t,
+		ExpectedAccessToken: "login token",
+		Response: func(mockClient) (*sso.GetRoleCredentialsOutput, error) {
+			return &sso.GetRoleCredentialsOutput{
+				RoleCredentials: &ssotypes.RoleCredentials{
+					AccessKeyId:     aws.String("AccessKey"),
+					SecretAccessKey: aws.String("SecretKey"),
+					SessionToken:    aws.String("SessionToken"),
+					Expiration:      1611177743123,
+				},
+			}, nil
+		},
+	}, "012345678901", "TestRole", testStartURL, func(o *Options) {
+		o.OIDCClient = oidcClient
+		o.PromptUser = func(context.Context, DeviceAuthorization) error {
+			prompted = true
+			return nil
+		}
+	})
+
+	creds, err := provider.Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if !prompted {
+		t.Errorf("expect user to be prompted to login")
+	}
+	if e, a := "AccessKey", creds.AccessKeyID; e != a {
+		t.Errorf("expect %v access key, got %v", e, a)
+	}
+}
+
+func rfc3339Ptr(v time.Time) *rfc3339 {
+	t := rfc3339(v)
+	return &t
+}
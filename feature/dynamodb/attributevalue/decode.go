@@ -126,6 +126,16 @@ type DecoderOptions struct {
 	// Number type instead of float64 when the destination type
 	// is interface{}. Similar to encoding/json.Number
 	UseNumber bool
+
+	// Functions unmarshaling AttributeValues into values of Go types, keyed
+	// by the type, used instead of the Decoder's default unmarshaling of the
+	// type. Register a function with the WithUnmarshalFunc option.
+	UnmarshalFuncs map[reflect.Type]UnmarshalFunc
+
+	// Names the struct fields that do not have a name set by their struct
+	// tag, if set. Otherwise the field's Go name is used. Must be the same
+	// FieldNamer the AttributeValues were marshaled with.
+	FieldNamer FieldNamer
 }
 
 // A Decoder provides unmarshaling AttributeValues to Go value types.
@@ -169,17 +179,20 @@ func (d *Decoder) decode(av types.AttributeValue, v reflect.Value, fieldTag tag)
 	var u Unmarshaler
 	_, isNull := av.(*types.AttributeValueMemberNULL)
 	if av == nil || isNull {
-		u, v = indirect(v, true)
+		u, v = indirect(v, true, nil)
 		if u != nil {
 			return u.UnmarshalDynamoDBAttributeValue(av)
 		}
 		return d.decodeNull(v)
 	}
 
-	u, v = indirect(v, false)
+	u, v = indirect(v, false, d.options.UnmarshalFuncs)
 	if u != nil {
 		return u.UnmarshalDynamoDBAttributeValue(av)
 	}
+	if fn, ok := d.options.UnmarshalFuncs[v.Type()]; ok && v.CanAddr() {
+		return fn(av, v.Addr().Interface())
+	}
 
 	switch tv := av.(type) {
 	case *types.AttributeValueMemberB:
@@ -301,7 +314,7 @@ func (d *Decoder) decodeBinarySet(bs [][]byte, v reflect.Value) error {
 		if !isArray {
 			v.SetLen(i + 1)
 		}
-		u, elem := indirect(v.Index(i), false)
+		u, elem := indirect(v.Index(i), false, nil)
 		if u != nil {
 			return u.UnmarshalDynamoDBAttributeValue(&types.AttributeValueMemberBS{Value: bs})
 		}
@@ -428,7 +441,7 @@ func (d *Decoder) decodeNumberSet(ns []string, v reflect.Value) error {
 		if !isArray {
 			v.SetLen(i + 1)
 		}
-		u, elem := indirect(v.Index(i), false)
+		u, elem := indirect(v.Index(i), false, nil)
 		if u != nil {
 			return u.UnmarshalDynamoDBAttributeValue(&types.AttributeValueMemberNS{Value: ns})
 		}
@@ -509,7 +522,8 @@ func (d *Decoder) decodeMap(avMap map[string]types.AttributeValue, v reflect.Val
 		}
 	} else if v.Kind() == reflect.Struct {
 		fields := unionStructFields(v.Type(), structFieldOptions{
-			TagKey: d.options.TagKey,
+			TagKey:     d.options.TagKey,
+			FieldNamer: d.options.FieldNamer,
 		})
 		for k, av := range avMap {
 			if f, ok := fields.FieldByName(k); ok {
@@ -590,7 +604,7 @@ func (d *Decoder) decodeStringSet(ss []string, v reflect.Value) error {
 		if !isArray {
 			v.SetLen(i + 1)
 		}
-		u, elem := indirect(v.Index(i), false)
+		u, elem := indirect(v.Index(i), false, nil)
 		if u != nil {
 			return u.UnmarshalDynamoDBAttributeValue(&types.AttributeValueMemberSS{Value: ss})
 		}
@@ -629,11 +643,14 @@ func decoderFieldByIndex(v reflect.Value, index []int) reflect.Value {
 }
 
 // indirect will walk a value's interface or pointer value types. Returning
-// the final value or the value a unmarshaler is defined on.
+// the final value or the value a unmarshaler is defined on. An UnmarshalFunc
+// of funcs registered for the type a pointer points to is returned as the
+// unmarshaler, before the Unmarshaler the pointer may implement, so values are
+// unmarshaled with the same function the Encoder marshals them with.
 //
 // Based on the enoding/json type reflect value type indirection in Go Stdlib
 // https://golang.org/src/encoding/json/decode.go indirect func.
-func indirect(v reflect.Value, decodingNull bool) (Unmarshaler, reflect.Value) {
+func indirect(v reflect.Value, decodingNull bool, funcs map[reflect.Type]UnmarshalFunc) (Unmarshaler, reflect.Value) {
 	if v.Kind() != reflect.Ptr && v.Type().Name() != "" && v.CanAddr() {
 		v = v.Addr()
 	}
@@ -654,6 +671,9 @@ func indirect(v reflect.Value, decodingNull bool) (Unmarshaler, reflect.Value) {
 		if v.IsNil() {
 			v.Set(reflect.New(v.Type().Elem()))
 		}
+		if fn, ok := funcs[v.Type().Elem()]; ok {
+			return unmarshalFuncValue{fn: fn, out: v.Interface()}, reflect.Value{}
+		}
 		if v.Type().NumMethod() > 0 {
 			if u, ok := v.Interface().(Unmarshaler); ok {
 				return u, reflect.Value{}
@@ -665,6 +685,17 @@ func indirect(v reflect.Value, decodingNull bool) (Unmarshaler, reflect.Value) {
 	return nil, v
 }
 
+// unmarshalFuncValue is the Unmarshaler of a value unmarshaled with the
+// UnmarshalFunc registered for its type.
+type unmarshalFuncValue struct {
+	fn  UnmarshalFunc
+	out interface{}
+}
+
+func (u unmarshalFuncValue) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return u.fn(av, u.out)
+}
+
 // A Number represents a Attributevalue number literal.
 type Number string
 
@@ -94,4 +94,27 @@
 //
 // See the Marshal and Unmarshal function for information on how struct tags
 // and fields are marshaled and unmarshaled.
+//
+// Fields without a name set by their struct tag are named with their Go
+// field name, unless a FieldNamer is set with the EncoderOptions and
+// DecoderOptions, FieldNamer option, such as SnakeCaseFieldNamer or
+// CamelCaseFieldNamer.
+//
+// Custom Type Marshaling
+//
+// Types that do not implement the Marshaler and Unmarshaler interfaces, such
+// as types of other packages, can be marshaled and unmarshaled with functions
+// registered for the type with the WithMarshalFunc and WithUnmarshalFunc
+// options, without wrapping the type. The package provides functions for
+// types implementing encoding.TextMarshaler, such as UUIDs and decimals,
+// time.Duration, and time.Time layouts.
+//
+//     encoder := attributevalue.NewEncoder(
+//         attributevalue.WithMarshalFunc(time.Duration(0), attributevalue.MarshalDurationString),
+//         attributevalue.WithMarshalFunc(time.Time{}, attributevalue.TimeLayoutMarshalFunc("2006-01-02")),
+//     )
+//     decoder := attributevalue.NewDecoder(
+//         attributevalue.WithUnmarshalFunc(time.Duration(0), attributevalue.UnmarshalDurationString),
+//         attributevalue.WithUnmarshalFunc(time.Time{}, attributevalue.TimeLayoutUnmarshalFunc("2006-01-02")),
+//     )
 package attributevalue
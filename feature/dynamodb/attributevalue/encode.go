@@ -230,6 +230,15 @@ type EncoderOptions struct {
 	// Defaults to enabled, because AttributeValue sets cannot currently be
 	// empty lists.
 	NullEmptySets bool
+
+	// Functions marshaling values of Go types, keyed by the type, used
+	// instead of the Encoder's default marshaling of the type. Register a
+	// function with the WithMarshalFunc option.
+	MarshalFuncs map[reflect.Type]MarshalFunc
+
+	// Names the struct fields that do not have a name set by their struct
+	// tag, if set. Otherwise the field's Go name is used.
+	FieldNamer FieldNamer
 }
 
 // An Encoder provides marshaling Go value types to AttributeValues.
@@ -282,6 +291,9 @@ func (e *Encoder) encode(v reflect.Value, fieldTag tag) (types.AttributeValue, e
 	v = valueElem(v)
 
 	if v.Kind() != reflect.Invalid {
+		if fn, ok := e.options.MarshalFuncs[v.Type()]; ok && v.CanInterface() {
+			return fn(v.Interface())
+		}
 		if av, err := tryMarshaler(v); err != nil {
 			return nil, err
 		} else if av != nil {
@@ -330,7 +342,8 @@ func (e *Encoder) encodeStruct(v reflect.Value, fieldTag tag) (types.AttributeVa
 
 	m := &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{}}
 	fields := unionStructFields(v.Type(), structFieldOptions{
-		TagKey: e.options.TagKey,
+		TagKey:     e.options.TagKey,
+		FieldNamer: e.options.FieldNamer,
 	})
 	for _, f := range fields.All() {
 		if f.Name == "" {
@@ -15,7 +15,7 @@ type field struct {
 	Type  reflect.Type
 }
 
-func buildField(pIdx []int, i int, sf reflect.StructField, fieldTag tag) field {
+func buildField(pIdx []int, i int, sf reflect.StructField, fieldTag tag, namer FieldNamer) field {
 	f := field{
 		Name: sf.Name,
 		Type: sf.Type,
@@ -24,6 +24,8 @@ func buildField(pIdx []int, i int, sf reflect.StructField, fieldTag tag) field {
 	if len(fieldTag.Name) != 0 {
 		f.NameFromTag = true
 		f.Name = fieldTag.Name
+	} else if namer != nil {
+		f.Name = namer.FieldName(sf.Name)
 	}
 
 	f.Index = make([]int, len(pIdx)+1)
@@ -41,16 +43,31 @@ type structFieldOptions struct {
 	// Tag key `dynamodbav` will always be read, but if custom tag key
 	// conflicts with `dynamodbav` the custom tag key value will be used.
 	TagKey string
+
+	// Names the fields that do not have a name set by their struct tag, if
+	// set. The fields are cached for each FieldNamer, unless the FieldNamer
+	// is not comparable.
+	FieldNamer FieldNamer
 }
 
 // unionStructFields returns a list of fields for the given type. Type info is cached
 // to avoid repeated calls into the reflect package
 func unionStructFields(t reflect.Type, opts structFieldOptions) *cachedFields {
-	if cached, ok := fieldCache.Load(t); ok {
+	if opts.FieldNamer != nil && !reflect.TypeOf(opts.FieldNamer).Comparable() {
+		// FieldNamers such as funcs cannot be part of the cache key.
+		return newCachedFields(enumFields(t, opts))
+	}
+
+	key := fieldCacheKey{typ: t, tagKey: opts.TagKey, namer: opts.FieldNamer}
+	if cached, ok := fieldCache.Load(key); ok {
 		return cached
 	}
+	cached, _ := fieldCache.LoadOrStore(key, newCachedFields(enumFields(t, opts)))
+	return cached
+}
 
-	f := enumFields(t, opts)
+// newCachedFields returns the visible fields of the enumerated fields.
+func newCachedFields(f []field) *cachedFields {
 	sort.Sort(fieldsByName(f))
 	f = visibleFields(f)
 
@@ -61,9 +78,7 @@ func unionStructFields(t reflect.Type, opts structFieldOptions) *cachedFields {
 	for i, f := range fs.fields {
 		fs.fieldsByName[f.Name] = i
 	}
-
-	cached, _ := fieldCache.LoadOrStore(t, fs)
-	return cached
+	return fs
 }
 
 // enumFields will recursively iterate through a structure and its nested
@@ -118,7 +133,7 @@ func enumFields(t reflect.Type, opts structFieldOptions) []field {
 					ft = ft.Elem()
 				}
 
-				structField := buildField(f.Index, i, sf, fieldTag)
+				structField := buildField(f.Index, i, sf, fieldTag, opts.FieldNamer)
 				structField.Type = ft
 
 				if !sf.Anonymous || ft.Kind() != reflect.Struct {
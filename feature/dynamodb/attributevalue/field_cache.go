@@ -1,25 +1,34 @@
 package attributevalue
 
 import (
+	"reflect"
 	"strings"
 	"sync"
 )
 
 var fieldCache fieldCacher
 
+// fieldCacheKey is the key of the fields of a struct type, cached for each
+// struct tag key and comparable FieldNamer the fields are enumerated with.
+type fieldCacheKey struct {
+	typ    reflect.Type
+	tagKey string
+	namer  FieldNamer
+}
+
 type fieldCacher struct {
 	cache sync.Map
 }
 
-func (c *fieldCacher) Load(t interface{}) (*cachedFields, bool) {
-	if v, ok := c.cache.Load(t); ok {
+func (c *fieldCacher) Load(key fieldCacheKey) (*cachedFields, bool) {
+	if v, ok := c.cache.Load(key); ok {
 		return v.(*cachedFields), true
 	}
 	return nil, false
 }
 
-func (c *fieldCacher) LoadOrStore(t interface{}, fs *cachedFields) (*cachedFields, bool) {
-	v, ok := c.cache.LoadOrStore(t, fs)
+func (c *fieldCacher) LoadOrStore(key fieldCacheKey, fs *cachedFields) (*cachedFields, bool) {
+	v, ok := c.cache.LoadOrStore(key, fs)
 	return v.(*cachedFields), ok
 }
 
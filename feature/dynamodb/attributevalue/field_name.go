@@ -0,0 +1,58 @@
+package attributevalue
+
+import (
+	"strings"
+	"unicode"
+)
+
+// A FieldNamer provides the AttributeValue map key of struct fields that do
+// not have a name set by their struct tag. Use EncoderOptions.FieldNamer and
+// DecoderOptions.FieldNamer to name fields with a FieldNamer, instead of the
+// field's Go name.
+type FieldNamer interface {
+	FieldName(name string) string
+}
+
+// SnakeCaseFieldNamer is a FieldNamer naming fields in snake_case, such as
+// "user_id" for a field named UserID.
+type SnakeCaseFieldNamer struct{}
+
+// FieldName returns the field name in snake_case.
+func (SnakeCaseFieldNamer) FieldName(name string) string {
+	runes := []rune(name)
+
+	var sb strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Start a new word at an upper case letter following a lower case
+			// letter or digit, or the last upper case letter of an acronym
+			// followed by a lower case letter, e.g. "HTTPServer".
+			if i > 0 && (!unicode.IsUpper(runes[i-1]) && runes[i-1] != '_' ||
+				i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1])) {
+				sb.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// CamelCaseFieldNamer is a FieldNamer naming fields in camelCase, such as
+// "userID" for a field named UserID, and "httpServer" for HTTPServer.
+type CamelCaseFieldNamer struct{}
+
+// FieldName returns the field name in camelCase.
+func (CamelCaseFieldNamer) FieldName(name string) string {
+	runes := []rune(name)
+
+	// Lower case the leading upper case letters, except the last letter of a
+	// leading acronym followed by a lower case letter, e.g. "HTTPServer".
+	for i := 0; i < len(runes) && unicode.IsUpper(runes[i]); i++ {
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
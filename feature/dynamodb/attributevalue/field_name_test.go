@@ -0,0 +1,234 @@
+package attributevalue
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestFieldNamers(t *testing.T) {
+	cases := []struct {
+		name            string
+		expectSnakeCase string
+		expectCamelCase string
+	}{
+		{"Name", "name", "name"},
+		{"UserID", "user_id", "userID"},
+		{"ID", "id", "id"},
+		{"HTTPServer", "http_server", "httpServer"},
+		{"Version2", "version2", "version2"},
+		{"Version2X", "version2_x", "version2X"},
+		{"Already_Snake", "already_snake", "already_Snake"},
+		{"lower", "lower", "lower"},
+	}
+
+	for _, c := range cases {
+		if e, a := c.expectSnakeCase, (SnakeCaseFieldNamer{}).FieldName(c.name); e != a {
+			t.Errorf("%v: expect %v snake case, got %v", c.name, e, a)
+		}
+		if e, a := c.expectCamelCase, (CamelCaseFieldNamer{}).FieldName(c.name); e != a {
+			t.Errorf("%v: expect %v camel case, got %v", c.name, e, a)
+		}
+	}
+}
+
+func TestFieldNamer_MarshalUnmarshal(t *testing.T) {
+	type inner struct {
+		InnerValue string
+	}
+	type record struct {
+		inner
+		UserID    string
+		FirstName string `dynamodbav:"First"`
+		Ignored   string `dynamodbav:"-"`
+		Count     int    `dynamodbav:",omitempty"`
+	}
+
+	in := record{
+		inner:     inner{InnerValue: "inner"},
+		UserID:    "abc",
+		FirstName: "first",
+		Ignored:   "ignored",
+		Count:     2,
+	}
+
+	cases := map[string]struct {
+		namer  FieldNamer
+		expect map[string]types.AttributeValue
+	}{
+		"default": {
+			expect: map[string]types.AttributeValue{
+				"InnerValue": &types.AttributeValueMemberS{Value: "inner"},
+				"UserID":     &types.AttributeValueMemberS{Value: "abc"},
+				"First":      &types.AttributeValueMemberS{Value: "first"},
+				"Count":      &types.AttributeValueMemberN{Value: "2"},
+			},
+		},
+		"snake case": {
+			namer: SnakeCaseFieldNamer{},
+			expect: map[string]types.AttributeValue{
+				"inner_value": &types.AttributeValueMemberS{Value: "inner"},
+				"user_id":     &types.AttributeValueMemberS{Value: "abc"},
+				"First":       &types.AttributeValueMemberS{Value: "first"},
+				"count":       &types.AttributeValueMemberN{Value: "2"},
+			},
+		},
+		"camel case": {
+			namer: CamelCaseFieldNamer{},
+			expect: map[string]types.AttributeValue{
+				"innerValue": &types.AttributeValueMemberS{Value: "inner"},
+				"userID":     &types.AttributeValueMemberS{Value: "abc"},
+				"First":      &types.AttributeValueMemberS{Value: "first"},
+				"count":      &types.AttributeValueMemberN{Value: "2"},
+			},
+		},
+	}
+
+	// Each case uses the same struct type, with the cached fields named by each
+	// FieldNamer.
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			av, err := NewEncoder(func(o *EncoderOptions) {
+				o.FieldNamer = c.namer
+			}).Encode(in)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.expect, av.(*types.AttributeValueMemberM).Value; !reflect.DeepEqual(e, a) {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+
+			var out record
+			err = NewDecoder(func(o *DecoderOptions) {
+				o.FieldNamer = c.namer
+			}).Decode(av, &out)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			expect := in
+			expect.Ignored = ""
+			if e, a := expect, out; !reflect.DeepEqual(e, a) {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestFieldNamer_DominantField(t *testing.T) {
+	type inner struct {
+		UserID    string `dynamodbav:"UserID"`
+		AccountId string
+	}
+	type record struct {
+		UserID    string
+		AccountID string
+		inner
+	}
+
+	// Fields are named before hidden fields are removed. The embedded UserID
+	// named by its tag is not hidden by the outer UserID named by the
+	// FieldNamer, and the embedded AccountId named the same as the outer
+	// AccountID by the FieldNamer is hidden.
+	av, err := NewEncoder(func(o *EncoderOptions) {
+		o.FieldNamer = SnakeCaseFieldNamer{}
+	}).Encode(record{
+		UserID:    "outer user",
+		AccountID: "outer account",
+		inner:     inner{UserID: "inner user", AccountId: "inner account"},
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	expect := map[string]types.AttributeValue{
+		"user_id":    &types.AttributeValueMemberS{Value: "outer user"},
+		"UserID":     &types.AttributeValueMemberS{Value: "inner user"},
+		"account_id": &types.AttributeValueMemberS{Value: "outer account"},
+	}
+	if e, a := expect, av.(*types.AttributeValueMemberM).Value; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
+
+func TestFieldNamer_Cached(t *testing.T) {
+	type record struct {
+		UserID string
+	}
+	typ := reflect.TypeOf(record{})
+
+	opts := structFieldOptions{FieldNamer: SnakeCaseFieldNamer{}}
+	fields := unionStructFields(typ, opts)
+	if e, a := fields, unionStructFields(typ, opts); e != a {
+		t.Errorf("expect fields cached for comparable FieldNamer")
+	}
+	if _, ok := fields.FieldByName("user_id"); !ok {
+		t.Errorf("expect field named by FieldNamer")
+	}
+
+	other := unionStructFields(typ, structFieldOptions{FieldNamer: CamelCaseFieldNamer{}})
+	if _, ok := other.FieldByName("userID"); !ok {
+		t.Errorf("expect field named by other FieldNamer")
+	}
+}
+
+// fieldNamerFunc is a FieldNamer that is not comparable.
+type fieldNamerFunc func(string) string
+
+func (fn fieldNamerFunc) FieldName(name string) string {
+	return fn(name)
+}
+
+func TestFieldNamer_Func(t *testing.T) {
+	type record struct {
+		UserID string
+		Name   string `dynamodbav:"name"`
+	}
+
+	cacheSize := func() (n int) {
+		fieldCache.cache.Range(func(interface{}, interface{}) bool {
+			n++
+			return true
+		})
+		return n
+	}
+
+	// Each encode and decode uses a different FieldNamer func, which must
+	// not grow the field cache.
+	var size int
+	for i, prefix := range []string{"a_", "b_", "c_"} {
+		prefix := prefix
+		namer := fieldNamerFunc(func(name string) string { return prefix + name })
+
+		av, err := NewEncoder(func(o *EncoderOptions) {
+			o.FieldNamer = namer
+		}).Encode(record{UserID: "abc", Name: "name"})
+		if err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		expect := map[string]types.AttributeValue{
+			prefix + "UserID": &types.AttributeValueMemberS{Value: "abc"},
+			"name":            &types.AttributeValueMemberS{Value: "name"},
+		}
+		if e, a := expect, av.(*types.AttributeValueMemberM).Value; !reflect.DeepEqual(e, a) {
+			t.Errorf("expect %v, got %v", e, a)
+		}
+
+		var out record
+		err = NewDecoder(func(o *DecoderOptions) {
+			o.FieldNamer = namer
+		}).Decode(av, &out)
+		if err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		if e, a := (record{UserID: "abc", Name: "name"}), out; e != a {
+			t.Errorf("expect %v, got %v", e, a)
+		}
+
+		if i == 0 {
+			size = cacheSize()
+		}
+	}
+	if e, a := size, cacheSize(); e != a {
+		t.Errorf("expect field cache size %v, got %v", e, a)
+	}
+}
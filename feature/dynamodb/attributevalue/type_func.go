@@ -0,0 +1,192 @@
+package attributevalue
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// MarshalFunc marshals a Go value of the type it is registered for to an
+// AttributeValue. Use a MarshalFunc to customize how values of a type are
+// marshaled, such as types of other packages, without wrapping the type to
+// implement Marshaler.
+//
+// Register a MarshalFunc for a type with the WithMarshalFunc Encoder option.
+type MarshalFunc func(v interface{}) (types.AttributeValue, error)
+
+// UnmarshalFunc unmarshals an AttributeValue into out, a pointer to a Go value
+// of the type it is registered for. Use an UnmarshalFunc to customize how
+// values of a type are unmarshaled, such as types of other packages, without
+// wrapping the type to implement Unmarshaler.
+//
+// Register an UnmarshalFunc for a type with the WithUnmarshalFunc Decoder
+// option.
+type UnmarshalFunc func(av types.AttributeValue, out interface{}) error
+
+// WithMarshalFunc returns an Encoder option registering the MarshalFunc for
+// the type of v. Values of the type, and pointers to it, are marshaled with
+// the function, instead of the Encoder's default marshaling of the type, or the
+// type's Marshaler.
+//
+//     encoder := attributevalue.NewEncoder(
+//         attributevalue.WithMarshalFunc(time.Duration(0), attributevalue.MarshalDurationString),
+//         attributevalue.WithMarshalFunc(uuid.UUID{}, attributevalue.MarshalTextString),
+//     )
+func WithMarshalFunc(v interface{}, fn MarshalFunc) func(*EncoderOptions) {
+	t := reflect.TypeOf(v)
+	return func(o *EncoderOptions) {
+		funcs := make(map[reflect.Type]MarshalFunc, len(o.MarshalFuncs)+1)
+		for k, v := range o.MarshalFuncs {
+			funcs[k] = v
+		}
+		funcs[t] = fn
+		o.MarshalFuncs = funcs
+	}
+}
+
+// WithUnmarshalFunc returns a Decoder option registering the UnmarshalFunc
+// for the type of v. AttributeValues unmarshaled into values of the type, and
+// pointers to it, are unmarshaled with the function, instead of the Decoder's
+// default unmarshaling of the type, or the type's Unmarshaler. NULL
+// AttributeValues are unmarshaled as the type's zero value without calling the
+// function.
+//
+//     decoder := attributevalue.NewDecoder(
+//         attributevalue.WithUnmarshalFunc(time.Duration(0), attributevalue.UnmarshalDurationString),
+//         attributevalue.WithUnmarshalFunc(uuid.UUID{}, attributevalue.UnmarshalText),
+//     )
+func WithUnmarshalFunc(v interface{}, fn UnmarshalFunc) func(*DecoderOptions) {
+	t := reflect.TypeOf(v)
+	return func(o *DecoderOptions) {
+		funcs := make(map[reflect.Type]UnmarshalFunc, len(o.UnmarshalFuncs)+1)
+		for k, v := range o.UnmarshalFuncs {
+			funcs[k] = v
+		}
+		funcs[t] = fn
+		o.UnmarshalFuncs = funcs
+	}
+}
+
+// MarshalTextString is a MarshalFunc marshaling values implementing
+// encoding.TextMarshaler to a string AttributeValue, such as UUIDs.
+func MarshalTextString(v interface{}) (types.AttributeValue, error) {
+	text, err := marshalText(v)
+	if err != nil {
+		return nil, err
+	}
+	return &types.AttributeValueMemberS{Value: text}, nil
+}
+
+// MarshalTextNumber is a MarshalFunc marshaling values implementing
+// encoding.TextMarshaler to a number AttributeValue, such as arbitrary
+// precision decimals. The text must be a valid DynamoDB number.
+func MarshalTextNumber(v interface{}) (types.AttributeValue, error) {
+	text, err := marshalText(v)
+	if err != nil {
+		return nil, err
+	}
+	return &types.AttributeValueMemberN{Value: text}, nil
+}
+
+func marshalText(v interface{}) (string, error) {
+	m, ok := v.(encoding.TextMarshaler)
+	if !ok {
+		return "", &InvalidMarshalError{msg: fmt.Sprintf("%T does not implement encoding.TextMarshaler", v)}
+	}
+	text, err := m.MarshalText()
+	if err != nil {
+		return "", err
+	}
+	return string(text), nil
+}
+
+// UnmarshalText is an UnmarshalFunc unmarshaling string and number
+// AttributeValues into values implementing encoding.TextUnmarshaler, such as
+// UUIDs and arbitrary precision decimals.
+func UnmarshalText(av types.AttributeValue, out interface{}) error {
+	u, ok := out.(encoding.TextUnmarshaler)
+	if !ok {
+		return &InvalidUnmarshalError{Type: reflect.TypeOf(out)}
+	}
+
+	switch tv := av.(type) {
+	case *types.AttributeValueMemberS:
+		return u.UnmarshalText([]byte(tv.Value))
+	case *types.AttributeValueMemberN:
+		return u.UnmarshalText([]byte(tv.Value))
+	default:
+		return &UnmarshalTypeError{Value: fmt.Sprintf("%T", av), Type: reflect.TypeOf(out).Elem()}
+	}
+}
+
+// MarshalDurationString is a MarshalFunc marshaling time.Duration values to a
+// string AttributeValue in the format of the Duration's String method, such
+// as "1h30m0s", instead of a number of nanoseconds.
+func MarshalDurationString(v interface{}) (types.AttributeValue, error) {
+	d, ok := v.(time.Duration)
+	if !ok {
+		return nil, &InvalidMarshalError{msg: fmt.Sprintf("%T is not a time.Duration", v)}
+	}
+	return &types.AttributeValueMemberS{Value: d.String()}, nil
+}
+
+// UnmarshalDurationString is an UnmarshalFunc unmarshaling string
+// AttributeValues parsed with time.ParseDuration into time.Duration values.
+// Number AttributeValues are unmarshaled as a number of nanoseconds.
+func UnmarshalDurationString(av types.AttributeValue, out interface{}) error {
+	d, ok := out.(*time.Duration)
+	if !ok {
+		return &InvalidUnmarshalError{Type: reflect.TypeOf(out)}
+	}
+
+	switch tv := av.(type) {
+	case *types.AttributeValueMemberS:
+		v, err := time.ParseDuration(tv.Value)
+		if err != nil {
+			return err
+		}
+		*d = v
+		return nil
+	case *types.AttributeValueMemberN:
+		return NewDecoder().Decode(av, out)
+	default:
+		return &UnmarshalTypeError{Value: fmt.Sprintf("%T", av), Type: reflect.TypeOf(*d)}
+	}
+}
+
+// TimeLayoutMarshalFunc returns a MarshalFunc marshaling time.Time values to a
+// string AttributeValue formatted with the layout, instead of RFC3339Nano.
+func TimeLayoutMarshalFunc(layout string) MarshalFunc {
+	return func(v interface{}) (types.AttributeValue, error) {
+		t, ok := v.(time.Time)
+		if !ok {
+			return nil, &InvalidMarshalError{msg: fmt.Sprintf("%T is not a time.Time", v)}
+		}
+		return &types.AttributeValueMemberS{Value: t.Format(layout)}, nil
+	}
+}
+
+// TimeLayoutUnmarshalFunc returns an UnmarshalFunc unmarshaling string
+// AttributeValues parsed with the layout into time.Time values.
+func TimeLayoutUnmarshalFunc(layout string) UnmarshalFunc {
+	return func(av types.AttributeValue, out interface{}) error {
+		t, ok := out.(*time.Time)
+		if !ok {
+			return &InvalidUnmarshalError{Type: reflect.TypeOf(out)}
+		}
+
+		s, ok := av.(*types.AttributeValueMemberS)
+		if !ok {
+			return &UnmarshalTypeError{Value: fmt.Sprintf("%T", av), Type: timeType}
+		}
+		v, err := time.Parse(layout, s.Value)
+		if err != nil {
+			return err
+		}
+		*t = v
+		return nil
+	}
+}
@@ -0,0 +1,289 @@
+package attributevalue
+
+import (
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// testUUID is a UUID like type marshaled to text by encoding.TextMarshaler.
+type testUUID [4]byte
+
+func (u testUUID) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(u[:])), nil
+}
+
+func (u *testUUID) UnmarshalText(text []byte) error {
+	b, err := hex.DecodeString(string(text))
+	if err != nil {
+		return err
+	}
+	if len(b) != len(u) {
+		return fmt.Errorf("invalid UUID length %d", len(b))
+	}
+	copy(u[:], b)
+	return nil
+}
+
+// testDecimal is a decimal like type with unexported fields, marshaled to text
+// by encoding.TextMarshaler.
+type testDecimal struct {
+	value string
+}
+
+func (d testDecimal) MarshalText() ([]byte, error) {
+	return []byte(d.value), nil
+}
+
+func (d *testDecimal) UnmarshalText(text []byte) error {
+	d.value = string(text)
+	return nil
+}
+
+func TestTypeFuncs(t *testing.T) {
+	type record struct {
+		ID       testUUID
+		Parent   *testUUID
+		Price    testDecimal
+		Timeout  time.Duration
+		Interval time.Duration
+		Date     time.Time
+		Created  time.Time `dynamodbav:",unixtime"`
+		IDs      []testUUID
+	}
+
+	date := time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC)
+	in := record{
+		ID:       testUUID{1, 2, 3, 4},
+		Parent:   &testUUID{5, 6, 7, 8},
+		Price:    testDecimal{value: "12.50"},
+		Timeout:  90 * time.Second,
+		Interval: 0,
+		Date:     date,
+		Created:  date,
+		IDs:      []testUUID{{0xa, 0xb, 0xc, 0xd}},
+	}
+
+	encoder := NewEncoder(
+		WithMarshalFunc(testUUID{}, MarshalTextString),
+		WithMarshalFunc(testDecimal{}, MarshalTextNumber),
+		WithMarshalFunc(time.Duration(0), MarshalDurationString),
+		WithMarshalFunc(time.Time{}, TimeLayoutMarshalFunc("2006-01-02")),
+	)
+	av, err := encoder.Encode(in)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	expect := &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"ID":       &types.AttributeValueMemberS{Value: "01020304"},
+		"Parent":   &types.AttributeValueMemberS{Value: "05060708"},
+		"Price":    &types.AttributeValueMemberN{Value: "12.50"},
+		"Timeout":  &types.AttributeValueMemberS{Value: "1m30s"},
+		"Interval": &types.AttributeValueMemberS{Value: "0s"},
+		"Date":     &types.AttributeValueMemberS{Value: "2021-02-03"},
+		"Created":  &types.AttributeValueMemberS{Value: "2021-02-03"},
+		"IDs": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+			&types.AttributeValueMemberS{Value: "0a0b0c0d"},
+		}},
+	}}
+	if e, a := expect, av; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+
+	decoder := NewDecoder(
+		WithUnmarshalFunc(testUUID{}, UnmarshalText),
+		WithUnmarshalFunc(testDecimal{}, UnmarshalText),
+		WithUnmarshalFunc(time.Duration(0), UnmarshalDurationString),
+		WithUnmarshalFunc(time.Time{}, TimeLayoutUnmarshalFunc("2006-01-02")),
+	)
+	var out record
+	if err := decoder.Decode(av, &out); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := in, out; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
+
+func TestTypeFuncs_Default(t *testing.T) {
+	// Without registered functions, types are (un)marshaled by default.
+	av, err := Marshal(time.Duration(90 * time.Second))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := (&types.AttributeValueMemberN{Value: "90000000000"}), av; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+
+	var d time.Duration
+	err = NewDecoder(WithUnmarshalFunc(time.Duration(0), UnmarshalDurationString)).Decode(av, &d)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := 90*time.Second, d; e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
+
+// testMarshalerValue implements both Marshaler and Unmarshaler, prefixing its
+// value with "marshaler:".
+type testMarshalerValue struct {
+	Value string
+}
+
+func (v testMarshalerValue) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return &types.AttributeValueMemberS{Value: "marshaler:" + v.Value}, nil
+}
+
+func (v *testMarshalerValue) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	v.Value = strings.TrimPrefix(av.(*types.AttributeValueMemberS).Value, "marshaler:")
+	return nil
+}
+
+func TestTypeFuncs_Marshaler(t *testing.T) {
+	// Registered functions are used instead of the type's Marshaler and
+	// Unmarshaler, so the value round trips through the functions.
+	encoder := NewEncoder(WithMarshalFunc(testMarshalerValue{}, func(v interface{}) (types.AttributeValue, error) {
+		return &types.AttributeValueMemberS{Value: "func:" + v.(testMarshalerValue).Value}, nil
+	}))
+	decoder := NewDecoder(WithUnmarshalFunc(testMarshalerValue{}, func(av types.AttributeValue, out interface{}) error {
+		value := av.(*types.AttributeValueMemberS).Value
+		if !strings.HasPrefix(value, "func:") {
+			return fmt.Errorf("expect func marshaled value, got %v", value)
+		}
+		out.(*testMarshalerValue).Value = strings.TrimPrefix(value, "func:")
+		return nil
+	}))
+
+	type record struct {
+		Value testMarshalerValue
+		Ptr   *testMarshalerValue
+	}
+	in := record{
+		Value: testMarshalerValue{Value: "x"},
+		Ptr:   &testMarshalerValue{Value: "y"},
+	}
+
+	av, err := encoder.Encode(in)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	expect := &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"Value": &types.AttributeValueMemberS{Value: "func:x"},
+		"Ptr":   &types.AttributeValueMemberS{Value: "func:y"},
+	}}
+	if e, a := expect, av; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+
+	var out record
+	if err := decoder.Decode(av, &out); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := in, out; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+}
+
+func TestTypeFuncs_Null(t *testing.T) {
+	decoder := NewDecoder(WithUnmarshalFunc(testUUID{}, func(types.AttributeValue, interface{}) error {
+		return fmt.Errorf("expect unmarshal func not called")
+	}))
+
+	id := &testUUID{1}
+	if err := decoder.Decode(&types.AttributeValueMemberNULL{Value: true}, &id); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if id != nil {
+		t.Errorf("expect nil, got %v", id)
+	}
+}
+
+func TestTypeFuncs_Errors(t *testing.T) {
+	cases := map[string]struct {
+		err         func() error
+		expectedErr string
+	}{
+		"marshal func error": {
+			err: func() error {
+				_, err := NewEncoder(WithMarshalFunc(testUUID{}, func(interface{}) (types.AttributeValue, error) {
+					return nil, fmt.Errorf("marshal error")
+				})).Encode(map[string]testUUID{"a": {}})
+				return err
+			},
+			expectedErr: "marshal error",
+		},
+		"not a text marshaler": {
+			err: func() error {
+				_, err := NewEncoder(WithMarshalFunc(0, MarshalTextString)).Encode(1)
+				return err
+			},
+			expectedErr: "does not implement encoding.TextMarshaler",
+		},
+		"text unmarshal error": {
+			err: func() error {
+				var id testUUID
+				return NewDecoder(WithUnmarshalFunc(testUUID{}, UnmarshalText)).
+					Decode(&types.AttributeValueMemberS{Value: "xyz"}, &id)
+			},
+			expectedErr: "invalid byte",
+		},
+		"text unmarshal type": {
+			err: func() error {
+				var id testUUID
+				return NewDecoder(WithUnmarshalFunc(testUUID{}, UnmarshalText)).
+					Decode(&types.AttributeValueMemberBOOL{Value: true}, &id)
+			},
+			expectedErr: "cannot unmarshal",
+		},
+		"duration parse error": {
+			err: func() error {
+				var d time.Duration
+				return NewDecoder(WithUnmarshalFunc(time.Duration(0), UnmarshalDurationString)).
+					Decode(&types.AttributeValueMemberS{Value: "abc"}, &d)
+			},
+			expectedErr: "invalid duration",
+		},
+		"time layout parse error": {
+			err: func() error {
+				var tm time.Time
+				return NewDecoder(WithUnmarshalFunc(time.Time{}, TimeLayoutUnmarshalFunc("2006-01-02"))).
+					Decode(&types.AttributeValueMemberS{Value: "2021-02-03T00:00:00Z"}, &tm)
+			},
+			expectedErr: "extra text",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := c.err()
+			if err == nil {
+				t.Fatalf("expect error, got none")
+			}
+			if e, a := c.expectedErr, err.Error(); !strings.Contains(a, e) {
+				t.Errorf("expect error to contain %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestWithMarshalFunc_CopiesFuncs(t *testing.T) {
+	funcs := map[reflect.Type]MarshalFunc{}
+
+	var opts EncoderOptions
+	opts.MarshalFuncs = funcs
+	WithMarshalFunc(testUUID{}, MarshalTextString)(&opts)
+
+	if e, a := 0, len(funcs); e != a {
+		t.Errorf("expect %v original funcs, got %v", e, a)
+	}
+	if _, ok := opts.MarshalFuncs[reflect.TypeOf(testUUID{})]; !ok {
+		t.Errorf("expect func registered for type")
+	}
+}
@@ -0,0 +1,91 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/internal/sdk"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+const userAgentKey = "ddb-batch"
+
+// DefaultConcurrency is the default number of goroutines to spin up when
+// using the Writer or Getter.
+const DefaultConcurrency = 5
+
+// DefaultMaxAttempts is the default maximum number of requests made for each
+// batch by the Writer and Getter, retrying the batch's unprocessed items.
+const DefaultMaxAttempts = 10
+
+// DefaultMaxBackoff is the default maximum delay between the requests made for
+// a batch's unprocessed items.
+const DefaultMaxBackoff = 20 * time.Second
+
+// clientOptions returns a copy of the client options, adding the batch
+// feature to the user agent.
+func clientOptions(optFns []func(*dynamodb.Options)) []func(*dynamodb.Options) {
+	opts := make([]func(*dynamodb.Options), 0, len(optFns)+1)
+	opts = append(opts, func(o *dynamodb.Options) {
+		o.APIOptions = append(o.APIOptions, middleware.AddSDKAgentKey(middleware.FeatureMetadata, userAgentKey))
+	})
+	return append(opts, optFns...)
+}
+
+// backoff waits for the delay before the next attempt of a batch's
+// unprocessed items, or the context is canceled.
+func backoff(ctx context.Context, backoff retry.BackoffDelayer, attempt int) error {
+	delay, err := backoff.BackoffDelay(attempt, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get backoff delay, %w", err)
+	}
+	return sdk.SleepWithContext(ctx, delay)
+}
+
+// requestIterator is the iteration shared by the WriteRequestIterator and
+// GetRequestIterator.
+type requestIterator interface {
+	Next(context.Context) bool
+	Err() error
+}
+
+// sendBatches reads the requests of the iterator into batches of up to size
+// requests, sending the batches from concurrency goroutines. add is called to
+// add the iterator's current request to the batch being read, and flush
+// returns the function sending the batch read, starting the next batch. The
+// last partial batch is not sent if the iterator or context failed, and is
+// left to be reported by the caller. sendBatches returns once all batches have
+// been sent.
+func sendBatches(ctx context.Context, iter requestIterator, size, concurrency int, add func(), flush func() func()) {
+	sends := make(chan func(), concurrency)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for send := range sends {
+				send()
+			}
+		}()
+	}
+
+	var n int
+	for ctx.Err() == nil && iter.Next(ctx) {
+		add()
+		if n++; n >= size {
+			sends <- flush()
+			n = 0
+		}
+	}
+	if ctx.Err() == nil && iter.Err() == nil && n != 0 {
+		sends <- flush()
+	}
+
+	close(sends)
+	wg.Wait()
+}
@@ -0,0 +1,28 @@
+/*
+Package batch provides a Writer and Getter for writing and reading Amazon
+DynamoDB items in batches with the BatchWriteItem and BatchGetItem API
+operations.
+
+The Writer and Getter accept an unbounded stream of requests from an
+iterator, and send them in batches of up to 25 write requests, or 100 keys,
+concurrently. Items and keys DynamoDB does not process are retried with
+backoff, and the requests that were never processed are returned in a
+WriteError or GetError.
+
+  writer := batch.NewWriter(dynamodb.NewFromConfig(cfg))
+
+  requests := make(chan batch.WriteRequest)
+  go func() {
+      defer close(requests)
+      for _, item := range items {
+          requests <- batch.NewPutRequest("Table", item)
+      }
+  }()
+
+  err := writer.Write(context.TODO(), &batch.WriteRequestChanIterator{Requests: requests})
+  var writeErr *batch.WriteError
+  if errors.As(err, &writeErr) {
+      // retry or log writeErr.Unprocessed
+  }
+*/
+package batch
@@ -0,0 +1,382 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// MaxGetBatchSize is the maximum number of keys DynamoDB allows in a single
+// BatchGetItem request.
+const MaxGetBatchSize = 100
+
+// GetAPIClient is a DynamoDB client that implements the BatchGetItem API
+// operation.
+type GetAPIClient interface {
+	BatchGetItem(context.Context, *dynamodb.BatchGetItemInput, ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error)
+}
+
+var _ GetAPIClient = (*dynamodb.Client)(nil)
+
+// GetRequest is a request to get an item, to be read by the Getter.
+type GetRequest struct {
+	// The name of the table of the item.
+	TableName string
+
+	// The key of the item.
+	Key map[string]types.AttributeValue
+}
+
+// GetRequestIterator is an interface that iterates over the get requests to
+// be read by the Getter.
+type GetRequestIterator interface {
+	// Next advances the iterator to the next request, returning false if
+	// there are no more requests, or an error occurred.
+	Next(context.Context) bool
+
+	// Err returns the error that stopped the iterator before all of the
+	// requests were returned. The Getter sends the batches read before the
+	// error, and returns a GetError wrapping the error, with the requests of
+	// the partial batch not sent as unprocessed.
+	Err() error
+
+	// GetRequest returns the current request the iterator is positioned at.
+	GetRequest() GetRequest
+}
+
+// GetRequestsIterator is a GetRequestIterator over a slice of requests known
+// before Get is called.
+type GetRequestsIterator struct {
+	Requests []GetRequest
+
+	// The number of requests returned by Next, the current request being
+	// the last one returned.
+	next int
+}
+
+// Next returns the next request of Requests, returning false once all of the
+// requests have been returned.
+func (iter *GetRequestsIterator) Next(context.Context) bool {
+	if iter.next >= len(iter.Requests) {
+		return false
+	}
+	iter.next++
+	return true
+}
+
+// Err returns nil. Every request of Requests is given to the Getter.
+func (iter *GetRequestsIterator) Err() error {
+	return nil
+}
+
+// GetRequest returns the request last returned by Next.
+func (iter *GetRequestsIterator) GetRequest() GetRequest {
+	return iter.Requests[iter.next-1]
+}
+
+// GetRequestChanIterator is a GetRequestIterator over the requests received
+// from a channel, until the channel is closed.
+type GetRequestChanIterator struct {
+	Requests <-chan GetRequest
+
+	cur GetRequest
+	err error
+}
+
+// Next receives the next request from the channel, returning false once the
+// channel is closed, or the context is canceled.
+func (iter *GetRequestChanIterator) Next(ctx context.Context) bool {
+	select {
+	case req, ok := <-iter.Requests:
+		iter.cur = req
+		return ok
+	case <-ctx.Done():
+		iter.err = ctx.Err()
+		return false
+	}
+}
+
+// Err returns the context's error if the context was canceled while waiting
+// for a request.
+func (iter *GetRequestChanIterator) Err() error {
+	return iter.err
+}
+
+// GetRequest returns the current request.
+func (iter *GetRequestChanIterator) GetRequest() GetRequest {
+	return iter.cur
+}
+
+// GetError is returned by the Getter when get requests were never processed,
+// either because their BatchGetItem request failed, they were still
+// unprocessed after the maximum number of attempts, or the Get was stopped by
+// the iterator or context before their batch was sent.
+type GetError struct {
+	// The get requests that were not processed. Requests the iterator did not
+	// return before the Get was stopped are not included.
+	Unprocessed []GetRequest
+
+	// The error of the iterator, or context, that stopped the Get before all
+	// of the requests were read, if any.
+	Err error
+
+	// The errors of the failed BatchGetItem requests.
+	Errs []error
+}
+
+func (e *GetError) Error() string {
+	msg := fmt.Sprintf("%d get requests were not processed", len(e.Unprocessed))
+	if e.Err != nil {
+		msg += fmt.Sprintf(", %v", e.Err)
+	}
+	if len(e.Errs) != 0 {
+		msg += fmt.Sprintf(", %d batches failed, %v", len(e.Errs), e.Errs[0])
+	}
+	return msg
+}
+
+// Unwrap returns the error that stopped the Get, or the error of the first
+// failed BatchGetItem request, if any.
+func (e *GetError) Unwrap() error {
+	if e.Err != nil {
+		return e.Err
+	}
+	if len(e.Errs) == 0 {
+		return nil
+	}
+	return e.Errs[0]
+}
+
+// The Getter structure that calls Get(). It is safe to call Get() on this
+// structure for multiple request streams and across concurrent goroutines.
+// Mutating the Getter's properties is not safe to be done concurrently.
+type Getter struct {
+	// The number of keys sent in each BatchGetItem request. If this value is
+	// zero, or greater than MaxGetBatchSize, MaxGetBatchSize will be used.
+	BatchSize int
+
+	// The number of goroutines to spin up in parallel when sending batches.
+	// If this is set to zero, the DefaultConcurrency value will be used.
+	Concurrency int
+
+	// The maximum number of BatchGetItem requests made for each batch,
+	// retrying the batch's unprocessed keys. If this is set to zero, the
+	// DefaultMaxAttempts value will be used.
+	MaxAttempts int
+
+	// The backoff used to delay retrying a batch's unprocessed keys. If not
+	// set, an exponential backoff with jitter up to DefaultMaxBackoff will be
+	// used.
+	Backoff retry.BackoffDelayer
+
+	// Determines if strongly consistent reads are used.
+	ConsistentRead bool
+
+	// A DynamoDB client to use when reading items.
+	Client GetAPIClient
+
+	// List of client options that will be passed down to individual API
+	// operation requests made by the getter.
+	ClientOptions []func(*dynamodb.Options)
+}
+
+// NewGetter creates a new Getter instance to read items from DynamoDB in
+// concurrent batches. Pass in additional functional options to customize the
+// getter's behavior.
+func NewGetter(client GetAPIClient, options ...func(*Getter)) *Getter {
+	g := &Getter{
+		BatchSize:   MaxGetBatchSize,
+		Concurrency: DefaultConcurrency,
+		MaxAttempts: DefaultMaxAttempts,
+		Client:      client,
+	}
+	for _, option := range options {
+		option(g)
+	}
+
+	return g
+}
+
+// WithGetterClientOptions appends to the Getter's API request options.
+func WithGetterClientOptions(opts ...func(*dynamodb.Options)) func(*Getter) {
+	return func(g *Getter) {
+		g.ClientOptions = append(g.ClientOptions, opts...)
+	}
+}
+
+// Get reads the items with the keys of the requests returned by the iterator,
+// in batches of BatchSize keys sent with BatchGetItem concurrently. The keys
+// of a batch may be for multiple tables. fn is called with each item read,
+// and its table name. Calls of fn are not made concurrently, and the items
+// are not in the order of the requests. Items that do not exist are not
+// returned. If fn returns an error, Get stops and returns the error.
+//
+// Keys DynamoDB did not process are retried with backoff, up to MaxAttempts
+// requests for each batch. A GetError listing the requests that were never
+// processed is returned if any requests were not processed, or their batch
+// failed. If the iterator fails, or the context is canceled, the GetError
+// wraps the error, and lists the requests read that were not sent. The
+// requests of a single batch must not include the same key more than once.
+//
+// Additional functional options can be provided to configure the individual
+// get. These options are copies of the Getter instance Get is called from.
+// Modifying the options will not impact the original Getter instance.
+//
+// It is safe to call this method concurrently across goroutines.
+func (g Getter) Get(ctx context.Context, iter GetRequestIterator, fn func(tableName string, item map[string]types.AttributeValue) error, opts ...func(*Getter)) error {
+	for _, opt := range opts {
+		opt(&g)
+	}
+	if g.BatchSize <= 0 || g.BatchSize > MaxGetBatchSize {
+		g.BatchSize = MaxGetBatchSize
+	}
+	if g.Concurrency <= 0 {
+		g.Concurrency = DefaultConcurrency
+	}
+	if g.MaxAttempts <= 0 {
+		g.MaxAttempts = DefaultMaxAttempts
+	}
+	if g.Backoff == nil {
+		g.Backoff = retry.NewExponentialJitterBackoff(DefaultMaxBackoff)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	b := batchGetter{
+		ctx:           ctx,
+		cancel:        cancel,
+		cfg:           g,
+		fn:            fn,
+		clientOptions: clientOptions(g.ClientOptions),
+	}
+	return b.get(iter)
+}
+
+// batchGetter tracks the state of a single Getter.Get call.
+type batchGetter struct {
+	ctx           context.Context
+	cancel        func()
+	cfg           Getter
+	fn            func(string, map[string]types.AttributeValue) error
+	clientOptions []func(*dynamodb.Options)
+
+	m     sync.Mutex
+	err   GetError
+	fnErr error
+}
+
+func (b *batchGetter) get(iter GetRequestIterator) error {
+	var batch []GetRequest
+	sendBatches(b.ctx, iter, b.cfg.BatchSize, b.cfg.Concurrency, func() {
+		batch = append(batch, iter.GetRequest())
+	}, func() func() {
+		reqs := batch
+		batch = nil
+		return func() { b.getBatch(reqs) }
+	})
+
+	if b.fnErr != nil {
+		return b.fnErr
+	}
+	err := iter.Err()
+	if err != nil {
+		err = fmt.Errorf("failed to iterate get requests, %w", err)
+	} else {
+		err = b.ctx.Err()
+	}
+	if err != nil {
+		// The partial batch read before the Get was stopped was not sent.
+		b.err.Unprocessed = append(b.err.Unprocessed, batch...)
+		b.err.Err = err
+		return &b.err
+	}
+	if len(b.err.Unprocessed) != 0 {
+		return &b.err
+	}
+	return nil
+}
+
+// getBatch reads the batch of keys, retrying the unprocessed keys until all
+// keys are processed, or the maximum attempts are made.
+func (b *batchGetter) getBatch(batch []GetRequest) {
+	pending := map[string]types.KeysAndAttributes{}
+	for _, req := range batch {
+		keys := pending[req.TableName]
+		keys.Keys = append(keys.Keys, req.Key)
+		if b.cfg.ConsistentRead {
+			keys.ConsistentRead = aws.Bool(true)
+		}
+		pending[req.TableName] = keys
+	}
+
+	for attempt := 1; ; attempt++ {
+		out, err := b.cfg.Client.BatchGetItem(b.ctx, &dynamodb.BatchGetItemInput{
+			RequestItems: pending,
+		}, b.clientOptions...)
+		if err != nil {
+			b.unprocessed(pending, err)
+			return
+		}
+
+		if err := b.items(out.Responses); err != nil {
+			return
+		}
+
+		pending = out.UnprocessedKeys
+		if len(pending) == 0 {
+			return
+		}
+		if attempt >= b.cfg.MaxAttempts {
+			b.unprocessed(pending, nil)
+			return
+		}
+
+		if err := backoff(b.ctx, b.cfg.Backoff, attempt); err != nil {
+			b.unprocessed(pending, err)
+			return
+		}
+	}
+}
+
+// items calls the Get's function with the items read. If the function
+// returns an error, the Get is canceled.
+func (b *batchGetter) items(responses map[string][]map[string]types.AttributeValue) error {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	if b.fnErr != nil {
+		return b.fnErr
+	}
+	for table, items := range responses {
+		for _, item := range items {
+			if err := b.fn(table, item); err != nil {
+				b.fnErr = err
+				b.cancel()
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// unprocessed records the requests as not processed, with the error of the
+// batch if any.
+func (b *batchGetter) unprocessed(pending map[string]types.KeysAndAttributes, err error) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	for table, keys := range pending {
+		for _, key := range keys.Keys {
+			b.err.Unprocessed = append(b.err.Unprocessed, GetRequest{TableName: table, Key: key})
+		}
+	}
+	if err != nil {
+		b.err.Errs = append(b.err.Errs, err)
+	}
+}
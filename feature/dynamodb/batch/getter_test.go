@@ -0,0 +1,363 @@
+package batch_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/batch"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// mockGetClient is a GetAPIClient returning an item for each key requested.
+// The unprocessed function returns the number of the first keys of a table to
+// be returned as unprocessed, if set.
+type mockGetClient struct {
+	unprocessed func(keys int) int
+	err         error
+
+	m              sync.Mutex
+	calls          int
+	batchSizes     []int
+	consistentRead bool
+}
+
+func (c *mockGetClient) BatchGetItem(ctx context.Context, in *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.calls++
+	var size int
+	for _, keys := range in.RequestItems {
+		size += len(keys.Keys)
+		if keys.ConsistentRead != nil && *keys.ConsistentRead {
+			c.consistentRead = true
+		}
+	}
+	c.batchSizes = append(c.batchSizes, size)
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	out := &dynamodb.BatchGetItemOutput{
+		Responses: map[string][]map[string]types.AttributeValue{},
+	}
+	for table, keys := range in.RequestItems {
+		var n int
+		if c.unprocessed != nil {
+			n = c.unprocessed(len(keys.Keys))
+		}
+		if n != 0 {
+			if out.UnprocessedKeys == nil {
+				out.UnprocessedKeys = map[string]types.KeysAndAttributes{}
+			}
+			out.UnprocessedKeys[table] = types.KeysAndAttributes{
+				Keys:           keys.Keys[:n],
+				ConsistentRead: keys.ConsistentRead,
+			}
+		}
+		for _, key := range keys.Keys[n:] {
+			out.Responses[table] = append(out.Responses[table], map[string]types.AttributeValue{
+				"id":    key["id"],
+				"value": &types.AttributeValueMemberS{Value: "value"},
+			})
+		}
+	}
+	return out, nil
+}
+
+func newGetRequests(n int, tables ...string) []batch.GetRequest {
+	var reqs []batch.GetRequest
+	for i := 0; i < n; i++ {
+		reqs = append(reqs, batch.GetRequest{
+			TableName: tables[i%len(tables)],
+			Key: map[string]types.AttributeValue{
+				"id": &types.AttributeValueMemberS{Value: strconv.Itoa(i)},
+			},
+		})
+	}
+	return reqs
+}
+
+// itemCollector collects the items read by the Getter, by table.
+type itemCollector map[string][]string
+
+func (c itemCollector) collect(table string, item map[string]types.AttributeValue) error {
+	c[table] = append(c[table], item["id"].(*types.AttributeValueMemberS).Value)
+	return nil
+}
+
+func TestGetter_Get(t *testing.T) {
+	cases := map[string]struct {
+		requests         int
+		batchSize        int
+		unprocessed      func(int) int
+		expectBatchSizes []int
+	}{
+		"multiple batches": {
+			requests:         250,
+			expectBatchSizes: []int{50, 100, 100},
+		},
+		"single batch": {
+			requests:         3,
+			expectBatchSizes: []int{3},
+		},
+		"custom batch size": {
+			requests:         10,
+			batchSize:        4,
+			expectBatchSizes: []int{2, 4, 4},
+		},
+		"unprocessed keys": {
+			requests: 20,
+			unprocessed: func(keys int) int {
+				// Half of the keys are unprocessed on each attempt.
+				return keys / 2
+			},
+			expectBatchSizes: []int{2, 4, 10, 20},
+		},
+		"no requests": {},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := &mockGetClient{unprocessed: c.unprocessed}
+			items := itemCollector{}
+
+			err := batch.NewGetter(client, func(g *batch.Getter) {
+				g.BatchSize = c.batchSize
+				g.Backoff = &noBackoff{}
+				g.Concurrency = 1
+			}).Get(context.Background(), &batch.GetRequestsIterator{
+				Requests: newGetRequests(c.requests, "A", "B"),
+			}, items.collect)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			sort.Ints(client.batchSizes)
+			if e, a := fmt.Sprint(c.expectBatchSizes), fmt.Sprint(client.batchSizes); e != a {
+				t.Errorf("expect %v batch sizes, got %v", e, a)
+			}
+			if client.consistentRead {
+				t.Errorf("expect no consistent read")
+			}
+
+			seen := map[string]bool{}
+			for i, table := range []string{"A", "B"} {
+				for _, id := range items[table] {
+					if v, _ := strconv.Atoi(id); v%2 != i {
+						t.Errorf("expect item %v not read from table %v", id, table)
+					}
+					if seen[id] {
+						t.Errorf("expect item %v read once", id)
+					}
+					seen[id] = true
+				}
+			}
+			if e, a := c.requests, len(seen); e != a {
+				t.Errorf("expect %v items read, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestGetter_ConsistentRead(t *testing.T) {
+	client := &mockGetClient{}
+
+	err := batch.NewGetter(client).Get(context.Background(), &batch.GetRequestsIterator{
+		Requests: newGetRequests(10, "A"),
+	}, itemCollector{}.collect, func(g *batch.Getter) {
+		g.ConsistentRead = true
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if !client.consistentRead {
+		t.Errorf("expect consistent read")
+	}
+}
+
+func TestGetter_MaxAttempts(t *testing.T) {
+	client := &mockGetClient{
+		unprocessed: func(keys int) int {
+			return 2
+		},
+	}
+	items := itemCollector{}
+
+	err := batch.NewGetter(client, func(g *batch.Getter) {
+		g.Backoff = &noBackoff{}
+		g.MaxAttempts = 2
+		g.BatchSize = 5
+	}).Get(context.Background(), &batch.GetRequestsIterator{
+		Requests: newGetRequests(10, "A"),
+	}, items.collect)
+
+	var getErr *batch.GetError
+	if !errors.As(err, &getErr) {
+		t.Fatalf("expect GetError, got %v", err)
+	}
+	if e, a := 4, len(getErr.Unprocessed); e != a {
+		t.Errorf("expect %v unprocessed requests, got %v", e, a)
+	}
+	if e, a := 0, len(getErr.Errs); e != a {
+		t.Errorf("expect %v errors, got %v", e, a)
+	}
+	if e, a := 4, client.calls; e != a {
+		t.Errorf("expect %v calls, got %v", e, a)
+	}
+	if e, a := 6, len(items["A"]); e != a {
+		t.Errorf("expect %v items read, got %v", e, a)
+	}
+}
+
+func TestGetter_BatchError(t *testing.T) {
+	client := &mockGetClient{err: fmt.Errorf("mock error")}
+
+	err := batch.NewGetter(client).Get(context.Background(), &batch.GetRequestsIterator{
+		Requests: newGetRequests(150, "A", "B"),
+	}, itemCollector{}.collect)
+
+	var getErr *batch.GetError
+	if !errors.As(err, &getErr) {
+		t.Fatalf("expect GetError, got %v", err)
+	}
+	if e, a := 150, len(getErr.Unprocessed); e != a {
+		t.Errorf("expect %v unprocessed requests, got %v", e, a)
+	}
+	if e, a := 2, len(getErr.Errs); e != a {
+		t.Errorf("expect %v errors, got %v", e, a)
+	}
+	if e, a := client.err, errors.Unwrap(err); e != a {
+		t.Errorf("expect %v unwrapped error, got %v", e, a)
+	}
+}
+
+func TestGetter_FnError(t *testing.T) {
+	client := &mockGetClient{}
+	fnErr := fmt.Errorf("fn error")
+
+	var calls int
+	err := batch.NewGetter(client, func(g *batch.Getter) {
+		g.BatchSize = 10
+	}).Get(context.Background(), &batch.GetRequestsIterator{
+		Requests: newGetRequests(1000, "A"),
+	}, func(table string, item map[string]types.AttributeValue) error {
+		calls++
+		if calls == 15 {
+			return fnErr
+		}
+		return nil
+	})
+	if e, a := fnErr, err; e != a {
+		t.Fatalf("expect %v error, got %v", e, a)
+	}
+	if e, a := 15, calls; e != a {
+		t.Errorf("expect %v calls, got %v", e, a)
+	}
+	if client.calls >= 100 {
+		t.Errorf("expect get to stop, got %v calls", client.calls)
+	}
+}
+
+func TestGetter_ChanIterator(t *testing.T) {
+	client := &mockGetClient{}
+	items := itemCollector{}
+
+	requests := make(chan batch.GetRequest)
+	go func() {
+		defer close(requests)
+		for _, req := range newGetRequests(250, "A") {
+			requests <- req
+		}
+	}()
+
+	err := batch.NewGetter(client).Get(context.Background(), &batch.GetRequestChanIterator{
+		Requests: requests,
+	}, items.collect)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := 250, len(items["A"]); e != a {
+		t.Errorf("expect %v items read, got %v", e, a)
+	}
+	if e, a := 3, client.calls; e != a {
+		t.Errorf("expect %v calls, got %v", e, a)
+	}
+}
+
+func TestGetter_ContextCanceledMidStream(t *testing.T) {
+	client := &mockGetClient{}
+	items := itemCollector{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	requests := make(chan batch.GetRequest)
+	go func() {
+		for _, req := range newGetRequests(15, "A") {
+			requests <- req
+		}
+		cancel()
+	}()
+
+	err := batch.NewGetter(client, func(g *batch.Getter) {
+		g.BatchSize = 10
+	}).Get(ctx, &batch.GetRequestChanIterator{
+		Requests: requests,
+	}, items.collect)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expect context canceled error, got %v", err)
+	}
+	var getErr *batch.GetError
+	if !errors.As(err, &getErr) {
+		t.Fatalf("expect GetError, got %v", err)
+	}
+	if e, a := 5, len(getErr.Unprocessed); e != a {
+		t.Errorf("expect %v unprocessed requests, got %v", e, a)
+	}
+	if e, a := 10, len(items["A"]); e != a {
+		t.Errorf("expect %v items read, got %v", e, a)
+	}
+}
+
+// failingGetIterator returns the requests, then stops with an error.
+type failingGetIterator struct {
+	batch.GetRequestsIterator
+	err error
+}
+
+func (iter *failingGetIterator) Err() error {
+	return iter.err
+}
+
+func TestGetter_IteratorError(t *testing.T) {
+	client := &mockGetClient{}
+	items := itemCollector{}
+	iterErr := fmt.Errorf("iterator error")
+
+	err := batch.NewGetter(client, func(g *batch.Getter) {
+		g.BatchSize = 10
+	}).Get(context.Background(), &failingGetIterator{
+		GetRequestsIterator: batch.GetRequestsIterator{Requests: newGetRequests(25, "A")},
+		err:                 iterErr,
+	}, items.collect)
+	if !errors.Is(err, iterErr) {
+		t.Fatalf("expect iterator error, got %v", err)
+	}
+	var getErr *batch.GetError
+	if !errors.As(err, &getErr) {
+		t.Fatalf("expect GetError, got %v", err)
+	}
+	if e, a := 5, len(getErr.Unprocessed); e != a {
+		t.Errorf("expect %v unprocessed requests, got %v", e, a)
+	}
+	if e, a := 20, len(items["A"]); e != a {
+		t.Errorf("expect %v items read, got %v", e, a)
+	}
+}
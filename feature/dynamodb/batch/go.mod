@@ -0,0 +1,16 @@
+module github.com/aws/aws-sdk-go-v2/feature/dynamodb/batch
+
+go 1.15
+
+require (
+	github.com/aws/aws-sdk-go-v2 v1.2.0
+	github.com/aws/aws-sdk-go-v2/service/dynamodb v1.1.1
+	github.com/aws/aws-sdk-go-v2/service/internal/accept-encoding v1.0.1 // indirect
+)
+
+replace (
+	github.com/aws/aws-sdk-go-v2 => ../../../
+	github.com/aws/aws-sdk-go-v2/service/dynamodb => ../../../service/dynamodb/
+)
+
+replace github.com/aws/aws-sdk-go-v2/service/internal/accept-encoding => ../../../service/internal/accept-encoding/
@@ -0,0 +1,355 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// MaxWriteBatchSize is the maximum number of write requests DynamoDB allows
+// in a single BatchWriteItem request.
+const MaxWriteBatchSize = 25
+
+// WriteAPIClient is a DynamoDB client that implements the BatchWriteItem API
+// operation.
+type WriteAPIClient interface {
+	BatchWriteItem(context.Context, *dynamodb.BatchWriteItemInput, ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error)
+}
+
+var _ WriteAPIClient = (*dynamodb.Client)(nil)
+
+// WriteRequest is a request to put or delete an item, to be written by the
+// Writer.
+type WriteRequest struct {
+	// The name of the table of the item.
+	TableName string
+
+	// The put or delete request of the item.
+	Request types.WriteRequest
+}
+
+// NewPutRequest returns a WriteRequest putting the item in the table.
+func NewPutRequest(tableName string, item map[string]types.AttributeValue) WriteRequest {
+	return WriteRequest{
+		TableName: tableName,
+		Request:   types.WriteRequest{PutRequest: &types.PutRequest{Item: item}},
+	}
+}
+
+// NewDeleteRequest returns a WriteRequest deleting the item with the key from
+// the table.
+func NewDeleteRequest(tableName string, key map[string]types.AttributeValue) WriteRequest {
+	return WriteRequest{
+		TableName: tableName,
+		Request:   types.WriteRequest{DeleteRequest: &types.DeleteRequest{Key: key}},
+	}
+}
+
+// WriteRequestIterator is an interface that iterates over the write requests
+// to be written by the Writer.
+type WriteRequestIterator interface {
+	// Next advances the iterator to the next request, returning false if
+	// there are no more requests, or an error occurred.
+	Next(context.Context) bool
+
+	// Err returns the error that stopped the iterator before all of the
+	// requests were returned. The Writer sends the batches read before the
+	// error, and returns a WriteError wrapping the error, with the requests
+	// of the partial batch not sent as unprocessed.
+	Err() error
+
+	// WriteRequest returns the current request the iterator is positioned
+	// at.
+	WriteRequest() WriteRequest
+}
+
+// WriteRequestsIterator is a WriteRequestIterator over a slice of requests
+// known before Write is called.
+type WriteRequestsIterator struct {
+	Requests []WriteRequest
+
+	// The number of requests returned by Next, the current request being
+	// the last one returned.
+	next int
+}
+
+// Next returns the next request of Requests, returning false once all of the
+// requests have been returned.
+func (iter *WriteRequestsIterator) Next(context.Context) bool {
+	if iter.next >= len(iter.Requests) {
+		return false
+	}
+	iter.next++
+	return true
+}
+
+// Err returns nil. Every request of Requests is given to the Writer.
+func (iter *WriteRequestsIterator) Err() error {
+	return nil
+}
+
+// WriteRequest returns the request last returned by Next.
+func (iter *WriteRequestsIterator) WriteRequest() WriteRequest {
+	return iter.Requests[iter.next-1]
+}
+
+// WriteRequestChanIterator is a WriteRequestIterator over the requests
+// received from a channel, until the channel is closed.
+type WriteRequestChanIterator struct {
+	Requests <-chan WriteRequest
+
+	cur WriteRequest
+	err error
+}
+
+// Next receives the next request from the channel, returning false once the
+// channel is closed, or the context is canceled.
+func (iter *WriteRequestChanIterator) Next(ctx context.Context) bool {
+	select {
+	case req, ok := <-iter.Requests:
+		iter.cur = req
+		return ok
+	case <-ctx.Done():
+		iter.err = ctx.Err()
+		return false
+	}
+}
+
+// Err returns the context's error if the context was canceled while waiting
+// for a request.
+func (iter *WriteRequestChanIterator) Err() error {
+	return iter.err
+}
+
+// WriteRequest returns the current request.
+func (iter *WriteRequestChanIterator) WriteRequest() WriteRequest {
+	return iter.cur
+}
+
+// WriteError is returned by the Writer when write requests were never
+// processed, either because their BatchWriteItem request failed, they were
+// still unprocessed after the maximum number of attempts, or the Write was
+// stopped by the iterator or context before their batch was sent.
+type WriteError struct {
+	// The write requests that were not processed. Requests the iterator did
+	// not return before the Write was stopped are not included.
+	Unprocessed []WriteRequest
+
+	// The error of the iterator, or context, that stopped the Write before
+	// all of the requests were read, if any.
+	Err error
+
+	// The errors of the failed BatchWriteItem requests.
+	Errs []error
+}
+
+func (e *WriteError) Error() string {
+	msg := fmt.Sprintf("%d write requests were not processed", len(e.Unprocessed))
+	if e.Err != nil {
+		msg += fmt.Sprintf(", %v", e.Err)
+	}
+	if len(e.Errs) != 0 {
+		msg += fmt.Sprintf(", %d batches failed, %v", len(e.Errs), e.Errs[0])
+	}
+	return msg
+}
+
+// Unwrap returns the error that stopped the Write, or the error of the first
+// failed BatchWriteItem request, if any.
+func (e *WriteError) Unwrap() error {
+	if e.Err != nil {
+		return e.Err
+	}
+	if len(e.Errs) == 0 {
+		return nil
+	}
+	return e.Errs[0]
+}
+
+// The Writer structure that calls Write(). It is safe to call Write() on this
+// structure for multiple request streams and across concurrent goroutines.
+// Mutating the Writer's properties is not safe to be done concurrently.
+type Writer struct {
+	// The number of write requests sent in each BatchWriteItem request. If
+	// this value is zero, or greater than MaxWriteBatchSize, MaxWriteBatchSize
+	// will be used.
+	BatchSize int
+
+	// The number of goroutines to spin up in parallel when sending batches.
+	// If this is set to zero, the DefaultConcurrency value will be used.
+	Concurrency int
+
+	// The maximum number of BatchWriteItem requests made for each batch,
+	// retrying the batch's unprocessed items. If this is set to zero, the
+	// DefaultMaxAttempts value will be used.
+	MaxAttempts int
+
+	// The backoff used to delay retrying a batch's unprocessed items. If
+	// not set, an exponential backoff with jitter up to DefaultMaxBackoff
+	// will be used.
+	Backoff retry.BackoffDelayer
+
+	// A DynamoDB client to use when writing items.
+	Client WriteAPIClient
+
+	// List of client options that will be passed down to individual API
+	// operation requests made by the writer.
+	ClientOptions []func(*dynamodb.Options)
+}
+
+// NewWriter creates a new Writer instance to write items to DynamoDB in
+// concurrent batches. Pass in additional functional options to customize the
+// writer's behavior.
+func NewWriter(client WriteAPIClient, options ...func(*Writer)) *Writer {
+	w := &Writer{
+		BatchSize:   MaxWriteBatchSize,
+		Concurrency: DefaultConcurrency,
+		MaxAttempts: DefaultMaxAttempts,
+		Client:      client,
+	}
+	for _, option := range options {
+		option(w)
+	}
+
+	return w
+}
+
+// WithWriterClientOptions appends to the Writer's API request options.
+func WithWriterClientOptions(opts ...func(*dynamodb.Options)) func(*Writer) {
+	return func(w *Writer) {
+		w.ClientOptions = append(w.ClientOptions, opts...)
+	}
+}
+
+// Write writes the items of the requests returned by the iterator, in
+// batches of BatchSize requests sent with BatchWriteItem concurrently. The
+// items of a batch may be for multiple tables.
+//
+// Items DynamoDB did not process are retried with backoff, up to MaxAttempts
+// requests for each batch. A WriteError listing the requests that were never
+// processed is returned if any requests were not processed, or their batch
+// failed. If the iterator fails, or the context is canceled, the WriteError
+// wraps the error, and lists the requests read that were not sent. The
+// requests of a single batch must not include multiple requests for the same
+// item.
+//
+// Additional functional options can be provided to configure the individual
+// write. These options are copies of the Writer instance Write is called
+// from. Modifying the options will not impact the original Writer instance.
+//
+// It is safe to call this method concurrently across goroutines.
+func (w Writer) Write(ctx context.Context, iter WriteRequestIterator, opts ...func(*Writer)) error {
+	for _, opt := range opts {
+		opt(&w)
+	}
+	if w.BatchSize <= 0 || w.BatchSize > MaxWriteBatchSize {
+		w.BatchSize = MaxWriteBatchSize
+	}
+	if w.Concurrency <= 0 {
+		w.Concurrency = DefaultConcurrency
+	}
+	if w.MaxAttempts <= 0 {
+		w.MaxAttempts = DefaultMaxAttempts
+	}
+	if w.Backoff == nil {
+		w.Backoff = retry.NewExponentialJitterBackoff(DefaultMaxBackoff)
+	}
+
+	b := batchWriter{
+		ctx:           ctx,
+		cfg:           w,
+		clientOptions: clientOptions(w.ClientOptions),
+	}
+	return b.write(iter)
+}
+
+// batchWriter tracks the state of a single Writer.Write call.
+type batchWriter struct {
+	ctx           context.Context
+	cfg           Writer
+	clientOptions []func(*dynamodb.Options)
+
+	m   sync.Mutex
+	err WriteError
+}
+
+func (b *batchWriter) write(iter WriteRequestIterator) error {
+	var batch []WriteRequest
+	sendBatches(b.ctx, iter, b.cfg.BatchSize, b.cfg.Concurrency, func() {
+		batch = append(batch, iter.WriteRequest())
+	}, func() func() {
+		reqs := batch
+		batch = nil
+		return func() { b.writeBatch(reqs) }
+	})
+
+	err := iter.Err()
+	if err != nil {
+		err = fmt.Errorf("failed to iterate write requests, %w", err)
+	} else {
+		err = b.ctx.Err()
+	}
+	if err != nil {
+		// The partial batch read before the Write was stopped was not sent.
+		b.err.Unprocessed = append(b.err.Unprocessed, batch...)
+		b.err.Err = err
+		return &b.err
+	}
+	if len(b.err.Unprocessed) != 0 {
+		return &b.err
+	}
+	return nil
+}
+
+// writeBatch writes the batch of requests, retrying the unprocessed items
+// until all items are processed, or the maximum attempts are made.
+func (b *batchWriter) writeBatch(batch []WriteRequest) {
+	pending := map[string][]types.WriteRequest{}
+	for _, req := range batch {
+		pending[req.TableName] = append(pending[req.TableName], req.Request)
+	}
+
+	for attempt := 1; ; attempt++ {
+		out, err := b.cfg.Client.BatchWriteItem(b.ctx, &dynamodb.BatchWriteItemInput{
+			RequestItems: pending,
+		}, b.clientOptions...)
+		if err != nil {
+			b.unprocessed(pending, err)
+			return
+		}
+
+		pending = out.UnprocessedItems
+		if len(pending) == 0 {
+			return
+		}
+		if attempt >= b.cfg.MaxAttempts {
+			b.unprocessed(pending, nil)
+			return
+		}
+
+		if err := backoff(b.ctx, b.cfg.Backoff, attempt); err != nil {
+			b.unprocessed(pending, err)
+			return
+		}
+	}
+}
+
+// unprocessed records the requests as not processed, with the error of the
+// batch if any.
+func (b *batchWriter) unprocessed(pending map[string][]types.WriteRequest, err error) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	for table, reqs := range pending {
+		for _, req := range reqs {
+			b.err.Unprocessed = append(b.err.Unprocessed, WriteRequest{TableName: table, Request: req})
+		}
+	}
+	if err != nil {
+		b.err.Errs = append(b.err.Errs, err)
+	}
+}
@@ -0,0 +1,385 @@
+package batch_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/batch"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// noBackoff is a backoff without delay, recording the attempts backed off.
+type noBackoff struct {
+	m        sync.Mutex
+	attempts []int
+}
+
+func (b *noBackoff) BackoffDelay(attempt int, err error) (time.Duration, error) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	b.attempts = append(b.attempts, attempt)
+	return 0, nil
+}
+
+var _ retry.BackoffDelayer = (*noBackoff)(nil)
+
+// mockWriteClient is a WriteAPIClient recording the items written. The
+// unprocessed function returns the requests of a BatchWriteItem request to be
+// returned as unprocessed, if set.
+type mockWriteClient struct {
+	unprocessed func(attempt int, table string, reqs []types.WriteRequest) []types.WriteRequest
+	err         error
+
+	m          sync.Mutex
+	calls      int
+	batchSizes []int
+	written    map[string][]string
+	attempts   map[string]int
+}
+
+func (c *mockWriteClient) BatchWriteItem(ctx context.Context, in *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.calls++
+	if c.written == nil {
+		c.written = map[string][]string{}
+		c.attempts = map[string]int{}
+	}
+
+	var size int
+	for _, reqs := range in.RequestItems {
+		size += len(reqs)
+	}
+	c.batchSizes = append(c.batchSizes, size)
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	out := &dynamodb.BatchWriteItemOutput{}
+	for table, reqs := range in.RequestItems {
+		var unprocessed []types.WriteRequest
+		if c.unprocessed != nil {
+			c.attempts[itemID(reqs[0])]++
+			unprocessed = c.unprocessed(c.attempts[itemID(reqs[0])], table, reqs)
+		}
+
+		skip := map[string]bool{}
+		for _, req := range unprocessed {
+			skip[itemID(req)] = true
+		}
+		for _, req := range reqs {
+			if !skip[itemID(req)] {
+				c.written[table] = append(c.written[table], itemID(req))
+			}
+		}
+
+		if len(unprocessed) != 0 {
+			if out.UnprocessedItems == nil {
+				out.UnprocessedItems = map[string][]types.WriteRequest{}
+			}
+			out.UnprocessedItems[table] = unprocessed
+		}
+	}
+	return out, nil
+}
+
+func itemID(req types.WriteRequest) string {
+	if req.PutRequest != nil {
+		return req.PutRequest.Item["id"].(*types.AttributeValueMemberS).Value
+	}
+	return req.DeleteRequest.Key["id"].(*types.AttributeValueMemberS).Value
+}
+
+func newWriteRequests(n int, tables ...string) []batch.WriteRequest {
+	var reqs []batch.WriteRequest
+	for i := 0; i < n; i++ {
+		table := tables[i%len(tables)]
+		item := map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: strconv.Itoa(i)},
+		}
+		if i%2 == 0 {
+			reqs = append(reqs, batch.NewPutRequest(table, item))
+		} else {
+			reqs = append(reqs, batch.NewDeleteRequest(table, item))
+		}
+	}
+	return reqs
+}
+
+func assertWritten(t *testing.T, written map[string][]string, n int, tables ...string) {
+	t.Helper()
+
+	var all []string
+	for i, table := range tables {
+		for _, id := range written[table] {
+			if v, _ := strconv.Atoi(id); v%len(tables) != i {
+				t.Errorf("expect item %v not written to table %v", id, table)
+			}
+			all = append(all, id)
+		}
+	}
+	if e, a := n, len(all); e != a {
+		t.Fatalf("expect %v items written, got %v", e, a)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		a, _ := strconv.Atoi(all[i])
+		b, _ := strconv.Atoi(all[j])
+		return a < b
+	})
+	for i, id := range all {
+		if e, a := strconv.Itoa(i), id; e != a {
+			t.Fatalf("expect item %v written once, got %v", e, a)
+		}
+	}
+}
+
+func TestWriter_Write(t *testing.T) {
+	cases := map[string]struct {
+		requests         int
+		batchSize        int
+		expectBatchSizes []int
+	}{
+		"multiple batches": {
+			requests:         60,
+			expectBatchSizes: []int{10, 25, 25},
+		},
+		"single batch": {
+			requests:         3,
+			expectBatchSizes: []int{3},
+		},
+		"custom batch size": {
+			requests:         10,
+			batchSize:        4,
+			expectBatchSizes: []int{2, 4, 4},
+		},
+		"batch size above max": {
+			requests:         30,
+			batchSize:        100,
+			expectBatchSizes: []int{5, 25},
+		},
+		"no requests": {},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := &mockWriteClient{}
+
+			err := batch.NewWriter(client, func(w *batch.Writer) {
+				w.BatchSize = c.batchSize
+			}).Write(context.Background(), &batch.WriteRequestsIterator{
+				Requests: newWriteRequests(c.requests, "A", "B"),
+			})
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			sort.Ints(client.batchSizes)
+			if e, a := fmt.Sprint(c.expectBatchSizes), fmt.Sprint(client.batchSizes); e != a {
+				t.Errorf("expect %v batch sizes, got %v", e, a)
+			}
+			assertWritten(t, client.written, c.requests, "A", "B")
+		})
+	}
+}
+
+func TestWriter_RetryUnprocessed(t *testing.T) {
+	client := &mockWriteClient{
+		unprocessed: func(attempt int, table string, reqs []types.WriteRequest) []types.WriteRequest {
+			// Half of the requests are unprocessed on each attempt.
+			return reqs[:len(reqs)/2]
+		},
+	}
+	backoff := &noBackoff{}
+
+	err := batch.NewWriter(client, func(w *batch.Writer) {
+		w.Backoff = backoff
+		w.Concurrency = 1
+	}).Write(context.Background(), &batch.WriteRequestsIterator{
+		Requests: newWriteRequests(20, "A"),
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	assertWritten(t, client.written, 20, "A")
+	if e, a := "[20 10 5 2 1]", fmt.Sprint(client.batchSizes); e != a {
+		t.Errorf("expect %v batch sizes, got %v", e, a)
+	}
+	if e, a := "[1 2 3 4]", fmt.Sprint(backoff.attempts); e != a {
+		t.Errorf("expect %v backoff attempts, got %v", e, a)
+	}
+}
+
+func TestWriter_MaxAttempts(t *testing.T) {
+	client := &mockWriteClient{
+		unprocessed: func(attempt int, table string, reqs []types.WriteRequest) []types.WriteRequest {
+			return reqs[:1]
+		},
+	}
+
+	err := batch.NewWriter(client, func(w *batch.Writer) {
+		w.Backoff = &noBackoff{}
+		w.MaxAttempts = 3
+		w.BatchSize = 5
+	}).Write(context.Background(), &batch.WriteRequestsIterator{
+		Requests: newWriteRequests(10, "A"),
+	})
+
+	var writeErr *batch.WriteError
+	if !errors.As(err, &writeErr) {
+		t.Fatalf("expect WriteError, got %v", err)
+	}
+	if e, a := 2, len(writeErr.Unprocessed); e != a {
+		t.Errorf("expect %v unprocessed requests, got %v", e, a)
+	}
+	if e, a := 0, len(writeErr.Errs); e != a {
+		t.Errorf("expect %v errors, got %v", e, a)
+	}
+	for _, req := range writeErr.Unprocessed {
+		if e, a := "A", req.TableName; e != a {
+			t.Errorf("expect %v table, got %v", e, a)
+		}
+	}
+	if e, a := 6, client.calls; e != a {
+		t.Errorf("expect %v calls, got %v", e, a)
+	}
+	if e, a := 8, len(client.written["A"]); e != a {
+		t.Errorf("expect %v items written, got %v", e, a)
+	}
+}
+
+func TestWriter_BatchError(t *testing.T) {
+	client := &mockWriteClient{err: fmt.Errorf("mock error")}
+
+	err := batch.NewWriter(client).Write(context.Background(), &batch.WriteRequestsIterator{
+		Requests: newWriteRequests(30, "A", "B"),
+	})
+
+	var writeErr *batch.WriteError
+	if !errors.As(err, &writeErr) {
+		t.Fatalf("expect WriteError, got %v", err)
+	}
+	if e, a := 30, len(writeErr.Unprocessed); e != a {
+		t.Errorf("expect %v unprocessed requests, got %v", e, a)
+	}
+	if e, a := 2, len(writeErr.Errs); e != a {
+		t.Errorf("expect %v errors, got %v", e, a)
+	}
+	if e, a := "mock error", err.Error(); !strings.Contains(a, e) {
+		t.Errorf("expect error to contain %v, got %v", e, a)
+	}
+}
+
+func TestWriter_ChanIterator(t *testing.T) {
+	client := &mockWriteClient{}
+
+	requests := make(chan batch.WriteRequest)
+	go func() {
+		defer close(requests)
+		for _, req := range newWriteRequests(100, "A") {
+			requests <- req
+		}
+	}()
+
+	err := batch.NewWriter(client).Write(context.Background(), &batch.WriteRequestChanIterator{
+		Requests: requests,
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	assertWritten(t, client.written, 100, "A")
+	if e, a := 4, client.calls; e != a {
+		t.Errorf("expect %v calls, got %v", e, a)
+	}
+}
+
+func TestWriter_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := batch.NewWriter(&mockWriteClient{}).Write(ctx, &batch.WriteRequestChanIterator{
+		Requests: make(chan batch.WriteRequest),
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expect context canceled error, got %v", err)
+	}
+}
+
+func TestWriter_ContextCanceledMidStream(t *testing.T) {
+	client := &mockWriteClient{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	requests := make(chan batch.WriteRequest)
+	go func() {
+		for _, req := range newWriteRequests(15, "A") {
+			requests <- req
+		}
+		cancel()
+	}()
+
+	err := batch.NewWriter(client, func(w *batch.Writer) {
+		w.BatchSize = 10
+	}).Write(ctx, &batch.WriteRequestChanIterator{
+		Requests: requests,
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expect context canceled error, got %v", err)
+	}
+	var writeErr *batch.WriteError
+	if !errors.As(err, &writeErr) {
+		t.Fatalf("expect WriteError, got %v", err)
+	}
+	if e, a := 5, len(writeErr.Unprocessed); e != a {
+		t.Errorf("expect %v unprocessed requests, got %v", e, a)
+	}
+	if e, a := 10, len(client.written["A"]); e != a {
+		t.Errorf("expect %v items written, got %v", e, a)
+	}
+}
+
+// failingWriteIterator returns the requests, then stops with an error.
+type failingWriteIterator struct {
+	batch.WriteRequestsIterator
+	err error
+}
+
+func (iter *failingWriteIterator) Err() error {
+	return iter.err
+}
+
+func TestWriter_IteratorError(t *testing.T) {
+	client := &mockWriteClient{}
+	iterErr := fmt.Errorf("iterator error")
+
+	err := batch.NewWriter(client, func(w *batch.Writer) {
+		w.BatchSize = 10
+	}).Write(context.Background(), &failingWriteIterator{
+		WriteRequestsIterator: batch.WriteRequestsIterator{Requests: newWriteRequests(25, "A")},
+		err:                   iterErr,
+	})
+	if !errors.Is(err, iterErr) {
+		t.Fatalf("expect iterator error, got %v", err)
+	}
+	var writeErr *batch.WriteError
+	if !errors.As(err, &writeErr) {
+		t.Fatalf("expect WriteError, got %v", err)
+	}
+	if e, a := 5, len(writeErr.Unprocessed); e != a {
+		t.Errorf("expect %v unprocessed requests, got %v", e, a)
+	}
+	if e, a := 20, len(client.written["A"]); e != a {
+		t.Errorf("expect %v items written, got %v", e, a)
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Package mapper provides a Table type mapping a Go struct type to the items of
+an Amazon DynamoDB table, with typed Get, Put, Update, and Delete of items, and
+iterators of the pages of items returned by Query and Scan.
+
+Items are marshaled and unmarshaled with the attributevalue package, using the
+dynamodbav struct tag to name attributes. The fields of the table's hash key,
+and optional range key, are identified with the dynamodbmapper struct tag.
+
+  type Song struct {
+      Artist string `dynamodbav:"artist" dynamodbmapper:"hash"`
+      Title  string `dynamodbav:"title" dynamodbmapper:"range"`
+      Plays  int    `dynamodbav:"plays"`
+  }
+
+  table, err := mapper.NewTable(dynamodb.NewFromConfig(cfg), "Songs", Song{})
+  if err != nil {
+      return err
+  }
+
+  song := Song{Artist: "No One You Know", Title: "Call Me Today"}
+  if err := table.Get(context.TODO(), &song); err != nil {
+      return err
+  }
+
+Optimistic Locking
+
+An integer field tagged with dynamodbmapper:"version" is the version
+attribute of the item. Put, Update, and Delete only modify the item in the
+table if its version matches the version of the item being written, and Put
+and Update increment the version. A version of zero requires that the item
+does not exist in the table. A VersionConflictError is returned if the
+versions do not match, such as when the item was modified concurrently.
+
+  type Account struct {
+      ID      string `dynamodbav:"id" dynamodbmapper:"hash"`
+      Balance int64  `dynamodbav:"balance"`
+      Version int64  `dynamodbav:"version" dynamodbmapper:"version"`
+  }
+
+Query and Scan
+
+Query and Scan return an ItemIterator built on the QueryPaginator and
+ScanPaginator, unmarshaling each page of items into a slice of the struct
+type.
+
+  iter, err := table.Query(expression.Key("artist").Equal(expression.Value("No One You Know")))
+  if err != nil {
+      return err
+  }
+  for iter.HasMorePages() {
+      var songs []Song
+      if err := iter.NextPage(context.TODO(), &songs); err != nil {
+          return err
+      }
+      // use songs
+  }
+*/
+package mapper
@@ -0,0 +1,21 @@
+module github.com/aws/aws-sdk-go-v2/feature/dynamodb/mapper
+
+go 1.15
+
+require (
+	github.com/aws/aws-sdk-go-v2 v1.2.0
+	github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue v1.0.2
+	github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression v1.0.2
+	github.com/aws/aws-sdk-go-v2/service/dynamodb v1.1.1
+	github.com/aws/aws-sdk-go-v2/service/internal/accept-encoding v1.0.1 // indirect
+)
+
+replace (
+	github.com/aws/aws-sdk-go-v2 => ../../../
+	github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue => ../../../feature/dynamodb/attributevalue/
+	github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression => ../../../feature/dynamodb/expression/
+	github.com/aws/aws-sdk-go-v2/service/dynamodb => ../../../service/dynamodb/
+	github.com/aws/aws-sdk-go-v2/service/dynamodbstreams => ../../../service/dynamodbstreams/
+)
+
+replace github.com/aws/aws-sdk-go-v2/service/internal/accept-encoding => ../../../service/internal/accept-encoding/
@@ -0,0 +1,164 @@
+package mapper
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// QueryOptions are the options of a Table's Query.
+type QueryOptions struct {
+	// The name of the index to query, instead of the table.
+	IndexName string
+
+	// The condition the items matching the key condition are filtered by, if
+	// set.
+	Filter *expression.ConditionBuilder
+
+	// Determines if the items are returned in ascending order of the range
+	// key, or descending order if set to false.
+	ScanIndexForward *bool
+
+	// Determines if strongly consistent reads are used.
+	ConsistentRead *bool
+
+	// The maximum number of items to evaluate for each page.
+	Limit *int32
+}
+
+// ScanOptions are the options of a Table's Scan.
+type ScanOptions struct {
+	// The name of the index to scan, instead of the table.
+	IndexName string
+
+	// The condition the items are filtered by, if set.
+	Filter *expression.ConditionBuilder
+
+	// Determines if strongly consistent reads are used.
+	ConsistentRead *bool
+
+	// The maximum number of items to evaluate for each page.
+	Limit *int32
+
+	// The segment to scan, of the total number of segments, for a parallel
+	// scan.
+	Segment       *int32
+	TotalSegments *int32
+}
+
+// Query returns an iterator of the pages of items matching the key condition,
+// made with the QueryPaginator.
+func (t *Table) Query(keyCondition expression.KeyConditionBuilder, optFns ...func(*QueryOptions)) (*ItemIterator, error) {
+	var opts QueryOptions
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	builder := expression.NewBuilder().WithKeyCondition(keyCondition)
+	if opts.Filter != nil {
+		builder = builder.WithFilter(*opts.Filter)
+	}
+	expr, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	in := &dynamodb.QueryInput{
+		TableName:                 aws.String(t.name),
+		KeyConditionExpression:    expr.KeyCondition(),
+		FilterExpression:          expr.Filter(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ScanIndexForward:          opts.ScanIndexForward,
+		ConsistentRead:            opts.ConsistentRead,
+		Limit:                     opts.Limit,
+	}
+	if len(opts.IndexName) != 0 {
+		in.IndexName = aws.String(opts.IndexName)
+	}
+
+	p := dynamodb.NewQueryPaginator(t.client, in)
+	return &ItemIterator{
+		hasMorePages: p.HasMorePages,
+		nextPage: func(ctx context.Context, optFns ...func(*dynamodb.Options)) ([]map[string]types.AttributeValue, error) {
+			out, err := p.NextPage(ctx, optFns...)
+			if err != nil {
+				return nil, err
+			}
+			return out.Items, nil
+		},
+	}, nil
+}
+
+// Scan returns an iterator of the pages of items in the table, made with the
+// ScanPaginator.
+func (t *Table) Scan(optFns ...func(*ScanOptions)) (*ItemIterator, error) {
+	var opts ScanOptions
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	in := &dynamodb.ScanInput{
+		TableName:      aws.String(t.name),
+		ConsistentRead: opts.ConsistentRead,
+		Limit:          opts.Limit,
+		Segment:        opts.Segment,
+		TotalSegments:  opts.TotalSegments,
+	}
+	if len(opts.IndexName) != 0 {
+		in.IndexName = aws.String(opts.IndexName)
+	}
+	if opts.Filter != nil {
+		expr, err := expression.NewBuilder().WithFilter(*opts.Filter).Build()
+		if err != nil {
+			return nil, err
+		}
+		in.FilterExpression = expr.Filter()
+		in.ExpressionAttributeNames = expr.Names()
+		in.ExpressionAttributeValues = expr.Values()
+	}
+
+	p := dynamodb.NewScanPaginator(t.client, in)
+	return &ItemIterator{
+		hasMorePages: p.HasMorePages,
+		nextPage: func(ctx context.Context, optFns ...func(*dynamodb.Options)) ([]map[string]types.AttributeValue, error) {
+			out, err := p.NextPage(ctx, optFns...)
+			if err != nil {
+				return nil, err
+			}
+			return out.Items, nil
+		},
+	}, nil
+}
+
+// ItemIterator iterates over the pages of items returned by a Table's Query
+// or Scan.
+type ItemIterator struct {
+	hasMorePages func() bool
+	nextPage     func(context.Context, ...func(*dynamodb.Options)) ([]map[string]types.AttributeValue, error)
+}
+
+// HasMorePages returns a boolean indicating whether more pages are available.
+func (i *ItemIterator) HasMorePages() bool {
+	return i.hasMorePages()
+}
+
+// NextPage retrieves the next page of items, unmarshaling them into out with
+// attributevalue.UnmarshalListOfMaps. out must be a pointer to a slice of the
+// Table's struct type, or pointers to it. The page may be empty, even if there
+// are more pages.
+func (i *ItemIterator) NextPage(ctx context.Context, out interface{}, optFns ...func(*dynamodb.Options)) error {
+	items, err := i.nextPage(ctx, optFns...)
+	if err != nil {
+		return err
+	}
+	if err := attributevalue.UnmarshalListOfMaps(items, out); err != nil {
+		return fmt.Errorf("unable to unmarshal items, %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package mapper
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// tagKey is the struct tag key identifying the key and version attributes of
+// an item type.
+const tagKey = "dynamodbmapper"
+
+// schema describes the key and version attributes of an item type.
+type schema struct {
+	typ reflect.Type
+
+	hashKey  string
+	rangeKey string
+
+	// version is the optimistic locking version attribute, if any.
+	version *versionField
+}
+
+// versionField is the integer struct field holding the version of an item.
+type versionField struct {
+	name  string
+	index []int
+}
+
+// newSchema returns the schema of the struct type, from the struct tags of
+// its fields.
+func newSchema(t reflect.Type) (*schema, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("item type must be a struct, got %v", t)
+	}
+
+	s := &schema{typ: t}
+	if err := s.addFields(t, nil); err != nil {
+		return nil, err
+	}
+	if len(s.hashKey) == 0 {
+		return nil, fmt.Errorf("item type %v has no hash key field, tagged with %s:\"hash\"", t, tagKey)
+	}
+	return s, nil
+}
+
+// addFields adds the tagged fields of the struct type, and its embedded
+// structs, to the schema.
+func (s *schema) addFields(t reflect.Type, index []int) error {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if len(sf.PkgPath) != 0 && !sf.Anonymous {
+			// unexported
+			continue
+		}
+
+		name := sf.Name
+		if avTag := sf.Tag.Get("dynamodbav"); len(avTag) != 0 {
+			avName := strings.Split(avTag, ",")[0]
+			if avName == "-" {
+				continue
+			}
+			if len(avName) != 0 {
+				name = avName
+			}
+		}
+
+		fieldIndex := make([]int, len(index)+1)
+		copy(fieldIndex, index)
+		fieldIndex[len(index)] = i
+
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct && name == sf.Name {
+			if err := s.addFields(sf.Type, fieldIndex); err != nil {
+				return err
+			}
+			continue
+		}
+
+		switch opt := sf.Tag.Get(tagKey); opt {
+		case "":
+		case "hash":
+			if len(s.hashKey) != 0 {
+				return fmt.Errorf("item type %v has multiple hash key fields", s.typ)
+			}
+			s.hashKey = name
+		case "range":
+			if len(s.rangeKey) != 0 {
+				return fmt.Errorf("item type %v has multiple range key fields", s.typ)
+			}
+			s.rangeKey = name
+		case "version":
+			if s.version != nil {
+				return fmt.Errorf("item type %v has multiple version fields", s.typ)
+			}
+			switch sf.Type.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			default:
+				return fmt.Errorf("item type %v version field %s must be an integer, got %v", s.typ, sf.Name, sf.Type)
+			}
+			s.version = &versionField{name: name, index: fieldIndex}
+		default:
+			return fmt.Errorf("item type %v field %s has unknown %s tag %q", s.typ, sf.Name, tagKey, opt)
+		}
+	}
+
+	return nil
+}
+
+// isKey returns if the attribute is one of the item's key attributes.
+func (s *schema) isKey(name string) bool {
+	return name == s.hashKey || (len(s.rangeKey) != 0 && name == s.rangeKey)
+}
+
+// value returns the addressable struct value of the item, which must be a
+// pointer to the schema's type.
+func (s *schema) value(item interface{}) (reflect.Value, error) {
+	v := reflect.ValueOf(item)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return reflect.Value{}, fmt.Errorf("item must be a non-nil pointer to %v, got %T", s.typ, item)
+	}
+	v = v.Elem()
+	if v.Type() != s.typ {
+		return reflect.Value{}, fmt.Errorf("item must be a non-nil pointer to %v, got %T", s.typ, item)
+	}
+	return v, nil
+}
+
+// get returns the version of the item.
+func (f *versionField) get(v reflect.Value) int64 {
+	fv := v.FieldByIndex(f.index)
+	switch fv.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(fv.Uint())
+	default:
+		return fv.Int()
+	}
+}
+
+// set sets the version of the item.
+func (f *versionField) set(v reflect.Value, version int64) {
+	fv := v.FieldByIndex(f.index)
+	switch fv.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		fv.SetUint(uint64(version))
+	default:
+		fv.SetInt(version)
+	}
+}
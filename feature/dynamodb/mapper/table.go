@@ -0,0 +1,363 @@
+package mapper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// TableAPIClient is a DynamoDB client that implements the API operations used
+// by a Table.
+type TableAPIClient interface {
+	GetItem(context.Context, *dynamodb.GetItemInput, ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
+	PutItem(context.Context, *dynamodb.PutItemInput, ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
+	UpdateItem(context.Context, *dynamodb.UpdateItemInput, ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error)
+	DeleteItem(context.Context, *dynamodb.DeleteItemInput, ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)
+	Query(context.Context, *dynamodb.QueryInput, ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
+	Scan(context.Context, *dynamodb.ScanInput, ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error)
+}
+
+var _ TableAPIClient = (*dynamodb.Client)(nil)
+
+// ErrItemNotFound is returned by Get when the item does not exist in the
+// table.
+var ErrItemNotFound = errors.New("item not found")
+
+// VersionConflictError is returned when an item cannot be written or deleted
+// because the version of the item in the table does not match the version of
+// the item written.
+type VersionConflictError struct {
+	// The name of the table.
+	Table string
+
+	// The version the item in the table was expected to have. Zero if the
+	// item was expected to not exist.
+	Version int64
+
+	Err error
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("item in table %s does not match version %d, %v", e.Table, e.Version, e.Err)
+}
+
+func (e *VersionConflictError) Unwrap() error {
+	return e.Err
+}
+
+// Table maps a Go struct type to the items of a DynamoDB table. The Table's
+// methods read and write items as values of that type, marshaled with the
+// attributevalue package.
+//
+// It is safe to use a Table concurrently across goroutines.
+type Table struct {
+	name   string
+	client TableAPIClient
+	schema *schema
+}
+
+// NewTable returns a Table mapping items of the table to the struct type of
+// item. item is only used for its type, and may be a zero value, or pointer to
+// it.
+//
+// The struct type's fields are mapped to attributes as they are by
+// attributevalue.MarshalMap, using the dynamodbav struct tag. The fields of
+// the table's key attributes, and the optional version attribute, are
+// identified by the dynamodbmapper struct tag.
+//
+//   type Song struct {
+//       Artist  string `dynamodbav:"artist" dynamodbmapper:"hash"`
+//       Title   string `dynamodbav:"title" dynamodbmapper:"range"`
+//       Plays   int    `dynamodbav:"plays"`
+//       Version int64  `dynamodbav:"version" dynamodbmapper:"version"`
+//   }
+//
+// An error is returned if the struct type has no hash key field.
+func NewTable(client TableAPIClient, name string, item interface{}) (*Table, error) {
+	s, err := newSchema(reflect.TypeOf(item))
+	if err != nil {
+		return nil, err
+	}
+	return &Table{name: name, client: client, schema: s}, nil
+}
+
+// Name returns the name of the table.
+func (t *Table) Name() string {
+	return t.name
+}
+
+// Get reads the item with the key of item from the table, unmarshaling it
+// into item. item must be a pointer to the Table's struct type. Returns
+// ErrItemNotFound if the item does not exist.
+func (t *Table) Get(ctx context.Context, item interface{}, optFns ...func(*dynamodb.GetItemInput)) error {
+	if _, err := t.schema.value(item); err != nil {
+		return err
+	}
+	key, err := t.key(item)
+	if err != nil {
+		return err
+	}
+
+	in := &dynamodb.GetItemInput{
+		TableName: aws.String(t.name),
+		Key:       key,
+	}
+	for _, fn := range optFns {
+		fn(in)
+	}
+
+	out, err := t.client.GetItem(ctx, in)
+	if err != nil {
+		return err
+	}
+	if len(out.Item) == 0 {
+		return ErrItemNotFound
+	}
+
+	if err := attributevalue.UnmarshalMap(out.Item, item); err != nil {
+		return fmt.Errorf("unable to unmarshal item, %w", err)
+	}
+	return nil
+}
+
+// Put writes item to the table, replacing the existing item with the same
+// key. item must be a pointer to the Table's struct type.
+//
+// If the struct type has a version field, the item is only written if the
+// version of the item in the table matches the version of item, or if the item
+// does not exist in the table when item's version is zero. The version is
+// incremented by one when written, and updated in item. A
+// VersionConflictError is returned if the versions do not match.
+func (t *Table) Put(ctx context.Context, item interface{}, optFns ...func(*dynamodb.PutItemInput)) error {
+	v, err := t.schema.value(item)
+	if err != nil {
+		return err
+	}
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return fmt.Errorf("unable to marshal item, %w", err)
+	}
+
+	in := &dynamodb.PutItemInput{
+		TableName: aws.String(t.name),
+		Item:      av,
+	}
+
+	var version int64
+	if f := t.schema.version; f != nil {
+		version = f.get(v)
+		av[f.name] = versionValue(version + 1)
+
+		expr, err := expression.NewBuilder().WithCondition(t.versionCondition(version)).Build()
+		if err != nil {
+			return err
+		}
+		in.ConditionExpression = expr.Condition()
+		in.ExpressionAttributeNames = expr.Names()
+		in.ExpressionAttributeValues = expr.Values()
+	}
+
+	for _, fn := range optFns {
+		fn(in)
+	}
+
+	if _, err := t.client.PutItem(ctx, in); err != nil {
+		return t.versionConflict(err, version)
+	}
+
+	if f := t.schema.version; f != nil {
+		f.set(v, version+1)
+	}
+	return nil
+}
+
+// Update updates the item in the table with the key of item, setting the
+// attributes of item. Unlike Put, attributes of the item in the table that
+// are omitted when item is marshaled are not removed. The item is created if
+// it does not exist. item must be a pointer to the Table's struct type, and
+// is updated with all the attributes of the updated item.
+//
+// If the struct type has a version field, the item is only updated if the
+// version of the item in the table matches the version of item, or if the item
+// does not exist in the table when item's version is zero. The version is
+// incremented by one when updated. A VersionConflictError is returned if the
+// versions do not match.
+func (t *Table) Update(ctx context.Context, item interface{}, optFns ...func(*dynamodb.UpdateItemInput)) error {
+	v, err := t.schema.value(item)
+	if err != nil {
+		return err
+	}
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return fmt.Errorf("unable to marshal item, %w", err)
+	}
+	key, err := t.keyOf(av)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(av))
+	for name := range av {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var update expression.UpdateBuilder
+	var hasUpdate bool
+	for _, name := range names {
+		if t.schema.isKey(name) || (t.schema.version != nil && name == t.schema.version.name) {
+			continue
+		}
+		update = update.Set(expression.Name(name), expression.Value(av[name]))
+		hasUpdate = true
+	}
+
+	builder := expression.NewBuilder()
+	var version int64
+	if f := t.schema.version; f != nil {
+		version = f.get(v)
+		update = update.Set(expression.Name(f.name), expression.Value(versionValue(version+1)))
+		hasUpdate = true
+		builder = builder.WithCondition(t.versionCondition(version))
+	}
+
+	in := &dynamodb.UpdateItemInput{
+		TableName:    aws.String(t.name),
+		Key:          key,
+		ReturnValues: types.ReturnValueAllNew,
+	}
+	if hasUpdate {
+		expr, err := builder.WithUpdate(update).Build()
+		if err != nil {
+			return err
+		}
+		in.UpdateExpression = expr.Update()
+		in.ConditionExpression = expr.Condition()
+		in.ExpressionAttributeNames = expr.Names()
+		in.ExpressionAttributeValues = expr.Values()
+	}
+
+	for _, fn := range optFns {
+		fn(in)
+	}
+
+	out, err := t.client.UpdateItem(ctx, in)
+	if err != nil {
+		return t.versionConflict(err, version)
+	}
+
+	if len(out.Attributes) != 0 {
+		if err := attributevalue.UnmarshalMap(out.Attributes, item); err != nil {
+			return fmt.Errorf("unable to unmarshal item, %w", err)
+		}
+	}
+	return nil
+}
+
+// Delete deletes the item with the key of item from the table. item must be a
+// pointer to the Table's struct type.
+//
+// If the struct type has a version field, and the version of item is not
+// zero, the item is only deleted if the version of the item in the table
+// matches the version of item. A VersionConflictError is returned if the
+// versions do not match.
+func (t *Table) Delete(ctx context.Context, item interface{}, optFns ...func(*dynamodb.DeleteItemInput)) error {
+	v, err := t.schema.value(item)
+	if err != nil {
+		return err
+	}
+	key, err := t.key(item)
+	if err != nil {
+		return err
+	}
+
+	in := &dynamodb.DeleteItemInput{
+		TableName: aws.String(t.name),
+		Key:       key,
+	}
+
+	var version int64
+	if f := t.schema.version; f != nil {
+		if version = f.get(v); version != 0 {
+			expr, err := expression.NewBuilder().WithCondition(t.versionCondition(version)).Build()
+			if err != nil {
+				return err
+			}
+			in.ConditionExpression = expr.Condition()
+			in.ExpressionAttributeNames = expr.Names()
+			in.ExpressionAttributeValues = expr.Values()
+		}
+	}
+
+	for _, fn := range optFns {
+		fn(in)
+	}
+
+	if _, err := t.client.DeleteItem(ctx, in); err != nil {
+		return t.versionConflict(err, version)
+	}
+	return nil
+}
+
+// key returns the key attributes of the item.
+func (t *Table) key(item interface{}) (map[string]types.AttributeValue, error) {
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal item, %w", err)
+	}
+	return t.keyOf(av)
+}
+
+// keyOf returns the key attributes of the marshaled item.
+func (t *Table) keyOf(av map[string]types.AttributeValue) (map[string]types.AttributeValue, error) {
+	key := map[string]types.AttributeValue{}
+	for _, name := range []string{t.schema.hashKey, t.schema.rangeKey} {
+		if len(name) == 0 {
+			continue
+		}
+		v, ok := av[name]
+		if !ok {
+			return nil, fmt.Errorf("item has no %s key attribute", name)
+		}
+		if _, ok := v.(*types.AttributeValueMemberNULL); ok {
+			return nil, fmt.Errorf("item has no %s key attribute", name)
+		}
+		key[name] = v
+	}
+	return key, nil
+}
+
+// versionCondition returns the condition that the item in the table has the
+// version, or does not exist for version zero.
+func (t *Table) versionCondition(version int64) expression.ConditionBuilder {
+	if version == 0 {
+		return expression.AttributeNotExists(expression.Name(t.schema.hashKey))
+	}
+	return expression.Name(t.schema.version.name).Equal(expression.Value(versionValue(version)))
+}
+
+// versionConflict returns a VersionConflictError for the error if the
+// request failed because of the version condition, or the error otherwise.
+func (t *Table) versionConflict(err error, version int64) error {
+	if t.schema.version == nil {
+		return err
+	}
+	var condErr *types.ConditionalCheckFailedException
+	if errors.As(err, &condErr) {
+		return &VersionConflictError{Table: t.name, Version: version, Err: err}
+	}
+	return err
+}
+
+func versionValue(version int64) types.AttributeValue {
+	return &types.AttributeValueMemberN{Value: strconv.FormatInt(version, 10)}
+}
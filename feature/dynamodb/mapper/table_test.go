@@ -0,0 +1,544 @@
+package mapper_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/mapper"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type song struct {
+	Artist string `dynamodbav:"artist" dynamodbmapper:"hash"`
+	Title  string `dynamodbav:"title" dynamodbmapper:"range"`
+	Plays  int    `dynamodbav:"plays,omitempty"`
+	Ignore string `dynamodbav:"-"`
+}
+
+type account struct {
+	versioned
+	ID      string `dynamodbmapper:"hash"`
+	Balance int64
+}
+
+type versioned struct {
+	Version uint32 `dynamodbav:"ver" dynamodbmapper:"version"`
+}
+
+// mockClient is a TableAPIClient recording the requests made, returning the
+// responses of the functions set.
+type mockClient struct {
+	GetItemFn    func(*dynamodb.GetItemInput) (*dynamodb.GetItemOutput, error)
+	PutItemFn    func(*dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error)
+	UpdateItemFn func(*dynamodb.UpdateItemInput) (*dynamodb.UpdateItemOutput, error)
+	DeleteItemFn func(*dynamodb.DeleteItemInput) (*dynamodb.DeleteItemOutput, error)
+	QueryFn      func(*dynamodb.QueryInput) (*dynamodb.QueryOutput, error)
+	ScanFn       func(*dynamodb.ScanInput) (*dynamodb.ScanOutput, error)
+
+	Requests []interface{}
+}
+
+func (c *mockClient) GetItem(_ context.Context, in *dynamodb.GetItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	c.Requests = append(c.Requests, in)
+	return c.GetItemFn(in)
+}
+
+func (c *mockClient) PutItem(_ context.Context, in *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	c.Requests = append(c.Requests, in)
+	if c.PutItemFn == nil {
+		return &dynamodb.PutItemOutput{}, nil
+	}
+	return c.PutItemFn(in)
+}
+
+func (c *mockClient) UpdateItem(_ context.Context, in *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	c.Requests = append(c.Requests, in)
+	return c.UpdateItemFn(in)
+}
+
+func (c *mockClient) DeleteItem(_ context.Context, in *dynamodb.DeleteItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	c.Requests = append(c.Requests, in)
+	if c.DeleteItemFn == nil {
+		return &dynamodb.DeleteItemOutput{}, nil
+	}
+	return c.DeleteItemFn(in)
+}
+
+func (c *mockClient) Query(_ context.Context, in *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	c.Requests = append(c.Requests, in)
+	return c.QueryFn(in)
+}
+
+func (c *mockClient) Scan(_ context.Context, in *dynamodb.ScanInput, _ ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+	c.Requests = append(c.Requests, in)
+	return c.ScanFn(in)
+}
+
+func str(v string) types.AttributeValue {
+	return &types.AttributeValueMemberS{Value: v}
+}
+
+func num(v string) types.AttributeValue {
+	return &types.AttributeValueMemberN{Value: v}
+}
+
+func TestNewTable(t *testing.T) {
+	cases := map[string]struct {
+		item      interface{}
+		expectErr string
+	}{
+		"hash and range": {
+			item: song{},
+		},
+		"pointer": {
+			item: &account{},
+		},
+		"not struct": {
+			item:      "abc",
+			expectErr: "must be a struct",
+		},
+		"no hash key": {
+			item: struct {
+				ID string
+			}{},
+			expectErr: "no hash key",
+		},
+		"multiple hash keys": {
+			item: struct {
+				A string `dynamodbmapper:"hash"`
+				B string `dynamodbmapper:"hash"`
+			}{},
+			expectErr: "multiple hash key",
+		},
+		"non integer version": {
+			item: struct {
+				ID      string `dynamodbmapper:"hash"`
+				Version string `dynamodbmapper:"version"`
+			}{},
+			expectErr: "must be an integer",
+		},
+		"unknown tag": {
+			item: struct {
+				ID string `dynamodbmapper:"partition"`
+			}{},
+			expectErr: "unknown dynamodbmapper tag",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			table, err := mapper.NewTable(&mockClient{}, "Table", c.item)
+			if len(c.expectErr) != 0 {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				if e, a := c.expectErr, err.Error(); !strings.Contains(a, e) {
+					t.Fatalf("expect error to contain %v, got %v", e, a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := "Table", table.Name(); e != a {
+				t.Errorf("expect %v name, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestTable_Get(t *testing.T) {
+	client := &mockClient{
+		GetItemFn: func(in *dynamodb.GetItemInput) (*dynamodb.GetItemOutput, error) {
+			return &dynamodb.GetItemOutput{
+				Item: map[string]types.AttributeValue{
+					"artist": str("artist"),
+					"title":  str("title"),
+					"plays":  num("10"),
+				},
+			}, nil
+		},
+	}
+	table, err := mapper.NewTable(client, "Songs", song{})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	item := song{Artist: "artist", Title: "title"}
+	err = table.Get(context.Background(), &item, func(in *dynamodb.GetItemInput) {
+		in.ConsistentRead = aws.Bool(true)
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := 10, item.Plays; e != a {
+		t.Errorf("expect %v plays, got %v", e, a)
+	}
+
+	in := client.Requests[0].(*dynamodb.GetItemInput)
+	expectKey := map[string]types.AttributeValue{
+		"artist": str("artist"),
+		"title":  str("title"),
+	}
+	if e, a := expectKey, in.Key; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v key, got %v", e, a)
+	}
+	if e, a := "Songs", aws.ToString(in.TableName); e != a {
+		t.Errorf("expect %v table, got %v", e, a)
+	}
+	if !aws.ToBool(in.ConsistentRead) {
+		t.Errorf("expect consistent read option to be applied")
+	}
+}
+
+func TestTable_GetNotFound(t *testing.T) {
+	client := &mockClient{
+		GetItemFn: func(in *dynamodb.GetItemInput) (*dynamodb.GetItemOutput, error) {
+			return &dynamodb.GetItemOutput{}, nil
+		},
+	}
+	table, _ := mapper.NewTable(client, "Songs", song{})
+
+	err := table.Get(context.Background(), &song{Artist: "artist", Title: "title"})
+	if !errors.Is(err, mapper.ErrItemNotFound) {
+		t.Fatalf("expect ErrItemNotFound, got %v", err)
+	}
+}
+
+func TestTable_InvalidItem(t *testing.T) {
+	table, _ := mapper.NewTable(&mockClient{}, "Songs", song{})
+
+	if err := table.Put(context.Background(), song{}); err == nil {
+		t.Errorf("expect error for non-pointer item")
+	}
+	if err := table.Put(context.Background(), &account{ID: "id"}); err == nil {
+		t.Errorf("expect error for item of other type")
+	}
+}
+
+func TestTable_Put(t *testing.T) {
+	client := &mockClient{}
+	table, _ := mapper.NewTable(client, "Songs", song{})
+
+	item := song{Artist: "artist", Title: "title", Plays: 1, Ignore: "ignored"}
+	if err := table.Put(context.Background(), &item); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	in := client.Requests[0].(*dynamodb.PutItemInput)
+	expectItem := map[string]types.AttributeValue{
+		"artist": str("artist"),
+		"title":  str("title"),
+		"plays":  num("1"),
+	}
+	if e, a := expectItem, in.Item; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v item, got %v", e, a)
+	}
+	if in.ConditionExpression != nil {
+		t.Errorf("expect no condition, got %v", aws.ToString(in.ConditionExpression))
+	}
+}
+
+func TestTable_PutVersion(t *testing.T) {
+	cases := map[string]struct {
+		version         uint32
+		expectCondition string
+		expectValues    map[string]types.AttributeValue
+	}{
+		"new item": {
+			version:         0,
+			expectCondition: "attribute_not_exists (#0)",
+		},
+		"existing item": {
+			version:         3,
+			expectCondition: "#0 = :0",
+			expectValues: map[string]types.AttributeValue{
+				":0": num("3"),
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := &mockClient{}
+			table, _ := mapper.NewTable(client, "Accounts", account{})
+
+			item := account{ID: "id", Balance: 100}
+			item.Version = c.version
+			if err := table.Put(context.Background(), &item); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.version+1, item.Version; e != a {
+				t.Errorf("expect item version %v, got %v", e, a)
+			}
+
+			in := client.Requests[0].(*dynamodb.PutItemInput)
+			if e, a := c.expectCondition, aws.ToString(in.ConditionExpression); e != a {
+				t.Errorf("expect %v condition, got %v", e, a)
+			}
+			if e, a := c.expectValues, in.ExpressionAttributeValues; !reflect.DeepEqual(e, a) {
+				t.Errorf("expect %v values, got %v", e, a)
+			}
+			if e, a := num(fmt.Sprint(c.version+1)), in.Item["ver"]; !reflect.DeepEqual(e, a) {
+				t.Errorf("expect %v version attribute, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestTable_PutVersionConflict(t *testing.T) {
+	client := &mockClient{
+		PutItemFn: func(in *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error) {
+			return nil, &types.ConditionalCheckFailedException{Message: aws.String("conditional check failed")}
+		},
+	}
+	table, _ := mapper.NewTable(client, "Accounts", account{})
+
+	item := account{ID: "id"}
+	item.Version = 2
+	err := table.Put(context.Background(), &item)
+
+	var conflictErr *mapper.VersionConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Fatalf("expect VersionConflictError, got %v", err)
+	}
+	if e, a := int64(2), conflictErr.Version; e != a {
+		t.Errorf("expect %v version, got %v", e, a)
+	}
+	if e, a := uint32(2), item.Version; e != a {
+		t.Errorf("expect item version to be unchanged %v, got %v", e, a)
+	}
+}
+
+func TestTable_Update(t *testing.T) {
+	client := &mockClient{
+		UpdateItemFn: func(in *dynamodb.UpdateItemInput) (*dynamodb.UpdateItemOutput, error) {
+			return &dynamodb.UpdateItemOutput{
+				Attributes: map[string]types.AttributeValue{
+					"ID":      str("id"),
+					"Balance": num("50"),
+					"ver":     num("5"),
+				},
+			}, nil
+		},
+	}
+	table, _ := mapper.NewTable(client, "Accounts", account{})
+
+	item := account{ID: "id", Balance: 50}
+	item.Version = 4
+	if err := table.Update(context.Background(), &item); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := uint32(5), item.Version; e != a {
+		t.Errorf("expect item version %v, got %v", e, a)
+	}
+
+	in := client.Requests[0].(*dynamodb.UpdateItemInput)
+	if e, a := (map[string]types.AttributeValue{"ID": str("id")}), in.Key; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v key, got %v", e, a)
+	}
+	if e, a := "SET #1 = :1, #0 = :2\n", aws.ToString(in.UpdateExpression); e != a {
+		t.Errorf("expect %q update, got %q", e, a)
+	}
+	if e, a := "#0 = :0", aws.ToString(in.ConditionExpression); e != a {
+		t.Errorf("expect %v condition, got %v", e, a)
+	}
+	expectNames := map[string]string{"#0": "ver", "#1": "Balance"}
+	if e, a := expectNames, in.ExpressionAttributeNames; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v names, got %v", e, a)
+	}
+	expectValues := map[string]types.AttributeValue{
+		":0": num("4"),
+		":1": num("50"),
+		":2": num("5"),
+	}
+	if e, a := expectValues, in.ExpressionAttributeValues; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v values, got %v", e, a)
+	}
+	if e, a := types.ReturnValueAllNew, in.ReturnValues; e != a {
+		t.Errorf("expect %v return values, got %v", e, a)
+	}
+}
+
+func TestTable_Delete(t *testing.T) {
+	cases := map[string]struct {
+		version         uint32
+		expectCondition string
+	}{
+		"unversioned": {
+			version: 0,
+		},
+		"versioned": {
+			version:         7,
+			expectCondition: "#0 = :0",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := &mockClient{}
+			table, _ := mapper.NewTable(client, "Accounts", account{})
+
+			item := account{ID: "id"}
+			item.Version = c.version
+			if err := table.Delete(context.Background(), &item); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			in := client.Requests[0].(*dynamodb.DeleteItemInput)
+			if e, a := (map[string]types.AttributeValue{"ID": str("id")}), in.Key; !reflect.DeepEqual(e, a) {
+				t.Errorf("expect %v key, got %v", e, a)
+			}
+			if e, a := c.expectCondition, aws.ToString(in.ConditionExpression); e != a {
+				t.Errorf("expect %v condition, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestTable_MissingKey(t *testing.T) {
+	table, _ := mapper.NewTable(&mockClient{}, "Songs", struct {
+		ID *string `dynamodbmapper:"hash"`
+	}{})
+
+	err := table.Delete(context.Background(), &struct {
+		ID *string `dynamodbmapper:"hash"`
+	}{})
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+}
+
+func TestTable_Query(t *testing.T) {
+	pages := []*dynamodb.QueryOutput{
+		{
+			Items: []map[string]types.AttributeValue{
+				{"artist": str("artist"), "title": str("a")},
+				{"artist": str("artist"), "title": str("b")},
+			},
+			LastEvaluatedKey: map[string]types.AttributeValue{"artist": str("artist"), "title": str("b")},
+		},
+		{
+			Items: []map[string]types.AttributeValue{
+				{"artist": str("artist"), "title": str("c"), "plays": num("3")},
+			},
+		},
+	}
+	client := &mockClient{
+		QueryFn: func(in *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+			page := pages[0]
+			pages = pages[1:]
+			return page, nil
+		},
+	}
+	table, _ := mapper.NewTable(client, "Songs", song{})
+
+	filter := expression.Name("plays").GreaterThan(expression.Value(0))
+	iter, err := table.Query(expression.Key("artist").Equal(expression.Value("artist")), func(o *mapper.QueryOptions) {
+		o.IndexName = "Index"
+		o.Filter = &filter
+		o.ScanIndexForward = aws.Bool(false)
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	var titles []string
+	for iter.HasMorePages() {
+		var songs []*song
+		if err := iter.NextPage(context.Background(), &songs); err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		for _, s := range songs {
+			titles = append(titles, s.Title)
+		}
+	}
+	if e, a := []string{"a", "b", "c"}, titles; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v titles, got %v", e, a)
+	}
+
+	if e, a := 2, len(client.Requests); e != a {
+		t.Fatalf("expect %v requests, got %v", e, a)
+	}
+	in := client.Requests[0].(*dynamodb.QueryInput)
+	if e, a := "#1 = :1", aws.ToString(in.KeyConditionExpression); e != a {
+		t.Errorf("expect %v key condition, got %v", e, a)
+	}
+	if e, a := "#0 > :0", aws.ToString(in.FilterExpression); e != a {
+		t.Errorf("expect %v filter, got %v", e, a)
+	}
+	if e, a := "Index", aws.ToString(in.IndexName); e != a {
+		t.Errorf("expect %v index, got %v", e, a)
+	}
+	if e, a := false, aws.ToBool(in.ScanIndexForward); e != a {
+		t.Errorf("expect %v scan index forward, got %v", e, a)
+	}
+	if in := client.Requests[1].(*dynamodb.QueryInput); in.ExclusiveStartKey == nil {
+		t.Errorf("expect second page to start from last evaluated key")
+	}
+}
+
+func TestTable_Scan(t *testing.T) {
+	client := &mockClient{
+		ScanFn: func(in *dynamodb.ScanInput) (*dynamodb.ScanOutput, error) {
+			return &dynamodb.ScanOutput{
+				Items: []map[string]types.AttributeValue{
+					{"artist": str("artist"), "title": str("a")},
+				},
+			}, nil
+		},
+	}
+	table, _ := mapper.NewTable(client, "Songs", song{})
+
+	iter, err := table.Scan(func(o *mapper.ScanOptions) {
+		o.Segment = aws.Int32(1)
+		o.TotalSegments = aws.Int32(4)
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	var songs []song
+	for iter.HasMorePages() {
+		if err := iter.NextPage(context.Background(), &songs); err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+	}
+	if e, a := []song{{Artist: "artist", Title: "a"}}, songs; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v songs, got %v", e, a)
+	}
+
+	in := client.Requests[0].(*dynamodb.ScanInput)
+	if e, a := int32(4), aws.ToInt32(in.TotalSegments); e != a {
+		t.Errorf("expect %v total segments, got %v", e, a)
+	}
+	if in.FilterExpression != nil {
+		t.Errorf("expect no filter, got %v", aws.ToString(in.FilterExpression))
+	}
+}
+
+func TestTable_QueryPageError(t *testing.T) {
+	client := &mockClient{
+		QueryFn: func(in *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+			return nil, fmt.Errorf("mock error")
+		},
+	}
+	table, _ := mapper.NewTable(client, "Songs", song{})
+
+	iter, err := table.Query(expression.Key("artist").Equal(expression.Value("artist")))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	var songs []song
+	if err := iter.NextPage(context.Background(), &songs); err == nil {
+		t.Fatalf("expect error, got none")
+	}
+}
@@ -0,0 +1,315 @@
+package partiql
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// SelectBuilder builds a PartiQL SELECT statement reading items from a table
+// or index. Create a SelectBuilder with Select.
+//
+// Example:
+//
+//     stmt, err := partiql.Select("Music", "Artist", "SongTitle").
+//         Where(`"Artist" = ?`, "Acme Band").
+//         Where(`"Year" >= ?`, 2000).
+//         Build()
+//
+//     // SELECT "Artist", "SongTitle" FROM "Music" WHERE ("Artist" = ?) AND ("Year" >= ?)
+type SelectBuilder struct {
+	table string
+	index string
+	names []string
+	conds []condition
+}
+
+// Select returns a SelectBuilder reading the attributes with the names from
+// the items of the table. All attributes are read if no names are given.
+// Names may be document paths, such as "a.b[1]".
+func Select(table string, names ...string) SelectBuilder {
+	return SelectBuilder{
+		table: table,
+		names: names,
+	}
+}
+
+// Index returns a copy of the SelectBuilder reading from the index of the
+// table.
+func (b SelectBuilder) Index(name string) SelectBuilder {
+	b.index = name
+	return b
+}
+
+// Where returns a copy of the SelectBuilder with the condition added to its
+// WHERE clause. The condition is a PartiQL expression, with the values of its
+// positional ? parameters marshaled with attributevalue.Marshal. Multiple
+// conditions are joined with AND.
+func (b SelectBuilder) Where(condition string, params ...interface{}) SelectBuilder {
+	b.conds = appendCondition(b.conds, condition, params)
+	return b
+}
+
+// Build returns the SELECT statement, or an error if a name or parameter is
+// invalid.
+func (b SelectBuilder) Build() (Statement, error) {
+	var sb statementBuilder
+
+	sb.WriteString("SELECT ")
+	if len(b.names) == 0 {
+		sb.WriteString("*")
+	}
+	for i, name := range b.names {
+		if i != 0 {
+			sb.WriteString(", ")
+		}
+		if err := sb.writeName(name); err != nil {
+			return Statement{}, err
+		}
+	}
+
+	sb.WriteString(" FROM ")
+	if err := sb.writeTable(b.table, b.index); err != nil {
+		return Statement{}, err
+	}
+	if err := sb.writeConditions(b.conds); err != nil {
+		return Statement{}, err
+	}
+
+	return sb.statement(), nil
+}
+
+// InsertBuilder builds a PartiQL INSERT statement putting a new item in a
+// table. Create an InsertBuilder with Insert.
+//
+// Example:
+//
+//     stmt, err := partiql.Insert("Music", Song{Artist: "Acme Band", SongTitle: "Happy Day"}).Build()
+//
+//     // INSERT INTO "Music" VALUE {'Artist': ?, 'SongTitle': ?}
+type InsertBuilder struct {
+	table string
+	item  interface{}
+}
+
+// Insert returns an InsertBuilder inserting the item into the table. The item
+// is marshaled with attributevalue.Marshal, and must marshal to a map.
+func Insert(table string, item interface{}) InsertBuilder {
+	return InsertBuilder{
+		table: table,
+		item:  item,
+	}
+}
+
+// Build returns the INSERT statement, with a parameter for each attribute of
+// the item, or an error if the item cannot be marshaled.
+func (b InsertBuilder) Build() (Statement, error) {
+	av, err := attributevalue.Marshal(b.item)
+	if err != nil {
+		return Statement{}, fmt.Errorf("unable to marshal item, %w", err)
+	}
+	m, ok := av.(*types.AttributeValueMemberM)
+	if !ok {
+		return Statement{}, fmt.Errorf("item must marshal to a map, got %T", av)
+	}
+	item := m.Value
+	if len(item) == 0 {
+		return Statement{}, fmt.Errorf("item must have attributes")
+	}
+
+	var sb statementBuilder
+
+	sb.WriteString("INSERT INTO ")
+	if err := sb.writeTable(b.table, ""); err != nil {
+		return Statement{}, err
+	}
+
+	names := make([]string, 0, len(item))
+	for name := range item {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sb.WriteString(" VALUE {")
+	for i, name := range names {
+		if i != 0 {
+			sb.WriteString(", ")
+		}
+		sb.writeString(name)
+		sb.WriteString(": ")
+		if err := sb.writeParam(item[name]); err != nil {
+			return Statement{}, err
+		}
+	}
+	sb.WriteString("}")
+
+	return sb.statement(), nil
+}
+
+// UpdateBuilder builds a PartiQL UPDATE statement modifying the attributes of
+// an item. Create an UpdateBuilder with Update.
+//
+// Example:
+//
+//     stmt, err := partiql.Update("Music").
+//         Set("AwardsWon", 1).
+//         Remove("Pending").
+//         Where(`"Artist" = ? AND "SongTitle" = ?`, "Acme Band", "Happy Day").
+//         Build()
+//
+//     // UPDATE "Music" SET "AwardsWon" = ? REMOVE "Pending" WHERE "Artist" = ? AND "SongTitle" = ?
+type UpdateBuilder struct {
+	table   string
+	actions []updateAction
+	conds   []condition
+}
+
+// updateAction is a SET or REMOVE clause of an UPDATE statement.
+type updateAction struct {
+	name   string
+	value  interface{}
+	remove bool
+}
+
+// Update returns an UpdateBuilder modifying an item of the table.
+func Update(table string) UpdateBuilder {
+	return UpdateBuilder{
+		table: table,
+	}
+}
+
+// Set returns a copy of the UpdateBuilder setting the attribute with the name
+// to the value, marshaled with attributevalue.Marshal.
+func (b UpdateBuilder) Set(name string, value interface{}) UpdateBuilder {
+	b.actions = append(b.actions[:len(b.actions):len(b.actions)], updateAction{
+		name:  name,
+		value: value,
+	})
+	return b
+}
+
+// Remove returns a copy of the UpdateBuilder removing the attribute with the
+// name.
+func (b UpdateBuilder) Remove(name string) UpdateBuilder {
+	b.actions = append(b.actions[:len(b.actions):len(b.actions)], updateAction{
+		name:   name,
+		remove: true,
+	})
+	return b
+}
+
+// Where returns a copy of the UpdateBuilder with the condition added to its
+// WHERE clause. The condition must identify the item to update by its key. The
+// condition is a PartiQL expression, with the values of its positional ?
+// parameters marshaled with attributevalue.Marshal. Multiple conditions are
+// joined with AND.
+func (b UpdateBuilder) Where(condition string, params ...interface{}) UpdateBuilder {
+	b.conds = appendCondition(b.conds, condition, params)
+	return b
+}
+
+// Build returns the UPDATE statement, or an error if the UpdateBuilder has no
+// SET or REMOVE clauses, or WHERE conditions, or a name or parameter is
+// invalid.
+func (b UpdateBuilder) Build() (Statement, error) {
+	if len(b.actions) == 0 {
+		return Statement{}, fmt.Errorf("update must set or remove attributes")
+	}
+	if len(b.conds) == 0 {
+		return Statement{}, fmt.Errorf("update must have a where condition")
+	}
+
+	var sb statementBuilder
+
+	sb.WriteString("UPDATE ")
+	if err := sb.writeTable(b.table, ""); err != nil {
+		return Statement{}, err
+	}
+
+	for _, action := range b.actions {
+		if action.remove {
+			sb.WriteString(" REMOVE ")
+		} else {
+			sb.WriteString(" SET ")
+		}
+		if err := sb.writeName(action.name); err != nil {
+			return Statement{}, err
+		}
+		if action.remove {
+			continue
+		}
+		sb.WriteString(" = ")
+		if err := sb.writeParam(action.value); err != nil {
+			return Statement{}, err
+		}
+	}
+
+	if err := sb.writeConditions(b.conds); err != nil {
+		return Statement{}, err
+	}
+
+	return sb.statement(), nil
+}
+
+// DeleteBuilder builds a PartiQL DELETE statement deleting an item from a
+// table. Create a DeleteBuilder with Delete.
+//
+// Example:
+//
+//     stmt, err := partiql.Delete("Music").
+//         Where(`"Artist" = ? AND "SongTitle" = ?`, "Acme Band", "Happy Day").
+//         Build()
+//
+//     // DELETE FROM "Music" WHERE "Artist" = ? AND "SongTitle" = ?
+type DeleteBuilder struct {
+	table string
+	conds []condition
+}
+
+// Delete returns a DeleteBuilder deleting an item from the table.
+func Delete(table string) DeleteBuilder {
+	return DeleteBuilder{
+		table: table,
+	}
+}
+
+// Where returns a copy of the DeleteBuilder with the condition added to its
+// WHERE clause. The condition must identify the item to delete by its key. The
+// condition is a PartiQL expression, with the values of its positional ?
+// parameters marshaled with attributevalue.Marshal. Multiple conditions are
+// joined with AND.
+func (b DeleteBuilder) Where(condition string, params ...interface{}) DeleteBuilder {
+	b.conds = appendCondition(b.conds, condition, params)
+	return b
+}
+
+// Build returns the DELETE statement, or an error if the DeleteBuilder has no
+// WHERE conditions, or a parameter is invalid.
+func (b DeleteBuilder) Build() (Statement, error) {
+	if len(b.conds) == 0 {
+		return Statement{}, fmt.Errorf("delete must have a where condition")
+	}
+
+	var sb statementBuilder
+
+	sb.WriteString("DELETE FROM ")
+	if err := sb.writeTable(b.table, ""); err != nil {
+		return Statement{}, err
+	}
+	if err := sb.writeConditions(b.conds); err != nil {
+		return Statement{}, err
+	}
+
+	return sb.statement(), nil
+}
+
+// appendCondition returns a copy of the conditions with the condition
+// appended, so builders copied before the append are not modified.
+func appendCondition(conds []condition, expr string, params []interface{}) []condition {
+	return append(conds[:len(conds):len(conds)], condition{
+		expr:   expr,
+		params: params,
+	})
+}
@@ -0,0 +1,279 @@
+package partiql_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/partiql"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type song struct {
+	Artist    string
+	SongTitle string
+	Year      int    `dynamodbav:",omitempty"`
+	Album     string `dynamodbav:"album,omitempty"`
+}
+
+type builder interface {
+	Build() (partiql.Statement, error)
+}
+
+func TestBuild(t *testing.T) {
+	cases := map[string]struct {
+		builder     builder
+		expect      partiql.Statement
+		expectedErr string
+	}{
+		"select all": {
+			builder: partiql.Select("Music"),
+			expect: partiql.Statement{
+				Statement: `SELECT * FROM "Music"`,
+			},
+		},
+		"select names from index": {
+			builder: partiql.Select("Music", "Artist", "info.tracks[0]", `we"ird`).
+				Index("ByYear"),
+			expect: partiql.Statement{
+				Statement: `SELECT "Artist", "info"."tracks"[0], "we""ird" FROM "Music"."ByYear"`,
+			},
+		},
+		"select nested list indexes": {
+			builder: partiql.Select("Music", "info.tracks[0][12].name"),
+			expect: partiql.Statement{
+				Statement: `SELECT "info"."tracks"[0][12]."name" FROM "Music"`,
+			},
+		},
+		"select where": {
+			builder: partiql.Select("Music").
+				Where(`"Artist" = ?`, "Acme Band"),
+			expect: partiql.Statement{
+				Statement: `SELECT * FROM "Music" WHERE "Artist" = ?`,
+				Parameters: []types.AttributeValue{
+					&types.AttributeValueMemberS{Value: "Acme Band"},
+				},
+			},
+		},
+		"select multiple where": {
+			builder: partiql.Select("Music").
+				Where(`"Artist" = ? OR "Artist" = ?`, "Acme Band", "No One").
+				Where(`"Year" BETWEEN ? AND ?`, 2000, 2010).
+				Where(`"Genre" = 'What?'`),
+			expect: partiql.Statement{
+				Statement: `SELECT * FROM "Music" WHERE ("Artist" = ? OR "Artist" = ?) AND ("Year" BETWEEN ? AND ?) AND ("Genre" = 'What?')`,
+				Parameters: []types.AttributeValue{
+					&types.AttributeValueMemberS{Value: "Acme Band"},
+					&types.AttributeValueMemberS{Value: "No One"},
+					&types.AttributeValueMemberN{Value: "2000"},
+					&types.AttributeValueMemberN{Value: "2010"},
+				},
+			},
+		},
+		"select attribute value parameter": {
+			builder: partiql.Select("Music").
+				Where(`"Tags" = ?`, &types.AttributeValueMemberSS{Value: []string{"a", "b"}}),
+			expect: partiql.Statement{
+				Statement: `SELECT * FROM "Music" WHERE "Tags" = ?`,
+				Parameters: []types.AttributeValue{
+					&types.AttributeValueMemberSS{Value: []string{"a", "b"}},
+				},
+			},
+		},
+		"select missing parameter": {
+			builder: partiql.Select("Music").
+				Where(`"Artist" = ? AND "SongTitle" = ?`, "Acme Band"),
+			expectedErr: "has 2 parameters, got 1 values",
+		},
+		"select extra parameter": {
+			builder: partiql.Select("Music").
+				Where(`"Artist" = 'Acme?'`, "Acme Band"),
+			expectedErr: "has 0 parameters, got 1 values",
+		},
+		"select invalid name": {
+			builder:     partiql.Select("Music", "a..b"),
+			expectedErr: "invalid attribute name",
+		},
+		"select name with statement": {
+			builder:     partiql.Select("Music", `a[0] FROM "Other"`),
+			expectedErr: "invalid attribute name",
+		},
+		"select name with invalid index": {
+			builder:     partiql.Select("Music", "a[b]"),
+			expectedErr: "invalid attribute name",
+		},
+		"select name with empty index": {
+			builder:     partiql.Select("Music", "a[]"),
+			expectedErr: "invalid attribute name",
+		},
+		"select name with unclosed index": {
+			builder:     partiql.Select("Music", "a[0][1"),
+			expectedErr: "invalid attribute name",
+		},
+		"select empty table": {
+			builder:     partiql.Select(""),
+			expectedErr: "table name must not be empty",
+		},
+		"insert": {
+			builder: partiql.Insert("Music", song{
+				Artist:    "Acme Band",
+				SongTitle: "Happy Day",
+				Album:     "Songs About Life",
+			}),
+			expect: partiql.Statement{
+				Statement: `INSERT INTO "Music" VALUE {'Artist': ?, 'SongTitle': ?, 'album': ?}`,
+				Parameters: []types.AttributeValue{
+					&types.AttributeValueMemberS{Value: "Acme Band"},
+					&types.AttributeValueMemberS{Value: "Happy Day"},
+					&types.AttributeValueMemberS{Value: "Songs About Life"},
+				},
+			},
+		},
+		"insert map": {
+			builder: partiql.Insert("Music", map[string]interface{}{
+				"it's": 1,
+			}),
+			expect: partiql.Statement{
+				Statement: `INSERT INTO "Music" VALUE {'it''s': ?}`,
+				Parameters: []types.AttributeValue{
+					&types.AttributeValueMemberN{Value: "1"},
+				},
+			},
+		},
+		"insert not a map": {
+			builder:     partiql.Insert("Music", "Acme Band"),
+			expectedErr: "item must marshal to a map",
+		},
+		"insert empty item": {
+			builder:     partiql.Insert("Music", map[string]string{}),
+			expectedErr: "item must have attributes",
+		},
+		"update": {
+			builder: partiql.Update("Music").
+				Set("AwardsWon", 1).
+				Remove("Pending").
+				Set("info.tracks[1]", []string{"a"}).
+				Where(`"Artist" = ? AND "SongTitle" = ?`, "Acme Band", "Happy Day"),
+			expect: partiql.Statement{
+				Statement: `UPDATE "Music" SET "AwardsWon" = ? REMOVE "Pending" SET "info"."tracks"[1] = ? WHERE "Artist" = ? AND "SongTitle" = ?`,
+				Parameters: []types.AttributeValue{
+					&types.AttributeValueMemberN{Value: "1"},
+					&types.AttributeValueMemberL{Value: []types.AttributeValue{
+						&types.AttributeValueMemberS{Value: "a"},
+					}},
+					&types.AttributeValueMemberS{Value: "Acme Band"},
+					&types.AttributeValueMemberS{Value: "Happy Day"},
+				},
+			},
+		},
+		"update without actions": {
+			builder: partiql.Update("Music").
+				Where(`"Artist" = ?`, "Acme Band"),
+			expectedErr: "update must set or remove attributes",
+		},
+		"update invalid name": {
+			builder: partiql.Update("Music").
+				Remove("Pending[0] SET x").
+				Where(`"Artist" = ?`, "Acme Band"),
+			expectedErr: "invalid attribute name",
+		},
+		"update without where": {
+			builder:     partiql.Update("Music").Set("AwardsWon", 1),
+			expectedErr: "update must have a where condition",
+		},
+		"delete": {
+			builder: partiql.Delete("Music").
+				Where(`"Artist" = ?`, "Acme Band").
+				Where(`"SongTitle" = ?`, "Happy Day"),
+			expect: partiql.Statement{
+				Statement: `DELETE FROM "Music" WHERE ("Artist" = ?) AND ("SongTitle" = ?)`,
+				Parameters: []types.AttributeValue{
+					&types.AttributeValueMemberS{Value: "Acme Band"},
+					&types.AttributeValueMemberS{Value: "Happy Day"},
+				},
+			},
+		},
+		"delete without where": {
+			builder:     partiql.Delete("Music"),
+			expectedErr: "delete must have a where condition",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			stmt, err := c.builder.Build()
+			if len(c.expectedErr) != 0 {
+				if err == nil {
+					t.Fatalf("expect error %v, got none", c.expectedErr)
+				}
+				if e, a := c.expectedErr, err.Error(); !strings.Contains(a, e) {
+					t.Fatalf("expect error to contain %v, got %v", e, a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			if e, a := c.expect.Statement, stmt.Statement; e != a {
+				t.Errorf("expect statement\n%v\ngot\n%v", e, a)
+			}
+			if e, a := c.expect.Parameters, stmt.Parameters; !reflect.DeepEqual(e, a) {
+				t.Errorf("expect parameters %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestBuild_CopiesBuilder(t *testing.T) {
+	base := partiql.Select("Music").Where(`"Artist" = ?`, "Acme Band")
+
+	a, err := base.Where(`"Year" = ?`, 2000).Build()
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	b, err := base.Where(`"Genre" = ?`, "Rock").Build()
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if e, a := `SELECT * FROM "Music" WHERE ("Artist" = ?) AND ("Year" = ?)`, a.Statement; e != a {
+		t.Errorf("expect %v statement, got %v", e, a)
+	}
+	if e, a := `SELECT * FROM "Music" WHERE ("Artist" = ?) AND ("Genre" = ?)`, b.Statement; e != a {
+		t.Errorf("expect %v statement, got %v", e, a)
+	}
+}
+
+func TestStatementInputs(t *testing.T) {
+	put, err := partiql.Insert("Music", song{Artist: "Acme Band", SongTitle: "Happy Day"}).Build()
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	del, err := partiql.Delete("Music").Where(`"Artist" = ?`, "No One").Build()
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	batch := partiql.NewBatchExecuteStatementInput(put, del)
+	if e, a := 2, len(batch.Statements); e != a {
+		t.Fatalf("expect %v statements, got %v", e, a)
+	}
+	if e, a := del.Statement, *batch.Statements[1].Statement; e != a {
+		t.Errorf("expect %v statement, got %v", e, a)
+	}
+	if e, a := put.Parameters, batch.Statements[0].Parameters; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v parameters, got %v", e, a)
+	}
+
+	tx := partiql.NewExecuteTransactionInput(put, del)
+	if e, a := 2, len(tx.TransactStatements); e != a {
+		t.Fatalf("expect %v statements, got %v", e, a)
+	}
+	if e, a := put.Statement, *tx.TransactStatements[0].Statement; e != a {
+		t.Errorf("expect %v statement, got %v", e, a)
+	}
+	if e, a := del.Parameters, tx.TransactStatements[1].Parameters; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v parameters, got %v", e, a)
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Package partiql provides builders of PartiQL statements for the Amazon
+DynamoDB ExecuteStatement, BatchExecuteStatement, and ExecuteTransaction API
+operations, and a paginator unmarshaling the items read by a statement.
+
+Select, Insert, Update, and Delete return builders of SELECT, INSERT, UPDATE,
+and DELETE statements. Table and attribute names are quoted, and values are
+passed as positional ? parameters, marshaled with attributevalue.Marshal.
+WHERE conditions are PartiQL expressions with their own ? parameters.
+
+  stmt, err := partiql.Update("Music").
+      Set("AwardsWon", 1).
+      Where(`"Artist" = ? AND "SongTitle" = ?`, "Acme Band", "Happy Day").
+      Build()
+  if err != nil {
+      return err
+  }
+
+  _, err = client.ExecuteStatement(context.TODO(), stmt.ExecuteStatementInput())
+
+Multiple statements are run in a batch, or transaction, with the inputs
+returned by NewBatchExecuteStatementInput and NewExecuteTransactionInput.
+
+Reading Items
+
+The ResultPaginator runs a statement with ExecuteStatement, unmarshaling each
+page of items read into a slice of structs.
+
+  stmt, err := partiql.Select("Music").
+      Where(`"Artist" = ?`, "Acme Band").
+      Build()
+  if err != nil {
+      return err
+  }
+
+  p := partiql.NewResultPaginator(client, stmt.ExecuteStatementInput())
+  for p.HasMorePages() {
+      var songs []Song
+      if err := p.NextPage(context.TODO(), &songs); err != nil {
+          return err
+      }
+      // use songs
+  }
+*/
+package partiql
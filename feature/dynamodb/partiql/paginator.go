@@ -0,0 +1,92 @@
+package partiql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+// ExecuteStatementAPIClient is a DynamoDB client that implements the
+// ExecuteStatement API operation.
+type ExecuteStatementAPIClient interface {
+	ExecuteStatement(context.Context, *dynamodb.ExecuteStatementInput, ...func(*dynamodb.Options)) (*dynamodb.ExecuteStatementOutput, error)
+}
+
+var _ ExecuteStatementAPIClient = (*dynamodb.Client)(nil)
+
+// ResultPaginatorOptions is the paginator options for ExecuteStatement
+type ResultPaginatorOptions struct {
+	// Set to true if pagination should stop if the service returns a pagination
+	// token that matches the most recent token provided to the service.
+	StopOnDuplicateToken bool
+}
+
+// ResultPaginator is a paginator for the items read by an ExecuteStatement
+// statement, unmarshaling each page of items.
+type ResultPaginator struct {
+	options   ResultPaginatorOptions
+	client    ExecuteStatementAPIClient
+	params    *dynamodb.ExecuteStatementInput
+	nextToken *string
+	firstPage bool
+}
+
+// NewResultPaginator returns a new ResultPaginator running the statement of
+// the ExecuteStatement input, such as the input returned by a Statement's
+// ExecuteStatementInput method.
+func NewResultPaginator(client ExecuteStatementAPIClient, params *dynamodb.ExecuteStatementInput, optFns ...func(*ResultPaginatorOptions)) *ResultPaginator {
+	options := ResultPaginatorOptions{}
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
+	if params == nil {
+		params = &dynamodb.ExecuteStatementInput{}
+	}
+
+	return &ResultPaginator{
+		options:   options,
+		client:    client,
+		params:    params,
+		nextToken: params.NextToken,
+		firstPage: true,
+	}
+}
+
+// HasMorePages returns a boolean indicating whether more pages are available
+func (p *ResultPaginator) HasMorePages() bool {
+	return p.firstPage || p.nextToken != nil
+}
+
+// NextPage retrieves the next page of items, unmarshaling them into out with
+// attributevalue.UnmarshalListOfMaps. out must be a pointer to a slice of
+// structs, maps, or pointers to them. The page may be empty, even if there are
+// more pages.
+func (p *ResultPaginator) NextPage(ctx context.Context, out interface{}, optFns ...func(*dynamodb.Options)) error {
+	if !p.HasMorePages() {
+		return fmt.Errorf("no more pages available")
+	}
+
+	params := *p.params
+	params.NextToken = p.nextToken
+
+	result, err := p.client.ExecuteStatement(ctx, &params, optFns...)
+	if err != nil {
+		return err
+	}
+	p.firstPage = false
+
+	prevToken := p.nextToken
+	p.nextToken = result.NextToken
+
+	if p.options.StopOnDuplicateToken && prevToken != nil && p.nextToken != nil && *prevToken == *p.nextToken {
+		p.nextToken = nil
+	}
+
+	if err := attributevalue.UnmarshalListOfMaps(result.Items, out); err != nil {
+		return fmt.Errorf("unable to unmarshal items, %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package partiql_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/partiql"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type mockExecuteStatementClient struct {
+	pages []*dynamodb.ExecuteStatementOutput
+	err   error
+
+	inputs []*dynamodb.ExecuteStatementInput
+}
+
+func (c *mockExecuteStatementClient) ExecuteStatement(ctx context.Context, in *dynamodb.ExecuteStatementInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ExecuteStatementOutput, error) {
+	c.inputs = append(c.inputs, in)
+	if c.err != nil {
+		return nil, c.err
+	}
+	page := c.pages[0]
+	c.pages = c.pages[1:]
+	return page, nil
+}
+
+func songItem(artist, title string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"Artist":    &types.AttributeValueMemberS{Value: artist},
+		"SongTitle": &types.AttributeValueMemberS{Value: title},
+	}
+}
+
+func TestResultPaginator(t *testing.T) {
+	client := &mockExecuteStatementClient{
+		pages: []*dynamodb.ExecuteStatementOutput{
+			{
+				Items: []map[string]types.AttributeValue{
+					songItem("Acme Band", "Happy Day"),
+					songItem("Acme Band", "Sad Day"),
+				},
+				NextToken: aws.String("token1"),
+			},
+			{
+				NextToken: aws.String("token2"),
+			},
+			{
+				Items: []map[string]types.AttributeValue{
+					songItem("Acme Band", "Some Day"),
+				},
+			},
+		},
+	}
+
+	stmt, err := partiql.Select("Music").Where(`"Artist" = ?`, "Acme Band").Build()
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	in := stmt.ExecuteStatementInput()
+	in.ConsistentRead = aws.Bool(true)
+
+	p := partiql.NewResultPaginator(client, in)
+
+	var songs []song
+	var pages int
+	for p.HasMorePages() {
+		var page []song
+		if err := p.NextPage(context.Background(), &page); err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		songs = append(songs, page...)
+		pages++
+	}
+
+	if e, a := 3, pages; e != a {
+		t.Errorf("expect %v pages, got %v", e, a)
+	}
+	expect := []song{
+		{Artist: "Acme Band", SongTitle: "Happy Day"},
+		{Artist: "Acme Band", SongTitle: "Sad Day"},
+		{Artist: "Acme Band", SongTitle: "Some Day"},
+	}
+	if e, a := fmt.Sprint(expect), fmt.Sprint(songs); e != a {
+		t.Errorf("expect %v songs, got %v", e, a)
+	}
+
+	expectTokens := []*string{nil, aws.String("token1"), aws.String("token2")}
+	for i, in := range client.inputs {
+		if e, a := aws.ToString(expectTokens[i]), aws.ToString(in.NextToken); e != a {
+			t.Errorf("%d, expect %v token, got %v", i, e, a)
+		}
+		if e, a := stmt.Statement, aws.ToString(in.Statement); e != a {
+			t.Errorf("%d, expect %v statement, got %v", i, e, a)
+		}
+		if !aws.ToBool(in.ConsistentRead) {
+			t.Errorf("%d, expect consistent read", i)
+		}
+	}
+
+	if err := p.NextPage(context.Background(), &songs); err == nil {
+		t.Errorf("expect error for no more pages")
+	}
+}
+
+func TestResultPaginator_StopOnDuplicateToken(t *testing.T) {
+	client := &mockExecuteStatementClient{
+		pages: []*dynamodb.ExecuteStatementOutput{
+			{NextToken: aws.String("token")},
+			{NextToken: aws.String("token")},
+		},
+	}
+
+	p := partiql.NewResultPaginator(client, &dynamodb.ExecuteStatementInput{
+		Statement: aws.String(`SELECT * FROM "Music"`),
+	}, func(o *partiql.ResultPaginatorOptions) {
+		o.StopOnDuplicateToken = true
+	})
+
+	var pages int
+	for p.HasMorePages() {
+		var page []song
+		if err := p.NextPage(context.Background(), &page); err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		pages++
+	}
+	if e, a := 2, pages; e != a {
+		t.Errorf("expect %v pages, got %v", e, a)
+	}
+}
+
+func TestResultPaginator_Error(t *testing.T) {
+	client := &mockExecuteStatementClient{err: fmt.Errorf("mock error")}
+
+	p := partiql.NewResultPaginator(client, &dynamodb.ExecuteStatementInput{
+		Statement: aws.String(`SELECT * FROM "Music"`),
+	})
+
+	var page []song
+	err := p.NextPage(context.Background(), &page)
+	if e, a := client.err, err; e != a {
+		t.Fatalf("expect %v error, got %v", e, a)
+	}
+	if !p.HasMorePages() {
+		t.Errorf("expect more pages after error")
+	}
+}
+
+func TestResultPaginator_UnmarshalError(t *testing.T) {
+	client := &mockExecuteStatementClient{
+		pages: []*dynamodb.ExecuteStatementOutput{
+			{Items: []map[string]types.AttributeValue{songItem("Acme Band", "Happy Day")}},
+		},
+	}
+
+	p := partiql.NewResultPaginator(client, &dynamodb.ExecuteStatementInput{
+		Statement: aws.String(`SELECT * FROM "Music"`),
+	})
+
+	var page []int
+	if err := p.NextPage(context.Background(), &page); err == nil {
+		t.Fatalf("expect error, got none")
+	}
+}
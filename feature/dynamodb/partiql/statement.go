@@ -0,0 +1,240 @@
+package partiql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Statement is a PartiQL statement, and the values of its positional ?
+// parameters, built by a SelectBuilder, InsertBuilder, UpdateBuilder, or
+// DeleteBuilder.
+type Statement struct {
+	// The PartiQL statement.
+	Statement string
+
+	// The values of the statement's parameters, in the order of the
+	// parameters in the statement.
+	Parameters []types.AttributeValue
+}
+
+// ExecuteStatementInput returns the input to run the statement with the
+// ExecuteStatement API operation.
+func (s Statement) ExecuteStatementInput() *dynamodb.ExecuteStatementInput {
+	return &dynamodb.ExecuteStatementInput{
+		Statement:  aws.String(s.Statement),
+		Parameters: s.Parameters,
+	}
+}
+
+// BatchStatementRequest returns the request to run the statement in a batch
+// with the BatchExecuteStatement API operation.
+func (s Statement) BatchStatementRequest() types.BatchStatementRequest {
+	return types.BatchStatementRequest{
+		Statement:  aws.String(s.Statement),
+		Parameters: s.Parameters,
+	}
+}
+
+// ParameterizedStatement returns the statement to run in a transaction with
+// the ExecuteTransaction API operation.
+func (s Statement) ParameterizedStatement() types.ParameterizedStatement {
+	return types.ParameterizedStatement{
+		Statement:  aws.String(s.Statement),
+		Parameters: s.Parameters,
+	}
+}
+
+// NewBatchExecuteStatementInput returns the input to run the statements in a
+// batch with the BatchExecuteStatement API operation.
+func NewBatchExecuteStatementInput(statements ...Statement) *dynamodb.BatchExecuteStatementInput {
+	in := &dynamodb.BatchExecuteStatementInput{
+		Statements: make([]types.BatchStatementRequest, 0, len(statements)),
+	}
+	for _, s := range statements {
+		in.Statements = append(in.Statements, s.BatchStatementRequest())
+	}
+	return in
+}
+
+// NewExecuteTransactionInput returns the input to run the statements in a
+// transaction with the ExecuteTransaction API operation.
+func NewExecuteTransactionInput(statements ...Statement) *dynamodb.ExecuteTransactionInput {
+	in := &dynamodb.ExecuteTransactionInput{
+		TransactStatements: make([]types.ParameterizedStatement, 0, len(statements)),
+	}
+	for _, s := range statements {
+		in.TransactStatements = append(in.TransactStatements, s.ParameterizedStatement())
+	}
+	return in
+}
+
+// statementBuilder accumulates the text and parameters of a statement.
+type statementBuilder struct {
+	strings.Builder
+	params []types.AttributeValue
+}
+
+// writeName writes the attribute name, quoting each element of the document
+// path. A name of "a.b[1]" is written as "a"."b"[1]. Elements may only be
+// followed by list indexes.
+func (b *statementBuilder) writeName(name string) error {
+	if len(name) == 0 {
+		return fmt.Errorf("attribute name must not be empty")
+	}
+
+	for i, part := range strings.Split(name, ".") {
+		index := strings.IndexByte(part, '[')
+		if index == -1 {
+			index = len(part)
+		}
+		if index == 0 || !isListIndexes(part[index:]) {
+			return fmt.Errorf("invalid attribute name %q", name)
+		}
+		if i != 0 {
+			b.WriteByte('.')
+		}
+		b.writeIdentifier(part[:index])
+		b.WriteString(part[index:])
+	}
+	return nil
+}
+
+// isListIndexes returns if s is a sequence of zero or more list indexes,
+// such as "[1][2]".
+func isListIndexes(s string) bool {
+	for len(s) != 0 {
+		end := strings.IndexByte(s, ']')
+		if s[0] != '[' || end < 2 {
+			return false
+		}
+		for _, c := range s[1:end] {
+			if c < '0' || c > '9' {
+				return false
+			}
+		}
+		s = s[end+1:]
+	}
+	return true
+}
+
+// writeTable writes the quoted name of the table, and index if set.
+func (b *statementBuilder) writeTable(table, index string) error {
+	if len(table) == 0 {
+		return fmt.Errorf("table name must not be empty")
+	}
+	b.writeIdentifier(table)
+	if len(index) != 0 {
+		b.WriteByte('.')
+		b.writeIdentifier(index)
+	}
+	return nil
+}
+
+// writeIdentifier writes the identifier in double quotes, escaping any double
+// quotes it contains.
+func (b *statementBuilder) writeIdentifier(s string) {
+	b.WriteByte('"')
+	b.WriteString(strings.Replace(s, `"`, `""`, -1))
+	b.WriteByte('"')
+}
+
+// writeString writes the string literal in single quotes, escaping any single
+// quotes it contains.
+func (b *statementBuilder) writeString(s string) {
+	b.WriteByte('\'')
+	b.WriteString(strings.Replace(s, `'`, `''`, -1))
+	b.WriteByte('\'')
+}
+
+// writeParam writes a positional parameter, marshaling its value.
+func (b *statementBuilder) writeParam(v interface{}) error {
+	av, err := marshalParam(v)
+	if err != nil {
+		return err
+	}
+	b.WriteByte('?')
+	b.params = append(b.params, av)
+	return nil
+}
+
+// writeConditions writes the WHERE clause of the conditions, joined with AND.
+func (b *statementBuilder) writeConditions(conds []condition) error {
+	for i, cond := range conds {
+		if n := countParams(cond.expr); n != len(cond.params) {
+			return fmt.Errorf("condition %q has %d parameters, got %d values",
+				cond.expr, n, len(cond.params))
+		}
+
+		if i == 0 {
+			b.WriteString(" WHERE ")
+		} else {
+			b.WriteString(" AND ")
+		}
+		if len(conds) > 1 {
+			b.WriteString("(" + cond.expr + ")")
+		} else {
+			b.WriteString(cond.expr)
+		}
+
+		for _, v := range cond.params {
+			av, err := marshalParam(v)
+			if err != nil {
+				return err
+			}
+			b.params = append(b.params, av)
+		}
+	}
+	return nil
+}
+
+func (b *statementBuilder) statement() Statement {
+	return Statement{
+		Statement:  b.String(),
+		Parameters: b.params,
+	}
+}
+
+// condition is a condition of a WHERE clause, with the values of its
+// positional parameters.
+type condition struct {
+	expr   string
+	params []interface{}
+}
+
+// marshalParam marshals the value of a parameter with attributevalue.Marshal.
+// AttributeValues are used as is.
+func marshalParam(v interface{}) (types.AttributeValue, error) {
+	if av, ok := v.(types.AttributeValue); ok {
+		return av, nil
+	}
+	av, err := attributevalue.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal parameter, %w", err)
+	}
+	return av, nil
+}
+
+// countParams returns the number of positional ? parameters in the PartiQL
+// expression, ignoring any in quoted identifiers or string literals.
+func countParams(expr string) int {
+	var n int
+	var quote rune
+	for _, r := range expr {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '?':
+			n++
+		}
+	}
+	return n
+}
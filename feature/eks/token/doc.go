@@ -0,0 +1,21 @@
+/*
+Package token provides utilities for generating bearer tokens to authenticate
+with Amazon EKS clusters with AWS IAM credentials.
+
+A token is the URL of an STS GetCallerIdentity request presigned with the ID
+of the cluster signed in the x-k8s-aws-id header, base64 encoded and prefixed
+with "k8s-aws-v1.". EKS sends the presigned request to STS to retrieve the
+identity of the credentials the token was signed with.
+
+  generator := token.NewGenerator(sts.NewPresignClient(sts.NewFromConfig(cfg)))
+
+  tok, err := generator.GetToken(context.TODO(), "my-cluster")
+  if err != nil {
+      return err
+  }
+  // Authenticate with the cluster with the bearer token tok.Token.
+
+VerifyToken decodes a token into its presigned GetCallerIdentity request, for
+testing tokens locally.
+*/
+package token
@@ -0,0 +1,16 @@
+module github.com/aws/aws-sdk-go-v2/feature/eks/token
+
+go 1.15
+
+require (
+	github.com/aws/aws-sdk-go-v2 v1.2.0
+	github.com/aws/aws-sdk-go-v2/service/sts v1.1.1
+	github.com/aws/smithy-go v1.1.0
+)
+
+replace (
+	github.com/aws/aws-sdk-go-v2 => ../../../
+	github.com/aws/aws-sdk-go-v2/service/sts => ../../../service/sts/
+)
+
+replace github.com/aws/aws-sdk-go-v2/service/internal/presigned-url => ../../../service/internal/presigned-url/
@@ -0,0 +1,153 @@
+package token
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+const (
+	// Prefix is the prefix of the tokens EKS accepts to authenticate with
+	// AWS IAM.
+	Prefix = "k8s-aws-v1."
+
+	// ClusterIDHeader is the header the ID of the cluster a token is for is
+	// signed into the token with.
+	ClusterIDHeader = "x-k8s-aws-id"
+
+	// DefaultExpires is the default time a token is valid for.
+	DefaultExpires = 60 * time.Second
+
+	// MaxExpires is the maximum time a token is valid for. EKS does not accept
+	// tokens signed more than 15 minutes ago.
+	MaxExpires = 15 * time.Minute
+
+	expiresQueryKey  = "X-Amz-Expires"
+	dateQueryKey     = "X-Amz-Date"
+	dateQueryFormat  = "20060102T150405Z"
+	signedHeadersKey = "X-Amz-SignedHeaders"
+)
+
+// Token is a bearer token to authenticate with an EKS cluster.
+type Token struct {
+	// The bearer token, the prefixed, base64 encoded URL of a presigned STS
+	// GetCallerIdentity request.
+	Token string
+
+	// The time the token expires at.
+	Expiration time.Time
+}
+
+// PresignGetCallerIdentityAPIClient is the STS presign client used by the
+// Generator to presign GetCallerIdentity requests.
+type PresignGetCallerIdentityAPIClient interface {
+	PresignGetCallerIdentity(context.Context, *sts.GetCallerIdentityInput, ...func(*sts.PresignOptions)) (*v4.PresignedHTTPRequest, error)
+}
+
+var _ PresignGetCallerIdentityAPIClient = (*sts.PresignClient)(nil)
+
+// GeneratorOptions is the options of the Generator.
+type GeneratorOptions struct {
+	// The time the token is valid for after it was signed. Defaults to
+	// DefaultExpires if zero. Must not be more than MaxExpires.
+	Expires time.Duration
+}
+
+// Generator generates bearer tokens to authenticate with EKS clusters with
+// the credentials of the STS presign client.
+type Generator struct {
+	client  PresignGetCallerIdentityAPIClient
+	options GeneratorOptions
+}
+
+// NewGenerator returns a Generator that presigns tokens with the STS presign
+// client.
+//
+//     generator := token.NewGenerator(sts.NewPresignClient(sts.NewFromConfig(cfg)))
+func NewGenerator(client PresignGetCallerIdentityAPIClient, optFns ...func(*GeneratorOptions)) *Generator {
+	var options GeneratorOptions
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
+	return &Generator{
+		client:  client,
+		options: options,
+	}
+}
+
+// GetToken returns a bearer token to authenticate with the EKS cluster with
+// the ID. The ID of the cluster is its name, or the ID of the cluster if
+// authenticating with the cluster by its ID.
+func (g *Generator) GetToken(ctx context.Context, clusterID string, optFns ...func(*GeneratorOptions)) (Token, error) {
+	options := g.options
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
+	if len(clusterID) == 0 {
+		return Token{}, fmt.Errorf("cluster ID is required")
+	}
+	expires := options.Expires
+	if expires == 0 {
+		expires = DefaultExpires
+	}
+	if expires < time.Second || expires > MaxExpires {
+		return Token{}, fmt.Errorf("token expiry must be between 1s and %v, got %v", MaxExpires, expires)
+	}
+
+	presigned, err := g.client.PresignGetCallerIdentity(ctx, &sts.GetCallerIdentityInput{},
+		sts.WithPresignClientFromClientOptions(sts.WithAPIOptions(
+			smithyhttp.SetHeaderValue(ClusterIDHeader, clusterID),
+			addExpiresQueryMiddleware(expires),
+		)),
+	)
+	if err != nil {
+		return Token{}, fmt.Errorf("failed to presign GetCallerIdentity, %w", err)
+	}
+
+	u, err := url.Parse(presigned.URL)
+	if err != nil {
+		return Token{}, fmt.Errorf("failed to parse presigned URL, %w", err)
+	}
+	signedAt, err := time.Parse(dateQueryFormat, u.Query().Get(dateQueryKey))
+	if err != nil {
+		return Token{}, fmt.Errorf("failed to parse presigned URL signing time, %w", err)
+	}
+
+	return Token{
+		Token:      Prefix + base64.RawURLEncoding.EncodeToString([]byte(presigned.URL)),
+		Expiration: signedAt.Add(expires),
+	}, nil
+}
+
+// addExpiresQueryMiddleware returns a stack mutator adding a middleware that
+// sets the time the presigned request is valid for in the request's query.
+func addExpiresQueryMiddleware(expires time.Duration) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Build.Add(middleware.BuildMiddlewareFunc("EKSTokenExpires",
+			func(ctx context.Context, in middleware.BuildInput, next middleware.BuildHandler) (
+				middleware.BuildOutput, middleware.Metadata, error,
+			) {
+				req, ok := in.Request.(*smithyhttp.Request)
+				if !ok {
+					return middleware.BuildOutput{}, middleware.Metadata{},
+						fmt.Errorf("unknown transport type %T", in.Request)
+				}
+
+				query := req.URL.Query()
+				query.Set(expiresQueryKey, strconv.FormatInt(int64(expires/time.Second), 10))
+				req.URL.RawQuery = query.Encode()
+
+				return next.HandleBuild(ctx, in)
+			}), middleware.After)
+	}
+}
@@ -0,0 +1,165 @@
+package token
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/internal/awstesting/unit"
+	"github.com/aws/aws-sdk-go-v2/internal/sdk"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+var testSigningTime = time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)
+
+func newTestGenerator(optFns ...func(*GeneratorOptions)) *Generator {
+	client := sts.New(sts.Options{
+		Region:      "us-west-2",
+		Credentials: unit.StubCredentialsProvider{},
+	})
+	return NewGenerator(sts.NewPresignClient(client), optFns...)
+}
+
+func TestGenerator_GetToken(t *testing.T) {
+	defer func(fn func() time.Time) { sdk.NowTime = fn }(sdk.NowTime)
+	sdk.NowTime = func() time.Time { return testSigningTime }
+
+	cases := map[string]struct {
+		options       []func(*GeneratorOptions)
+		expectExpires string
+		expectExpiry  time.Time
+	}{
+		"default expiry": {
+			expectExpires: "60",
+			expectExpiry:  testSigningTime.Add(time.Minute),
+		},
+		"custom expiry": {
+			options: []func(*GeneratorOptions){func(o *GeneratorOptions) {
+				o.Expires = 10 * time.Minute
+			}},
+			expectExpires: "600",
+			expectExpiry:  testSigningTime.Add(10 * time.Minute),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			tok, err := newTestGenerator(c.options...).GetToken(context.Background(), "my-cluster")
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			if !strings.HasPrefix(tok.Token, "k8s-aws-v1.") {
+				t.Fatalf("expect token prefix, got %v", tok.Token)
+			}
+			if strings.ContainsAny(tok.Token, "+/=") {
+				t.Errorf("expect unpadded base64 URL encoding, got %v", tok.Token)
+			}
+			if e, a := c.expectExpiry, tok.Expiration; !e.Equal(a) {
+				t.Errorf("expect %v expiration, got %v", e, a)
+			}
+
+			b, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(tok.Token, Prefix))
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			u, err := url.Parse(string(b))
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			query := u.Query()
+			expect := map[string]string{
+				"Action":              "GetCallerIdentity",
+				"Version":             "2011-06-15",
+				"X-Amz-Credential":    "AKID/20210203/us-west-2/sts/aws4_request",
+				"X-Amz-Date":          "20210203T040506Z",
+				"X-Amz-Expires":       c.expectExpires,
+				"X-Amz-SignedHeaders": "host;x-k8s-aws-id",
+			}
+			for k, e := range expect {
+				if a := query.Get(k); e != a {
+					t.Errorf("expect %v %v, got %v", k, e, a)
+				}
+			}
+			if len(query.Get("X-Amz-Signature")) == 0 {
+				t.Errorf("expect signature, got none")
+			}
+
+			// The request decoded from the token must be signed with the
+			// cluster ID header to match the signature.
+			req, err := VerifyToken(tok.Token, "my-cluster")
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			signature := req.URL.Query().Get("X-Amz-Signature")
+			unsigned := req.URL.Query()
+			for _, k := range []string{
+				"X-Amz-Algorithm", "X-Amz-Credential", "X-Amz-Date",
+				"X-Amz-Security-Token", "X-Amz-SignedHeaders", "X-Amz-Signature",
+			} {
+				unsigned.Del(k)
+			}
+			req.URL.RawQuery = unsigned.Encode()
+
+			creds, _ := unit.StubCredentialsProvider{}.Retrieve(context.Background())
+			signedURL, _, err := v4.NewSigner().PresignHTTP(context.Background(), creds, req,
+				"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+				"sts", "us-west-2", testSigningTime)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if !strings.Contains(signedURL, "X-Amz-Signature="+signature) {
+				t.Errorf("expect %v signature, got %v", signature, signedURL)
+			}
+		})
+	}
+}
+
+func TestGenerator_GetToken_Errors(t *testing.T) {
+	cases := map[string]struct {
+		generator   *Generator
+		clusterID   string
+		expectedErr string
+	}{
+		"missing cluster ID": {
+			generator:   newTestGenerator(),
+			expectedErr: "cluster ID is required",
+		},
+		"expiry too long": {
+			generator: newTestGenerator(func(o *GeneratorOptions) {
+				o.Expires = 20 * time.Minute
+			}),
+			clusterID:   "my-cluster",
+			expectedErr: "token expiry must be between",
+		},
+		"presign error": {
+			generator: NewGenerator(sts.NewPresignClient(sts.New(sts.Options{
+				Region: "us-west-2",
+				Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+					return aws.Credentials{}, fmt.Errorf("retrieve error")
+				}),
+			}))),
+			clusterID:   "my-cluster",
+			expectedErr: "retrieve error",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := c.generator.GetToken(context.Background(), c.clusterID)
+			if err == nil {
+				t.Fatalf("expect error, got none")
+			}
+			if e, a := c.expectedErr, err.Error(); !strings.Contains(a, e) {
+				t.Errorf("expect error to contain %v, got %v", e, a)
+			}
+		})
+	}
+}
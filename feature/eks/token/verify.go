@@ -0,0 +1,108 @@
+package token
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/internal/sdk"
+)
+
+// VerifyToken decodes the token into the presigned STS GetCallerIdentity
+// request it was built from. The returned request has the cluster ID header
+// set to clusterID, and can be sent to STS to retrieve the identity the token
+// was signed with.
+//
+// Returns an error if the token is not a presigned GetCallerIdentity request
+// with the cluster ID header in its signed headers, or has expired. The
+// token's cluster ID is not compared with clusterID. VerifyToken does not
+// validate the request's signature, so the cluster ID is only enforced when
+// STS validates the signature, failing the request if the token was signed
+// for a different cluster.
+func VerifyToken(token, clusterID string) (*http.Request, error) {
+	if !strings.HasPrefix(token, Prefix) {
+		return nil, fmt.Errorf("token is missing the %v prefix", Prefix)
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(token, Prefix))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode token, %w", err)
+	}
+
+	u, err := url.Parse(string(b))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token URL, %w", err)
+	}
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf("token URL scheme must be https, got %v", u.Scheme)
+	}
+	if !isSTSHost(u.Hostname()) {
+		return nil, fmt.Errorf("token URL host is not an STS endpoint, %v", u.Host)
+	}
+
+	query := u.Query()
+	if v := query.Get("Action"); v != "GetCallerIdentity" {
+		return nil, fmt.Errorf("token is not for the GetCallerIdentity action, %v", v)
+	}
+	if !isHeaderSigned(query.Get(signedHeadersKey), ClusterIDHeader) {
+		return nil, fmt.Errorf("token is missing the signed %v header", ClusterIDHeader)
+	}
+
+	signedAt, err := time.Parse(dateQueryFormat, query.Get(dateQueryKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token signing time, %w", err)
+	}
+	expires, err := strconv.ParseInt(query.Get(expiresQueryKey), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token expiry, %w", err)
+	}
+	expiration := signedAt.Add(time.Duration(expires) * time.Second)
+	if max := signedAt.Add(MaxExpires); expiration.After(max) {
+		expiration = max
+	}
+	if now := sdk.NowTime(); !now.Before(expiration) {
+		return nil, fmt.Errorf("token expired at %v", expiration.UTC())
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build token request, %w", err)
+	}
+	req.Header.Set(ClusterIDHeader, clusterID)
+
+	return req, nil
+}
+
+// isSTSHost returns if the host is an endpoint of STS, either the global
+// endpoint or a regional endpoint.
+func isSTSHost(host string) bool {
+	if host == "sts.amazonaws.com" {
+		return true
+	}
+
+	parts := strings.SplitN(host, ".", 3)
+	if len(parts) != 3 || parts[0] != "sts" || len(parts[1]) == 0 {
+		return false
+	}
+	switch parts[2] {
+	case "amazonaws.com", "amazonaws.com.cn":
+		return true
+	default:
+		return false
+	}
+}
+
+// isHeaderSigned returns if the header is one of the semicolon separated
+// signed headers.
+func isHeaderSigned(signedHeaders, header string) bool {
+	for _, h := range strings.Split(signedHeaders, ";") {
+		if strings.EqualFold(h, header) {
+			return true
+		}
+	}
+	return false
+}
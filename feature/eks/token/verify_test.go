@@ -0,0 +1,113 @@
+package token
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/internal/sdk"
+)
+
+func encodeTestToken(u string) string {
+	return Prefix + base64.RawURLEncoding.EncodeToString([]byte(u))
+}
+
+func TestVerifyToken(t *testing.T) {
+	defer func(fn func() time.Time) { sdk.NowTime = fn }(sdk.NowTime)
+
+	const validQuery = "Action=GetCallerIdentity&Version=2011-06-15" +
+		"&X-Amz-Algorithm=AWS4-HMAC-SHA256" +
+		"&X-Amz-Credential=AKID%2F20210203%2Fus-west-2%2Fsts%2Faws4_request" +
+		"&X-Amz-Date=20210203T040506Z&X-Amz-Expires=60" +
+		"&X-Amz-SignedHeaders=host%3Bx-k8s-aws-id&X-Amz-Signature=abc123"
+
+	cases := map[string]struct {
+		token       string
+		now         time.Time
+		expectedErr string
+	}{
+		"valid": {
+			token: encodeTestToken("https://sts.us-west-2.amazonaws.com/?" + validQuery),
+			now:   testSigningTime.Add(30 * time.Second),
+		},
+		"global endpoint": {
+			token: encodeTestToken("https://sts.amazonaws.com/?" + validQuery),
+			now:   testSigningTime,
+		},
+		"china endpoint": {
+			token: encodeTestToken("https://sts.cn-north-1.amazonaws.com.cn/?" + validQuery),
+			now:   testSigningTime,
+		},
+		"missing prefix": {
+			token:       base64.RawURLEncoding.EncodeToString([]byte("https://sts.amazonaws.com/?" + validQuery)),
+			now:         testSigningTime,
+			expectedErr: "prefix",
+		},
+		"invalid encoding": {
+			token:       Prefix + "%%%",
+			now:         testSigningTime,
+			expectedErr: "failed to decode token",
+		},
+		"not https": {
+			token:       encodeTestToken("http://sts.amazonaws.com/?" + validQuery),
+			now:         testSigningTime,
+			expectedErr: "scheme must be https",
+		},
+		"not STS host": {
+			token:       encodeTestToken("https://sts.example.com/?" + validQuery),
+			now:         testSigningTime,
+			expectedErr: "not an STS endpoint",
+		},
+		"not GetCallerIdentity": {
+			token:       encodeTestToken("https://sts.amazonaws.com/?" + strings.Replace(validQuery, "GetCallerIdentity", "AssumeRole", 1)),
+			now:         testSigningTime,
+			expectedErr: "GetCallerIdentity action",
+		},
+		"cluster ID not signed": {
+			token:       encodeTestToken("https://sts.amazonaws.com/?" + strings.Replace(validQuery, "host%3Bx-k8s-aws-id", "host", 1)),
+			now:         testSigningTime,
+			expectedErr: "signed x-k8s-aws-id header",
+		},
+		"expired": {
+			token:       encodeTestToken("https://sts.amazonaws.com/?" + validQuery),
+			now:         testSigningTime.Add(time.Minute),
+			expectedErr: "token expired",
+		},
+		"expiry over maximum": {
+			token:       encodeTestToken("https://sts.amazonaws.com/?" + strings.Replace(validQuery, "X-Amz-Expires=60", "X-Amz-Expires=3600", 1)),
+			now:         testSigningTime.Add(MaxExpires),
+			expectedErr: "token expired",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			sdk.NowTime = func() time.Time { return c.now }
+
+			req, err := VerifyToken(c.token, "my-cluster")
+			if len(c.expectedErr) != 0 {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				if e, a := c.expectedErr, err.Error(); !strings.Contains(a, e) {
+					t.Errorf("expect error to contain %v, got %v", e, a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			if e, a := "GET", req.Method; e != a {
+				t.Errorf("expect %v method, got %v", e, a)
+			}
+			if e, a := "my-cluster", req.Header.Get(ClusterIDHeader); e != a {
+				t.Errorf("expect %v cluster ID header, got %v", e, a)
+			}
+			if e, a := "abc123", req.URL.Query().Get("X-Amz-Signature"); e != a {
+				t.Errorf("expect %v signature, got %v", e, a)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/internal/sdk"
+)
+
+const (
+	signingID = "rds-db"
+
+	// The SHA256 hash of an empty payload.
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	// The time the authentication token is valid for. RDS does not accept
+	// tokens presigned for longer than 15 minutes.
+	tokenExpiresIn = 15 * time.Minute
+)
+
+// BuildAuthToken returns an authentication token to use as the password of a
+// DB connection with IAM database authentication.
+//
+// The token is a URL presigned with AWS Signature Version 4 for the
+// rds-db:connect action, without the URL scheme, and is valid for 15 minutes.
+//
+// * endpoint - The endpoint of the DB, including the port. <host>:<port>
+// * region - The region the DB is in.
+// * dbUser - The database user account to connect as.
+// * credentialsProvider - The provider of the credentials to sign the token with.
+//
+// The following example builds an authentication token to connect to a MySQL
+// DB instance.
+//
+//     authToken, err := auth.BuildAuthToken(
+//         context.TODO(), dbEndpoint, awsRegion, dbUser, cfg.Credentials)
+//     if err != nil {
+//         return err
+//     }
+//
+//     // user:password@protocol(endpoint)/dbname?<params>
+//     dsn := fmt.Sprintf("%s:%s@tcp(%s)/%s?allowCleartextPasswords=true&tls=rds",
+//         dbUser, authToken, dbEndpoint, dbName)
+//
+//     db, err := sql.Open("mysql", dsn)
+//
+// See https://docs.aws.amazon.com/AmazonRDS/latest/UserGuide/UsingWithRDS.IAMDBAuth.html
+// for more information on IAM database authentication with RDS.
+func BuildAuthToken(ctx context.Context, endpoint, region, dbUser string, credentialsProvider aws.CredentialsProvider) (string, error) {
+	if err := validateEndpoint(endpoint); err != nil {
+		return "", err
+	}
+	if len(region) == 0 {
+		return "", fmt.Errorf("region is required")
+	}
+	if len(dbUser) == 0 {
+		return "", fmt.Errorf("database user is required")
+	}
+	if credentialsProvider == nil {
+		return "", fmt.Errorf("credentials provider is required")
+	}
+
+	req, err := http.NewRequest("GET", "https://"+endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build auth token request, %w", err)
+	}
+
+	query := req.URL.Query()
+	query.Set("Action", "connect")
+	query.Set("DBUser", dbUser)
+	query.Set("X-Amz-Expires", strconv.FormatInt(int64(tokenExpiresIn/time.Second), 10))
+	req.URL.RawQuery = query.Encode()
+
+	credentials, err := credentialsProvider.Retrieve(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve credentials, %w", err)
+	}
+
+	signedURL, _, err := v4.NewSigner().PresignHTTP(ctx, credentials, req, emptyPayloadHash,
+		signingID, region, sdk.NowTime().UTC())
+	if err != nil {
+		return "", fmt.Errorf("failed to presign auth token, %w", err)
+	}
+
+	return strings.TrimPrefix(signedURL, "https://"), nil
+}
+
+// validateEndpoint returns an error if the endpoint is not a host and port,
+// without a URL scheme.
+func validateEndpoint(endpoint string) error {
+	if strings.Contains(endpoint, "://") {
+		return fmt.Errorf("endpoint must not include a URL scheme, %v", endpoint)
+	}
+
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return fmt.Errorf("endpoint must be a host and port, %v, %w", endpoint, err)
+	}
+	if len(host) == 0 {
+		return fmt.Errorf("endpoint is missing a host, %v", endpoint)
+	}
+	if p, err := strconv.ParseUint(port, 10, 16); err != nil || p == 0 {
+		return fmt.Errorf("endpoint has an invalid port, %v", endpoint)
+	}
+
+	return nil
+}
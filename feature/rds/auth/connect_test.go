@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/internal/awstesting/unit"
+	"github.com/aws/aws-sdk-go-v2/internal/sdk"
+)
+
+func TestBuildAuthToken(t *testing.T) {
+	defer func(fn func() time.Time) { sdk.NowTime = fn }(sdk.NowTime)
+	sdk.NowTime = func() time.Time {
+		return time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)
+	}
+
+	token, err := BuildAuthToken(context.Background(),
+		"prod-instance.us-east-1.rds.amazonaws.com:3306", "us-east-1", "mysqlUser",
+		unit.StubCredentialsProvider{})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	const expectPrefix = "prod-instance.us-east-1.rds.amazonaws.com:3306?"
+	if !strings.HasPrefix(token, expectPrefix) {
+		t.Fatalf("expect token to start with %v, got %v", expectPrefix, token)
+	}
+
+	query, err := url.ParseQuery(strings.TrimPrefix(token, expectPrefix))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	expect := url.Values{
+		"Action":               {"connect"},
+		"DBUser":               {"mysqlUser"},
+		"X-Amz-Algorithm":      {"AWS4-HMAC-SHA256"},
+		"X-Amz-Credential":     {"AKID/20210203/us-east-1/rds-db/aws4_request"},
+		"X-Amz-Date":           {"20210203T040506Z"},
+		"X-Amz-Expires":        {"900"},
+		"X-Amz-Security-Token": {"SESSION"},
+		"X-Amz-SignedHeaders":  {"host"},
+		"X-Amz-Signature":      {"6a8dc63e80df16a79a53545b2fc609d7555468a38252afd2539345b7f8548f19"},
+	}
+	for k := range expect {
+		if e, a := expect.Get(k), query.Get(k); e != a {
+			t.Errorf("expect %v %v, got %v", k, e, a)
+		}
+	}
+	if e, a := len(expect), len(query); e != a {
+		t.Errorf("expect %v query parameters, got %v, %v", e, a, query)
+	}
+}
+
+func TestBuildAuthToken_Errors(t *testing.T) {
+	cases := map[string]struct {
+		endpoint    string
+		region      string
+		dbUser      string
+		creds       aws.CredentialsProvider
+		expectedErr string
+	}{
+		"missing port": {
+			endpoint:    "prod-instance.us-east-1.rds.amazonaws.com",
+			region:      "us-east-1",
+			dbUser:      "mysqlUser",
+			creds:       unit.StubCredentialsProvider{},
+			expectedErr: "host and port",
+		},
+		"invalid port": {
+			endpoint:    "prod-instance.us-east-1.rds.amazonaws.com:abc",
+			region:      "us-east-1",
+			dbUser:      "mysqlUser",
+			creds:       unit.StubCredentialsProvider{},
+			expectedErr: "invalid port",
+		},
+		"with scheme": {
+			endpoint:    "https://prod-instance.us-east-1.rds.amazonaws.com:3306",
+			region:      "us-east-1",
+			dbUser:      "mysqlUser",
+			creds:       unit.StubCredentialsProvider{},
+			expectedErr: "URL scheme",
+		},
+		"missing region": {
+			endpoint:    "prod-instance.us-east-1.rds.amazonaws.com:3306",
+			dbUser:      "mysqlUser",
+			creds:       unit.StubCredentialsProvider{},
+			expectedErr: "region is required",
+		},
+		"missing user": {
+			endpoint:    "prod-instance.us-east-1.rds.amazonaws.com:3306",
+			region:      "us-east-1",
+			creds:       unit.StubCredentialsProvider{},
+			expectedErr: "user is required",
+		},
+		"missing credentials": {
+			endpoint:    "prod-instance.us-east-1.rds.amazonaws.com:3306",
+			region:      "us-east-1",
+			dbUser:      "mysqlUser",
+			expectedErr: "credentials provider is required",
+		},
+		"credentials error": {
+			endpoint: "prod-instance.us-east-1.rds.amazonaws.com:3306",
+			region:   "us-east-1",
+			dbUser:   "mysqlUser",
+			creds: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+				return aws.Credentials{}, fmt.Errorf("retrieve error")
+			}),
+			expectedErr: "retrieve error",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := BuildAuthToken(context.Background(), c.endpoint, c.region, c.dbUser, c.creds)
+			if err == nil {
+				t.Fatalf("expect error, got none")
+			}
+			if e, a := c.expectedErr, err.Error(); !strings.Contains(a, e) {
+				t.Errorf("expect error to contain %v, got %v", e, a)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+/*
+Package auth provides utilities for building authentication tokens to connect
+to Amazon RDS DB instances and clusters with IAM database authentication.
+
+An authentication token is used in place of a password when connecting to the
+DB with a database user configured for IAM database authentication. Tokens are
+valid for 15 minutes, and should be built for each new connection.
+
+  authToken, err := auth.BuildAuthToken(context.TODO(),
+      "mydb.123456789012.us-east-1.rds.amazonaws.com:3306",
+      "us-east-1", "dbuser", cfg.Credentials)
+*/
+package auth
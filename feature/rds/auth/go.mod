@@ -0,0 +1,7 @@
+module github.com/aws/aws-sdk-go-v2/feature/rds/auth
+
+go 1.15
+
+require github.com/aws/aws-sdk-go-v2 v1.2.0
+
+replace github.com/aws/aws-sdk-go-v2 => ../../../
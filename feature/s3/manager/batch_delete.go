@@ -0,0 +1,402 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// DefaultBatchDeleteSize is the default number of objects deleted by each
+// DeleteObjects request made by BatchDelete. This is the maximum number of
+// keys S3 allows in a single DeleteObjects request.
+const DefaultBatchDeleteSize = 1000
+
+// DefaultBatchDeleteConcurrency is the default number of goroutines to spin up
+// when using BatchDelete.
+const DefaultBatchDeleteConcurrency = 5
+
+// BatchDeleteObject is an object to be deleted by BatchDelete.
+type BatchDeleteObject struct {
+	// The bucket containing the object.
+	Bucket *string
+
+	// The key of the object.
+	Key *string
+
+	// The version of the object. If not set the latest version of the object
+	// will be deleted.
+	VersionID *string
+}
+
+// BatchDeleteIterator is an interface that iterates over the objects to be
+// deleted by BatchDelete.
+type BatchDeleteIterator interface {
+	// Next advances the iterator to the next object, returning false if there
+	// are no more objects, or an error occurred.
+	Next(context.Context) bool
+
+	// Err returns the error, if any, that caused the iterator to stop.
+	Err() error
+
+	// DeleteObject returns the current object the iterator is positioned at.
+	DeleteObject() BatchDeleteObject
+}
+
+// DeleteObjectsIterator is a BatchDeleteIterator over a caller-supplied list
+// of objects.
+type DeleteObjectsIterator struct {
+	Objects []BatchDeleteObject
+
+	index int
+	inc   bool
+}
+
+// Next advances the iterator to the next object in the list.
+func (iter *DeleteObjectsIterator) Next(context.Context) bool {
+	if iter.inc {
+		iter.index++
+	} else {
+		iter.inc = true
+	}
+	return iter.index < len(iter.Objects)
+}
+
+// Err always returns nil, as iterating over a list cannot fail.
+func (iter *DeleteObjectsIterator) Err() error {
+	return nil
+}
+
+// DeleteObject returns the current object.
+func (iter *DeleteObjectsIterator) DeleteObject() BatchDeleteObject {
+	return iter.Objects[iter.index]
+}
+
+// DeleteListIterator is a BatchDeleteIterator over the objects listed by the
+// ListObjectsV2 operation, such as all of the objects under a prefix.
+type DeleteListIterator struct {
+	bucket    *string
+	paginator *s3.ListObjectsV2Paginator
+	objects   []types.Object
+	err       error
+}
+
+// NewDeleteListIterator returns a DeleteListIterator that will delete all of
+// the objects listed by the ListObjectsV2 input parameters. Pages of objects
+// are only listed as the iterator is advanced.
+//
+// Example:
+//
+//	iter := manager.NewDeleteListIterator(client, &s3.ListObjectsV2Input{
+//		Bucket: aws.String("bucket"),
+//		Prefix: aws.String("prefix/"),
+//	})
+//
+//	err := manager.NewBatchDelete(client).Delete(context.TODO(), iter)
+func NewDeleteListIterator(client ListObjectsV2APIClient, params *s3.ListObjectsV2Input, optFns ...func(*s3.ListObjectsV2PaginatorOptions)) *DeleteListIterator {
+	return &DeleteListIterator{
+		bucket:    params.Bucket,
+		paginator: s3.NewListObjectsV2Paginator(client, params, optFns...),
+	}
+}
+
+// Next advances the iterator to the next listed object, listing the next page
+// of objects if needed.
+func (iter *DeleteListIterator) Next(ctx context.Context) bool {
+	if len(iter.objects) > 0 {
+		iter.objects = iter.objects[1:]
+	}
+
+	for len(iter.objects) == 0 && iter.paginator.HasMorePages() {
+		page, err := iter.paginator.NextPage(ctx)
+		if err != nil {
+			iter.err = err
+			return false
+		}
+		iter.objects = page.Contents
+	}
+
+	return len(iter.objects) > 0
+}
+
+// Err returns the error, if any, returned by ListObjectsV2.
+func (iter *DeleteListIterator) Err() error {
+	return iter.err
+}
+
+// DeleteObject returns the current listed object.
+func (iter *DeleteListIterator) DeleteObject() BatchDeleteObject {
+	return BatchDeleteObject{
+		Bucket: iter.bucket,
+		Key:    iter.objects[0].Key,
+	}
+}
+
+// BatchDeleteObjectError is the error for a single object BatchDelete failed
+// to delete.
+type BatchDeleteObjectError struct {
+	Bucket    string
+	Key       string
+	VersionID string
+
+	// The error code and message returned by S3 for the object. Empty if the
+	// DeleteObjects request itself failed.
+	Code    string
+	Message string
+
+	// The error of the DeleteObjects request the object was part of, if the
+	// request failed.
+	Err error
+}
+
+// Error returns the string representation of the error.
+func (e *BatchDeleteObjectError) Error() string {
+	var version string
+	if len(e.VersionID) != 0 {
+		version = fmt.Sprintf(", version %s", e.VersionID)
+	}
+	if e.Err != nil {
+		return fmt.Sprintf("failed to delete %s/%s%s, %v", e.Bucket, e.Key, version, e.Err)
+	}
+	return fmt.Sprintf("failed to delete %s/%s%s, %s: %s", e.Bucket, e.Key, version, e.Code, e.Message)
+}
+
+// Unwrap returns the error of the DeleteObjects request, if any.
+func (e *BatchDeleteObjectError) Unwrap() error {
+	return e.Err
+}
+
+// BatchDeleteError is the aggregated error returned by BatchDelete when one
+// or more objects could not be deleted.
+//
+// Example:
+//
+//	err := manager.NewBatchDelete(client).Delete(context.TODO(), iter)
+//	if err != nil {
+//		var batchErr *manager.BatchDeleteError
+//		if errors.As(err, &batchErr) {
+//			for _, objErr := range batchErr.Errors {
+//				fmt.Println(objErr.Error())
+//			}
+//		}
+//	}
+type BatchDeleteError struct {
+	Errors []*BatchDeleteObjectError
+}
+
+// Error returns the string representation of the error.
+func (e *BatchDeleteError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "batch delete failed to delete %d objects", len(e.Errors))
+	for i, err := range e.Errors {
+		if i == 3 {
+			fmt.Fprintf(&sb, "\n\t... and %d more", len(e.Errors)-i)
+			break
+		}
+		fmt.Fprintf(&sb, "\n\t%s", err.Error())
+	}
+	return sb.String()
+}
+
+// WithBatchDeleteClientOptions appends to the BatchDelete's API client options.
+func WithBatchDeleteClientOptions(opts ...func(*s3.Options)) func(*BatchDelete) {
+	return func(d *BatchDelete) {
+		d.ClientOptions = append(d.ClientOptions, opts...)
+	}
+}
+
+// BatchDelete deletes objects from S3 in batches using concurrent
+// DeleteObjects requests. It is safe to call Delete() on this structure
+// across concurrent goroutines. Mutating the BatchDelete's properties is not
+// safe to be done concurrently.
+type BatchDelete struct {
+	// The number of objects to delete with each DeleteObjects request. If this
+	// value is zero, or greater than DefaultBatchDeleteSize, the
+	// DefaultBatchDeleteSize value will be used.
+	BatchSize int
+
+	// The number of goroutines to spin up in parallel when sending batches. If
+	// this is set to zero, the DefaultBatchDeleteConcurrency value will be
+	// used.
+	Concurrency int
+
+	// The client to use when deleting objects.
+	S3 DeleteObjectsAPIClient
+
+	// List of client options that will be passed down to individual API
+	// operation requests made by the batch delete.
+	ClientOptions []func(*s3.Options)
+}
+
+// NewBatchDelete creates a new BatchDelete instance to delete objects from S3
+// in concurrent batches. Pass in additional functional options to customize
+// the batch delete behavior.
+func NewBatchDelete(client DeleteObjectsAPIClient, options ...func(*BatchDelete)) *BatchDelete {
+	d := &BatchDelete{
+		S3:          client,
+		BatchSize:   DefaultBatchDeleteSize,
+		Concurrency: DefaultBatchDeleteConcurrency,
+	}
+	for _, option := range options {
+		option(d)
+	}
+
+	return d
+}
+
+// Delete deletes all of the objects returned by the iterator. Objects are
+// grouped by bucket into batches of up to BatchSize keys, and the batches are
+// deleted concurrently.
+//
+// If one or more objects could not be deleted, a *BatchDeleteError is
+// returned listing each object that failed. If the iterator fails, its error
+// is returned after any in-flight batches complete.
+func (d BatchDelete) Delete(ctx context.Context, iter BatchDeleteIterator, opts ...func(*BatchDelete)) error {
+	for _, opt := range opts {
+		opt(&d)
+	}
+	if d.BatchSize <= 0 || d.BatchSize > DefaultBatchDeleteSize {
+		d.BatchSize = DefaultBatchDeleteSize
+	}
+	if d.Concurrency <= 0 {
+		d.Concurrency = DefaultBatchDeleteConcurrency
+	}
+
+	clientOptions := make([]func(*s3.Options), 0, len(d.ClientOptions)+1)
+	clientOptions = append(clientOptions, func(o *s3.Options) {
+		o.APIOptions = append(o.APIOptions, middleware.AddSDKAgentKey(middleware.FeatureMetadata, userAgentKey))
+	})
+	clientOptions = append(clientOptions, d.ClientOptions...)
+
+	b := batchDeleter{
+		ctx:           ctx,
+		cfg:           d,
+		clientOptions: clientOptions,
+		batches:       make(chan deleteBatch, d.Concurrency),
+		pending:       map[string]*deleteBatch{},
+	}
+	return b.delete(iter)
+}
+
+// deleteBatch is a set of objects in a single bucket to be deleted with one
+// DeleteObjects request.
+type deleteBatch struct {
+	bucket  string
+	objects []types.ObjectIdentifier
+}
+
+// batchDeleter tracks the state of a single BatchDelete.Delete call.
+type batchDeleter struct {
+	ctx           context.Context
+	cfg           BatchDelete
+	clientOptions []func(*s3.Options)
+
+	batches chan deleteBatch
+	wg      sync.WaitGroup
+
+	// batches being filled, keyed by bucket, in the order the buckets were
+	// first seen.
+	pending      map[string]*deleteBatch
+	pendingOrder []string
+
+	m      sync.Mutex
+	errors []*BatchDeleteObjectError
+}
+
+func (b *batchDeleter) delete(iter BatchDeleteIterator) error {
+	for i := 0; i < b.cfg.Concurrency; i++ {
+		b.wg.Add(1)
+		go b.deleteBatches()
+	}
+
+	for b.ctx.Err() == nil && iter.Next(b.ctx) {
+		obj := iter.DeleteObject()
+		bucket := aws.ToString(obj.Bucket)
+
+		batch, ok := b.pending[bucket]
+		if !ok {
+			batch = &deleteBatch{bucket: bucket}
+			b.pending[bucket] = batch
+			b.pendingOrder = append(b.pendingOrder, bucket)
+		}
+		batch.objects = append(batch.objects, types.ObjectIdentifier{
+			Key:       obj.Key,
+			VersionId: obj.VersionID,
+		})
+
+		if len(batch.objects) >= b.cfg.BatchSize {
+			b.batches <- *batch
+			batch.objects = nil
+		}
+	}
+
+	if b.ctx.Err() == nil && iter.Err() == nil {
+		for _, bucket := range b.pendingOrder {
+			if batch := b.pending[bucket]; len(batch.objects) != 0 {
+				b.batches <- *batch
+			}
+		}
+	}
+
+	close(b.batches)
+	b.wg.Wait()
+
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("failed to iterate objects to delete, %w", err)
+	}
+	if err := b.ctx.Err(); err != nil {
+		return err
+	}
+	if len(b.errors) != 0 {
+		return &BatchDeleteError{Errors: b.errors}
+	}
+	return nil
+}
+
+func (b *batchDeleter) deleteBatches() {
+	defer b.wg.Done()
+
+	for batch := range b.batches {
+		b.deleteBatch(batch)
+	}
+}
+
+func (b *batchDeleter) deleteBatch(batch deleteBatch) {
+	out, err := b.cfg.S3.DeleteObjects(b.ctx, &s3.DeleteObjectsInput{
+		Bucket: aws.String(batch.bucket),
+		Delete: &types.Delete{
+			Objects: batch.objects,
+			Quiet:   true,
+		},
+	}, b.clientOptions...)
+
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	if err != nil {
+		for _, obj := range batch.objects {
+			b.errors = append(b.errors, &BatchDeleteObjectError{
+				Bucket:    batch.bucket,
+				Key:       aws.ToString(obj.Key),
+				VersionID: aws.ToString(obj.VersionId),
+				Err:       err,
+			})
+		}
+		return
+	}
+
+	for _, objErr := range out.Errors {
+		b.errors = append(b.errors, &BatchDeleteObjectError{
+			Bucket:    batch.bucket,
+			Key:       aws.ToString(objErr.Key),
+			VersionID: aws.ToString(objErr.VersionId),
+			Code:      aws.ToString(objErr.Code),
+			Message:   aws.ToString(objErr.Message),
+		})
+	}
+}
@@ -0,0 +1,289 @@
+package manager_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+type batchDeleteClient struct {
+	m      sync.Mutex
+	params []*s3.DeleteObjectsInput
+
+	deleteObjectsFn func(*s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error)
+}
+
+func (c *batchDeleteClient) DeleteObjects(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+	c.m.Lock()
+	c.params = append(c.params, params)
+	c.m.Unlock()
+
+	if c.deleteObjectsFn != nil {
+		return c.deleteObjectsFn(params)
+	}
+	return &s3.DeleteObjectsOutput{}, nil
+}
+
+type listObjectsV2Client struct {
+	pages []*s3.ListObjectsV2Output
+	err   error
+	calls int
+}
+
+func (c *listObjectsV2Client) ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	if c.calls == len(c.pages) {
+		return nil, c.err
+	}
+	page := c.pages[c.calls]
+	c.calls++
+	return page, nil
+}
+
+func batchDeleteObjects(bucket string, n int) []manager.BatchDeleteObject {
+	objects := make([]manager.BatchDeleteObject, n)
+	for i := range objects {
+		objects[i] = manager.BatchDeleteObject{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(fmt.Sprintf("key%d", i)),
+		}
+	}
+	return objects
+}
+
+func TestBatchDelete_Batches(t *testing.T) {
+	cases := map[string]struct {
+		objects     []manager.BatchDeleteObject
+		batchSize   int
+		expectSizes map[string][]int
+	}{
+		"no objects": {
+			expectSizes: map[string][]int{},
+		},
+		"single batch": {
+			objects: batchDeleteObjects("bucket", 10),
+			expectSizes: map[string][]int{
+				"bucket": {10},
+			},
+		},
+		"multiple batches": {
+			objects: batchDeleteObjects("bucket", 2500),
+			expectSizes: map[string][]int{
+				"bucket": {500, 1000, 1000},
+			},
+		},
+		"batch size capped": {
+			objects:   batchDeleteObjects("bucket", 2000),
+			batchSize: 5000,
+			expectSizes: map[string][]int{
+				"bucket": {1000, 1000},
+			},
+		},
+		"custom batch size": {
+			objects:   batchDeleteObjects("bucket", 25),
+			batchSize: 10,
+			expectSizes: map[string][]int{
+				"bucket": {5, 10, 10},
+			},
+		},
+		"multiple buckets": {
+			objects: append(batchDeleteObjects("bucket1", 1200), batchDeleteObjects("bucket2", 30)...),
+			expectSizes: map[string][]int{
+				"bucket1": {200, 1000},
+				"bucket2": {30},
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := &batchDeleteClient{}
+			err := manager.NewBatchDelete(client, func(d *manager.BatchDelete) {
+				if c.batchSize != 0 {
+					d.BatchSize = c.batchSize
+				}
+			}).Delete(context.Background(), &manager.DeleteObjectsIterator{Objects: c.objects})
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			sizes := map[string][]int{}
+			keys := map[string]struct{}{}
+			for _, params := range client.params {
+				bucket := aws.ToString(params.Bucket)
+				sizes[bucket] = append(sizes[bucket], len(params.Delete.Objects))
+				if !params.Delete.Quiet {
+					t.Errorf("expect quiet delete")
+				}
+				for _, obj := range params.Delete.Objects {
+					keys[bucket+"/"+aws.ToString(obj.Key)] = struct{}{}
+				}
+			}
+			for _, s := range sizes {
+				sort.Ints(s)
+			}
+
+			if e, a := len(c.expectSizes), len(sizes); e != a {
+				t.Fatalf("expect %v buckets, got %v", e, a)
+			}
+			for bucket, expect := range c.expectSizes {
+				actual := sizes[bucket]
+				if e, a := fmt.Sprint(expect), fmt.Sprint(actual); e != a {
+					t.Errorf("expect %v batch sizes for %v, got %v", e, bucket, a)
+				}
+			}
+			if e, a := len(c.objects), len(keys); e != a {
+				t.Errorf("expect %v unique keys deleted, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestBatchDelete_Errors(t *testing.T) {
+	client := &batchDeleteClient{
+		deleteObjectsFn: func(params *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error) {
+			first := aws.ToString(params.Delete.Objects[0].Key)
+			switch first {
+			case "key0":
+				return nil, fmt.Errorf("mock request error")
+			default:
+				return &s3.DeleteObjectsOutput{
+					Errors: []types.Error{
+						{
+							Key:     params.Delete.Objects[1].Key,
+							Code:    aws.String("AccessDenied"),
+							Message: aws.String("Access Denied"),
+						},
+					},
+				}, nil
+			}
+		},
+	}
+
+	err := manager.NewBatchDelete(client, func(d *manager.BatchDelete) {
+		d.BatchSize = 3
+	}).Delete(context.Background(), &manager.DeleteObjectsIterator{
+		Objects: batchDeleteObjects("bucket", 6),
+	})
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+
+	var batchErr *manager.BatchDeleteError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expect BatchDeleteError, got %T", err)
+	}
+	if e, a := 4, len(batchErr.Errors); e != a {
+		t.Fatalf("expect %v object errors, got %v", e, a)
+	}
+
+	errs := map[string]*manager.BatchDeleteObjectError{}
+	for _, objErr := range batchErr.Errors {
+		errs[objErr.Key] = objErr
+	}
+	for _, key := range []string{"key0", "key1", "key2"} {
+		objErr, ok := errs[key]
+		if !ok {
+			t.Fatalf("expect %v error", key)
+		}
+		if objErr.Err == nil {
+			t.Errorf("expect %v request error", key)
+		}
+		if e, a := "bucket", objErr.Bucket; e != a {
+			t.Errorf("expect %v bucket, got %v", e, a)
+		}
+	}
+	objErr, ok := errs["key4"]
+	if !ok {
+		t.Fatalf("expect key4 error")
+	}
+	if e, a := "AccessDenied", objErr.Code; e != a {
+		t.Errorf("expect %v code, got %v", e, a)
+	}
+	if objErr.Err != nil {
+		t.Errorf("expect no request error, got %v", objErr.Err)
+	}
+	if e, a := "failed to delete 4 objects", err.Error(); !strings.Contains(a, e) {
+		t.Errorf("expect error to contain %v, got %v", e, a)
+	}
+}
+
+func TestBatchDelete_ListIterator(t *testing.T) {
+	listClient := &listObjectsV2Client{
+		pages: []*s3.ListObjectsV2Output{
+			{
+				Contents:              []types.Object{{Key: aws.String("prefix/a")}, {Key: aws.String("prefix/b")}},
+				IsTruncated:           true,
+				NextContinuationToken: aws.String("token1"),
+			},
+			{
+				IsTruncated:           true,
+				NextContinuationToken: aws.String("token2"),
+			},
+			{
+				Contents: []types.Object{{Key: aws.String("prefix/c")}},
+			},
+		},
+	}
+
+	iter := manager.NewDeleteListIterator(listClient, &s3.ListObjectsV2Input{
+		Bucket: aws.String("bucket"),
+		Prefix: aws.String("prefix/"),
+	})
+
+	client := &batchDeleteClient{}
+	if err := manager.NewBatchDelete(client).Delete(context.Background(), iter); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if e, a := 1, len(client.params); e != a {
+		t.Fatalf("expect %v requests, got %v", e, a)
+	}
+	var keys []string
+	for _, obj := range client.params[0].Delete.Objects {
+		keys = append(keys, aws.ToString(obj.Key))
+	}
+	if e, a := "prefix/a,prefix/b,prefix/c", strings.Join(keys, ","); e != a {
+		t.Errorf("expect %v keys, got %v", e, a)
+	}
+	if e, a := "bucket", aws.ToString(client.params[0].Bucket); e != a {
+		t.Errorf("expect %v bucket, got %v", e, a)
+	}
+}
+
+func TestBatchDelete_ListIteratorError(t *testing.T) {
+	listClient := &listObjectsV2Client{
+		pages: []*s3.ListObjectsV2Output{
+			{
+				Contents:              []types.Object{{Key: aws.String("prefix/a")}},
+				IsTruncated:           true,
+				NextContinuationToken: aws.String("token1"),
+			},
+		},
+		err: fmt.Errorf("mock list error"),
+	}
+
+	iter := manager.NewDeleteListIterator(listClient, &s3.ListObjectsV2Input{
+		Bucket: aws.String("bucket"),
+	})
+
+	client := &batchDeleteClient{}
+	err := manager.NewBatchDelete(client).Delete(context.Background(), iter)
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+	if e, a := "mock list error", err.Error(); !strings.Contains(a, e) {
+		t.Errorf("expect error to contain %v, got %v", e, a)
+	}
+	if e, a := 0, len(client.params); e != a {
+		t.Errorf("expect %v requests, got %v", e, a)
+	}
+}
@@ -0,0 +1,513 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/internal/awsutil"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// DefaultCopyPartSize is the default part size to copy with each
+// UploadPartCopy request made by Copy(). Objects no larger than the part size
+// are copied with a single CopyObject request.
+const DefaultCopyPartSize = 1024 * 1024 * 64
+
+// DefaultCopyConcurrency is the default number of goroutines to spin up when
+// using Copy().
+const DefaultCopyConcurrency = 5
+
+// MaxCopyPartSize is the maximum allowed part size when copying a part of an
+// object with UploadPartCopy.
+const MaxCopyPartSize = 1024 * 1024 * 1024 * 5
+
+// CopyOutput represents a response from the Copy() call.
+type CopyOutput struct {
+	// The entity tag of the copied object.
+	ETag *string
+
+	// The version of the copied object. Will only be populated if the
+	// destination bucket is versioned.
+	VersionID *string
+
+	// The version of the source object that was copied.
+	CopySourceVersionID *string
+
+	// The ID for a multipart upload to S3. In the case of an error the error
+	// can be cast to the MultiUploadFailure interface to extract the upload ID.
+	// Empty if the object was copied with a single CopyObject request.
+	UploadID string
+}
+
+// WithCopierClientOptions appends to the Copier's API client options.
+func WithCopierClientOptions(opts ...func(*s3.Options)) func(*Copier) {
+	return func(c *Copier) {
+		c.ClientOptions = append(c.ClientOptions, opts...)
+	}
+}
+
+// The Copier structure that calls Copy(). It is safe to call Copy() on this
+// structure for multiple objects and across concurrent goroutines. Mutating
+// the Copier's properties is not safe to be done concurrently.
+type Copier struct {
+	// The size (in bytes) of each part copied with UploadPartCopy. The minimum
+	// allowed part size is 5MB, and if this value is set to zero, the
+	// DefaultCopyPartSize value will be used. The part size is increased if
+	// the object would be copied in more than MaxUploadParts parts.
+	PartSize int64
+
+	// The number of goroutines to spin up in parallel per call to Copy when
+	// copying parts. If this is set to zero, the DefaultCopyConcurrency value
+	// will be used.
+	Concurrency int
+
+	// Setting this value to true will cause the SDK to avoid calling
+	// AbortMultipartUpload on a failure, leaving all successfully copied
+	// parts on S3 for manual recovery.
+	//
+	// Note that storing parts of an incomplete multipart upload counts towards
+	// space usage on S3 and will add additional costs if not cleaned up.
+	LeavePartsOnError bool
+
+	// MaxUploadParts is the max number of parts which will be copied. Will be
+	// used to increase the part size of large objects.
+	//
+	// Defaults to package const's MaxUploadParts value.
+	MaxUploadParts int32
+
+	// Setting this value to true will cause the destination object to be
+	// encrypted with the source object's server side encryption settings,
+	// unless the input sets the ServerSideEncryption, or SSEKMSKeyId. Source
+	// objects encrypted with customer provided keys are not affected.
+	PreserveServerSideEncryption bool
+
+	// The client to use when copying objects.
+	S3 CopyAPIClient
+
+	// List of client options that will be passed down to individual API
+	// operation requests made by the copier.
+	ClientOptions []func(*s3.Options)
+}
+
+// NewCopier creates a new Copier instance to copy objects within S3. Pass in
+// additional functional options to customize the copier's behavior.
+//
+// Example:
+//
+//	// Create a copier with the client and custom options
+//	copier := manager.NewCopier(client, func(c *manager.Copier) {
+//		c.PartSize = 256 * 1024 * 1024 // 256MB per part
+//	})
+func NewCopier(client CopyAPIClient, options ...func(*Copier)) *Copier {
+	c := &Copier{
+		S3:             client,
+		PartSize:       DefaultCopyPartSize,
+		Concurrency:    DefaultCopyConcurrency,
+		MaxUploadParts: MaxUploadParts,
+	}
+	for _, option := range options {
+		option(c)
+	}
+
+	return c
+}
+
+// Copy copies an object within S3, from the input's CopySource to the
+// input's Bucket and Key. The source object is described with HeadObject,
+// and objects larger than the PartSize are copied with a multipart upload,
+// copying byte ranges of the source object concurrently with UploadPartCopy.
+// Each part copy requires the source object's ETag to match, so that changes
+// to the source object during the copy fail the copy.
+//
+// As with CopyObject, the source object's metadata and tags are copied unless
+// the input's MetadataDirective, or TaggingDirective, is REPLACE. Copying tags
+// of a multipart copy uses GetObjectTagging.
+//
+// Additional functional options can be provided to configure the individual
+// copy. These options are copies of the Copier instance Copy is called from.
+// Modifying the options will not impact the original Copier instance.
+//
+// It is safe to call this method concurrently across goroutines.
+func (c Copier) Copy(ctx context.Context, input *s3.CopyObjectInput, opts ...func(*Copier)) (*CopyOutput, error) {
+	i := copier{in: input, cfg: c, ctx: ctx}
+
+	// Copy ClientOptions
+	clientOptions := make([]func(*s3.Options), 0, len(i.cfg.ClientOptions)+1)
+	clientOptions = append(clientOptions, func(o *s3.Options) {
+		o.APIOptions = append(o.APIOptions, middleware.AddSDKAgentKey(middleware.FeatureMetadata, userAgentKey))
+	})
+	clientOptions = append(clientOptions, i.cfg.ClientOptions...)
+	i.cfg.ClientOptions = clientOptions
+
+	for _, opt := range opts {
+		opt(&i.cfg)
+	}
+
+	return i.copy()
+}
+
+// internal structure to manage a copy within S3.
+type copier struct {
+	ctx context.Context
+	cfg Copier
+
+	in *s3.CopyObjectInput
+
+	// the source object
+	head *s3.HeadObjectOutput
+}
+
+// init will initialize all default options.
+func (c *copier) init() error {
+	if c.cfg.Concurrency == 0 {
+		c.cfg.Concurrency = DefaultCopyConcurrency
+	}
+	if c.cfg.PartSize == 0 {
+		c.cfg.PartSize = DefaultCopyPartSize
+	}
+	if c.cfg.MaxUploadParts == 0 {
+		c.cfg.MaxUploadParts = MaxUploadParts
+	}
+
+	if c.cfg.PartSize < MinUploadPartSize {
+		return fmt.Errorf("part size must be at least %d bytes", MinUploadPartSize)
+	}
+	if c.cfg.PartSize > MaxCopyPartSize {
+		return fmt.Errorf("part size must be at most %d bytes", MaxCopyPartSize)
+	}
+	return nil
+}
+
+func (c *copier) copy() (*CopyOutput, error) {
+	if err := c.init(); err != nil {
+		return nil, fmt.Errorf("unable to initialize copy: %w", err)
+	}
+
+	bucket, key, versionID, err := parseCopySource(aws.ToString(c.in.CopySource))
+	if err != nil {
+		return nil, err
+	}
+
+	c.head, err = c.cfg.S3.HeadObject(c.ctx, &s3.HeadObjectInput{
+		Bucket:               aws.String(bucket),
+		Key:                  aws.String(key),
+		VersionId:            versionID,
+		IfMatch:              c.in.CopySourceIfMatch,
+		IfNoneMatch:          c.in.CopySourceIfNoneMatch,
+		IfModifiedSince:      c.in.CopySourceIfModifiedSince,
+		IfUnmodifiedSince:    c.in.CopySourceIfUnmodifiedSince,
+		SSECustomerAlgorithm: c.in.CopySourceSSECustomerAlgorithm,
+		SSECustomerKey:       c.in.CopySourceSSECustomerKey,
+		SSECustomerKeyMD5:    c.in.CopySourceSSECustomerKeyMD5,
+		ExpectedBucketOwner:  c.in.ExpectedSourceBucketOwner,
+		RequestPayer:         c.in.RequestPayer,
+	}, c.cfg.ClientOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe copy source, %w", err)
+	}
+
+	// Adjust the part size so the object fits within the max number of parts.
+	size := c.head.ContentLength
+	if size/c.cfg.PartSize >= int64(c.cfg.MaxUploadParts) {
+		c.cfg.PartSize = (size / int64(c.cfg.MaxUploadParts)) + 1
+		if c.cfg.PartSize > MaxCopyPartSize {
+			return nil, fmt.Errorf("copy source of %d bytes exceeds the maximum size that can be copied in %d parts",
+				size, c.cfg.MaxUploadParts)
+		}
+	}
+
+	if size <= c.cfg.PartSize {
+		return c.singlePart()
+	}
+
+	mc := multicopier{copier: c}
+	return mc.copy()
+}
+
+// parseCopySource parses the bucket, key, and optional version ID out of the
+// URL encoded CopySource.
+func parseCopySource(v string) (bucket, key string, versionID *string, err error) {
+	source := strings.TrimPrefix(v, "/")
+	if idx := strings.Index(source, "?"); idx != -1 {
+		query, err := url.ParseQuery(source[idx+1:])
+		if err != nil {
+			return "", "", nil, fmt.Errorf("invalid copy source %q, %w", v, err)
+		}
+		if version := query.Get("versionId"); len(version) != 0 {
+			versionID = aws.String(version)
+		}
+		source = source[:idx]
+	}
+
+	source, err = url.PathUnescape(source)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("invalid copy source %q, %w", v, err)
+	}
+
+	parts := strings.SplitN(source, "/", 2)
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return "", "", nil, fmt.Errorf("invalid copy source %q, expect bucket/key", v)
+	}
+
+	return parts[0], parts[1], versionID, nil
+}
+
+// serverSideEncryption returns the server side encryption settings
+// of the destination object, preserving the source object's settings if
+// enabled.
+func (c *copier) serverSideEncryption() (types.ServerSideEncryption, *string, bool) {
+	sse, kmsKeyID, bucketKey := c.in.ServerSideEncryption, c.in.SSEKMSKeyId, c.in.BucketKeyEnabled
+	if c.cfg.PreserveServerSideEncryption && len(sse) == 0 && kmsKeyID == nil {
+		sse, kmsKeyID = c.head.ServerSideEncryption, c.head.SSEKMSKeyId
+		bucketKey = bucketKey || c.head.BucketKeyEnabled
+	}
+	return sse, kmsKeyID, bucketKey
+}
+
+// singlePart copies the object with a single CopyObject request.
+func (c *copier) singlePart() (*CopyOutput, error) {
+	params := &s3.CopyObjectInput{}
+	awsutil.Copy(params, c.in)
+	params.ServerSideEncryption, params.SSEKMSKeyId, params.BucketKeyEnabled = c.serverSideEncryption()
+	if params.CopySourceIfMatch == nil {
+		params.CopySourceIfMatch = c.head.ETag
+	}
+
+	out, err := c.cfg.S3.CopyObject(c.ctx, params, c.cfg.ClientOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &CopyOutput{
+		VersionID:           out.VersionId,
+		CopySourceVersionID: out.CopySourceVersionId,
+	}
+	if out.CopyObjectResult != nil {
+		output.ETag = out.CopyObjectResult.ETag
+	}
+	return output, nil
+}
+
+// internal structure to manage a specific multipart copy to S3.
+type multicopier struct {
+	*copier
+	wg       sync.WaitGroup
+	m        sync.Mutex
+	err      error
+	uploadID string
+	parts    completedParts
+}
+
+// keeps track of a single byte range of the source object being copied.
+type copyPart struct {
+	num        int32
+	start, end int64
+}
+
+// copy will perform a multipart copy.
+func (u *multicopier) copy() (*CopyOutput, error) {
+	params, err := u.createMultipartUploadInput()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := u.cfg.S3.CreateMultipartUpload(u.ctx, params, u.cfg.ClientOptions...)
+	if err != nil {
+		return nil, err
+	}
+	u.uploadID = *resp.UploadId
+
+	ch := make(chan copyPart, u.cfg.Concurrency)
+	for i := 0; i < u.cfg.Concurrency; i++ {
+		u.wg.Add(1)
+		go u.copyParts(ch)
+	}
+
+	var num int32
+	for start := int64(0); start < u.head.ContentLength && u.geterr() == nil; start += u.cfg.PartSize {
+		num++
+		end := start + u.cfg.PartSize - 1
+		if end >= u.head.ContentLength {
+			end = u.head.ContentLength - 1
+		}
+		ch <- copyPart{num: num, start: start, end: end}
+	}
+
+	close(ch)
+	u.wg.Wait()
+
+	complete := u.complete()
+	if err := u.geterr(); err != nil {
+		return nil, &multiUploadError{
+			err:      err,
+			uploadID: u.uploadID,
+		}
+	}
+
+	return &CopyOutput{
+		ETag:      complete.ETag,
+		VersionID: complete.VersionId,
+		UploadID:  u.uploadID,
+	}, nil
+}
+
+// createMultipartUploadInput returns the CreateMultipartUpload input for the
+// destination object, copying the source object's metadata, and tags, unless
+// they are replaced.
+func (u *multicopier) createMultipartUploadInput() (*s3.CreateMultipartUploadInput, error) {
+	params := &s3.CreateMultipartUploadInput{}
+	awsutil.Copy(params, u.in)
+	params.ServerSideEncryption, params.SSEKMSKeyId, params.BucketKeyEnabled = u.serverSideEncryption()
+
+	if u.in.MetadataDirective != types.MetadataDirectiveReplace {
+		params.CacheControl = u.head.CacheControl
+		params.ContentDisposition = u.head.ContentDisposition
+		params.ContentEncoding = u.head.ContentEncoding
+		params.ContentLanguage = u.head.ContentLanguage
+		params.ContentType = u.head.ContentType
+		params.Expires = u.head.Expires
+		params.Metadata = u.head.Metadata
+		params.WebsiteRedirectLocation = u.head.WebsiteRedirectLocation
+	}
+
+	if u.in.TaggingDirective != types.TaggingDirectiveReplace {
+		bucket, key, versionID, _ := parseCopySource(aws.ToString(u.in.CopySource))
+		tagging, err := u.cfg.S3.GetObjectTagging(u.ctx, &s3.GetObjectTaggingInput{
+			Bucket:              aws.String(bucket),
+			Key:                 aws.String(key),
+			VersionId:           versionID,
+			ExpectedBucketOwner: u.in.ExpectedSourceBucketOwner,
+		}, u.cfg.ClientOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get copy source tags, %w", err)
+		}
+
+		params.Tagging = nil
+		if len(tagging.TagSet) != 0 {
+			tags := url.Values{}
+			for _, tag := range tagging.TagSet {
+				tags.Add(aws.ToString(tag.Key), aws.ToString(tag.Value))
+			}
+			params.Tagging = aws.String(tags.Encode())
+		}
+	}
+
+	return params, nil
+}
+
+// copyParts runs in worker goroutines to pull parts off of the ch channel
+// and copy them with UploadPartCopy requests.
+func (u *multicopier) copyParts(ch chan copyPart) {
+	defer u.wg.Done()
+	for {
+		part, ok := <-ch
+		if !ok {
+			break
+		}
+
+		if u.geterr() == nil {
+			if err := u.copyPart(part); err != nil {
+				u.seterr(err)
+			}
+		}
+	}
+}
+
+// copyPart copies a byte range of the source object as a part of the
+// multipart upload.
+func (u *multicopier) copyPart(part copyPart) error {
+	params := &s3.UploadPartCopyInput{}
+	awsutil.Copy(params, u.in)
+	params.UploadId = &u.uploadID
+	params.PartNumber = part.num
+	params.CopySourceRange = aws.String(fmt.Sprintf("bytes=%d-%d", part.start, part.end))
+	if params.CopySourceIfMatch == nil {
+		params.CopySourceIfMatch = u.head.ETag
+	}
+
+	resp, err := u.cfg.S3.UploadPartCopy(u.ctx, params, u.cfg.ClientOptions...)
+	if err != nil {
+		return err
+	}
+
+	completed := types.CompletedPart{PartNumber: part.num}
+	if resp.CopyPartResult != nil {
+		completed.ETag = resp.CopyPartResult.ETag
+	}
+
+	u.m.Lock()
+	u.parts = append(u.parts, completed)
+	u.m.Unlock()
+
+	return nil
+}
+
+// geterr is a thread-safe getter for the error object
+func (u *multicopier) geterr() error {
+	u.m.Lock()
+	defer u.m.Unlock()
+
+	return u.err
+}
+
+// seterr is a thread-safe setter for the error object
+func (u *multicopier) seterr(e error) {
+	u.m.Lock()
+	defer u.m.Unlock()
+
+	u.err = e
+}
+
+// fail will abort the multipart unless LeavePartsOnError is set to true.
+func (u *multicopier) fail() {
+	if u.cfg.LeavePartsOnError {
+		return
+	}
+
+	params := &s3.AbortMultipartUploadInput{
+		Bucket:              u.in.Bucket,
+		Key:                 u.in.Key,
+		UploadId:            &u.uploadID,
+		ExpectedBucketOwner: u.in.ExpectedBucketOwner,
+		RequestPayer:        u.in.RequestPayer,
+	}
+	_, err := u.cfg.S3.AbortMultipartUpload(u.ctx, params, u.cfg.ClientOptions...)
+	if err != nil {
+		// TODO: Add logging
+		_ = err
+	}
+}
+
+// complete successfully completes a multipart upload and returns the response.
+func (u *multicopier) complete() *s3.CompleteMultipartUploadOutput {
+	if u.geterr() != nil {
+		u.fail()
+		return nil
+	}
+
+	// Parts must be sorted in PartNumber order.
+	sort.Sort(u.parts)
+
+	params := &s3.CompleteMultipartUploadInput{
+		Bucket:              u.in.Bucket,
+		Key:                 u.in.Key,
+		UploadId:            &u.uploadID,
+		MultipartUpload:     &types.CompletedMultipartUpload{Parts: u.parts},
+		ExpectedBucketOwner: u.in.ExpectedBucketOwner,
+		RequestPayer:        u.in.RequestPayer,
+	}
+	resp, err := u.cfg.S3.CompleteMultipartUpload(u.ctx, params, u.cfg.ClientOptions...)
+	if err != nil {
+		u.seterr(err)
+		u.fail()
+	}
+
+	return resp
+}
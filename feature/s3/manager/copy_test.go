@@ -0,0 +1,370 @@
+package manager_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// copyClient is a mock S3 client recording the copy operations invoked.
+type copyClient struct {
+	m           sync.Mutex
+	invocations []string
+
+	head         *s3.HeadObjectOutput
+	headParams   *s3.HeadObjectInput
+	tags         []types.Tag
+	copyParams   *s3.CopyObjectInput
+	createParams *s3.CreateMultipartUploadInput
+	partParams   []*s3.UploadPartCopyInput
+	completed    *s3.CompleteMultipartUploadInput
+	aborted      bool
+
+	uploadPartCopyErr func(*s3.UploadPartCopyInput) error
+}
+
+func (c *copyClient) trace(name string) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.invocations = append(c.invocations, name)
+}
+
+func (c *copyClient) HeadObject(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
+	c.trace("HeadObject")
+	c.headParams = params
+	return c.head, nil
+}
+
+func (c *copyClient) GetObjectTagging(ctx context.Context, params *s3.GetObjectTaggingInput, optFns ...func(*s3.Options)) (*s3.GetObjectTaggingOutput, error) {
+	c.trace("GetObjectTagging")
+	return &s3.GetObjectTaggingOutput{TagSet: c.tags}, nil
+}
+
+func (c *copyClient) CopyObject(ctx context.Context, params *s3.CopyObjectInput, optFns ...func(*s3.Options)) (*s3.CopyObjectOutput, error) {
+	c.trace("CopyObject")
+	c.copyParams = params
+	return &s3.CopyObjectOutput{
+		CopyObjectResult: &types.CopyObjectResult{ETag: aws.String("ETAG")},
+		VersionId:        aws.String("VERSION-ID"),
+	}, nil
+}
+
+func (c *copyClient) CreateMultipartUpload(ctx context.Context, params *s3.CreateMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error) {
+	c.trace("CreateMultipartUpload")
+	c.createParams = params
+	return &s3.CreateMultipartUploadOutput{UploadId: aws.String("UPLOAD-ID")}, nil
+}
+
+func (c *copyClient) UploadPartCopy(ctx context.Context, params *s3.UploadPartCopyInput, optFns ...func(*s3.Options)) (*s3.UploadPartCopyOutput, error) {
+	c.trace("UploadPartCopy")
+	c.m.Lock()
+	c.partParams = append(c.partParams, params)
+	c.m.Unlock()
+
+	if c.uploadPartCopyErr != nil {
+		if err := c.uploadPartCopyErr(params); err != nil {
+			return nil, err
+		}
+	}
+	return &s3.UploadPartCopyOutput{
+		CopyPartResult: &types.CopyPartResult{
+			ETag: aws.String(fmt.Sprintf("ETAG%d", params.PartNumber)),
+		},
+	}, nil
+}
+
+func (c *copyClient) CompleteMultipartUpload(ctx context.Context, params *s3.CompleteMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error) {
+	c.trace("CompleteMultipartUpload")
+	c.completed = params
+	return &s3.CompleteMultipartUploadOutput{
+		ETag:      aws.String("ETAG-3"),
+		VersionId: aws.String("VERSION-ID"),
+	}, nil
+}
+
+func (c *copyClient) AbortMultipartUpload(ctx context.Context, params *s3.AbortMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error) {
+	c.trace("AbortMultipartUpload")
+	c.aborted = true
+	return &s3.AbortMultipartUploadOutput{}, nil
+}
+
+func TestCopySinglePart(t *testing.T) {
+	client := &copyClient{
+		head: &s3.HeadObjectOutput{
+			ContentLength:        1024,
+			ETag:                 aws.String(`"source-etag"`),
+			ServerSideEncryption: types.ServerSideEncryptionAwsKms,
+			SSEKMSKeyId:          aws.String("key-id"),
+		},
+	}
+
+	out, err := manager.NewCopier(client, func(c *manager.Copier) {
+		c.PreserveServerSideEncryption = true
+	}).Copy(context.Background(), &s3.CopyObjectInput{
+		Bucket:     aws.String("dst-bucket"),
+		Key:        aws.String("dst-key"),
+		CopySource: aws.String("src-bucket/src%20key?versionId=v1"),
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if e, a := "HeadObject,CopyObject", strings.Join(client.invocations, ","); e != a {
+		t.Errorf("expect %v invocations, got %v", e, a)
+	}
+	if e, a := "src-bucket", aws.ToString(client.headParams.Bucket); e != a {
+		t.Errorf("expect %v bucket, got %v", e, a)
+	}
+	if e, a := "src key", aws.ToString(client.headParams.Key); e != a {
+		t.Errorf("expect %v key, got %v", e, a)
+	}
+	if e, a := "v1", aws.ToString(client.headParams.VersionId); e != a {
+		t.Errorf("expect %v version, got %v", e, a)
+	}
+	if e, a := `"source-etag"`, aws.ToString(client.copyParams.CopySourceIfMatch); e != a {
+		t.Errorf("expect %v copy source if match, got %v", e, a)
+	}
+	if e, a := types.ServerSideEncryptionAwsKms, client.copyParams.ServerSideEncryption; e != a {
+		t.Errorf("expect %v server side encryption, got %v", e, a)
+	}
+	if e, a := "key-id", aws.ToString(client.copyParams.SSEKMSKeyId); e != a {
+		t.Errorf("expect %v kms key, got %v", e, a)
+	}
+	if e, a := "ETAG", aws.ToString(out.ETag); e != a {
+		t.Errorf("expect %v etag, got %v", e, a)
+	}
+	if e, a := "", out.UploadID; e != a {
+		t.Errorf("expect %v upload id, got %v", e, a)
+	}
+}
+
+func TestCopyMultipart(t *testing.T) {
+	const partSize = manager.MinUploadPartSize
+	client := &copyClient{
+		head: &s3.HeadObjectOutput{
+			ContentLength: partSize*2 + 100,
+			ETag:          aws.String(`"source-etag"`),
+			ContentType:   aws.String("text/plain"),
+			Metadata:      map[string]string{"foo": "bar"},
+		},
+		tags: []types.Tag{
+			{Key: aws.String("k1"), Value: aws.String("v 1")},
+		},
+	}
+
+	out, err := manager.NewCopier(client, func(c *manager.Copier) {
+		c.PartSize = partSize
+	}).Copy(context.Background(), &s3.CopyObjectInput{
+		Bucket:       aws.String("dst-bucket"),
+		Key:          aws.String("dst-key"),
+		CopySource:   aws.String("src-bucket/src-key"),
+		StorageClass: types.StorageClassStandardIa,
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if e, a := "UPLOAD-ID", out.UploadID; e != a {
+		t.Errorf("expect %v upload id, got %v", e, a)
+	}
+	if e, a := "ETAG-3", aws.ToString(out.ETag); e != a {
+		t.Errorf("expect %v etag, got %v", e, a)
+	}
+
+	create := client.createParams
+	if e, a := "text/plain", aws.ToString(create.ContentType); e != a {
+		t.Errorf("expect %v content type, got %v", e, a)
+	}
+	if e, a := "bar", create.Metadata["foo"]; e != a {
+		t.Errorf("expect %v metadata, got %v", e, a)
+	}
+	if e, a := "k1=v+1", aws.ToString(create.Tagging); e != a {
+		t.Errorf("expect %v tagging, got %v", e, a)
+	}
+	if e, a := types.StorageClassStandardIa, create.StorageClass; e != a {
+		t.Errorf("expect %v storage class, got %v", e, a)
+	}
+
+	sort.Slice(client.partParams, func(i, j int) bool {
+		return client.partParams[i].PartNumber < client.partParams[j].PartNumber
+	})
+	expectRanges := []string{
+		fmt.Sprintf("bytes=0-%d", partSize-1),
+		fmt.Sprintf("bytes=%d-%d", partSize, partSize*2-1),
+		fmt.Sprintf("bytes=%d-%d", partSize*2, partSize*2+99),
+	}
+	if e, a := len(expectRanges), len(client.partParams); e != a {
+		t.Fatalf("expect %v parts, got %v", e, a)
+	}
+	for i, params := range client.partParams {
+		if e, a := expectRanges[i], aws.ToString(params.CopySourceRange); e != a {
+			t.Errorf("expect %v range, got %v", e, a)
+		}
+		if e, a := "src-bucket/src-key", aws.ToString(params.CopySource); e != a {
+			t.Errorf("expect %v copy source, got %v", e, a)
+		}
+		if e, a := `"source-etag"`, aws.ToString(params.CopySourceIfMatch); e != a {
+			t.Errorf("expect %v copy source if match, got %v", e, a)
+		}
+		if e, a := "UPLOAD-ID", aws.ToString(params.UploadId); e != a {
+			t.Errorf("expect %v upload id, got %v", e, a)
+		}
+	}
+
+	parts := client.completed.MultipartUpload.Parts
+	for i, part := range parts {
+		if e, a := int32(i+1), part.PartNumber; e != a {
+			t.Errorf("expect %v part number, got %v", e, a)
+		}
+		if e, a := fmt.Sprintf("ETAG%d", i+1), aws.ToString(part.ETag); e != a {
+			t.Errorf("expect %v etag, got %v", e, a)
+		}
+	}
+}
+
+func TestCopyMultipart_ReplaceDirectives(t *testing.T) {
+	const partSize = manager.MinUploadPartSize
+	client := &copyClient{
+		head: &s3.HeadObjectOutput{
+			ContentLength: partSize + 1,
+			ContentType:   aws.String("text/plain"),
+			Metadata:      map[string]string{"foo": "bar"},
+		},
+	}
+
+	_, err := manager.NewCopier(client, func(c *manager.Copier) {
+		c.PartSize = partSize
+	}).Copy(context.Background(), &s3.CopyObjectInput{
+		Bucket:            aws.String("dst-bucket"),
+		Key:               aws.String("dst-key"),
+		CopySource:        aws.String("src-bucket/src-key"),
+		MetadataDirective: types.MetadataDirectiveReplace,
+		ContentType:       aws.String("application/json"),
+		TaggingDirective:  types.TaggingDirectiveReplace,
+		Tagging:           aws.String("a=b"),
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	for _, op := range client.invocations {
+		if op == "GetObjectTagging" {
+			t.Errorf("expect tags to not be retrieved")
+		}
+	}
+	create := client.createParams
+	if e, a := "application/json", aws.ToString(create.ContentType); e != a {
+		t.Errorf("expect %v content type, got %v", e, a)
+	}
+	if e, a := 0, len(create.Metadata); e != a {
+		t.Errorf("expect %v metadata, got %v", e, a)
+	}
+	if e, a := "a=b", aws.ToString(create.Tagging); e != a {
+		t.Errorf("expect %v tagging, got %v", e, a)
+	}
+}
+
+func TestCopyMultipart_Failure(t *testing.T) {
+	cases := map[string]struct {
+		leavePartsOnError bool
+		expectAbort       bool
+	}{
+		"abort": {
+			expectAbort: true,
+		},
+		"leave parts": {
+			leavePartsOnError: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			const partSize = manager.MinUploadPartSize
+			client := &copyClient{
+				head: &s3.HeadObjectOutput{ContentLength: partSize * 3},
+				uploadPartCopyErr: func(params *s3.UploadPartCopyInput) error {
+					if params.PartNumber == 2 {
+						return fmt.Errorf("mock part error")
+					}
+					return nil
+				},
+			}
+
+			_, err := manager.NewCopier(client, func(cp *manager.Copier) {
+				cp.PartSize = partSize
+				cp.Concurrency = 1
+				cp.LeavePartsOnError = c.leavePartsOnError
+			}).Copy(context.Background(), &s3.CopyObjectInput{
+				Bucket:           aws.String("dst-bucket"),
+				Key:              aws.String("dst-key"),
+				CopySource:       aws.String("src-bucket/src-key"),
+				TaggingDirective: types.TaggingDirectiveReplace,
+			})
+			if err == nil {
+				t.Fatalf("expect error, got none")
+			}
+
+			var multiErr manager.MultiUploadFailure
+			if !errors.As(err, &multiErr) {
+				t.Fatalf("expect MultiUploadFailure, got %T", err)
+			}
+			if e, a := "UPLOAD-ID", multiErr.UploadID(); e != a {
+				t.Errorf("expect %v upload id, got %v", e, a)
+			}
+			if e, a := "mock part error", err.Error(); !strings.Contains(a, e) {
+				t.Errorf("expect error to contain %v, got %v", e, a)
+			}
+			if e, a := c.expectAbort, client.aborted; e != a {
+				t.Errorf("expect %v aborted, got %v", e, a)
+			}
+			if client.completed != nil {
+				t.Errorf("expect upload to not be completed")
+			}
+		})
+	}
+}
+
+func TestCopy_PartSizeAdjusted(t *testing.T) {
+	client := &copyClient{
+		head: &s3.HeadObjectOutput{ContentLength: manager.MinUploadPartSize * 10},
+	}
+
+	_, err := manager.NewCopier(client, func(c *manager.Copier) {
+		c.PartSize = manager.MinUploadPartSize
+		c.MaxUploadParts = 4
+	}).Copy(context.Background(), &s3.CopyObjectInput{
+		Bucket:           aws.String("dst-bucket"),
+		Key:              aws.String("dst-key"),
+		CopySource:       aws.String("/src-bucket/src-key"),
+		TaggingDirective: types.TaggingDirectiveReplace,
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := 4, len(client.partParams); e != a {
+		t.Errorf("expect %v parts, got %v", e, a)
+	}
+}
+
+func TestCopy_InvalidCopySource(t *testing.T) {
+	_, err := manager.NewCopier(&copyClient{}).Copy(context.Background(), &s3.CopyObjectInput{
+		Bucket:     aws.String("dst-bucket"),
+		Key:        aws.String("dst-key"),
+		CopySource: aws.String("src-bucket"),
+	})
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+	if e, a := "invalid copy source", err.Error(); !strings.Contains(a, e) {
+		t.Errorf("expect error to contain %v, got %v", e, a)
+	}
+}
@@ -0,0 +1,300 @@
+package manager
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// DefaultDirectoryConcurrency is the default number of files transferred in
+// parallel when using UploadDirectory() or DownloadDirectory(). Each file
+// transfer may in turn use the Uploader's, or Downloader's, Concurrency
+// goroutines to transfer its parts.
+const DefaultDirectoryConcurrency = 5
+
+// SymlinkPolicy is the policy for how symbolic links are handled when
+// transferring a directory.
+type SymlinkPolicy int
+
+// Enumeration values for SymlinkPolicy.
+const (
+	// SymlinkPolicySkip skips symbolic links, reporting them as skipped.
+	SymlinkPolicySkip SymlinkPolicy = iota
+
+	// SymlinkPolicyFollow follows symbolic links, transferring the file, or
+	// directory, the link refers to. A directory is transferred under each
+	// path it is linked from, except links to a directory it is within,
+	// which are not followed to prevent a loop.
+	SymlinkPolicyFollow
+
+	// SymlinkPolicyError fails the transfer of symbolic links.
+	SymlinkPolicyError
+)
+
+// SyncMode is the comparison used to determine if a file has changed, and
+// needs to be transferred, when transferring a directory.
+type SyncMode int
+
+// Enumeration values for SyncMode.
+const (
+	// SyncModeNone transfers all files regardless of whether they have
+	// changed.
+	SyncModeNone SyncMode = iota
+
+	// SyncModeSizeAndModTime skips files whose size matches, and whose
+	// destination was last modified at, or after, the source.
+	SyncModeSizeAndModTime
+
+	// SyncModeETag skips files whose size matches, and whose content MD5
+	// matches the object's ETag. Objects whose ETag is not a MD5 of the
+	// content, such as multipart uploads, are compared using
+	// SyncModeSizeAndModTime instead.
+	SyncModeETag
+)
+
+// FileTransferStatus is the outcome of transferring a single file of a
+// directory.
+type FileTransferStatus string
+
+// Enumeration values for FileTransferStatus.
+const (
+	// FileTransferStatusTransferred is the status of a file that was
+	// transferred.
+	FileTransferStatusTransferred FileTransferStatus = "Transferred"
+
+	// FileTransferStatusSkipped is the status of a file that was not
+	// transferred because it had not changed, or was a skipped symbolic link.
+	FileTransferStatusSkipped FileTransferStatus = "Skipped"
+
+	// FileTransferStatusFailed is the status of a file that failed to be
+	// transferred.
+	FileTransferStatusFailed FileTransferStatus = "Failed"
+)
+
+// FileTransferResult is the result of transferring a single file of a
+// directory.
+type FileTransferResult struct {
+	// The local path of the file.
+	Path string
+
+	// The S3 object key of the file.
+	Key string
+
+	// The number of bytes transferred.
+	Bytes int64
+
+	// The outcome of the transfer.
+	Status FileTransferStatus
+
+	// The error the transfer failed with, if the Status is
+	// FileTransferStatusFailed.
+	Err error
+}
+
+// DirectoryTransferError is returned by UploadDirectory(), and
+// DownloadDirectory() when one or more files failed to be transferred.
+type DirectoryTransferError struct {
+	// The results of the files that failed.
+	Failed []FileTransferResult
+}
+
+// Error returns the string representation of the error.
+func (e *DirectoryTransferError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "failed to transfer %d files", len(e.Failed))
+	for i, r := range e.Failed {
+		if i == 3 {
+			fmt.Fprintf(&sb, "\n\t... and %d more", len(e.Failed)-i)
+			break
+		}
+		fmt.Fprintf(&sb, "\n\t%s, %v", r.Path, r.Err)
+	}
+	return sb.String()
+}
+
+// directoryFilter selects the files of a directory to transfer with include,
+// and exclude patterns.
+type directoryFilter struct {
+	include []string
+	exclude []string
+}
+
+func newDirectoryFilter(include, exclude []string) (directoryFilter, error) {
+	for _, patterns := range [][]string{include, exclude} {
+		for _, p := range patterns {
+			if _, err := path.Match(p, ""); err != nil {
+				return directoryFilter{}, fmt.Errorf("invalid filter pattern %q, %w", p, err)
+			}
+		}
+	}
+	return directoryFilter{include: include, exclude: exclude}, nil
+}
+
+// Matches returns if the slash separated path relative to the directory root
+// is selected by the filter. A path is selected if it matches any include
+// pattern, or there are no include patterns, and does not match any exclude
+// pattern. Patterns are matched against both the relative path, and the
+// path's base name.
+func (f directoryFilter) Matches(rel string) bool {
+	if len(f.include) != 0 && !matchesAnyPattern(f.include, rel) {
+		return false
+	}
+	return !matchesAnyPattern(f.exclude, rel)
+}
+
+func matchesAnyPattern(patterns []string, rel string) bool {
+	base := path.Base(rel)
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, rel); ok {
+			return true
+		}
+		if ok, _ := path.Match(p, base); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// directoryKeyPrefix returns the key prefix with a trailing slash, if the key
+// prefix is not empty.
+func directoryKeyPrefix(prefix *string) string {
+	p := aws.ToString(prefix)
+	if len(p) != 0 && !strings.HasSuffix(p, "/") {
+		p += "/"
+	}
+	return p
+}
+
+// remoteObject is the metadata of an object used to compare it with a local
+// file.
+type remoteObject struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+	ETag         string
+}
+
+// listRemoteObjects returns the objects under the prefix, keyed by the object
+// key relative to the prefix.
+func listRemoteObjects(ctx context.Context, client ListObjectsV2APIClient, bucket *string, prefix string, optFns []func(*s3.Options)) (map[string]remoteObject, error) {
+	objects := map[string]remoteObject{}
+
+	p := s3.NewListObjectsV2Paginator(client, &s3.ListObjectsV2Input{
+		Bucket: bucket,
+		Prefix: aws.String(prefix),
+	})
+	for p.HasMorePages() {
+		page, err := p.NextPage(ctx, optFns...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects, %w", err)
+		}
+		for _, obj := range page.Contents {
+			key := aws.ToString(obj.Key)
+			objects[strings.TrimPrefix(key, prefix)] = remoteObject{
+				Key:          key,
+				Size:         obj.Size,
+				LastModified: aws.ToTime(obj.LastModified),
+				ETag:         aws.ToString(obj.ETag),
+			}
+		}
+	}
+
+	return objects, nil
+}
+
+// fileUnchanged returns if the local file and remote object are the same
+// using the sync mode. localIsSource is true when the local file is the source
+// of the transfer, so that a modification time comparison requires the
+// source not be modified after the destination.
+func fileUnchanged(mode SyncMode, filePath string, info os.FileInfo, obj remoteObject, localIsSource bool) (bool, error) {
+	if mode == SyncModeNone || info.Size() != obj.Size {
+		return false, nil
+	}
+
+	if mode == SyncModeETag {
+		etag := strings.Trim(obj.ETag, `"`)
+		if len(etag) == md5.Size*2 && !strings.Contains(etag, "-") {
+			sum, err := fileMD5(filePath)
+			if err != nil {
+				return false, err
+			}
+			return strings.EqualFold(sum, etag), nil
+		}
+	}
+
+	if localIsSource {
+		return !info.ModTime().After(obj.LastModified), nil
+	}
+	return !obj.LastModified.After(info.ModTime()), nil
+}
+
+// fileMD5 returns the hex encoded MD5 of the file's content.
+func fileMD5(filePath string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := md5.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// transferFiles calls fn for each index in [0, n) using up to concurrency
+// goroutines, returning when all calls have completed.
+func transferFiles(concurrency, n int, fn func(i int)) {
+	if concurrency <= 0 {
+		concurrency = DefaultDirectoryConcurrency
+	}
+
+	ch := make(chan int, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range ch {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		ch <- i
+	}
+	close(ch)
+	wg.Wait()
+}
+
+// directoryTransferResults sorts the results by path, and returns a
+// DirectoryTransferError if any of the results failed.
+func directoryTransferResults(results []FileTransferResult) error {
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Path < results[j].Path
+	})
+
+	var failed []FileTransferResult
+	for _, r := range results {
+		if r.Status == FileTransferStatusFailed {
+			failed = append(failed, r)
+		}
+	}
+	if len(failed) != 0 {
+		return &DirectoryTransferError{Failed: failed}
+	}
+	return nil
+}
@@ -0,0 +1,672 @@
+package manager_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	s3testing "github.com/aws/aws-sdk-go-v2/feature/s3/manager/internal/testing"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// directoryClient is a mock S3 client serving objects from memory.
+type directoryClient struct {
+	*s3testing.UploadLoggingClient
+
+	m       sync.Mutex
+	objects map[string]types.Object
+	content map[string][]byte
+	gets    []string
+}
+
+func newDirectoryClient() *directoryClient {
+	c := &directoryClient{
+		objects: map[string]types.Object{},
+		content: map[string][]byte{},
+	}
+	c.UploadLoggingClient, _, _ = s3testing.NewUploadLoggingClient(nil)
+	c.UploadLoggingClient.PutObjectFn = func(u *s3testing.UploadLoggingClient, params *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+		b, err := ioutil.ReadAll(params.Body)
+		if err != nil {
+			return nil, err
+		}
+		c.putObject(aws.ToString(params.Key), b, time.Now())
+		return &s3.PutObjectOutput{}, nil
+	}
+	return c
+}
+
+func (c *directoryClient) putObject(key string, b []byte, modTime time.Time) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	sum := md5.Sum(b)
+	c.objects[key] = types.Object{
+		Key:          aws.String(key),
+		Size:         int64(len(b)),
+		LastModified: aws.Time(modTime),
+		ETag:         aws.String(`"` + hex.EncodeToString(sum[:]) + `"`),
+	}
+	c.content[key] = b
+}
+
+func (c *directoryClient) ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	out := &s3.ListObjectsV2Output{}
+	for key, obj := range c.objects {
+		if strings.HasPrefix(key, aws.ToString(params.Prefix)) {
+			out.Contents = append(out.Contents, obj)
+		}
+	}
+	sort.Slice(out.Contents, func(i, j int) bool {
+		return aws.ToString(out.Contents[i].Key) < aws.ToString(out.Contents[j].Key)
+	})
+	return out, nil
+}
+
+func (c *directoryClient) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	key := aws.ToString(params.Key)
+	c.gets = append(c.gets, key)
+
+	b, ok := c.content[key]
+	if !ok {
+		return nil, fmt.Errorf("mock object not found, %v", key)
+	}
+
+	start, fin := parseRange(aws.ToString(params.Range))
+	if fin >= int64(len(b)) {
+		fin = int64(len(b)) - 1
+	}
+	body := b[start : fin+1]
+
+	return &s3.GetObjectOutput{
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		ContentRange:  aws.String(fmt.Sprintf("bytes %d-%d/%d", start, fin, len(b))),
+	}, nil
+}
+
+func writeTestFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+	}
+}
+
+func resultStatuses(results []manager.FileTransferResult) map[string]manager.FileTransferStatus {
+	statuses := map[string]manager.FileTransferStatus{}
+	for _, r := range results {
+		statuses[r.Key] = r.Status
+	}
+	return statuses
+}
+
+func TestUploadDirectory(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		"a.txt":         "hello",
+		"b.tmp":         "ignored",
+		"sub/c.txt":     "world",
+		"sub/deep/d.md": "deep",
+	})
+
+	client := newDirectoryClient()
+	out, err := manager.NewUploader(client).UploadDirectory(context.Background(), &manager.UploadDirectoryInput{
+		Bucket:    aws.String("bucket"),
+		KeyPrefix: aws.String("prefix"),
+		Source:    root,
+		Exclude:   []string{"*.tmp"},
+		PutObjectInputFn: func(filePath string, input *s3.PutObjectInput) {
+			input.ContentType = aws.String("text/plain")
+		},
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	expect := map[string]manager.FileTransferStatus{
+		"prefix/a.txt":         manager.FileTransferStatusTransferred,
+		"prefix/sub/c.txt":     manager.FileTransferStatusTransferred,
+		"prefix/sub/deep/d.md": manager.FileTransferStatusTransferred,
+	}
+	if e, a := fmt.Sprint(expect), fmt.Sprint(resultStatuses(out.Results)); e != a {
+		t.Errorf("expect %v results, got %v", e, a)
+	}
+	if e, a := "world", string(client.content["prefix/sub/c.txt"]); e != a {
+		t.Errorf("expect %v content, got %v", e, a)
+	}
+	for _, params := range client.Params {
+		if e, a := "text/plain", aws.ToString(params.(*s3.PutObjectInput).ContentType); e != a {
+			t.Errorf("expect %v content type, got %v", e, a)
+		}
+	}
+	if e, a := int64(5), out.Results[0].Bytes; e != a {
+		t.Errorf("expect %v bytes, got %v", e, a)
+	}
+}
+
+func TestUploadDirectory_Include(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		"a.txt":     "hello",
+		"b.md":      "world",
+		"sub/c.txt": "!",
+	})
+
+	client := newDirectoryClient()
+	out, err := manager.NewUploader(client).UploadDirectory(context.Background(), &manager.UploadDirectoryInput{
+		Bucket:  aws.String("bucket"),
+		Source:  root,
+		Include: []string{"*.txt"},
+		Exclude: []string{"sub/*"},
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	expect := map[string]manager.FileTransferStatus{
+		"a.txt": manager.FileTransferStatusTransferred,
+	}
+	if e, a := fmt.Sprint(expect), fmt.Sprint(resultStatuses(out.Results)); e != a {
+		t.Errorf("expect %v results, got %v", e, a)
+	}
+}
+
+func TestUploadDirectory_InvalidPattern(t *testing.T) {
+	_, err := manager.NewUploader(newDirectoryClient()).UploadDirectory(context.Background(), &manager.UploadDirectoryInput{
+		Bucket:  aws.String("bucket"),
+		Source:  t.TempDir(),
+		Include: []string{"["},
+	})
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+	if e, a := "invalid filter pattern", err.Error(); !strings.Contains(a, e) {
+		t.Errorf("expect error to contain %v, got %v", e, a)
+	}
+}
+
+func TestUploadDirectory_Symlinks(t *testing.T) {
+	root := t.TempDir()
+	target := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		"a.txt": "hello",
+	})
+	writeTestFiles(t, target, map[string]string{
+		"linked.txt": "linked",
+	})
+	if err := os.Symlink(target, filepath.Join(root, "dirlink")); err != nil {
+		t.Skipf("unable to create symbolic link, %v", err)
+	}
+	if err := os.Symlink(root, filepath.Join(root, "loop")); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	cases := map[string]struct {
+		policy    manager.SymlinkPolicy
+		expect    map[string]manager.FileTransferStatus
+		expectErr bool
+	}{
+		"skip": {
+			policy: manager.SymlinkPolicySkip,
+			expect: map[string]manager.FileTransferStatus{
+				"a.txt":   manager.FileTransferStatusTransferred,
+				"dirlink": manager.FileTransferStatusSkipped,
+				"loop":    manager.FileTransferStatusSkipped,
+			},
+		},
+		"follow": {
+			policy: manager.SymlinkPolicyFollow,
+			expect: map[string]manager.FileTransferStatus{
+				"a.txt":              manager.FileTransferStatusTransferred,
+				"dirlink/linked.txt": manager.FileTransferStatusTransferred,
+			},
+		},
+		"error": {
+			policy: manager.SymlinkPolicyError,
+			expect: map[string]manager.FileTransferStatus{
+				"a.txt":   manager.FileTransferStatusTransferred,
+				"dirlink": manager.FileTransferStatusFailed,
+				"loop":    manager.FileTransferStatusFailed,
+			},
+			expectErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			out, err := manager.NewUploader(newDirectoryClient()).UploadDirectory(context.Background(), &manager.UploadDirectoryInput{
+				Bucket:        aws.String("bucket"),
+				Source:        root,
+				SymlinkPolicy: c.policy,
+			})
+			if c.expectErr {
+				var dirErr *manager.DirectoryTransferError
+				if !errors.As(err, &dirErr) {
+					t.Fatalf("expect DirectoryTransferError, got %v", err)
+				}
+				if e, a := 2, len(dirErr.Failed); e != a {
+					t.Errorf("expect %v failed, got %v", e, a)
+				}
+			} else if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			if e, a := fmt.Sprint(c.expect), fmt.Sprint(resultStatuses(out.Results)); e != a {
+				t.Errorf("expect %v results, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestUploadDirectory_SymlinkToSibling(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		"z_dir/f.txt": "hello",
+	})
+	// The link is walked before the directory it links to.
+	if err := os.Symlink(filepath.Join(root, "z_dir"), filepath.Join(root, "a_link")); err != nil {
+		t.Skipf("unable to create symbolic link, %v", err)
+	}
+
+	out, err := manager.NewUploader(newDirectoryClient()).UploadDirectory(context.Background(), &manager.UploadDirectoryInput{
+		Bucket:        aws.String("bucket"),
+		Source:        root,
+		SymlinkPolicy: manager.SymlinkPolicyFollow,
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	expect := map[string]manager.FileTransferStatus{
+		"a_link/f.txt": manager.FileTransferStatusTransferred,
+		"z_dir/f.txt":  manager.FileTransferStatusTransferred,
+	}
+	if e, a := fmt.Sprint(expect), fmt.Sprint(resultStatuses(out.Results)); e != a {
+		t.Errorf("expect %v results, got %v", e, a)
+	}
+}
+
+func TestUploadDirectory_Sync(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		"same.txt":    "hello",
+		"changed.txt": "new content",
+		"resized.txt": "resized",
+		"new.txt":     "new",
+	})
+	past := time.Now().Add(-time.Hour)
+	for _, name := range []string{"same.txt", "changed.txt", "resized.txt", "new.txt"} {
+		if err := os.Chtimes(filepath.Join(root, name), past, past); err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+	}
+
+	cases := map[string]struct {
+		mode   manager.SyncMode
+		expect map[string]manager.FileTransferStatus
+	}{
+		"none": {
+			mode: manager.SyncModeNone,
+			expect: map[string]manager.FileTransferStatus{
+				"p/same.txt":    manager.FileTransferStatusTransferred,
+				"p/changed.txt": manager.FileTransferStatusTransferred,
+				"p/resized.txt": manager.FileTransferStatusTransferred,
+				"p/new.txt":     manager.FileTransferStatusTransferred,
+			},
+		},
+		"size and mod time": {
+			mode: manager.SyncModeSizeAndModTime,
+			expect: map[string]manager.FileTransferStatus{
+				"p/same.txt":    manager.FileTransferStatusSkipped,
+				"p/changed.txt": manager.FileTransferStatusSkipped,
+				"p/resized.txt": manager.FileTransferStatusTransferred,
+				"p/new.txt":     manager.FileTransferStatusTransferred,
+			},
+		},
+		"etag": {
+			mode: manager.SyncModeETag,
+			expect: map[string]manager.FileTransferStatus{
+				"p/same.txt":    manager.FileTransferStatusSkipped,
+				"p/changed.txt": manager.FileTransferStatusTransferred,
+				"p/resized.txt": manager.FileTransferStatusTransferred,
+				"p/new.txt":     manager.FileTransferStatusTransferred,
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := newDirectoryClient()
+			client.putObject("p/same.txt", []byte("hello"), time.Now())
+			client.putObject("p/changed.txt", []byte("old content"), time.Now())
+			client.putObject("p/resized.txt", []byte("old"), time.Now())
+
+			out, err := manager.NewUploader(client).UploadDirectory(context.Background(), &manager.UploadDirectoryInput{
+				Bucket:    aws.String("bucket"),
+				KeyPrefix: aws.String("p/"),
+				Source:    root,
+				SyncMode:  c.mode,
+			})
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := fmt.Sprint(c.expect), fmt.Sprint(resultStatuses(out.Results)); e != a {
+				t.Errorf("expect %v results, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestUploadDirectory_SyncRequiresList(t *testing.T) {
+	client, _, _ := s3testing.NewUploadLoggingClient(nil)
+	_, err := manager.NewUploader(client).UploadDirectory(context.Background(), &manager.UploadDirectoryInput{
+		Bucket:   aws.String("bucket"),
+		Source:   t.TempDir(),
+		SyncMode: manager.SyncModeETag,
+	})
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+	if e, a := "ListObjectsV2APIClient", err.Error(); !strings.Contains(a, e) {
+		t.Errorf("expect error to contain %v, got %v", e, a)
+	}
+}
+
+func TestUploadDirectory_FailedFiles(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		"a.txt": "hello",
+		"b.txt": "world",
+	})
+
+	client := newDirectoryClient()
+	client.UploadLoggingClient.PutObjectFn = func(u *s3testing.UploadLoggingClient, params *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+		if aws.ToString(params.Key) == "b.txt" {
+			return nil, fmt.Errorf("mock put error")
+		}
+		return &s3.PutObjectOutput{}, nil
+	}
+
+	out, err := manager.NewUploader(client).UploadDirectory(context.Background(), &manager.UploadDirectoryInput{
+		Bucket: aws.String("bucket"),
+		Source: root,
+	})
+	var dirErr *manager.DirectoryTransferError
+	if !errors.As(err, &dirErr) {
+		t.Fatalf("expect DirectoryTransferError, got %v", err)
+	}
+	if e, a := 1, len(dirErr.Failed); e != a {
+		t.Fatalf("expect %v failed, got %v", e, a)
+	}
+	if e, a := "mock put error", dirErr.Failed[0].Err.Error(); !strings.Contains(a, e) {
+		t.Errorf("expect error to contain %v, got %v", e, a)
+	}
+	if e, a := 2, len(out.Results); e != a {
+		t.Errorf("expect %v results, got %v", e, a)
+	}
+}
+
+func TestDownloadDirectory(t *testing.T) {
+	modTime := time.Date(2021, 2, 1, 12, 0, 0, 0, time.UTC)
+
+	client := newDirectoryClient()
+	client.putObject("prefix/a.txt", []byte("hello"), modTime)
+	client.putObject("prefix/sub/b.txt", []byte("world"), modTime)
+	client.putObject("prefix/sub/c.tmp", []byte("ignored"), modTime)
+	client.putObject("prefix/folder/", nil, modTime)
+	client.putObject("prefix/../escape.txt", []byte("escape"), modTime)
+	client.putObject(`prefix/..\escape.txt`, []byte("escape"), modTime)
+	client.putObject("other/d.txt", []byte("other"), modTime)
+
+	root := t.TempDir()
+	dest := filepath.Join(root, "dest")
+	out, err := manager.NewDownloader(client).DownloadDirectory(context.Background(), &manager.DownloadDirectoryInput{
+		Bucket:      aws.String("bucket"),
+		KeyPrefix:   aws.String("prefix"),
+		Destination: dest,
+		Exclude:     []string{"*.tmp"},
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	expect := map[string]manager.FileTransferStatus{
+		"prefix/a.txt":     manager.FileTransferStatusTransferred,
+		"prefix/sub/b.txt": manager.FileTransferStatusTransferred,
+	}
+	if e, a := fmt.Sprint(expect), fmt.Sprint(resultStatuses(out.Results)); e != a {
+		t.Errorf("expect %v results, got %v", e, a)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dest, "sub", "b.txt"))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "world", string(b); e != a {
+		t.Errorf("expect %v content, got %v", e, a)
+	}
+
+	info, err := os.Stat(filepath.Join(dest, "a.txt"))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := modTime, info.ModTime(); !e.Equal(a) {
+		t.Errorf("expect %v mod time, got %v", e, a)
+	}
+	if _, err := os.Stat(filepath.Join(root, "escape.txt")); err == nil {
+		t.Errorf("expect object outside destination to not be downloaded")
+	}
+
+	infos, err := ioutil.ReadDir(filepath.Join(dest, "sub"))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := 1, len(infos); e != a {
+		t.Errorf("expect %v files, got %v", e, a)
+	}
+}
+
+func TestDownloadDirectory_Sync(t *testing.T) {
+	modTime := time.Date(2021, 2, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		mode       manager.SyncMode
+		expectGets []string
+	}{
+		"none": {
+			mode:       manager.SyncModeNone,
+			expectGets: []string{"changed.txt", "new.txt", "same.txt", "stale.txt"},
+		},
+		"size and mod time": {
+			mode:       manager.SyncModeSizeAndModTime,
+			expectGets: []string{"new.txt", "stale.txt"},
+		},
+		"etag": {
+			mode:       manager.SyncModeETag,
+			expectGets: []string{"changed.txt", "new.txt"},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			dest := t.TempDir()
+			writeTestFiles(t, dest, map[string]string{
+				"same.txt":    "hello",
+				"changed.txt": "old",
+				"stale.txt":   "stale",
+			})
+			for _, name := range []string{"same.txt", "changed.txt"} {
+				if err := os.Chtimes(filepath.Join(dest, name), modTime, modTime); err != nil {
+					t.Fatalf("expect no error, got %v", err)
+				}
+			}
+			past := modTime.Add(-time.Hour)
+			if err := os.Chtimes(filepath.Join(dest, "stale.txt"), past, past); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			client := newDirectoryClient()
+			client.putObject("same.txt", []byte("hello"), modTime)
+			client.putObject("changed.txt", []byte("new"), modTime)
+			client.putObject("stale.txt", []byte("stale"), modTime)
+			client.putObject("new.txt", []byte("new"), modTime)
+
+			_, err := manager.NewDownloader(client).DownloadDirectory(context.Background(), &manager.DownloadDirectoryInput{
+				Bucket:      aws.String("bucket"),
+				Destination: dest,
+				SyncMode:    c.mode,
+			})
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			sort.Strings(client.gets)
+			if e, a := fmt.Sprint(c.expectGets), fmt.Sprint(client.gets); e != a {
+				t.Errorf("expect %v gets, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestDownloadDirectory_Symlinks(t *testing.T) {
+	cases := map[string]struct {
+		policy        manager.SymlinkPolicy
+		expectStatus  manager.FileTransferStatus
+		expectContent string
+	}{
+		"skip": {
+			policy:        manager.SymlinkPolicySkip,
+			expectStatus:  manager.FileTransferStatusSkipped,
+			expectContent: "target",
+		},
+		"follow": {
+			policy:        manager.SymlinkPolicyFollow,
+			expectStatus:  manager.FileTransferStatusTransferred,
+			expectContent: "hello",
+		},
+		"error": {
+			policy:        manager.SymlinkPolicyError,
+			expectStatus:  manager.FileTransferStatusFailed,
+			expectContent: "target",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			dest := t.TempDir()
+			other := t.TempDir()
+			writeTestFiles(t, other, map[string]string{"target.txt": "target"})
+			if err := os.Symlink(filepath.Join(other, "target.txt"), filepath.Join(dest, "a.txt")); err != nil {
+				t.Skipf("unable to create symbolic link, %v", err)
+			}
+
+			client := newDirectoryClient()
+			client.putObject("a.txt", []byte("hello"), time.Now())
+
+			out, _ := manager.NewDownloader(client).DownloadDirectory(context.Background(), &manager.DownloadDirectoryInput{
+				Bucket:        aws.String("bucket"),
+				Destination:   dest,
+				SymlinkPolicy: c.policy,
+			})
+			if e, a := c.expectStatus, out.Results[0].Status; e != a {
+				t.Errorf("expect %v status, got %v", e, a)
+			}
+
+			b, err := ioutil.ReadFile(filepath.Join(other, "target.txt"))
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.expectContent, string(b); e != a {
+				t.Errorf("expect %v content, got %v", e, a)
+			}
+			info, err := os.Lstat(filepath.Join(dest, "a.txt"))
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if info.Mode()&os.ModeSymlink == 0 {
+				t.Errorf("expect symbolic link to be preserved")
+			}
+		})
+	}
+}
+
+func TestDownloadDirectory_SymlinkedDirectory(t *testing.T) {
+	cases := map[string]struct {
+		policy        manager.SymlinkPolicy
+		expectStatus  manager.FileTransferStatus
+		expectContent string
+	}{
+		"skip": {
+			policy:        manager.SymlinkPolicySkip,
+			expectStatus:  manager.FileTransferStatusSkipped,
+			expectContent: "target",
+		},
+		"follow": {
+			policy:        manager.SymlinkPolicyFollow,
+			expectStatus:  manager.FileTransferStatusTransferred,
+			expectContent: "hello",
+		},
+		"error": {
+			policy:        manager.SymlinkPolicyError,
+			expectStatus:  manager.FileTransferStatusFailed,
+			expectContent: "target",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			dest := t.TempDir()
+			other := t.TempDir()
+			writeTestFiles(t, other, map[string]string{"a.txt": "target"})
+			if err := os.Symlink(other, filepath.Join(dest, "sub")); err != nil {
+				t.Skipf("unable to create symbolic link, %v", err)
+			}
+
+			client := newDirectoryClient()
+			client.putObject("sub/a.txt", []byte("hello"), time.Now())
+
+			out, _ := manager.NewDownloader(client).DownloadDirectory(context.Background(), &manager.DownloadDirectoryInput{
+				Bucket:        aws.String("bucket"),
+				Destination:   dest,
+				SymlinkPolicy: c.policy,
+			})
+			if e, a := c.expectStatus, out.Results[0].Status; e != a {
+				t.Errorf("expect %v status, got %v", e, a)
+			}
+
+			b, err := ioutil.ReadFile(filepath.Join(other, "a.txt"))
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.expectContent, string(b); e != a {
+				t.Errorf("expect %v content, got %v", e, a)
+			}
+		})
+	}
+}
@@ -84,6 +84,17 @@ type Downloader struct {
 	// and will use the returned WriterReadFrom from the provider as the
 	// destination writer when copying from http response body.
 	BufferProvider WriterReadFromProvider
+
+	// ProgressListener is notified of the progress of each download, if set.
+	ProgressListener ProgressListener
+
+	// The maximum number of bytes DownloadStream will hold in memory for the
+	// parts being downloaded, and downloaded ahead of the part being written.
+	// At least one part is always held. If this value is zero,
+	// DefaultStreamBufferParts parts per goroutine will be used.
+	//
+	// StreamBufferSize is ignored if the Range input parameter is provided.
+	StreamBufferSize int64
 }
 
 // WithDownloaderClientOptions appends to the Downloader's API request options.
@@ -187,7 +198,16 @@ func (d Downloader) Download(ctx context.Context, w io.WriterAt, input *s3.GetOb
 		impl.cfg.PartSize = DefaultDownloadPartSize
 	}
 
-	return impl.download()
+	impl.progress = newProgressReporter(impl.cfg.ProgressListener, input.Bucket, input.Key)
+	impl.progress.TransferStarted()
+
+	n, err = impl.download()
+	if err != nil {
+		impl.progress.TransferFailed(err)
+	} else {
+		impl.progress.TransferCompleted()
+	}
+	return n, err
 }
 
 // downloader is the implementation structure used internally by Downloader.
@@ -206,7 +226,12 @@ type downloader struct {
 	written    int64
 	err        error
 
+	// the ETag of the object, as given by the response that set totalBytes.
+	etag *string
+
 	partBodyMaxRetries int
+
+	progress *progressReporter
 }
 
 // download performs the implementation of the object download across ranged
@@ -337,10 +362,18 @@ func (d *downloader) downloadChunk(chunk dlchunk) error {
 	// Get the next byte range of data
 	in.Range = aws.String(chunk.ByteRange())
 
+	chunk.num = d.partNumber(chunk)
+	chunk.progress = d.progress
+	d.progress.PartStarted(chunk.num)
+
+	// The attempts of the part are counted across the requests made to retry
+	// reading the body.
+	attempts := d.progress.PartAttempts(chunk.num)
+
 	var n int64
 	var err error
 	for retry := 0; retry <= d.partBodyMaxRetries; retry++ {
-		n, err = d.tryDownloadChunk(in, &chunk)
+		n, err = d.tryDownloadChunk(in, &chunk, attempts)
 		if err == nil {
 			break
 		}
@@ -359,21 +392,38 @@ func (d *downloader) downloadChunk(chunk dlchunk) error {
 
 		d.cfg.Logger.Logf(logging.Debug, "object part body download interrupted %s, err, %v, retrying attempt %d",
 			aws.ToString(in.Key), err, retry)
+
+		if retry < d.partBodyMaxRetries {
+			attempts.Retried(err)
+		}
 	}
 
 	d.incrWritten(n)
 
+	if err == nil {
+		d.progress.PartCompleted(chunk.num, n)
+	}
+
 	return err
 }
 
-func (d *downloader) tryDownloadChunk(in *s3.GetObjectInput, w io.Writer) (int64, error) {
+// partNumber returns the part number of the chunk, starting at 1.
+func (d *downloader) partNumber(chunk dlchunk) int32 {
+	if len(chunk.withRange) != 0 {
+		return 1
+	}
+	return int32(chunk.start/d.cfg.PartSize) + 1
+}
+
+func (d *downloader) tryDownloadChunk(in *s3.GetObjectInput, chunk *dlchunk, attempts *partAttempts) (int64, error) {
+	var w io.Writer = chunk
 	cleanup := func() {}
 	if d.cfg.BufferProvider != nil {
 		w, cleanup = d.cfg.BufferProvider.GetReadFrom(w)
 	}
 	defer cleanup()
 
-	resp, err := d.cfg.S3.GetObject(d.ctx, in, d.cfg.ClientOptions...)
+	resp, err := d.cfg.S3.GetObject(d.ctx, in, attempts.ClientOptions(d.cfg.ClientOptions)...)
 	if err != nil {
 		return 0, err
 	}
@@ -400,7 +450,8 @@ func (d *downloader) getTotalBytes() int64 {
 // Will extract the object's total bytes from the Content-Range if the file
 // will be chunked, or Content-Length. Content-Length is used when the response
 // does not include a Content-Range. Meaning the object was not chunked. This
-// occurs when the full file fits within the PartSize directive.
+// occurs when the full file fits within the PartSize directive. The object's
+// ETag is recorded along with the total.
 func (d *downloader) setTotalBytes(resp *s3.GetObjectOutput) {
 	d.m.Lock()
 	defer d.m.Unlock()
@@ -408,6 +459,10 @@ func (d *downloader) setTotalBytes(resp *s3.GetObjectOutput) {
 	if d.totalBytes >= 0 {
 		return
 	}
+	d.etag = resp.ETag
+	defer func() {
+		d.progress.SetTotalBytes(d.totalBytes)
+	}()
 
 	if resp.ContentRange == nil {
 		// ContentRange is nil when the full file contents is provided, and
@@ -472,6 +527,13 @@ type dlchunk struct {
 
 	// specifies the byte range the chunk should be downloaded with.
 	withRange string
+
+	// the part number of the chunk, and the number of bytes of the chunk
+	// reported as transferred. Bytes rewritten when the chunk's download is
+	// retried are not reported again.
+	num      int32
+	reported int64
+	progress *progressReporter
 }
 
 // Write wraps io.WriterAt for the dlchunk, writing from the dlchunk's start
@@ -487,6 +549,11 @@ func (c *dlchunk) Write(p []byte) (n int, err error) {
 	n, err = c.w.WriteAt(p, c.start+c.cur)
 	c.cur += int64(n)
 
+	if c.cur > c.reported {
+		c.progress.BytesTransferred(c.num, c.cur-c.reported)
+		c.reported = c.cur
+	}
+
 	return
 }
 
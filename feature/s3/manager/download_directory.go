@@ -0,0 +1,253 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// DownloadDirectoryInput provides the input parameters for downloading the
+// objects under a S3 key prefix to a local directory tree.
+type DownloadDirectoryInput struct {
+	// The bucket to download the objects from.
+	Bucket *string
+
+	// The key prefix of the objects to download. Each object is downloaded to
+	// the object's key relative to the prefix, joined with Destination.
+	KeyPrefix *string
+
+	// The local directory to download the objects into. The directory will be
+	// created if it does not exist.
+	Destination string
+
+	// Patterns, in path.Match syntax, selecting the objects to download.
+	// Patterns are matched against both the key relative to KeyPrefix, and
+	// the key's base name. If empty all objects are included.
+	Include []string
+
+	// Patterns, in path.Match syntax, of objects to not download. Exclude
+	// patterns take precedence over Include patterns.
+	Exclude []string
+
+	// How existing symbolic links within Destination are handled when an
+	// object would be written to, or through, them. Defaults to skipping the
+	// object.
+	SymlinkPolicy SymlinkPolicy
+
+	// The comparison used to skip objects that have not changed since they
+	// were last downloaded. Defaults to downloading all objects.
+	SyncMode SyncMode
+
+	// The number of objects to download in parallel. If this is set to zero,
+	// the DefaultDirectoryConcurrency value will be used. The Downloader's
+	// Concurrency limits the number of parts downloaded in parallel for each
+	// object.
+	Concurrency int
+}
+
+// DownloadDirectoryOutput represents a response from the DownloadDirectory()
+// call.
+type DownloadDirectoryOutput struct {
+	// The result of each object selected for download, sorted by path.
+	Results []FileTransferResult
+}
+
+// DownloadDirectory downloads the objects under a S3 key prefix into a local
+// directory tree, using the Downloader to download each object. The
+// Downloader's S3 client must also implement ListObjectsV2APIClient. Objects
+// are downloaded concurrently, with each object written to a temporary file
+// that is renamed once the download completes. The modification time of each
+// downloaded file is set to the object's last modified time.
+//
+// Objects whose key ends with a slash, or whose key relative to the prefix
+// would be written outside of Destination, are ignored. Keys containing a
+// backslash are also ignored.
+//
+// An error is returned without an output if the objects could not be listed.
+// If one or more objects failed to download, the output is returned along
+// with a *DirectoryTransferError.
+func (d Downloader) DownloadDirectory(ctx context.Context, input *DownloadDirectoryInput, opts ...func(*Downloader)) (*DownloadDirectoryOutput, error) {
+	filter, err := newDirectoryFilter(input.Include, input.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	client, ok := d.S3.(ListObjectsV2APIClient)
+	if !ok {
+		return nil, fmt.Errorf("download directory requires the S3 client to implement ListObjectsV2APIClient, %T", d.S3)
+	}
+
+	prefix := directoryKeyPrefix(input.KeyPrefix)
+	remote, err := listRemoteObjects(ctx, client, input.Bucket, prefix, d.ClientOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	rels := make([]string, 0, len(remote))
+	for rel := range remote {
+		if !validDownloadRel(rel) || !filter.Matches(rel) {
+			continue
+		}
+		rels = append(rels, rel)
+	}
+	sort.Strings(rels)
+
+	results := make([]FileTransferResult, len(rels))
+	transferFiles(input.Concurrency, len(rels), func(i int) {
+		results[i] = d.downloadDirectoryFile(ctx, input, remote[rels[i]], rels[i], opts)
+	})
+
+	out := &DownloadDirectoryOutput{Results: results}
+	return out, directoryTransferResults(out.Results)
+}
+
+// validDownloadRel returns if the object key relative to the prefix can be
+// written within the destination directory. Keys containing a backslash are
+// rejected, as the backslash is a path separator on Windows.
+func validDownloadRel(rel string) bool {
+	if len(rel) == 0 || strings.HasSuffix(rel, "/") || strings.HasPrefix(rel, "/") || strings.ContainsRune(rel, '\\') {
+		return false
+	}
+	for _, part := range strings.Split(rel, "/") {
+		if part == ".." || part == "." || len(part) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// withinDirectory returns if filePath is dir, or a path within dir.
+func withinDirectory(dir, filePath string) bool {
+	rel, err := filepath.Rel(dir, filePath)
+	if err != nil || filepath.IsAbs(rel) {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// pathHasSymlink returns if any existing component of the path of rel within
+// dir, including the file itself, is a symbolic link.
+func pathHasSymlink(dir, rel string) (bool, error) {
+	p := dir
+	for _, part := range strings.Split(rel, "/") {
+		p = filepath.Join(p, part)
+		info, err := os.Lstat(p)
+		if os.IsNotExist(err) {
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (d Downloader) downloadDirectoryFile(ctx context.Context, input *DownloadDirectoryInput, obj remoteObject, rel string, opts []func(*Downloader)) FileTransferResult {
+	result := FileTransferResult{
+		Path: filepath.Join(input.Destination, filepath.FromSlash(rel)),
+		Key:  obj.Key,
+	}
+	if err := ctx.Err(); err != nil {
+		result.Status, result.Err = FileTransferStatusFailed, err
+		return result
+	}
+	if !withinDirectory(input.Destination, result.Path) {
+		result.Status, result.Err = FileTransferStatusFailed, fmt.Errorf("object key would be written outside of destination, %v", obj.Key)
+		return result
+	}
+
+	// Symbolic links in any directory of the path, not only the file itself,
+	// would redirect the object outside of Destination.
+	linked, err := pathHasSymlink(input.Destination, rel)
+	if err != nil {
+		result.Status, result.Err = FileTransferStatusFailed, err
+		return result
+	}
+	if linked {
+		switch input.SymlinkPolicy {
+		case SymlinkPolicyFollow:
+		case SymlinkPolicyError:
+			result.Status, result.Err = FileTransferStatusFailed, fmt.Errorf("symbolic links are not allowed by policy")
+			return result
+		default:
+			result.Status = FileTransferStatusSkipped
+			return result
+		}
+	}
+
+	info, err := os.Stat(result.Path)
+	if err == nil && info.Mode().IsRegular() {
+		unchanged, err := fileUnchanged(input.SyncMode, result.Path, info, obj, false)
+		if err != nil {
+			result.Status, result.Err = FileTransferStatusFailed, err
+			return result
+		}
+		if unchanged {
+			result.Status = FileTransferStatusSkipped
+			return result
+		}
+	}
+
+	n, err := d.downloadToFile(ctx, input, obj, result.Path, opts)
+	if err != nil {
+		result.Status, result.Err = FileTransferStatusFailed, err
+		return result
+	}
+
+	result.Status = FileTransferStatusTransferred
+	result.Bytes = n
+	return result
+}
+
+// downloadToFile downloads the object into a temporary file in the same
+// directory as filePath, renaming it to filePath once complete.
+func (d Downloader) downloadToFile(ctx context.Context, input *DownloadDirectoryInput, obj remoteObject, filePath string, opts []func(*Downloader)) (int64, error) {
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return 0, err
+	}
+
+	// Write through the symbolic link to the file it refers to, if the
+	// symbolic link policy allowed following it.
+	if target, err := filepath.EvalSymlinks(filePath); err == nil {
+		filePath = target
+		dir = filepath.Dir(target)
+	}
+
+	tmp, err := ioutil.TempFile(dir, "."+path.Base(filepath.ToSlash(filePath))+".*.tmp")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := d.Download(ctx, tmp, &s3.GetObjectInput{
+		Bucket: input.Bucket,
+		Key:    &obj.Key,
+	}, opts...)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if !obj.LastModified.IsZero() {
+		if err := os.Chtimes(tmp.Name(), obj.LastModified, obj.LastModified); err != nil {
+			return 0, err
+		}
+	}
+	if err := os.Rename(tmp.Name(), filePath); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
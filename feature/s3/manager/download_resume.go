@@ -0,0 +1,397 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/internal/awsutil"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go/logging"
+)
+
+// ObjectChangedError is returned by ResumeDownload when the object in S3 no
+// longer matches the ETag recorded by the download's checkpoint. The
+// checkpoint, and the partially downloaded object, must be discarded to
+// download the object again.
+type ObjectChangedError struct {
+	// The checkpoint file of the download.
+	Checkpoint string
+
+	// The ETag of the object recorded by the checkpoint.
+	ETag string
+
+	Err error
+}
+
+func (e *ObjectChangedError) Error() string {
+	return fmt.Sprintf("object changed since download checkpoint %s was created with ETag %s, %v",
+		e.Checkpoint, e.ETag, e.Err)
+}
+
+func (e *ObjectChangedError) Unwrap() error {
+	return e.Err
+}
+
+// ResumeDownload downloads an object in S3 and writes the payload into w
+// using concurrent GET requests, like Download, recording the progress of the
+// download in the checkpoint file. The n int64 returned is the size of the
+// object downloaded in bytes, including the bytes downloaded before the
+// download was resumed.
+//
+// The checkpoint file records the object's ETag, and the ranges of the object
+// that have been written into w. If the checkpoint file exists when
+// ResumeDownload is called, only the ranges of the object not yet downloaded
+// are requested, with the recorded ETag as IfMatch. This ensures parts of
+// different versions of the object are never combined, and an
+// ObjectChangedError is returned if the object has changed. The checkpoint
+// must only be resumed with the same w as the download was started with. The
+// checkpoint file is removed once the download completes.
+//
+// The checkpoint is saved as each part is written. If w implements
+// Sync() error, such as an os.File, w is synced before the checkpoint is
+// saved.
+//
+// Additional functional options can be provided to configure the individual
+// download. These options are copies of the Downloader instance
+// ResumeDownload is called from. Modifying the options will not impact the
+// original Downloader instance.
+//
+// The GetObjectInput's Range value is not supported by ResumeDownload.
+//
+// It is safe to call this method concurrently across goroutines, for
+// different checkpoint files.
+func (d Downloader) ResumeDownload(ctx context.Context, w io.WriterAt, input *s3.GetObjectInput, checkpoint string, options ...func(*Downloader)) (n int64, err error) {
+	if len(aws.ToString(input.Range)) > 0 {
+		return 0, fmt.Errorf("resumable download does not support Range")
+	}
+	if len(checkpoint) == 0 {
+		return 0, fmt.Errorf("resumable download requires a checkpoint file")
+	}
+
+	in := &s3.GetObjectInput{}
+	awsutil.Copy(in, input)
+
+	impl := resumeDownloader{
+		downloader: downloader{w: w, in: in, cfg: d, ctx: ctx},
+		path:       checkpoint,
+	}
+
+	// Copy ClientOptions
+	clientOptions := make([]func(*s3.Options), 0, len(impl.cfg.ClientOptions)+1)
+	clientOptions = append(clientOptions, func(o *s3.Options) {
+		o.APIOptions = append(o.APIOptions, middleware.AddSDKAgentKey(middleware.FeatureMetadata, userAgentKey))
+	})
+	clientOptions = append(clientOptions, impl.cfg.ClientOptions...)
+	impl.cfg.ClientOptions = clientOptions
+
+	for _, option := range options {
+		option(&impl.cfg)
+	}
+
+	// Ensures we don't need nil checks later on
+	impl.cfg.Logger = logging.WithContext(ctx, impl.cfg.Logger)
+
+	impl.partBodyMaxRetries = impl.cfg.PartBodyMaxRetries
+
+	impl.totalBytes = -1
+	if impl.cfg.Concurrency == 0 {
+		impl.cfg.Concurrency = DefaultDownloadConcurrency
+	}
+
+	if impl.cfg.PartSize == 0 {
+		impl.cfg.PartSize = DefaultDownloadPartSize
+	}
+
+	impl.progress = newProgressReporter(impl.cfg.ProgressListener, input.Bucket, input.Key)
+	impl.progress.TransferStarted()
+
+	n, err = impl.download()
+	if err != nil {
+		impl.progress.TransferFailed(err)
+	} else {
+		impl.progress.TransferCompleted()
+	}
+	return n, err
+}
+
+// resumeDownloader is the implementation structure used internally by
+// Downloader for ResumeDownload.
+type resumeDownloader struct {
+	downloader
+
+	path string
+
+	cpMu sync.Mutex
+	cp   *downloadCheckpoint
+}
+
+// download performs the implementation of the resumable object download
+// across ranged GETs of the ranges missing from the checkpoint.
+func (d *resumeDownloader) download() (int64, error) {
+	cp, err := loadDownloadCheckpoint(d.path)
+	if err != nil {
+		return 0, err
+	}
+
+	// The bytes of the object downloaded before the download was resumed.
+	var resumed int64
+
+	if cp == nil {
+		cp, err = d.start()
+		if err != nil || cp == nil {
+			return d.written, err
+		}
+	} else {
+		if err := cp.validate(d.in); err != nil {
+			return 0, fmt.Errorf("invalid download checkpoint %s, %w", d.path, err)
+		}
+		resumed = cp.completedBytes()
+		d.progress.SetTotalBytes(cp.Size)
+		d.progress.BytesTransferred(0, resumed)
+	}
+
+	d.cp = cp
+	d.in.IfMatch = aws.String(cp.ETag)
+
+	// Spin up workers
+	ch := make(chan dlchunk, d.cfg.Concurrency)
+
+	for i := 0; i < d.cfg.Concurrency; i++ {
+		d.wg.Add(1)
+		go d.downloadMissingPart(ch)
+	}
+
+	// Assign work
+	for _, missing := range cp.missing() {
+		for pos := missing.Start; pos < missing.End && d.getErr() == nil; pos += d.cfg.PartSize {
+			size := d.cfg.PartSize
+			if pos+size > missing.End {
+				size = missing.End - pos
+			}
+
+			// Queue the next range of bytes to read.
+			ch <- dlchunk{w: d.w, start: pos, size: size}
+		}
+	}
+
+	// Wait for completion
+	close(ch)
+	d.wg.Wait()
+
+	if err := d.getErr(); err != nil {
+		var responseError interface {
+			HTTPStatusCode() int
+		}
+		if errors.As(err, &responseError) && responseError.HTTPStatusCode() == http.StatusPreconditionFailed {
+			err = &ObjectChangedError{Checkpoint: d.path, ETag: cp.ETag, Err: err}
+		}
+		return resumed + d.written, err
+	}
+
+	if err := os.Remove(d.path); err != nil && !os.IsNotExist(err) {
+		return resumed + d.written, fmt.Errorf("unable to remove download checkpoint, %w", err)
+	}
+
+	return resumed + d.written, nil
+}
+
+// start downloads the first part of the object, and creates the checkpoint
+// for the download from the object's ETag and size. Returns a nil checkpoint
+// if the entire object was downloaded by the first part.
+func (d *resumeDownloader) start() (*downloadCheckpoint, error) {
+	if err := d.downloadChunk(dlchunk{w: d.w, start: 0, size: d.cfg.PartSize}); err != nil {
+		return nil, err
+	}
+
+	total := d.getTotalBytes()
+	if total < 0 {
+		return nil, fmt.Errorf("unable to resume download of object with unknown size")
+	}
+	if d.written >= total {
+		return nil, nil
+	}
+	if d.etag == nil {
+		return nil, fmt.Errorf("unable to resume download of object without ETag")
+	}
+
+	cp := &downloadCheckpoint{
+		Bucket:    aws.ToString(d.in.Bucket),
+		Key:       aws.ToString(d.in.Key),
+		VersionID: aws.ToString(d.in.VersionId),
+		ETag:      aws.ToString(d.etag),
+		Size:      total,
+	}
+	cp.add(0, d.written)
+
+	if err := d.save(cp); err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
+
+// downloadMissingPart is an individual goroutine worker reading from the ch
+// channel, downloading the range of the chunk and recording the range as
+// completed in the checkpoint.
+func (d *resumeDownloader) downloadMissingPart(ch chan dlchunk) {
+	defer d.wg.Done()
+	for chunk := range ch {
+		if d.getErr() != nil {
+			// Drain the channel if there is an error, to prevent deadlocking
+			// of download producer.
+			continue
+		}
+
+		if err := d.downloadChunk(chunk); err != nil {
+			d.setErr(err)
+			continue
+		}
+
+		d.cpMu.Lock()
+		d.cp.add(chunk.start, chunk.start+chunk.size)
+		err := d.save(d.cp)
+		d.cpMu.Unlock()
+
+		if err != nil {
+			d.setErr(err)
+		}
+	}
+}
+
+// save syncs the writer, if supported, and writes the checkpoint to the
+// checkpoint file, replacing the existing file.
+func (d *resumeDownloader) save(cp *downloadCheckpoint) error {
+	if s, ok := d.w.(interface{ Sync() error }); ok {
+		if err := s.Sync(); err != nil {
+			return fmt.Errorf("unable to sync download, %w", err)
+		}
+	}
+
+	b, err := json.Marshal(cp)
+	if err != nil {
+		return fmt.Errorf("unable to encode download checkpoint, %w", err)
+	}
+
+	tmp := d.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, 0600); err != nil {
+		return fmt.Errorf("unable to write download checkpoint, %w", err)
+	}
+	if err := os.Rename(tmp, d.path); err != nil {
+		return fmt.Errorf("unable to write download checkpoint, %w", err)
+	}
+	return nil
+}
+
+// downloadCheckpoint is the state of a resumable download, saved in the
+// checkpoint file.
+type downloadCheckpoint struct {
+	Bucket    string `json:"bucket"`
+	Key       string `json:"key"`
+	VersionID string `json:"versionId,omitempty"`
+	ETag      string `json:"etag"`
+	Size      int64  `json:"size"`
+
+	// The ranges of the object that have been downloaded, sorted and not
+	// overlapping.
+	Completed []byteRange `json:"completed"`
+}
+
+// byteRange is a range of bytes of the object, from Start up to, but not
+// including, End.
+type byteRange struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+}
+
+// loadDownloadCheckpoint reads the checkpoint file, returning nil if the file
+// does not exist.
+func loadDownloadCheckpoint(path string) (*downloadCheckpoint, error) {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to read download checkpoint, %w", err)
+	}
+
+	var cp downloadCheckpoint
+	if err := json.Unmarshal(b, &cp); err != nil {
+		return nil, fmt.Errorf("unable to decode download checkpoint %s, %w", path, err)
+	}
+	return &cp, nil
+}
+
+// validate returns an error if the checkpoint is not for the object the
+// input downloads.
+func (c *downloadCheckpoint) validate(in *s3.GetObjectInput) error {
+	if c.Bucket != aws.ToString(in.Bucket) || c.Key != aws.ToString(in.Key) {
+		return fmt.Errorf("checkpoint is for object %s/%s", c.Bucket, c.Key)
+	}
+	if v := aws.ToString(in.VersionId); len(v) != 0 && c.VersionID != v {
+		return fmt.Errorf("checkpoint is for version %q", c.VersionID)
+	}
+	if m := aws.ToString(in.IfMatch); len(m) != 0 && c.ETag != m {
+		return fmt.Errorf("checkpoint is for ETag %s", c.ETag)
+	}
+	if len(c.ETag) == 0 || c.Size <= 0 {
+		return fmt.Errorf("checkpoint has no ETag or size")
+	}
+	return nil
+}
+
+// add records the range as completed, merging it with the adjacent or
+// overlapping ranges already completed.
+func (c *downloadCheckpoint) add(start, end int64) {
+	ranges := append(c.Completed, byteRange{Start: start, End: end})
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Start < ranges[j].Start
+	})
+
+	merged := ranges[:1]
+	for _, r := range ranges[1:] {
+		last := &merged[len(merged)-1]
+		if r.Start <= last.End {
+			if r.End > last.End {
+				last.End = r.End
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	c.Completed = merged
+}
+
+// missing returns the ranges of the object not yet completed.
+func (c *downloadCheckpoint) missing() []byteRange {
+	var missing []byteRange
+	var pos int64
+	for _, r := range c.Completed {
+		if r.Start > pos {
+			missing = append(missing, byteRange{Start: pos, End: r.Start})
+		}
+		if r.End > pos {
+			pos = r.End
+		}
+	}
+	if pos < c.Size {
+		missing = append(missing, byteRange{Start: pos, End: c.Size})
+	}
+	return missing
+}
+
+// completedBytes returns the number of bytes of the object completed.
+func (c *downloadCheckpoint) completedBytes() int64 {
+	var n int64
+	for _, r := range c.Completed {
+		n += r.End - r.Start
+	}
+	return n
+}
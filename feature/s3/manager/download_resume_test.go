@@ -0,0 +1,221 @@
+package manager_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// failRangeStart fails the client's requests for ranges starting at the
+// offset.
+func failRangeStart(client *downloadCaptureClient, start int64) *downloadCaptureClient {
+	getObject := client.GetObjectFn
+	client.GetObjectFn = func(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+		if s, _ := parseRange(aws.ToString(params.Range)); s == start {
+			return nil, fmt.Errorf("mock get error")
+		}
+		return getObject(ctx, params, optFns...)
+	}
+	return client
+}
+
+func TestResumeDownload(t *testing.T) {
+	data := newStreamData(1024 * 6)
+	checkpoint := filepath.Join(t.TempDir(), "checkpoint")
+	input := &s3.GetObjectInput{
+		Bucket: aws.String("bucket"),
+		Key:    aws.String("key"),
+	}
+	w := manager.NewWriteAtBuffer(make([]byte, len(data)))
+
+	// The download is interrupted by the failure of the fourth part.
+	client := failRangeStart(newDownloadETagRangeClient(data, "ETAG"), 1024 * 3)
+	downloader := manager.NewDownloader(client, func(d *manager.Downloader) {
+		d.PartSize = 1024
+		d.Concurrency = 1
+	})
+
+	n, err := downloader.ResumeDownload(context.Background(), w, input, checkpoint)
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+	if e, a := int64(1024*3), n; e != a {
+		t.Errorf("expect %v bytes downloaded, got %v", e, a)
+	}
+	if _, err := os.Stat(checkpoint); err != nil {
+		t.Fatalf("expect checkpoint to exist, got %v", err)
+	}
+
+	// The resumed download only requests the remaining parts.
+	client = newDownloadETagRangeClient(data, "ETAG")
+	downloader.S3 = client
+
+	n, err = downloader.ResumeDownload(context.Background(), w, input, checkpoint)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := int64(len(data)), n; e != a {
+		t.Errorf("expect %v bytes downloaded, got %v", e, a)
+	}
+	if !bytes.Equal(data, w.Bytes()) {
+		t.Errorf("expect downloaded data to match object")
+	}
+
+	expectRanges := []string{"bytes=3072-4095", "bytes=4096-5119", "bytes=5120-6143"}
+	if e, a := expectRanges, client.RetrievedRanges; fmt.Sprint(e) != fmt.Sprint(a) {
+		t.Errorf("expect %v ranges, got %v", e, a)
+	}
+	for _, ifMatch := range client.RetrievedIfMatches {
+		if e, a := "ETAG", ifMatch; e != a {
+			t.Errorf("expect %v IfMatch, got %v", e, a)
+		}
+	}
+
+	if _, err := os.Stat(checkpoint); !os.IsNotExist(err) {
+		t.Errorf("expect checkpoint to be removed, got %v", err)
+	}
+}
+
+func TestResumeDownload_NoCheckpoint(t *testing.T) {
+	data := newStreamData(1024*10 + 10)
+	checkpoint := filepath.Join(t.TempDir(), "checkpoint")
+	client := newDownloadETagRangeClient(data, "ETAG")
+	progress := &recordedProgress{}
+
+	w := manager.NewWriteAtBuffer(make([]byte, len(data)))
+	n, err := manager.NewDownloader(client, func(d *manager.Downloader) {
+		d.PartSize = 1024
+		d.ProgressListener = progress
+	}).ResumeDownload(context.Background(), w, &s3.GetObjectInput{
+		Bucket: aws.String("Bucket"),
+		Key:    aws.String("Key"),
+	}, checkpoint)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := int64(len(data)), n; e != a {
+		t.Errorf("expect %v bytes downloaded, got %v", e, a)
+	}
+	if !bytes.Equal(data, w.Bytes()) {
+		t.Errorf("expect downloaded data to match object")
+	}
+	if e, a := 11, client.GetObjectInvocations; e != a {
+		t.Errorf("expect %v GetObject calls, got %v", e, a)
+	}
+	for i, ifMatch := range client.RetrievedIfMatches {
+		expect := "ETAG"
+		if i == 0 {
+			expect = ""
+		}
+		if e, a := expect, ifMatch; e != a {
+			t.Errorf("expect %v IfMatch for request %v, got %v", e, i, a)
+		}
+	}
+	if _, err := os.Stat(checkpoint); !os.IsNotExist(err) {
+		t.Errorf("expect checkpoint to be removed, got %v", err)
+	}
+
+	assertProgressEvents(t, progress, manager.ProgressEventTransferCompleted, int64(len(data)), 11)
+}
+
+func TestResumeDownload_ObjectChanged(t *testing.T) {
+	data := newStreamData(1024 * 4)
+	checkpoint := filepath.Join(t.TempDir(), "checkpoint")
+	input := &s3.GetObjectInput{
+		Bucket: aws.String("bucket"),
+		Key:    aws.String("key"),
+	}
+	w := manager.NewWriteAtBuffer(make([]byte, len(data)))
+
+	client := failRangeStart(newDownloadETagRangeClient(data, "ETAG1"), 1024 * 2)
+	downloader := manager.NewDownloader(client, func(d *manager.Downloader) {
+		d.PartSize = 1024
+		d.Concurrency = 1
+	})
+	if _, err := downloader.ResumeDownload(context.Background(), w, input, checkpoint); err == nil {
+		t.Fatalf("expect error, got none")
+	}
+
+	// The object is replaced before the download is resumed.
+	downloader.S3 = newDownloadETagRangeClient(data, "ETAG2")
+
+	_, err := downloader.ResumeDownload(context.Background(), w, input, checkpoint)
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+	var changedErr *manager.ObjectChangedError
+	if !errors.As(err, &changedErr) {
+		t.Fatalf("expect ObjectChangedError, got %T, %v", err, err)
+	}
+	if e, a := "ETAG1", changedErr.ETag; e != a {
+		t.Errorf("expect %v ETag, got %v", e, a)
+	}
+	if _, err := os.Stat(checkpoint); err != nil {
+		t.Errorf("expect checkpoint to exist, got %v", err)
+	}
+}
+
+func TestResumeDownload_CheckpointForOtherObject(t *testing.T) {
+	data := newStreamData(1024 * 4)
+	checkpoint := filepath.Join(t.TempDir(), "checkpoint")
+	w := manager.NewWriteAtBuffer(make([]byte, len(data)))
+
+	client := failRangeStart(newDownloadETagRangeClient(data, "ETAG"), 1024 * 2)
+	downloader := manager.NewDownloader(client, func(d *manager.Downloader) {
+		d.PartSize = 1024
+		d.Concurrency = 1
+	})
+	_, err := downloader.ResumeDownload(context.Background(), w, &s3.GetObjectInput{
+		Bucket: aws.String("bucket"),
+		Key:    aws.String("key"),
+	}, checkpoint)
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+
+	client = newDownloadETagRangeClient(data, "ETAG")
+	downloader.S3 = client
+
+	_, err = downloader.ResumeDownload(context.Background(), w, &s3.GetObjectInput{
+		Bucket: aws.String("bucket"),
+		Key:    aws.String("other"),
+	}, checkpoint)
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+	if e, a := 0, client.GetObjectInvocations; e != a {
+		t.Errorf("expect no GetObject calls, got %v", a)
+	}
+}
+
+func TestResumeDownload_SingleResponse(t *testing.T) {
+	data := newStreamData(100)
+	checkpoint := filepath.Join(t.TempDir(), "checkpoint")
+	client := newDownloadETagRangeClient(data, "ETAG")
+
+	w := manager.NewWriteAtBuffer(make([]byte, len(data)))
+	n, err := manager.NewDownloader(client).ResumeDownload(context.Background(), w, &s3.GetObjectInput{
+		Bucket: aws.String("bucket"),
+		Key:    aws.String("key"),
+	}, checkpoint)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := int64(len(data)), n; e != a {
+		t.Errorf("expect %v bytes downloaded, got %v", e, a)
+	}
+	if !bytes.Equal(data, w.Bytes()) {
+		t.Errorf("expect downloaded data to match object")
+	}
+	if _, err := os.Stat(checkpoint); !os.IsNotExist(err) {
+		t.Errorf("expect no checkpoint, got %v", err)
+	}
+}
@@ -0,0 +1,511 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/internal/awsutil"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go/logging"
+)
+
+// DefaultStreamBufferParts is the default number of parts DownloadStream will
+// buffer in memory, per goroutine downloading parts, when
+// Downloader.StreamBufferSize is zero.
+const DefaultStreamBufferParts = 2
+
+// DownloadStream downloads an object in S3 and writes the payload into w in
+// order, using concurrent GET requests. The n int64 returned is the number of
+// bytes of the object written to w.
+//
+// Unlike Download, the writer does not need to support writing at an offset.
+// Parts are downloaded concurrently ahead of the part being written, and held
+// in memory until all the parts before them have been written. The memory
+// used for parts downloaded ahead is limited by the Downloader's
+// StreamBufferSize, and a slow writer will stop parts from being downloaded
+// once that limit is reached.
+//
+// Additional functional options can be provided to configure the individual
+// download. These options are copies of the Downloader instance DownloadStream
+// is called from. Modifying the options will not impact the original
+// Downloader instance.
+//
+// If the GetObjectInput's Range value is provided that will cause the
+// downloader to perform a single GetObject request for that object's range,
+// streaming the response body into w.
+//
+// Unless the GetObjectInput's IfMatch or VersionId values are provided, parts
+// after the first are requested with the ETag of the first part as IfMatch,
+// failing the download if the object is replaced while it is downloaded.
+//
+// It is safe to call this method concurrently across goroutines.
+func (d Downloader) DownloadStream(ctx context.Context, w io.Writer, input *s3.GetObjectInput, options ...func(*Downloader)) (n int64, err error) {
+	impl := streamDownloader{w: w, in: input, cfg: d}
+
+	// Copy ClientOptions
+	clientOptions := make([]func(*s3.Options), 0, len(impl.cfg.ClientOptions)+1)
+	clientOptions = append(clientOptions, func(o *s3.Options) {
+		o.APIOptions = append(o.APIOptions, middleware.AddSDKAgentKey(middleware.FeatureMetadata, userAgentKey))
+	})
+	clientOptions = append(clientOptions, impl.cfg.ClientOptions...)
+	impl.cfg.ClientOptions = clientOptions
+
+	for _, option := range options {
+		option(&impl.cfg)
+	}
+
+	// Ensures we don't need nil checks later on
+	impl.cfg.Logger = logging.WithContext(ctx, impl.cfg.Logger)
+
+	if impl.cfg.Concurrency == 0 {
+		impl.cfg.Concurrency = DefaultDownloadConcurrency
+	}
+
+	if impl.cfg.PartSize == 0 {
+		impl.cfg.PartSize = DefaultDownloadPartSize
+	}
+
+	if impl.cfg.StreamBufferSize == 0 {
+		impl.cfg.StreamBufferSize = impl.cfg.PartSize * int64(impl.cfg.Concurrency) * DefaultStreamBufferParts
+	}
+
+	impl.progress = newProgressReporter(impl.cfg.ProgressListener, input.Bucket, input.Key)
+	impl.progress.TransferStarted()
+
+	n, err = impl.download(ctx)
+	if err != nil {
+		impl.progress.TransferFailed(err)
+	} else {
+		impl.progress.TransferCompleted()
+	}
+	return n, err
+}
+
+// OpenStream returns a reader of the object in S3, downloading the object in
+// the background as the reader is read from, using concurrent GET requests.
+//
+// The object is downloaded with DownloadStream, and the reader returns the
+// error the download failed with, if any. The reader must be closed once
+// done with, which stops the download if it has not completed.
+//
+// The Context must not be nil, and is used for the entire download of the
+// object.
+//
+// It is safe to call this method concurrently across goroutines.
+func (d Downloader) OpenStream(ctx context.Context, input *s3.GetObjectInput, options ...func(*Downloader)) io.ReadCloser {
+	ctx, cancel := context.WithCancel(ctx)
+	pr, pw := io.Pipe()
+
+	s := &streamReader{
+		PipeReader: pr,
+		cancel:     cancel,
+		done:       make(chan struct{}),
+	}
+
+	go func() {
+		defer close(s.done)
+		_, err := d.DownloadStream(ctx, pw, input, options...)
+		pw.CloseWithError(err)
+	}()
+
+	return s
+}
+
+// streamReader is the io.ReadCloser returned by OpenStream, reading the
+// object from the pipe written to by DownloadStream.
+type streamReader struct {
+	*io.PipeReader
+	cancel func()
+	done   chan struct{}
+}
+
+// Close stops the download of the object, if it has not completed, and waits
+// for it to return.
+func (r *streamReader) Close() error {
+	r.cancel()
+	r.PipeReader.Close()
+	<-r.done
+	return nil
+}
+
+// streamDownloader is the implementation structure used internally by
+// Downloader for DownloadStream.
+type streamDownloader struct {
+	cfg Downloader
+
+	in *s3.GetObjectInput
+	w  io.Writer
+
+	written int64
+
+	// the ETag of the object, set by the first part if parts after it are
+	// required to match.
+	etag *string
+
+	partPool byteSlicePool
+
+	progress *progressReporter
+}
+
+// streamPart is a part of the object downloaded ahead into a buffer, until
+// it can be written.
+type streamPart struct {
+	num   int32
+	start int64
+
+	buf *[]byte
+	n   int
+
+	// whole is set if the first part's response included the entire object.
+	// body is the remaining body of the response not read into the buffer,
+	// to be written after the buffer.
+	whole bool
+	body  io.ReadCloser
+
+	// etag is the ETag of the object given by the part's response.
+	etag *string
+
+	// total is the size of the object as given by the part's response, or
+	// -1 if not known.
+	total int64
+
+	err  error
+	done chan struct{}
+}
+
+// download performs the implementation of the object download across ranged
+// GETs, writing the parts in order.
+func (d *streamDownloader) download(ctx context.Context) (int64, error) {
+	// If range is specified fall back to single download of that range, the
+	// response body is streamed directly into the writer.
+	if rng := aws.ToString(d.in.Range); len(rng) > 0 {
+		return d.written, d.downloadRange(ctx, rng)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	maxParts := int(d.cfg.StreamBufferSize / d.cfg.PartSize)
+	if maxParts < 1 {
+		maxParts = 1
+	}
+	concurrency := d.cfg.Concurrency
+	if concurrency > maxParts {
+		concurrency = maxParts
+	}
+
+	d.partPool = newByteSlicePool(d.cfg.PartSize)
+	d.partPool.ModifyCapacity(maxParts)
+	defer d.partPool.Close()
+
+	// The first part determines the size of the object, and the number of
+	// parts to download.
+	first, err := d.newPart(ctx, 1, 0)
+	if err != nil {
+		return d.written, err
+	}
+	d.getPart(ctx, first)
+	if first.err != nil {
+		return d.written, first.err
+	}
+	if !first.whole && d.in.IfMatch == nil && d.in.VersionId == nil {
+		d.etag = first.etag
+	}
+	d.progress.SetTotalBytes(first.total)
+
+	if first.whole || first.total < 0 {
+		if err := d.writePart(first); err != nil {
+			return d.written, err
+		}
+		d.partPool.Put(first.buf)
+
+		if first.whole {
+			// The entire object was included in the first response.
+			return d.written, nil
+		}
+		return d.written, d.downloadSequential(ctx)
+	}
+
+	pending := make(chan *streamPart, maxParts)
+	work := make(chan *streamPart, maxParts)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range work {
+				d.getPart(ctx, p)
+			}
+		}()
+	}
+
+	// Queue the parts to download in order. Each part is queued only once a
+	// buffer is available for it, bounding the parts downloaded ahead of the
+	// part being written.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(work)
+		defer close(pending)
+
+		num := first.num
+		for start := d.cfg.PartSize; start < first.total; start += d.cfg.PartSize {
+			num++
+			p, err := d.newPart(ctx, num, start)
+			if err != nil {
+				return
+			}
+			pending <- p
+			work <- p
+		}
+	}()
+
+	// Parts after the first are downloaded ahead while the first part is
+	// written.
+	err = d.writePart(first)
+	if err != nil {
+		cancel()
+	}
+	d.partPool.Put(first.buf)
+
+	for p := range pending {
+		<-p.done
+		if err == nil {
+			err = p.err
+		}
+		if err == nil {
+			err = d.writePart(p)
+		}
+		if err != nil {
+			// Stop the download of the remaining parts, and drain the queued
+			// parts so the goroutines can complete.
+			cancel()
+		}
+		d.partPool.Put(p.buf)
+	}
+	wg.Wait()
+
+	return d.written, err
+}
+
+// downloadSequential downloads the parts after the first one at a time, when
+// the size of the object is not known. Parts are downloaded until the range
+// requested is past the end of the object.
+func (d *streamDownloader) downloadSequential(ctx context.Context) error {
+	for num, start := int32(2), d.cfg.PartSize; ; num, start = num+1, start+d.cfg.PartSize {
+		p, err := d.newPart(ctx, num, start)
+		if err != nil {
+			return err
+		}
+		d.getPart(ctx, p)
+		if p.err == nil {
+			p.err = d.writePart(p)
+		}
+		d.partPool.Put(p.buf)
+
+		// We expect a 416 error letting us know we are done downloading the
+		// total bytes.
+		var responseError interface {
+			HTTPStatusCode() int
+		}
+		if errors.As(p.err, &responseError) {
+			if responseError.HTTPStatusCode() == http.StatusRequestedRangeNotSatisfiable {
+				return nil
+			}
+		}
+		if p.err != nil {
+			return p.err
+		}
+		if int64(p.n) < d.cfg.PartSize {
+			return nil
+		}
+	}
+}
+
+// downloadRange downloads an object given the passed in Byte-Range value,
+// writing the response body into the writer.
+func (d *streamDownloader) downloadRange(ctx context.Context, rng string) error {
+	in := &s3.GetObjectInput{}
+	awsutil.Copy(in, d.in)
+	in.Range = aws.String(rng)
+
+	d.progress.PartStarted(1)
+
+	resp, err := d.cfg.S3.GetObject(ctx, in, d.progress.PartClientOptions(1, d.cfg.ClientOptions)...)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	d.progress.SetTotalBytes(resp.ContentLength)
+
+	n, err := io.Copy(&streamPartWriter{d: d, num: 1}, resp.Body)
+	if err != nil {
+		return err
+	}
+	d.progress.PartCompleted(1, n)
+
+	return nil
+}
+
+// newPart returns the part starting at the offset, with a buffer from the
+// pool. Blocks until a buffer is available, or the context is canceled.
+func (d *streamDownloader) newPart(ctx context.Context, num int32, start int64) (*streamPart, error) {
+	buf, err := d.partPool.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &streamPart{
+		num:   num,
+		start: start,
+		buf:   buf,
+		total: -1,
+		done:  make(chan struct{}),
+	}, nil
+}
+
+// getPart downloads the part into its buffer, retrying reading the response
+// body if interrupted. The part's done channel is closed once complete.
+func (d *streamDownloader) getPart(ctx context.Context, p *streamPart) {
+	defer close(p.done)
+
+	in := &s3.GetObjectInput{}
+	awsutil.Copy(in, d.in)
+	in.Range = aws.String(fmt.Sprintf("bytes=%d-%d", p.start, p.start+d.cfg.PartSize-1))
+	if d.etag != nil {
+		in.IfMatch = d.etag
+	}
+
+	d.progress.PartStarted(p.num)
+
+	// The attempts of the part are counted across the requests made to retry
+	// reading the body.
+	attempts := d.progress.PartAttempts(p.num)
+
+	for retry := 0; retry <= d.cfg.PartBodyMaxRetries; retry++ {
+		err := d.tryGetPart(ctx, in, p, attempts)
+		if err == nil {
+			p.err = nil
+			return
+		}
+
+		// Only errors reading the response body are retried, as the client
+		// has already retried the request.
+		bodyErr, ok := err.(*errReadingBody)
+		if !ok {
+			p.err = err
+			return
+		}
+		p.err = bodyErr.Unwrap()
+
+		d.cfg.Logger.Logf(logging.Debug, "object part body download interrupted %s, err, %v, retrying attempt %d",
+			aws.ToString(in.Key), p.err, retry)
+
+		if retry < d.cfg.PartBodyMaxRetries {
+			attempts.Retried(p.err)
+		}
+	}
+}
+
+func (d *streamDownloader) tryGetPart(ctx context.Context, in *s3.GetObjectInput, p *streamPart, attempts *partAttempts) error {
+	resp, err := d.cfg.S3.GetObject(ctx, in, attempts.ClientOptions(d.cfg.ClientOptions)...)
+	if err != nil {
+		return err
+	}
+
+	p.n, err = readFillBuf(resp.Body, *p.buf)
+	if err != nil && err != io.EOF {
+		resp.Body.Close()
+		return &errReadingBody{err: err}
+	}
+
+	if resp.ContentRange == nil {
+		// ContentRange is nil when the full object contents is provided, and
+		// is not chunked. The remaining body is written after the buffer.
+		// Only the first part may be the entire object, as the parts before
+		// a later part have already been written.
+		if p.start != 0 {
+			resp.Body.Close()
+			return fmt.Errorf("expect part %d response to include Content-Range, got entire object", p.num)
+		}
+		p.whole = true
+		p.total = resp.ContentLength
+		if err == nil && int64(p.n) < resp.ContentLength {
+			p.body = resp.Body
+			return nil
+		}
+		resp.Body.Close()
+		return nil
+	}
+	resp.Body.Close()
+
+	p.total, err = parseContentRangeTotal(aws.ToString(resp.ContentRange))
+	if err != nil {
+		return err
+	}
+	p.etag = resp.ETag
+	return nil
+}
+
+// writePart writes the part's buffer, and remaining body if any, into the
+// writer.
+func (d *streamDownloader) writePart(p *streamPart) error {
+	w := &streamPartWriter{d: d, num: p.num}
+
+	if _, err := w.Write((*p.buf)[:p.n]); err != nil {
+		if p.body != nil {
+			p.body.Close()
+		}
+		return err
+	}
+
+	if p.body != nil {
+		_, err := io.Copy(w, p.body)
+		p.body.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	d.progress.PartCompleted(p.num, w.n)
+	return nil
+}
+
+// streamPartWriter writes the bytes of a part into the writer, reporting the
+// bytes written.
+type streamPartWriter struct {
+	d   *streamDownloader
+	num int32
+	n   int64
+}
+
+func (w *streamPartWriter) Write(p []byte) (int, error) {
+	n, err := w.d.w.Write(p)
+	w.n += int64(n)
+	w.d.written += int64(n)
+	w.d.progress.BytesTransferred(w.num, int64(n))
+	return n, err
+}
+
+// parseContentRangeTotal returns the total size of the object from the
+// Content-Range header value, or -1 if the size is not known.
+func parseContentRangeTotal(contentRange string) (int64, error) {
+	parts := strings.Split(contentRange, "/")
+
+	// Checking for whether or not a numbered total exists. If one does not
+	// exist, the total is -1, undefined.
+	totalStr := parts[len(parts)-1]
+	if totalStr == "*" {
+		return -1, nil
+	}
+	return strconv.ParseInt(totalStr, 10, 64)
+}
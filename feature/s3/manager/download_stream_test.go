@@ -0,0 +1,420 @@
+package manager_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+func newStreamData(n int) []byte {
+	data := make([]byte, n)
+	rand.Read(data)
+	return data
+}
+
+func TestDownloadStream(t *testing.T) {
+	cases := map[string]struct {
+		size        int
+		concurrency int
+		bufferSize  int64
+		expectGets  int
+	}{
+		"multiple parts": {
+			size:        1024*10 + 100,
+			concurrency: 5,
+			expectGets:  11,
+		},
+		"single part": {
+			size:        100,
+			concurrency: 5,
+			expectGets:  1,
+		},
+		"exact parts": {
+			size:        1024 * 4,
+			concurrency: 3,
+			expectGets:  4,
+		},
+		"sequential": {
+			size:        1024*3 + 1,
+			concurrency: 1,
+			expectGets:  4,
+		},
+		"buffer smaller than part": {
+			size:        1024*3 + 1,
+			concurrency: 5,
+			bufferSize:  1,
+			expectGets:  4,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			data := newStreamData(c.size)
+			client := newDownloadETagRangeClient(data, "ETAG")
+
+			var buf bytes.Buffer
+			n, err := manager.NewDownloader(client, func(d *manager.Downloader) {
+				d.PartSize = 1024
+				d.Concurrency = c.concurrency
+				d.StreamBufferSize = c.bufferSize
+			}).DownloadStream(context.Background(), &buf, &s3.GetObjectInput{
+				Bucket: aws.String("bucket"),
+				Key:    aws.String("key"),
+			})
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := int64(len(data)), n; e != a {
+				t.Errorf("expect %v bytes, got %v", e, a)
+			}
+			if !bytes.Equal(data, buf.Bytes()) {
+				t.Errorf("expect downloaded data to match object")
+			}
+			if e, a := c.expectGets, client.Invocations(); e != a {
+				t.Errorf("expect %v GetObject calls, got %v", e, a)
+			}
+
+			for i, ifMatch := range client.RetrievedIfMatches {
+				expect := "ETAG"
+				if i == 0 {
+					expect = ""
+				}
+				if e, a := expect, ifMatch; e != a {
+					t.Errorf("expect %v IfMatch for request %v, got %v", e, i, a)
+				}
+			}
+		})
+	}
+}
+
+// blockingWriter is an io.Writer blocking writes until unblocked.
+type blockingWriter struct {
+	bytes.Buffer
+	unblock chan struct{}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	<-w.unblock
+	return w.Buffer.Write(p)
+}
+
+func TestDownloadStream_BufferLimit(t *testing.T) {
+	data := newStreamData(1024 * 20)
+	client, _, _ := newDownloadRangeClient(data)
+
+	w := &blockingWriter{unblock: make(chan struct{})}
+
+	type result struct {
+		n   int64
+		err error
+	}
+	done := make(chan result)
+	go func() {
+		n, err := manager.NewDownloader(client, func(d *manager.Downloader) {
+			d.PartSize = 1024
+			d.Concurrency = 5
+			d.StreamBufferSize = 1024 * 3
+		}).DownloadStream(context.Background(), w, &s3.GetObjectInput{
+			Bucket: aws.String("bucket"),
+			Key:    aws.String("key"),
+		})
+		done <- result{n, err}
+	}()
+
+	// The first part is held until written, so only two more parts can be
+	// downloaded ahead while the writer is blocked.
+	time.Sleep(100 * time.Millisecond)
+	if e, a := 3, client.Invocations(); e != a {
+		t.Errorf("expect %v GetObject calls while blocked, got %v", e, a)
+	}
+
+	close(w.unblock)
+	r := <-done
+	if r.err != nil {
+		t.Fatalf("expect no error, got %v", r.err)
+	}
+	if e, a := int64(len(data)), r.n; e != a {
+		t.Errorf("expect %v bytes, got %v", e, a)
+	}
+	if !bytes.Equal(data, w.Bytes()) {
+		t.Errorf("expect downloaded data to match object")
+	}
+}
+
+func TestDownloadStream_PartError(t *testing.T) {
+	data := newStreamData(1024 * 10)
+	client, _, _ := newDownloadRangeClient(data)
+	getObject := client.GetObjectFn
+	client.GetObjectFn = func(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+		if start, _ := parseRange(aws.ToString(params.Range)); start == 1024*3 {
+			return nil, fmt.Errorf("mock part error")
+		}
+		return getObject(ctx, params, optFns...)
+	}
+
+	var buf bytes.Buffer
+	n, err := manager.NewDownloader(client, func(d *manager.Downloader) {
+		d.PartSize = 1024
+		d.Concurrency = 3
+	}).DownloadStream(context.Background(), &buf, &s3.GetObjectInput{
+		Bucket: aws.String("bucket"),
+		Key:    aws.String("key"),
+	})
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+	if e, a := "mock part error", err.Error(); !strings.Contains(a, e) {
+		t.Errorf("expect error to contain %v, got %v", e, a)
+	}
+	if e, a := int64(1024*3), n; e != a {
+		t.Errorf("expect %v bytes written, got %v", e, a)
+	}
+	if !bytes.Equal(data[:1024*3], buf.Bytes()) {
+		t.Errorf("expect parts before the failed part to be written")
+	}
+}
+
+func TestDownloadStream_NonRangePartResponse(t *testing.T) {
+	data := newStreamData(1024 * 10)
+	client, _, _ := newDownloadRangeClient(data)
+	getObject := client.GetObjectFn
+	client.GetObjectFn = func(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+		if start, _ := parseRange(aws.ToString(params.Range)); start == 1024*2 {
+			// The entire object is returned for a later part.
+			return &s3.GetObjectOutput{
+				Body:          ioutil.NopCloser(bytes.NewReader(data)),
+				ContentLength: int64(len(data)),
+			}, nil
+		}
+		return getObject(ctx, params, optFns...)
+	}
+
+	var buf bytes.Buffer
+	n, err := manager.NewDownloader(client, func(d *manager.Downloader) {
+		d.PartSize = 1024
+		d.Concurrency = 3
+	}).DownloadStream(context.Background(), &buf, &s3.GetObjectInput{
+		Bucket: aws.String("bucket"),
+		Key:    aws.String("key"),
+	})
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+	if e, a := "Content-Range", err.Error(); !strings.Contains(a, e) {
+		t.Errorf("expect error to contain %v, got %v", e, a)
+	}
+	if e, a := int64(1024*2), n; e != a {
+		t.Errorf("expect %v bytes written, got %v", e, a)
+	}
+	if !bytes.Equal(data[:1024*2], buf.Bytes()) {
+		t.Errorf("expect parts before the failed part to be written")
+	}
+}
+
+func TestDownloadStream_PartBodyRetry(t *testing.T) {
+	client, invocations := newDownloadWithErrReaderClient([]testErrReader{
+		{Buf: []byte("ab"), Len: 3, Err: io.ErrUnexpectedEOF},
+		{Buf: []byte("123"), Len: 3, Err: io.EOF},
+	})
+
+	var buf bytes.Buffer
+	n, err := manager.NewDownloader(client).DownloadStream(context.Background(), &buf, &s3.GetObjectInput{
+		Bucket: aws.String("bucket"),
+		Key:    aws.String("key"),
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := int64(3), n; e != a {
+		t.Errorf("expect %v bytes, got %v", e, a)
+	}
+	if e, a := "123", buf.String(); e != a {
+		t.Errorf("expect %q, got %q", e, a)
+	}
+	if e, a := 2, *invocations; e != a {
+		t.Errorf("expect %v GetObject calls, got %v", e, a)
+	}
+}
+
+func TestDownloadStream_UnknownTotal(t *testing.T) {
+	data := newStreamData(1024*3 + 10)
+	client, invocations := newDownloadContentRangeTotalAnyClient(data)
+
+	var buf bytes.Buffer
+	n, err := manager.NewDownloader(client, func(d *manager.Downloader) {
+		d.PartSize = 1024
+	}).DownloadStream(context.Background(), &buf, &s3.GetObjectInput{
+		Bucket: aws.String("bucket"),
+		Key:    aws.String("key"),
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := int64(len(data)), n; e != a {
+		t.Errorf("expect %v bytes, got %v", e, a)
+	}
+	if !bytes.Equal(data, buf.Bytes()) {
+		t.Errorf("expect downloaded data to match object")
+	}
+	if e, a := 4, *invocations; e != a {
+		t.Errorf("expect %v GetObject calls, got %v", e, a)
+	}
+}
+
+func TestDownloadStream_NonRangeResponse(t *testing.T) {
+	data := newStreamData(1024*3 + 10)
+	client, invocations := newDownloadNonRangeClient(data)
+
+	var buf bytes.Buffer
+	n, err := manager.NewDownloader(client, func(d *manager.Downloader) {
+		d.PartSize = 1024
+	}).DownloadStream(context.Background(), &buf, &s3.GetObjectInput{
+		Bucket: aws.String("bucket"),
+		Key:    aws.String("key"),
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := int64(len(data)), n; e != a {
+		t.Errorf("expect %v bytes, got %v", e, a)
+	}
+	if !bytes.Equal(data, buf.Bytes()) {
+		t.Errorf("expect downloaded data to match object")
+	}
+	if e, a := 1, *invocations; e != a {
+		t.Errorf("expect %v GetObject calls, got %v", e, a)
+	}
+}
+
+func TestDownloadStream_WithRange(t *testing.T) {
+	data := newStreamData(1024 * 10)
+	client, invocations, ranges := newDownloadRangeClient(data)
+
+	var buf bytes.Buffer
+	n, err := manager.NewDownloader(client, func(d *manager.Downloader) {
+		d.PartSize = 1024
+	}).DownloadStream(context.Background(), &buf, &s3.GetObjectInput{
+		Bucket: aws.String("bucket"),
+		Key:    aws.String("key"),
+		Range:  aws.String("bytes=100-5000"),
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := int64(4901), n; e != a {
+		t.Errorf("expect %v bytes, got %v", e, a)
+	}
+	if !bytes.Equal(data[100:5001], buf.Bytes()) {
+		t.Errorf("expect downloaded data to match range")
+	}
+	if e, a := 1, *invocations; e != a {
+		t.Errorf("expect %v GetObject calls, got %v", e, a)
+	}
+	if e, a := "bytes=100-5000", (*ranges)[0]; e != a {
+		t.Errorf("expect %v range, got %v", e, a)
+	}
+}
+
+func TestDownloadStream_Progress(t *testing.T) {
+	data := newStreamData(1024 * 5)
+	client, _, _ := newDownloadRangeClient(data)
+	progress := &recordedProgress{}
+
+	_, err := manager.NewDownloader(client, func(d *manager.Downloader) {
+		d.PartSize = 1024
+		d.ProgressListener = progress
+	}).DownloadStream(context.Background(), ioutil.Discard, &s3.GetObjectInput{
+		Bucket: aws.String("Bucket"),
+		Key:    aws.String("Key"),
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	assertProgressEvents(t, progress, manager.ProgressEventTransferCompleted, int64(len(data)), 5)
+}
+
+func TestOpenStream(t *testing.T) {
+	data := newStreamData(1024*10 + 100)
+	client, _, _ := newDownloadRangeClient(data)
+
+	r := manager.NewDownloader(client, func(d *manager.Downloader) {
+		d.PartSize = 1024
+	}).OpenStream(context.Background(), &s3.GetObjectInput{
+		Bucket: aws.String("bucket"),
+		Key:    aws.String("key"),
+	})
+
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("expect no close error, got %v", err)
+	}
+	if !bytes.Equal(data, b) {
+		t.Errorf("expect read data to match object")
+	}
+}
+
+func TestOpenStream_CloseEarly(t *testing.T) {
+	data := newStreamData(1024 * 100)
+	client, _, _ := newDownloadRangeClient(data)
+
+	r := manager.NewDownloader(client, func(d *manager.Downloader) {
+		d.PartSize = 1024
+		d.Concurrency = 2
+	}).OpenStream(context.Background(), &s3.GetObjectInput{
+		Bucket: aws.String("bucket"),
+		Key:    aws.String("key"),
+	})
+
+	b := make([]byte, 1500)
+	if _, err := io.ReadFull(r, b); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if !bytes.Equal(data[:1500], b) {
+		t.Errorf("expect read data to match object")
+	}
+
+	if err := r.Close(); err != nil {
+		t.Fatalf("expect no close error, got %v", err)
+	}
+	if a := client.Invocations(); a >= 100 {
+		t.Errorf("expect download to stop when closed, got %v GetObject calls", a)
+	}
+	if _, err := r.Read(b); err == nil {
+		t.Errorf("expect error reading closed stream")
+	}
+}
+
+func TestOpenStream_Error(t *testing.T) {
+	client, _, _ := newDownloadRangeClient(newStreamData(1024 * 3))
+	client.GetObjectFn = func(context.Context, *s3.GetObjectInput, ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+		return nil, fmt.Errorf("mock get error")
+	}
+
+	r := manager.NewDownloader(client).OpenStream(context.Background(), &s3.GetObjectInput{
+		Bucket: aws.String("bucket"),
+		Key:    aws.String("key"),
+	})
+	defer r.Close()
+
+	_, err := ioutil.ReadAll(r)
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+	if e, a := "mock get error", err.Error(); !strings.Contains(a, e) {
+		t.Errorf("expect error to contain %v, got %v", e, a)
+	}
+}
@@ -27,7 +27,8 @@ type downloadCaptureClient struct {
 	GetObjectFn          func(context.Context, *s3.GetObjectInput, ...func(*s3.Options)) (*s3.GetObjectOutput, error)
 	GetObjectInvocations int
 
-	RetrievedRanges []string
+	RetrievedRanges    []string
+	RetrievedIfMatches []string
 
 	lock sync.Mutex
 }
@@ -41,10 +42,20 @@ func (c *downloadCaptureClient) GetObject(ctx context.Context, params *s3.GetObj
 	if params.Range != nil {
 		c.RetrievedRanges = append(c.RetrievedRanges, aws.ToString(params.Range))
 	}
+	c.RetrievedIfMatches = append(c.RetrievedIfMatches, aws.ToString(params.IfMatch))
 
 	return c.GetObjectFn(ctx, params, optFns...)
 }
 
+// Invocations returns the number of GetObject calls, safe to call while
+// GetObject is being called concurrently.
+func (c *downloadCaptureClient) Invocations() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.GetObjectInvocations
+}
+
 var rangeValueRegex = regexp.MustCompile(`bytes=(\d+)-(\d+)`)
 
 func parseRange(rangeValue string) (start, fin int64) {
@@ -55,9 +66,22 @@ func parseRange(rangeValue string) (start, fin int64) {
 }
 
 func newDownloadRangeClient(data []byte) (*downloadCaptureClient, *int, *[]string) {
+	capture := newDownloadETagRangeClient(data, "")
+
+	return capture, &capture.GetObjectInvocations, &capture.RetrievedRanges
+}
+
+// newDownloadETagRangeClient returns a client returning the requested range of
+// the data with the ETag, if set. Requests with an IfMatch not matching the
+// ETag fail with a 412 status code.
+func newDownloadETagRangeClient(data []byte, etag string) *downloadCaptureClient {
 	capture := &downloadCaptureClient{}
 
 	capture.GetObjectFn = func(_ context.Context, params *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+		if m := aws.ToString(params.IfMatch); len(m) != 0 && m != etag {
+			return nil, &mockHTTPStatusError{StatusCode: 412}
+		}
+
 		start, fin := parseRange(aws.ToString(params.Range))
 		fin++
 
@@ -67,14 +91,18 @@ func newDownloadRangeClient(data []byte) (*downloadCaptureClient, *int, *[]strin
 
 		bodyBytes := data[start:fin]
 
-		return &s3.GetObjectOutput{
+		out := &s3.GetObjectOutput{
 			Body:          ioutil.NopCloser(bytes.NewReader(bodyBytes)),
 			ContentRange:  aws.String(fmt.Sprintf("bytes %d-%d/%d", start, fin-1, len(data))),
 			ContentLength: int64(len(bodyBytes)),
-		}, nil
+		}
+		if len(etag) != 0 {
+			out.ETag = aws.String(etag)
+		}
+		return out, nil
 	}
 
-	return capture, &capture.GetObjectInvocations, &capture.RetrievedRanges
+	return capture
 }
 
 func newDownloadNonRangeClient(data []byte) (*downloadCaptureClient, *int) {
@@ -6,6 +6,7 @@ require (
 	github.com/aws/aws-sdk-go-v2 v1.2.0
 	github.com/aws/aws-sdk-go-v2/config v1.1.1
 	github.com/aws/aws-sdk-go-v2/service/internal/accept-encoding v1.0.1 // indirect
+	github.com/aws/aws-sdk-go-v2/service/internal/checksum v1.0.0 // indirect
 	github.com/aws/aws-sdk-go-v2/service/internal/s3shared v1.1.0 // indirect
 	github.com/aws/aws-sdk-go-v2/service/s3 v1.2.0
 	github.com/aws/smithy-go v1.1.0
@@ -26,8 +27,12 @@ replace github.com/aws/aws-sdk-go-v2/service/sts => ../../../service/sts/
 
 replace github.com/aws/aws-sdk-go-v2/service/internal/accept-encoding => ../../../service/internal/accept-encoding/
 
+replace github.com/aws/aws-sdk-go-v2/service/internal/checksum => ../../../service/internal/checksum/
+
 replace github.com/aws/aws-sdk-go-v2/service/internal/s3shared => ../../../service/internal/s3shared/
 
 replace github.com/aws/aws-sdk-go-v2/service/internal/presigned-url => ../../../service/internal/presigned-url/
 
 replace github.com/aws/aws-sdk-go-v2/service/sso => ../../../service/sso/
+
+replace github.com/aws/aws-sdk-go-v2/service/ssooidc => ../../../service/ssooidc/
@@ -0,0 +1,300 @@
+package manager
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// ProgressEventType is the type of a transfer ProgressEvent.
+type ProgressEventType int
+
+// Enumeration values for ProgressEventType.
+const (
+	// ProgressEventTransferStarted is sent once when a transfer starts.
+	ProgressEventTransferStarted ProgressEventType = iota
+
+	// ProgressEventPartStarted is sent when the transfer of a part starts.
+	ProgressEventPartStarted
+
+	// ProgressEventPartCompleted is sent when the transfer of a part
+	// completes.
+	ProgressEventPartCompleted
+
+	// ProgressEventPartRetried is sent when the transfer of a part failed,
+	// and is retried.
+	ProgressEventPartRetried
+
+	// ProgressEventBytesTransferred is sent when bytes of the object have
+	// been transferred.
+	ProgressEventBytesTransferred
+
+	// ProgressEventTransferCompleted is sent once when a transfer completes
+	// successfully.
+	ProgressEventTransferCompleted
+
+	// ProgressEventTransferFailed is sent once when a transfer fails.
+	ProgressEventTransferFailed
+)
+
+func (t ProgressEventType) String() string {
+	switch t {
+	case ProgressEventTransferStarted:
+		return "TransferStarted"
+	case ProgressEventPartStarted:
+		return "PartStarted"
+	case ProgressEventPartCompleted:
+		return "PartCompleted"
+	case ProgressEventPartRetried:
+		return "PartRetried"
+	case ProgressEventBytesTransferred:
+		return "BytesTransferred"
+	case ProgressEventTransferCompleted:
+		return "TransferCompleted"
+	case ProgressEventTransferFailed:
+		return "TransferFailed"
+	default:
+		return "Unknown"
+	}
+}
+
+// ProgressEvent is an event describing the progress of a single upload, or
+// download.
+type ProgressEvent struct {
+	// The type of the event.
+	Type ProgressEventType
+
+	// The bucket and key of the object being transferred.
+	Bucket string
+	Key    string
+
+	// The part number of the part the event is for. Zero for transfer
+	// events.
+	PartNumber int32
+
+	// The number of bytes transferred by a BytesTransferred event, or the
+	// size of the part for a PartCompleted event.
+	Bytes int64
+
+	// The total number of bytes of the object transferred so far.
+	TransferredBytes int64
+
+	// The total size of the object in bytes, or -1 if the size is not known
+	// yet.
+	TotalBytes int64
+
+	// The attempt number of the part that is being retried for a PartRetried
+	// event, starting at 2 for the first retry. Attempts are counted across
+	// the retries of the part's requests, and the retries reading the part's
+	// response body.
+	Attempt int
+
+	// The error the transfer, or part attempt, failed with for TransferFailed
+	// and PartRetried events.
+	Err error
+}
+
+// ProgressListener is notified of the progress of transfers made by the
+// Uploader and Downloader.
+//
+// The events of a single transfer are delivered sequentially, in the order
+// they occurred. A listener shared by concurrent transfers will be called
+// concurrently, and must be safe for concurrent use.
+type ProgressListener interface {
+	OnProgress(ProgressEvent)
+}
+
+// ProgressListenerFunc is a function that implements the ProgressListener
+// interface.
+type ProgressListenerFunc func(ProgressEvent)
+
+// OnProgress calls the wrapped function with the event.
+func (fn ProgressListenerFunc) OnProgress(event ProgressEvent) {
+	fn(event)
+}
+
+// progressReporter tracks the progress of a single transfer, and sends its
+// events to the listener. A nil progressReporter discards all events.
+type progressReporter struct {
+	listener ProgressListener
+	bucket   string
+	key      string
+
+	m           sync.Mutex
+	transferred int64
+	total       int64
+}
+
+// newProgressReporter returns a progressReporter for the transfer of the
+// object, or nil if there is no listener.
+func newProgressReporter(listener ProgressListener, bucket, key *string) *progressReporter {
+	if listener == nil {
+		return nil
+	}
+	return &progressReporter{
+		listener: listener,
+		bucket:   aws.ToString(bucket),
+		key:      aws.ToString(key),
+		total:    -1,
+	}
+}
+
+// SetTotalBytes sets the total size of the object, if known.
+func (r *progressReporter) SetTotalBytes(n int64) {
+	if r == nil || n < 0 {
+		return
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	r.total = n
+}
+
+func (r *progressReporter) TransferStarted() {
+	r.send(ProgressEvent{Type: ProgressEventTransferStarted})
+}
+
+func (r *progressReporter) PartStarted(part int32) {
+	r.send(ProgressEvent{Type: ProgressEventPartStarted, PartNumber: part})
+}
+
+func (r *progressReporter) PartCompleted(part int32, n int64) {
+	r.send(ProgressEvent{Type: ProgressEventPartCompleted, PartNumber: part, Bytes: n})
+}
+
+func (r *progressReporter) PartRetried(part int32, attempt int, err error) {
+	r.send(ProgressEvent{Type: ProgressEventPartRetried, PartNumber: part, Attempt: attempt, Err: err})
+}
+
+func (r *progressReporter) BytesTransferred(part int32, n int64) {
+	if n <= 0 {
+		return
+	}
+	r.send(ProgressEvent{Type: ProgressEventBytesTransferred, PartNumber: part, Bytes: n})
+}
+
+func (r *progressReporter) TransferCompleted() {
+	r.send(ProgressEvent{Type: ProgressEventTransferCompleted})
+}
+
+func (r *progressReporter) TransferFailed(err error) {
+	r.send(ProgressEvent{Type: ProgressEventTransferFailed, Err: err})
+}
+
+func (r *progressReporter) send(event ProgressEvent) {
+	if r == nil {
+		return
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	switch event.Type {
+	case ProgressEventBytesTransferred:
+		r.transferred += event.Bytes
+	case ProgressEventTransferCompleted:
+		// The size of objects streamed from a reader of unknown length is
+		// only known once the transfer completes.
+		if r.total < 0 {
+			r.total = r.transferred
+		}
+	}
+
+	event.Bucket = r.bucket
+	event.Key = r.key
+	event.TransferredBytes = r.transferred
+	event.TotalBytes = r.total
+
+	r.listener.OnProgress(event)
+}
+
+// PartClientOptions returns the client options for the API operation request
+// transferring the part, reporting retried attempts of the request. The
+// client options passed in are not modified.
+func (r *progressReporter) PartClientOptions(part int32, optFns []func(*s3.Options)) []func(*s3.Options) {
+	return r.PartAttempts(part).ClientOptions(optFns)
+}
+
+// PartAttempts returns the attempts of the part, counted across each request
+// made for the part. Returns nil if progress is not reported.
+func (r *progressReporter) PartAttempts(part int32) *partAttempts {
+	if r == nil {
+		return nil
+	}
+	return &partAttempts{progress: r, part: part, attempts: 1}
+}
+
+// partAttempts counts the attempts of a part, reporting each attempt after
+// the first as a retry of the part. The part is retried by the retries of a
+// request made for it, and by making another request for it, such as to retry
+// reading a response body. A part's requests are made one at a time.
+type partAttempts struct {
+	progress *progressReporter
+	part     int32
+	attempts int
+}
+
+// ClientOptions returns the client options for a request of the part,
+// reporting the retried attempts of the request. The client options passed
+// in are not modified.
+func (a *partAttempts) ClientOptions(optFns []func(*s3.Options)) []func(*s3.Options) {
+	if a == nil {
+		return optFns
+	}
+
+	opts := make([]func(*s3.Options), 0, len(optFns)+1)
+	opts = append(opts, optFns...)
+	return append(opts, func(o *s3.Options) {
+		o.APIOptions = append(o.APIOptions, func(stack *middleware.Stack) error {
+			m := &partAttemptReporter{attempts: a}
+			if _, ok := stack.Finalize.Get(retryMiddlewareID); !ok {
+				return stack.Finalize.Add(m, middleware.Before)
+			}
+			return stack.Finalize.Insert(m, retryMiddlewareID, middleware.After)
+		})
+	})
+}
+
+// Retried reports the next attempt of the part, retried after the error.
+func (a *partAttempts) Retried(err error) {
+	if a == nil {
+		return
+	}
+	a.attempts++
+	a.progress.PartRetried(a.part, a.attempts, err)
+}
+
+// retryMiddlewareID is the ID of the API client's retry middleware, which
+// makes each attempt of a request.
+const retryMiddlewareID = "Retry"
+
+// partAttemptReporter is a middleware placed after the retry middleware,
+// reporting each attempt of the request after the first as a retry of the
+// part.
+type partAttemptReporter struct {
+	attempts *partAttempts
+
+	requestAttempts int
+	lastErr         error
+}
+
+// ID returns the middleware identifier.
+func (*partAttemptReporter) ID() string {
+	return "S3TransferManagerPartAttempt"
+}
+
+// HandleFinalize reports the retry, if the request is being retried.
+func (m *partAttemptReporter) HandleFinalize(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
+	out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+) {
+	m.requestAttempts++
+	if m.requestAttempts > 1 {
+		m.attempts.Retried(m.lastErr)
+	}
+
+	out, metadata, err = next.HandleFinalize(ctx, in)
+	m.lastErr = err
+	return out, metadata, err
+}
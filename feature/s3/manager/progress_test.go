@@ -0,0 +1,389 @@
+package manager_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	s3testing "github.com/aws/aws-sdk-go-v2/feature/s3/manager/internal/testing"
+	"github.com/aws/aws-sdk-go-v2/internal/sdk"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// recordedProgress is a ProgressListener recording the events received.
+type recordedProgress struct {
+	m      sync.Mutex
+	events []manager.ProgressEvent
+}
+
+func (r *recordedProgress) OnProgress(event manager.ProgressEvent) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recordedProgress) Count(typ manager.ProgressEventType) int {
+	var n int
+	for _, e := range r.events {
+		if e.Type == typ {
+			n++
+		}
+	}
+	return n
+}
+
+func (r *recordedProgress) BytesTransferred() int64 {
+	var n int64
+	for _, e := range r.events {
+		if e.Type == manager.ProgressEventBytesTransferred {
+			n += e.Bytes
+		}
+	}
+	return n
+}
+
+func assertProgressEvents(t *testing.T, r *recordedProgress, expectLast manager.ProgressEventType, expectTotal int64, expectParts int) {
+	t.Helper()
+
+	if len(r.events) < 2 {
+		t.Fatalf("expect at least 2 events, got %v", len(r.events))
+	}
+	if e, a := manager.ProgressEventTransferStarted, r.events[0].Type; e != a {
+		t.Errorf("expect first event %v, got %v", e, a)
+	}
+	last := r.events[len(r.events)-1]
+	if e, a := expectLast, last.Type; e != a {
+		t.Errorf("expect last event %v, got %v", e, a)
+	}
+	if e, a := 1, r.Count(manager.ProgressEventTransferStarted); e != a {
+		t.Errorf("expect %v started events, got %v", e, a)
+	}
+	if e, a := expectParts, r.Count(manager.ProgressEventPartStarted); e != a {
+		t.Errorf("expect %v part started events, got %v", e, a)
+	}
+
+	if expectLast != manager.ProgressEventTransferCompleted {
+		return
+	}
+
+	if e, a := expectParts, r.Count(manager.ProgressEventPartCompleted); e != a {
+		t.Errorf("expect %v part completed events, got %v", e, a)
+	}
+	if e, a := expectTotal, r.BytesTransferred(); e != a {
+		t.Errorf("expect %v bytes transferred, got %v", e, a)
+	}
+	if e, a := expectTotal, last.TransferredBytes; e != a {
+		t.Errorf("expect %v transferred bytes, got %v", e, a)
+	}
+	if e, a := expectTotal, last.TotalBytes; e != a {
+		t.Errorf("expect %v total bytes, got %v", e, a)
+	}
+
+	var prev int64
+	for _, e := range r.events {
+		if e.TransferredBytes < prev {
+			t.Errorf("expect transferred bytes to not decrease, %v then %v", prev, e.TransferredBytes)
+		}
+		prev = e.TransferredBytes
+		if e.Bucket != "Bucket" || e.Key != "Key" {
+			t.Errorf("expect event for Bucket/Key, got %v/%v", e.Bucket, e.Key)
+		}
+	}
+}
+
+func TestUploadProgress(t *testing.T) {
+	cases := map[string]struct {
+		body        io.Reader
+		expectTotal int64
+		expectParts int
+	}{
+		"single part": {
+			body:        bytes.NewReader(make([]byte, 1024)),
+			expectTotal: 1024,
+			expectParts: 1,
+		},
+		"multipart": {
+			body:        bytes.NewReader(make([]byte, 1024*1024*12)),
+			expectTotal: 1024 * 1024 * 12,
+			expectParts: 3,
+		},
+		"multipart unknown length": {
+			body:        ioutil.NopCloser(bytes.NewReader(make([]byte, 1024*1024*12))),
+			expectTotal: 1024 * 1024 * 12,
+			expectParts: 3,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			client, _, _ := s3testing.NewUploadLoggingClient(nil)
+			progress := &recordedProgress{}
+
+			_, err := manager.NewUploader(client, func(u *manager.Uploader) {
+				u.ProgressListener = progress
+			}).Upload(context.Background(), &s3.PutObjectInput{
+				Bucket: aws.String("Bucket"),
+				Key:    aws.String("Key"),
+				Body:   c.body,
+			})
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			assertProgressEvents(t, progress, manager.ProgressEventTransferCompleted, c.expectTotal, c.expectParts)
+		})
+	}
+}
+
+func TestUploadProgress_Failure(t *testing.T) {
+	client, _, _ := s3testing.NewUploadLoggingClient(nil)
+	client.UploadPartFn = func(u *s3testing.UploadLoggingClient, params *s3.UploadPartInput) (*s3.UploadPartOutput, error) {
+		if params.PartNumber == 2 {
+			return nil, fmt.Errorf("mock part error")
+		}
+		return &s3.UploadPartOutput{ETag: aws.String("ETAG")}, nil
+	}
+	progress := &recordedProgress{}
+
+	_, err := manager.NewUploader(client, func(u *manager.Uploader) {
+		u.ProgressListener = progress
+		u.Concurrency = 1
+	}).Upload(context.Background(), &s3.PutObjectInput{
+		Bucket: aws.String("Bucket"),
+		Key:    aws.String("Key"),
+		Body:   bytes.NewReader(make([]byte, 1024*1024*12)),
+	})
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+
+	assertProgressEvents(t, progress, manager.ProgressEventTransferFailed, 0, 2)
+	last := progress.events[len(progress.events)-1]
+	if e, a := "mock part error", last.Err.Error(); !strings.Contains(a, e) {
+		t.Errorf("expect error to contain %v, got %v", e, a)
+	}
+	if e, a := 1, progress.Count(manager.ProgressEventPartCompleted); e != a {
+		t.Errorf("expect %v part completed events, got %v", e, a)
+	}
+}
+
+func TestUploadProgress_PartRetried(t *testing.T) {
+	restoreSleep := sdk.TestingUseNopSleep()
+	defer restoreSleep()
+
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("ETag", "ETAG")
+	}))
+	defer server.Close()
+
+	client := s3.New(s3.Options{
+		EndpointResolver: s3testing.EndpointResolverFunc(func(region string, options s3.EndpointResolverOptions) (aws.Endpoint, error) {
+			return aws.Endpoint{
+				URL: server.URL,
+			}, nil
+		}),
+		UsePathStyle: true,
+		Retryer:      retry.NewStandard(),
+	})
+	progress := &recordedProgress{}
+
+	_, err := manager.NewUploader(client, func(u *manager.Uploader) {
+		u.ProgressListener = progress
+	}).Upload(context.Background(), &s3.PutObjectInput{
+		Bucket: aws.String("Bucket"),
+		Key:    aws.String("Key"),
+		Body:   strings.NewReader("hello world"),
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	assertProgressEvents(t, progress, manager.ProgressEventTransferCompleted, 11, 1)
+	if e, a := 1, progress.Count(manager.ProgressEventPartRetried); e != a {
+		t.Fatalf("expect %v part retried events, got %v", e, a)
+	}
+	for _, e := range progress.events {
+		if e.Type != manager.ProgressEventPartRetried {
+			continue
+		}
+		if e.Attempt != 2 || e.PartNumber != 1 || e.Err == nil {
+			t.Errorf("expect retry of part 1 attempt 2 with error, got %v %v %v", e.PartNumber, e.Attempt, e.Err)
+		}
+	}
+}
+
+func TestDownloadProgress(t *testing.T) {
+	data := make([]byte, 1024*1024*12)
+	client, _, _ := newDownloadRangeClient(data)
+	progress := &recordedProgress{}
+
+	w := manager.NewWriteAtBuffer(make([]byte, len(data)))
+	n, err := manager.NewDownloader(client, func(d *manager.Downloader) {
+		d.ProgressListener = progress
+		d.Concurrency = 2
+	}).Download(context.Background(), w, &s3.GetObjectInput{
+		Bucket: aws.String("Bucket"),
+		Key:    aws.String("Key"),
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := int64(len(data)), n; e != a {
+		t.Errorf("expect %v bytes, got %v", e, a)
+	}
+
+	assertProgressEvents(t, progress, manager.ProgressEventTransferCompleted, int64(len(data)), 3)
+	if e, a := int64(-1), progress.events[0].TotalBytes; e != a {
+		t.Errorf("expect %v total bytes at start, got %v", e, a)
+	}
+}
+
+func TestDownloadProgress_PartBodyRetry(t *testing.T) {
+	client, _ := newDownloadWithErrReaderClient([]testErrReader{
+		{Buf: []byte("ab"), Len: 3, Err: io.ErrUnexpectedEOF},
+		{Buf: []byte("123"), Len: 3, Err: io.EOF},
+	})
+	progress := &recordedProgress{}
+
+	w := manager.NewWriteAtBuffer([]byte{})
+	_, err := manager.NewDownloader(client, func(d *manager.Downloader) {
+		d.ProgressListener = progress
+		d.Concurrency = 1
+	}).Download(context.Background(), w, &s3.GetObjectInput{
+		Bucket: aws.String("Bucket"),
+		Key:    aws.String("Key"),
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	assertProgressEvents(t, progress, manager.ProgressEventTransferCompleted, 3, 1)
+	if e, a := 1, progress.Count(manager.ProgressEventPartRetried); e != a {
+		t.Fatalf("expect %v part retried events, got %v", e, a)
+	}
+	for _, e := range progress.events {
+		if e.Type == manager.ProgressEventPartRetried && e.Attempt != 2 {
+			t.Errorf("expect retry attempt 2, got %v", e.Attempt)
+		}
+	}
+}
+
+func TestDownloadProgress_PartRequestAndBodyRetry(t *testing.T) {
+	restoreSleep := sdk.TestingUseNopSleep()
+	defer restoreSleep()
+
+	data := []byte("hello world")
+
+	cases := map[string]func(*manager.Downloader) error{
+		"download": func(d *manager.Downloader) error {
+			_, err := d.Download(context.Background(), manager.NewWriteAtBuffer([]byte{}), &s3.GetObjectInput{
+				Bucket: aws.String("Bucket"),
+				Key:    aws.String("Key"),
+			})
+			return err
+		},
+		"download stream": func(d *manager.Downloader) error {
+			_, err := d.DownloadStream(context.Background(), ioutil.Discard, &s3.GetObjectInput{
+				Bucket: aws.String("Bucket"),
+				Key:    aws.String("Key"),
+			})
+			return err
+		},
+	}
+
+	for name, download := range cases {
+		t.Run(name, func(t *testing.T) {
+			// The first request is retried by the client, and the body of
+			// the retried request is interrupted, retrying the part with
+			// another request.
+			var requests int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				switch requests {
+				case 1:
+					w.WriteHeader(http.StatusInternalServerError)
+				case 2:
+					w.Header().Set("Content-Length", fmt.Sprint(len(data)))
+					w.Write(data[:3])
+				default:
+					w.Write(data)
+				}
+			}))
+			defer server.Close()
+
+			client := s3.New(s3.Options{
+				EndpointResolver: s3testing.EndpointResolverFunc(func(region string, options s3.EndpointResolverOptions) (aws.Endpoint, error) {
+					return aws.Endpoint{
+						URL: server.URL,
+					}, nil
+				}),
+				UsePathStyle: true,
+				Retryer:      retry.NewStandard(),
+			})
+			progress := &recordedProgress{}
+
+			err := download(manager.NewDownloader(client, func(d *manager.Downloader) {
+				d.ProgressListener = progress
+				d.Concurrency = 1
+			}))
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			var attempts []int
+			for _, e := range progress.events {
+				if e.Type != manager.ProgressEventPartRetried {
+					continue
+				}
+				if e.PartNumber != 1 || e.Err == nil {
+					t.Errorf("expect retry of part 1 with error, got %v %v", e.PartNumber, e.Err)
+				}
+				attempts = append(attempts, e.Attempt)
+			}
+			if e, a := fmt.Sprint([]int{2, 3}), fmt.Sprint(attempts); e != a {
+				t.Errorf("expect %v retry attempts, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestDownloadProgress_Failure(t *testing.T) {
+	client, _ := newDownloadWithErrReaderClient([]testErrReader{
+		{Buf: []byte("ab"), Len: 3, Err: io.ErrUnexpectedEOF},
+	})
+	progress := &recordedProgress{}
+
+	w := manager.NewWriteAtBuffer([]byte{})
+	_, err := manager.NewDownloader(client, func(d *manager.Downloader) {
+		d.ProgressListener = progress
+		d.PartBodyMaxRetries = 0
+	}).Download(context.Background(), w, &s3.GetObjectInput{
+		Bucket: aws.String("Bucket"),
+		Key:    aws.String("Key"),
+	})
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+
+	assertProgressEvents(t, progress, manager.ProgressEventTransferFailed, 0, 1)
+	if e, a := 0, progress.Count(manager.ProgressEventPartRetried); e != a {
+		t.Errorf("expect %v part retried events, got %v", e, a)
+	}
+}
@@ -4,8 +4,13 @@ import (
 	"bytes"
 	"context"
 	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"hash"
+	"hash/crc32"
 	"io"
 	"net/http"
 	"sort"
@@ -107,6 +112,24 @@ type UploadOutput struct {
 	// The ID for a multipart upload to S3. In the case of an error the error
 	// can be cast to the MultiUploadFailure interface to extract the upload ID.
 	UploadID string
+
+	// The base64-encoded CRC32 checksum of the object, if the object was
+	// uploaded with the CRC32 checksum algorithm. For a multipart upload
+	// the checksum is the checksum of the checksums of each part, followed
+	// by a hyphen and the number of parts.
+	ChecksumCRC32 *string
+
+	// The base64-encoded CRC32C checksum of the object, if the object was
+	// uploaded with the CRC32C checksum algorithm.
+	ChecksumCRC32C *string
+
+	// The base64-encoded SHA1 checksum of the object, if the object was
+	// uploaded with the SHA1 checksum algorithm.
+	ChecksumSHA1 *string
+
+	// The base64-encoded SHA256 checksum of the object, if the object was
+	// uploaded with the SHA256 checksum algorithm.
+	ChecksumSHA256 *string
 }
 
 // WithUploaderRequestOptions appends to the Uploader's API client options.
@@ -164,6 +187,10 @@ type Uploader struct {
 	// Defines the buffer strategy used when uploading a part
 	BufferProvider ReadSeekerWriteToProvider
 
+	// ProgressListener is notified of the progress of each upload, if set.
+	// For uploads, bytes transferred are reported as each part completes.
+	ProgressListener ProgressListener
+
 	// partPool allows for the re-usage of streaming payload part buffers between upload calls
 	partPool byteSlicePool
 }
@@ -274,6 +301,8 @@ type uploader struct {
 
 	readerPos int64 // current reader position
 	totalSize int64 // set to -1 if the size is not known
+
+	progress *progressReporter
 }
 
 // internal logic for deciding whether to upload a single part or use a
@@ -288,6 +317,22 @@ func (u *uploader) upload() (*UploadOutput, error) {
 		return nil, fmt.Errorf("part size must be at least %d bytes", MinUploadPartSize)
 	}
 
+	u.progress = newProgressReporter(u.cfg.ProgressListener, u.in.Bucket, u.in.Key)
+	u.progress.SetTotalBytes(u.totalSize)
+	u.progress.TransferStarted()
+
+	out, err := u.uploadParts()
+	if err != nil {
+		u.progress.TransferFailed(err)
+	} else {
+		u.progress.TransferCompleted()
+	}
+	return out, err
+}
+
+// uploadParts uploads the object as a single part, or as a multipart upload
+// if there is more than one part.
+func (u *uploader) uploadParts() (*UploadOutput, error) {
 	// Do one read to determine if we have more than one part
 	reader, _, cleanup, err := u.nextReader()
 	if err == io.EOF { // single part
@@ -429,18 +474,32 @@ func (u *uploader) singlePart(r io.ReadSeeker, cleanup func()) (*UploadOutput, e
 	awsutil.Copy(params, u.in)
 	params.Body = r
 
+	n, err := seekerLen(r)
+	if err != nil {
+		return nil, err
+	}
+	u.progress.PartStarted(1)
+
 	// Need to use request form because URL generated in request is
 	// used in return.
 
 	var locationRecorder recordLocationClient
-	out, err := u.cfg.S3.PutObject(u.ctx, params, append(u.cfg.ClientOptions, locationRecorder.WrapClient())...)
+	clientOptions := u.progress.PartClientOptions(1, u.cfg.ClientOptions)
+	out, err := u.cfg.S3.PutObject(u.ctx, params, append(clientOptions, locationRecorder.WrapClient())...)
 	if err != nil {
 		return nil, err
 	}
 
+	u.progress.BytesTransferred(1, n)
+	u.progress.PartCompleted(1, n)
+
 	return &UploadOutput{
-		Location:  locationRecorder.location,
-		VersionID: out.VersionId,
+		Location:       locationRecorder.location,
+		VersionID:      out.VersionId,
+		ChecksumCRC32:  out.ChecksumCRC32,
+		ChecksumCRC32C: out.ChecksumCRC32C,
+		ChecksumSHA1:   out.ChecksumSHA1,
+		ChecksumSHA256: out.ChecksumSHA256,
 	}, nil
 }
 
@@ -585,9 +644,13 @@ func (u *multiuploader) upload(firstBuf io.ReadSeeker, cleanup func()) (*UploadO
 	}
 
 	return &UploadOutput{
-		Location:  locationRecorder.location,
-		VersionID: complete.VersionId,
-		UploadID:  u.uploadID,
+		Location:       locationRecorder.location,
+		VersionID:      complete.VersionId,
+		UploadID:       u.uploadID,
+		ChecksumCRC32:  complete.ChecksumCRC32,
+		ChecksumCRC32C: complete.ChecksumCRC32C,
+		ChecksumSHA1:   complete.ChecksumSHA1,
+		ChecksumSHA256: complete.ChecksumSHA256,
 	}, nil
 }
 
@@ -646,10 +709,7 @@ func (u *multiuploader) readChunk(ch chan chunk) {
 }
 
 // completePart keeps track of completed part information
-func (u *multiuploader) completePart(c chunk, eTag *string) {
-	n := c.num
-	completed := types.CompletedPart{ETag: eTag, PartNumber: n}
-
+func (u *multiuploader) completePart(completed types.CompletedPart) {
 	u.m.Lock()
 	u.parts = append(u.parts, completed)
 	u.m.Unlock()
@@ -657,15 +717,39 @@ func (u *multiuploader) completePart(c chunk, eTag *string) {
 
 // check checks if a chunk's checksum matches its parts ETAG
 // and keeps track of the completed part information
+//
+// If the upload uses a checksum algorithm, the checksum of the chunk is
+// computed, since the completed parts of the upload must include the
+// checksum of each part.
 func (u *multiuploader) check(c chunk, eTag *string) error {
 	summer := md5.New()
-	io.Copy(summer, c.buf)
+
+	var checksum hash.Hash
+	w := io.Writer(summer)
+	if len(u.in.ChecksumAlgorithm) != 0 {
+		var err error
+		if checksum, err = newChecksumHash(u.in.ChecksumAlgorithm); err != nil {
+			return err
+		}
+		w = io.MultiWriter(summer, checksum)
+	}
+
+	n, _ := io.Copy(w, c.buf)
 	sum := hex.EncodeToString(summer.Sum([]byte{}))
 	if sum != *eTag {
 		return fmt.Errorf("checksum did not match for chunk %d, multipart upload out of sync with local file", c.num)
 	}
 
-	u.completePart(c, eTag)
+	completed := types.CompletedPart{ETag: eTag, PartNumber: c.num}
+	if checksum != nil {
+		setCompletedPartChecksum(&completed, u.in.ChecksumAlgorithm,
+			base64.StdEncoding.EncodeToString(checksum.Sum(nil)))
+	}
+
+	u.completePart(completed)
+
+	u.progress.BytesTransferred(c.num, n)
+	u.progress.PartCompleted(c.num, n)
 	return nil
 }
 
@@ -680,15 +764,31 @@ func (u *multiuploader) send(c chunk) error {
 		SSECustomerAlgorithm: u.in.SSECustomerAlgorithm,
 		SSECustomerKey:       u.in.SSECustomerKey,
 		PartNumber:           c.num,
+		ChecksumAlgorithm:    u.in.ChecksumAlgorithm,
+	}
+
+	n, err := seekerLen(c.buf)
+	if err != nil {
+		return err
 	}
+	u.progress.PartStarted(c.num)
 
-	resp, err := u.cfg.S3.UploadPart(u.ctx, params, u.cfg.ClientOptions...)
+	resp, err := u.cfg.S3.UploadPart(u.ctx, params, u.progress.PartClientOptions(c.num, u.cfg.ClientOptions)...)
 	if err != nil {
 		return err
 	}
 
-	u.completePart(c, resp.ETag)
+	u.completePart(types.CompletedPart{
+		ETag:           resp.ETag,
+		PartNumber:     c.num,
+		ChecksumCRC32:  resp.ChecksumCRC32,
+		ChecksumCRC32C: resp.ChecksumCRC32C,
+		ChecksumSHA1:   resp.ChecksumSHA1,
+		ChecksumSHA256: resp.ChecksumSHA256,
+	})
 
+	u.progress.BytesTransferred(c.num, n)
+	u.progress.PartCompleted(c.num, n)
 	return nil
 }
 
@@ -752,6 +852,38 @@ func (u *multiuploader) complete() *s3.CompleteMultipartUploadOutput {
 	return resp
 }
 
+// newChecksumHash returns the hash for computing the checksum of a part with
+// the checksum algorithm.
+func newChecksumHash(algorithm types.ChecksumAlgorithm) (hash.Hash, error) {
+	switch algorithm {
+	case types.ChecksumAlgorithmCrc32:
+		return crc32.NewIEEE(), nil
+	case types.ChecksumAlgorithmCrc32c:
+		return crc32.New(crc32.MakeTable(crc32.Castagnoli)), nil
+	case types.ChecksumAlgorithmSha1:
+		return sha1.New(), nil
+	case types.ChecksumAlgorithmSha256:
+		return sha256.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported checksum algorithm, %v", algorithm)
+	}
+}
+
+// setCompletedPartChecksum sets the completed part's checksum member of the
+// checksum algorithm.
+func setCompletedPartChecksum(part *types.CompletedPart, algorithm types.ChecksumAlgorithm, checksum string) {
+	switch algorithm {
+	case types.ChecksumAlgorithmCrc32:
+		part.ChecksumCRC32 = &checksum
+	case types.ChecksumAlgorithmCrc32c:
+		part.ChecksumCRC32C = &checksum
+	case types.ChecksumAlgorithmSha1:
+		part.ChecksumSHA1 = &checksum
+	case types.ChecksumAlgorithmSha256:
+		part.ChecksumSHA256 = &checksum
+	}
+}
+
 type readerAtSeeker interface {
 	io.ReaderAt
 	io.ReadSeeker
@@ -0,0 +1,278 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// UploadDirectoryInput provides the input parameters for uploading a local
+// directory tree to a S3 key prefix.
+type UploadDirectoryInput struct {
+	// The bucket to upload the directory to.
+	Bucket *string
+
+	// The key prefix the directory is uploaded under. Each file is uploaded to
+	// the key prefix joined with the file's slash separated path relative to
+	// Source.
+	KeyPrefix *string
+
+	// The local directory to upload.
+	Source string
+
+	// Patterns, in path.Match syntax, selecting the files to upload. Patterns
+	// are matched against both the slash separated path relative to Source,
+	// and the file's base name. If empty all files are included.
+	Include []string
+
+	// Patterns, in path.Match syntax, of files to not upload. Exclude patterns
+	// take precedence over Include patterns.
+	Exclude []string
+
+	// How symbolic links within Source are handled. Defaults to skipping
+	// symbolic links.
+	SymlinkPolicy SymlinkPolicy
+
+	// The comparison used to skip files that have not changed since they
+	// were last uploaded. Requires the Uploader's S3 client to also implement
+	// ListObjectsV2APIClient. Defaults to uploading all files.
+	SyncMode SyncMode
+
+	// The number of files to upload in parallel. If this is set to zero, the
+	// DefaultDirectoryConcurrency value will be used. The Uploader's
+	// Concurrency limits the number of parts uploaded in parallel for each
+	// file.
+	Concurrency int
+
+	// Optional function called for each file to customize the PutObjectInput
+	// used to upload the file, such as to set the ContentType, or
+	// ServerSideEncryption.
+	PutObjectInputFn func(filePath string, input *s3.PutObjectInput)
+}
+
+// UploadDirectoryOutput represents a response from the UploadDirectory()
+// call.
+type UploadDirectoryOutput struct {
+	// The result of each file selected for upload, sorted by path.
+	Results []FileTransferResult
+}
+
+// UploadDirectory uploads the files of a local directory tree to S3 under a
+// key prefix, using the Uploader to upload each file. Files are uploaded
+// concurrently.
+//
+// An error is returned without an output if the directory could not be read,
+// or the existing objects could not be listed for a sync. If one or more
+// files failed to upload, the output is returned along with a
+// *DirectoryTransferError.
+//
+// Example:
+//
+//	out, err := uploader.UploadDirectory(context.TODO(), &manager.UploadDirectoryInput{
+//		Bucket:    aws.String("bucket"),
+//		KeyPrefix: aws.String("build/"),
+//		Source:    "./build",
+//		Exclude:   []string{"*.tmp"},
+//		SyncMode:  manager.SyncModeETag,
+//	})
+func (u Uploader) UploadDirectory(ctx context.Context, input *UploadDirectoryInput, opts ...func(*Uploader)) (*UploadDirectoryOutput, error) {
+	filter, err := newDirectoryFilter(input.Include, input.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	w := directoryWalker{
+		filter:        filter,
+		symlinkPolicy: input.SymlinkPolicy,
+		walking:       map[string]struct{}{},
+	}
+	if err := w.Walk(input.Source); err != nil {
+		return nil, err
+	}
+
+	prefix := directoryKeyPrefix(input.KeyPrefix)
+
+	var remote map[string]remoteObject
+	if input.SyncMode != SyncModeNone {
+		client, ok := u.S3.(ListObjectsV2APIClient)
+		if !ok {
+			return nil, fmt.Errorf("sync mode requires the S3 client to implement ListObjectsV2APIClient, %T", u.S3)
+		}
+		remote, err = listRemoteObjects(ctx, client, input.Bucket, prefix, u.ClientOptions)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]FileTransferResult, len(w.files))
+	transferFiles(input.Concurrency, len(w.files), func(i int) {
+		f := w.files[i]
+		results[i] = u.uploadDirectoryFile(ctx, input, prefix, f, remote, opts)
+	})
+
+	out := &UploadDirectoryOutput{Results: results}
+	return out, directoryTransferResults(out.Results)
+}
+
+func (u Uploader) uploadDirectoryFile(ctx context.Context, input *UploadDirectoryInput, prefix string, f walkedFile, remote map[string]remoteObject, opts []func(*Uploader)) FileTransferResult {
+	result := FileTransferResult{
+		Path: f.Path,
+		Key:  prefix + f.Rel,
+	}
+	if f.Err != nil {
+		result.Status, result.Err = FileTransferStatusFailed, f.Err
+		return result
+	}
+	if f.Info == nil {
+		result.Status = FileTransferStatusSkipped
+		return result
+	}
+	if err := ctx.Err(); err != nil {
+		result.Status, result.Err = FileTransferStatusFailed, err
+		return result
+	}
+
+	if obj, ok := remote[f.Rel]; ok {
+		unchanged, err := fileUnchanged(input.SyncMode, f.Path, f.Info, obj, true)
+		if err != nil {
+			result.Status, result.Err = FileTransferStatusFailed, err
+			return result
+		}
+		if unchanged {
+			result.Status = FileTransferStatusSkipped
+			return result
+		}
+	}
+
+	file, err := os.Open(f.Path)
+	if err != nil {
+		result.Status, result.Err = FileTransferStatusFailed, err
+		return result
+	}
+	defer file.Close()
+
+	params := &s3.PutObjectInput{
+		Bucket: input.Bucket,
+		Key:    &result.Key,
+		Body:   file,
+	}
+	if input.PutObjectInputFn != nil {
+		input.PutObjectInputFn(f.Path, params)
+	}
+
+	if _, err := u.Upload(ctx, params, opts...); err != nil {
+		result.Status, result.Err = FileTransferStatusFailed, err
+		return result
+	}
+
+	result.Status = FileTransferStatusTransferred
+	result.Bytes = f.Info.Size()
+	return result
+}
+
+// walkedFile is a file found walking a directory tree.
+type walkedFile struct {
+	// The local path of the file.
+	Path string
+
+	// The slash separated path relative to the root of the walk.
+	Rel string
+
+	// The file's info, nil if the file is a skipped symbolic link.
+	Info os.FileInfo
+
+	// The error for a symbolic link that could not be followed, or is not
+	// allowed by the symbolic link policy.
+	Err error
+}
+
+// directoryWalker walks a directory tree collecting the regular files
+// selected by the filter.
+type directoryWalker struct {
+	filter        directoryFilter
+	symlinkPolicy SymlinkPolicy
+
+	// the real paths of the directories being walked, from the root to the
+	// current directory, to prevent following symbolic links into a loop.
+	walking map[string]struct{}
+
+	files []walkedFile
+}
+
+// Walk walks the directory tree rooted at root.
+func (w *directoryWalker) Walk(root string) error {
+	info, err := os.Stat(root)
+	if err != nil {
+		return fmt.Errorf("failed to read directory, %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("failed to read directory, %s is not a directory", root)
+	}
+	return w.walk(root, "")
+}
+
+func (w *directoryWalker) walk(dir, rel string) error {
+	realDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read directory, %w", err)
+	}
+	if _, ok := w.walking[realDir]; ok {
+		return nil
+	}
+	w.walking[realDir] = struct{}{}
+	defer delete(w.walking, realDir)
+
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read directory, %w", err)
+	}
+
+	for _, info := range infos {
+		filePath := filepath.Join(dir, info.Name())
+		fileRel := path.Join(rel, info.Name())
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			switch w.symlinkPolicy {
+			case SymlinkPolicyFollow:
+				info, err = os.Stat(filePath)
+				if err != nil {
+					w.addFile(walkedFile{Path: filePath, Rel: fileRel, Err: err})
+					continue
+				}
+			case SymlinkPolicyError:
+				w.addFile(walkedFile{Path: filePath, Rel: fileRel,
+					Err: fmt.Errorf("symbolic links are not allowed by policy")})
+				continue
+			default:
+				w.addFile(walkedFile{Path: filePath, Rel: fileRel})
+				continue
+			}
+		}
+
+		if info.IsDir() {
+			if err := w.walk(filePath, fileRel); err != nil {
+				return err
+			}
+			continue
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+
+		w.addFile(walkedFile{Path: filePath, Rel: fileRel, Info: info})
+	}
+
+	return nil
+}
+
+func (w *directoryWalker) addFile(f walkedFile) {
+	if !w.filter.Matches(f.Rel) {
+		return
+	}
+	w.files = append(w.files, f)
+}
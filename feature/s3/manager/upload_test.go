@@ -3,7 +3,11 @@ package manager_test
 import (
 	"bytes"
 	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"hash/crc32"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -1224,3 +1228,165 @@ const completeUploadResp = `<CompleteMultipartUploadResponse>
 const abortUploadResp = `<AbortMultipartUploadResponse></AbortMultipartUploadResponse>`
 
 const listPartsResp = `<ListPartsResult>%s</ListPartsResult>`
+
+func TestUploadChecksumAlgorithm(t *testing.T) {
+	partChecksum := func(part int32) *string {
+		return aws.String(fmt.Sprintf("CHECKSUM%d", part))
+	}
+
+	cases := map[string]struct {
+		body                []byte
+		expectInvocations   []string
+		expectChecksumCRC32 string
+	}{
+		"single part": {
+			body:                buf2MB,
+			expectInvocations:   []string{"PutObject"},
+			expectChecksumCRC32: "OBJECT-CHECKSUM",
+		},
+		"multipart": {
+			body: buf12MB,
+			expectInvocations: []string{"CreateMultipartUpload", "UploadPart", "UploadPart",
+				"UploadPart", "CompleteMultipartUpload"},
+			expectChecksumCRC32: "OBJECT-CHECKSUM-3",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			client, invocations, params := s3testing.NewUploadLoggingClient(nil)
+			client.PutObjectFn = func(*s3testing.UploadLoggingClient, *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+				return &s3.PutObjectOutput{
+					ChecksumCRC32: aws.String("OBJECT-CHECKSUM"),
+				}, nil
+			}
+			client.UploadPartFn = func(u *s3testing.UploadLoggingClient, in *s3.UploadPartInput) (*s3.UploadPartOutput, error) {
+				return &s3.UploadPartOutput{
+					ETag:          aws.String(fmt.Sprintf("ETAG%d", in.PartNumber)),
+					ChecksumCRC32: partChecksum(in.PartNumber),
+				}, nil
+			}
+			client.CompleteMultipartUploadFn = func(*s3testing.UploadLoggingClient, *s3.CompleteMultipartUploadInput) (*s3.CompleteMultipartUploadOutput, error) {
+				return &s3.CompleteMultipartUploadOutput{
+					ChecksumCRC32: aws.String("OBJECT-CHECKSUM-3"),
+				}, nil
+			}
+
+			mgr := manager.NewUploader(client)
+			resp, err := mgr.Upload(context.Background(), &s3.PutObjectInput{
+				Bucket:            aws.String("Bucket"),
+				Key:               aws.String("Key"),
+				Body:              bytes.NewReader(c.body),
+				ChecksumAlgorithm: types.ChecksumAlgorithmCrc32,
+			})
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			if diff := cmp.Diff(c.expectInvocations, *invocations); len(diff) > 0 {
+				t.Error(diff)
+			}
+			if e, a := c.expectChecksumCRC32, aws.ToString(resp.ChecksumCRC32); e != a {
+				t.Errorf("expect %v checksum, got %v", e, a)
+			}
+
+			for _, param := range *params {
+				var algorithm types.ChecksumAlgorithm
+				switch v := param.(type) {
+				case *s3.PutObjectInput:
+					algorithm = v.ChecksumAlgorithm
+				case *s3.CreateMultipartUploadInput:
+					algorithm = v.ChecksumAlgorithm
+				case *s3.UploadPartInput:
+					algorithm = v.ChecksumAlgorithm
+				case *s3.CompleteMultipartUploadInput:
+					parts := v.MultipartUpload.Parts
+					if e, a := 3, len(parts); e != a {
+						t.Fatalf("expect %v parts, got %v", e, a)
+					}
+					for _, part := range parts {
+						if e, a := aws.ToString(partChecksum(part.PartNumber)), aws.ToString(part.ChecksumCRC32); e != a {
+							t.Errorf("expect part %v checksum %v, got %v", part.PartNumber, e, a)
+						}
+					}
+					continue
+				}
+				if e, a := types.ChecksumAlgorithmCrc32, algorithm; e != a {
+					t.Errorf("expect %T checksum algorithm %v, got %v", param, e, a)
+				}
+			}
+		})
+	}
+}
+
+// listPartsClient returns the parts from ListParts.
+type listPartsClient struct {
+	*s3testing.UploadLoggingClient
+	parts []types.Part
+}
+
+func (c *listPartsClient) ListParts(ctx context.Context, params *s3.ListPartsInput, optFns ...func(*s3.Options)) (*s3.ListPartsOutput, error) {
+	out, err := c.UploadLoggingClient.ListParts(ctx, params, optFns...)
+	if err != nil {
+		return nil, err
+	}
+	out.Parts = c.parts
+	return out, nil
+}
+
+func TestResumeUploadChecksumAlgorithm(t *testing.T) {
+	const partSize = 1024 * 1024 * 5
+
+	// The first part was already uploaded, its checksum is computed locally.
+	eTag := md5.Sum(buf12MB[:partSize])
+	expectChecksum := crc32.NewIEEE()
+	expectChecksum.Write(buf12MB[:partSize])
+
+	loggingClient, invocations, params := s3testing.NewUploadLoggingClient(nil)
+	client := &listPartsClient{
+		UploadLoggingClient: loggingClient,
+		parts: []types.Part{
+			{PartNumber: 1, ETag: aws.String(strconv.Quote(hex.EncodeToString(eTag[:])))},
+		},
+	}
+	client.UploadPartFn = func(u *s3testing.UploadLoggingClient, in *s3.UploadPartInput) (*s3.UploadPartOutput, error) {
+		return &s3.UploadPartOutput{
+			ETag:          aws.String(fmt.Sprintf("ETAG%d", in.PartNumber)),
+			ChecksumCRC32: aws.String(fmt.Sprintf("CHECKSUM%d", in.PartNumber)),
+		}, nil
+	}
+
+	mgr := manager.NewUploader(client, func(u *manager.Uploader) {
+		u.PartSize = partSize
+		u.Concurrency = 1
+	})
+	_, err := mgr.ResumeUpload(context.Background(), &s3.PutObjectInput{
+		Bucket:            aws.String("Bucket"),
+		Key:               aws.String("Key"),
+		Body:              bytes.NewReader(buf12MB),
+		ChecksumAlgorithm: types.ChecksumAlgorithmCrc32,
+	}, aws.String("UPLOAD-ID"))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if diff := cmp.Diff([]string{"ListMultipartUploads", "UploadPart", "UploadPart", "CompleteMultipartUpload"},
+		*invocations); len(diff) > 0 {
+		t.Error(diff)
+	}
+
+	parts := (*params)[3].(*s3.CompleteMultipartUploadInput).MultipartUpload.Parts
+	expect := []string{
+		base64.StdEncoding.EncodeToString(expectChecksum.Sum(nil)),
+		"CHECKSUM2",
+		"CHECKSUM3",
+	}
+	if e, a := len(expect), len(parts); e != a {
+		t.Fatalf("expect %v parts, got %v", e, a)
+	}
+	for i, part := range parts {
+		if e, a := expect[i], aws.ToString(part.ChecksumCRC32); e != a {
+			t.Errorf("expect part %v checksum %v, got %v", part.PartNumber, e, a)
+		}
+	}
+}
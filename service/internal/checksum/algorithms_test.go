@@ -0,0 +1,127 @@
+package checksum
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseAlgorithm(t *testing.T) {
+	cases := map[string]struct {
+		Value     string
+		Expect    Algorithm
+		ExpectErr string
+	}{
+		"crc32c": {
+			Value:  "crc32c",
+			Expect: AlgorithmCRC32C,
+		},
+		"CRC32C": {
+			Value:  "CRC32C",
+			Expect: AlgorithmCRC32C,
+		},
+		"crc32": {
+			Value:  "crc32",
+			Expect: AlgorithmCRC32,
+		},
+		"sha1": {
+			Value:  "sha1",
+			Expect: AlgorithmSHA1,
+		},
+		"sha256": {
+			Value:  "Sha256",
+			Expect: AlgorithmSHA256,
+		},
+		"unknown": {
+			Value:     "md5",
+			ExpectErr: "unknown checksum algorithm",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			algorithm, err := ParseAlgorithm(c.Value)
+			if len(c.ExpectErr) != 0 {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				if e, a := c.ExpectErr, err.Error(); !strings.Contains(a, e) {
+					t.Fatalf("expect error to contain %v, got %v", e, a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.Expect, algorithm; e != a {
+				t.Errorf("expect %v algorithm, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestFilterSupportedAlgorithms(t *testing.T) {
+	cases := map[string]struct {
+		Values []string
+		Expect []Algorithm
+	}{
+		"no algorithms": {
+			Expect: []Algorithm{},
+		},
+		"no supported algorithms": {
+			Values: []string{"abc", "123"},
+			Expect: []Algorithm{},
+		},
+		"duplicate algorithms": {
+			Values: []string{"crc32", "crc32c", "crc32c"},
+			Expect: []Algorithm{AlgorithmCRC32, AlgorithmCRC32C},
+		},
+		"preserves order": {
+			Values: []string{"sha256", "crc32", "CRC32C"},
+			Expect: []Algorithm{AlgorithmSHA256, AlgorithmCRC32, AlgorithmCRC32C},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if e, a := c.Expect, FilterSupportedAlgorithms(c.Values); !reflect.DeepEqual(e, a) {
+				t.Errorf("expect %v algorithms, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestComputeChecksum(t *testing.T) {
+	cases := map[Algorithm]string{
+		AlgorithmCRC32:  "DUoRhQ==",
+		AlgorithmCRC32C: "yZRlqg==",
+		AlgorithmSHA1:   "Kq5sNclPz7QV2+lfQIuc6R7oRu0=",
+		AlgorithmSHA256: "uU0nuZNNPgilLlLX2n2r+sSE7+N6U4DukIj3rOLvzek=",
+	}
+
+	for algorithm, expect := range cases {
+		t.Run(string(algorithm), func(t *testing.T) {
+			checksum, err := computeChecksum(algorithm, strings.NewReader("hello world"))
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := expect, encodeChecksum(checksum); e != a {
+				t.Errorf("expect %v checksum, got %v", e, a)
+			}
+
+			length, err := AlgorithmChecksumLength(algorithm)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := length, len(checksum); e != a {
+				t.Errorf("expect %v checksum length, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestAlgorithmHTTPHeader(t *testing.T) {
+	if e, a := "x-amz-checksum-crc32c", AlgorithmHTTPHeader(AlgorithmCRC32C); e != a {
+		t.Errorf("expect %v header, got %v", e, a)
+	}
+}
@@ -0,0 +1,28 @@
+/*
+Package checksum provides the middleware for computing the checksum of the
+request payload, and validating the checksum of the response payload, of
+operations that support flexible checksums.
+
+Request checksums
+
+The operation's input selects the checksum algorithm of the request payload,
+e.g. the Amazon S3 PutObject ChecksumAlgorithm member. The checksum of a
+seekable payload is computed before the request is sent, and is sent as the
+algorithm's "x-amz-checksum-<algorithm>" header. The checksum of a payload
+that is not seekable is computed as the payload is sent with aws-chunked
+encoding, and is sent as a trailing header after the payload.
+
+Supported algorithms are CRC32, CRC32C, SHA1, and SHA256.
+
+Response checksums
+
+When the operation's input enables checksum validation, e.g. the Amazon S3
+GetObject ChecksumMode member, the response payload is wrapped with a reader
+that computes the checksum of the payload as it is read. Once the payload has
+been read the computed checksum is compared against the checksum returned by
+the response, and an error is returned by the reader if they do not match.
+
+Composite checksums of objects uploaded with multipart upload, that end with
+"-<number of parts>", are not checksums of the payload, and are not validated.
+*/
+package checksum
@@ -0,0 +1,10 @@
+module github.com/aws/aws-sdk-go-v2/service/internal/checksum
+
+go 1.15
+
+require (
+	github.com/aws/aws-sdk-go-v2 v1.2.0
+	github.com/aws/smithy-go v1.1.0
+)
+
+replace github.com/aws/aws-sdk-go-v2 => ../../../
@@ -0,0 +1,109 @@
+package checksum
+
+import (
+	"github.com/aws/smithy-go/middleware"
+)
+
+// InputMiddlewareOptions provides the options for the request
+// checksum middleware setup.
+type InputMiddlewareOptions struct {
+	// GetAlgorithm is a function to get the checksum algorithm of the
+	// input payload from the input parameters.
+	//
+	// Given the input parameter value, the function must return the algorithm
+	// and true, or false if no algorithm is specified.
+	GetAlgorithm func(interface{}) (string, bool)
+
+	// Enables support for computing the checksum of a payload that is not
+	// seekable, and sending it as a trailing header after the payload. The
+	// payload is sent with aws-chunked encoding, and its length must be known.
+	//
+	// If disabled, an error is returned if the checksum of a payload that is
+	// not seekable is requested.
+	EnableTrailingChecksum bool
+
+	// Enables the SHA256 checksum of the payload to be used as the SigV4
+	// payload hash, instead of computing the hash of the payload twice.
+	EnableComputeSHA256PayloadHash bool
+}
+
+// AddInputMiddleware adds the middleware for computing the checksum of the
+// operation's input payload, with the algorithm selected by the input
+// parameters.
+//
+// The checksum is sent as the algorithm's "x-amz-checksum-<algorithm>"
+// header, or as a trailing header if the payload is not seekable. The
+// checksum is not computed if the header was already provided.
+func AddInputMiddleware(stack *middleware.Stack, options InputMiddlewareOptions) (err error) {
+	err = stack.Initialize.Add(&setupInputContext{
+		GetAlgorithm: options.GetAlgorithm,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+
+	inputChecksum := &computeInputPayloadChecksum{
+		EnableTrailingChecksum:         options.EnableTrailingChecksum,
+		EnableComputeSHA256PayloadHash: options.EnableComputeSHA256PayloadHash,
+	}
+
+	// The checksum must be computed before the payload hash so that the
+	// payload can be signed as a streaming payload with a trailing checksum.
+	if _, ok := stack.Build.Get(computePayloadHashMiddlewareID); ok {
+		return stack.Build.Insert(inputChecksum, computePayloadHashMiddlewareID, middleware.Before)
+	}
+	return stack.Build.Add(inputChecksum, middleware.After)
+}
+
+// OutputMiddlewareOptions provides options for configuring output checksum
+// validation middleware.
+type OutputMiddlewareOptions struct {
+	// GetValidationMode is a function to get the checksum validation
+	// mode of the output payload from the input parameters.
+	//
+	// Given the input parameter value, the function must return the validation
+	// mode and true, or false if no mode is specified.
+	GetValidationMode func(interface{}) (string, bool)
+
+	// The set of checksum algorithms that should be used for response payload
+	// checksum validation. The algorithm(s) used will be a union of the
+	// output's returned algorithms and this set.
+	//
+	// Only the first algorithm in the union is currently used.
+	ValidationAlgorithms []string
+
+	// If set the middleware will ignore output multipart checksums. Otherwise
+	// a checksum format error will be returned by the middleware.
+	IgnoreMultipartValidation bool
+
+	// When set the middleware will log when output does not have checksum or
+	// algorithm to validate.
+	LogValidationSkipped bool
+
+	// When set the middleware will log when the output contains a multipart
+	// checksum that was skipped, and not validated.
+	LogMultipartValidationSkipped bool
+}
+
+// AddOutputMiddleware adds the middleware for validating response payload's
+// checksum.
+func AddOutputMiddleware(stack *middleware.Stack, options OutputMiddlewareOptions) error {
+	err := stack.Initialize.Add(&setupOutputContext{
+		GetValidationMode: options.GetValidationMode,
+	}, middleware.Before)
+	if err != nil {
+		return err
+	}
+
+	// Resolve a supported priority order list of algorithms to validate.
+	algorithms := FilterSupportedAlgorithms(options.ValidationAlgorithms)
+
+	m := &validateOutputPayloadChecksum{
+		Algorithms:                    algorithms,
+		IgnoreMultipartValidation:     options.IgnoreMultipartValidation,
+		LogMultipartValidationSkipped: options.LogMultipartValidationSkipped,
+		LogValidationSkipped:          options.LogValidationSkipped,
+	}
+
+	return stack.Deserialize.Add(m, middleware.After)
+}
@@ -0,0 +1,173 @@
+package checksum
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"hash"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+const (
+	// the ID of the SigV4 payload hash middleware
+	computePayloadHashMiddlewareID = "ComputePayloadHash"
+)
+
+// computeInputHeaderChecksumError is the error returned by the middleware
+// when the checksum of the request payload could not be computed.
+type computeInputHeaderChecksumError struct {
+	Msg string
+	Err error
+}
+
+// Error returns the error message for the error.
+func (e computeInputHeaderChecksumError) Error() string {
+	const intro = "compute input header checksum failed"
+
+	if e.Err != nil {
+		return fmt.Sprintf("%s, %s, %v", intro, e.Msg, e.Err)
+	}
+
+	return fmt.Sprintf("%s, %s", intro, e.Msg)
+}
+
+// Unwrap returns the underlying error if one is set.
+func (e computeInputHeaderChecksumError) Unwrap() error { return e.Err }
+
+// computeInputPayloadChecksum middleware computes the checksum of the request
+// payload with the algorithm selected by the operation's input.
+//
+// If the payload is seekable the checksum is computed from the payload, and
+// sent as the algorithm's checksum header. Otherwise, if trailing checksums
+// are enabled, the payload is signed with aws-chunked encoding and the
+// checksum is computed as the payload is sent, and sent as a trailing header.
+//
+// The checksum is not computed if the request already has a checksum header
+// for any algorithm.
+type computeInputPayloadChecksum struct {
+	// Enables support for computing the checksum of a payload that is not
+	// seekable, and sending it as a trailing header.
+	EnableTrailingChecksum bool
+
+	// Enables the SHA256 checksum of the payload to be used as the SigV4
+	// payload hash.
+	EnableComputeSHA256PayloadHash bool
+}
+
+// ID provides the middleware's identifier.
+func (m *computeInputPayloadChecksum) ID() string {
+	return "AWSChecksum:ComputeInputPayloadChecksum"
+}
+
+// HandleBuild handles computing the payload's checksum, in the following
+// cases:
+//   * Is seekable stream, compute checksum and set the checksum header.
+//   * Is not seekable stream, and trailing checksum is enabled, send the
+//     payload with a trailing checksum.
+//
+// An error is returned if the payload is not seekable, and trailing checksums
+// are not enabled.
+func (m *computeInputPayloadChecksum) HandleBuild(
+	ctx context.Context, in middleware.BuildInput, next middleware.BuildHandler,
+) (
+	out middleware.BuildOutput, metadata middleware.Metadata, err error,
+) {
+	v := getContextInputAlgorithm(ctx)
+	if len(v) == 0 {
+		return next.HandleBuild(ctx, in)
+	}
+
+	req, ok := in.Request.(*smithyhttp.Request)
+	if !ok {
+		return out, metadata, computeInputHeaderChecksumError{
+			Msg: fmt.Sprintf("unknown request type %T", in.Request),
+		}
+	}
+
+	algorithm, err := ParseAlgorithm(v)
+	if err != nil {
+		return out, metadata, computeInputHeaderChecksumError{
+			Msg: "failed to parse algorithm",
+			Err: err,
+		}
+	}
+
+	// If the checksum header is already set, nothing to do.
+	for _, a := range supportedAlgorithms {
+		if len(req.Header.Get(AlgorithmHTTPHeader(a))) != 0 {
+			return next.HandleBuild(ctx, in)
+		}
+	}
+
+	stream := req.GetStream()
+	if stream != nil && !req.IsStreamSeekable() {
+		ctx, err = m.useTrailingChecksum(ctx, req, algorithm)
+		if err != nil {
+			return out, metadata, err
+		}
+		return next.HandleBuild(ctx, in)
+	}
+
+	checksum, err := computeChecksum(algorithm, stream)
+	if err != nil {
+		return out, metadata, computeInputHeaderChecksumError{
+			Msg: "failed to compute checksum",
+			Err: err,
+		}
+	}
+	if stream != nil {
+		if err := req.RewindStream(); err != nil {
+			return out, metadata, computeInputHeaderChecksumError{
+				Msg: "failed to rewind stream",
+				Err: err,
+			}
+		}
+	}
+
+	req.Header.Set(AlgorithmHTTPHeader(algorithm), encodeChecksum(checksum))
+
+	if algorithm == AlgorithmSHA256 && m.EnableComputeSHA256PayloadHash {
+		if len(v4.GetPayloadHash(ctx)) == 0 {
+			ctx = v4.SetPayloadHash(ctx, hex.EncodeToString(checksum))
+		}
+	}
+
+	return next.HandleBuild(ctx, in)
+}
+
+// useTrailingChecksum updates the request to send the payload with
+// aws-chunked encoding, and the checksum of the payload as a trailing header.
+func (m *computeInputPayloadChecksum) useTrailingChecksum(
+	ctx context.Context, req *smithyhttp.Request, algorithm Algorithm,
+) (context.Context, error) {
+	if !m.EnableTrailingChecksum {
+		return ctx, computeInputHeaderChecksumError{
+			Msg: "unseekable stream is not supported without trailing checksum",
+		}
+	}
+
+	if len(v4.GetPayloadHash(ctx)) != 0 {
+		return ctx, computeInputHeaderChecksumError{
+			Msg: "unseekable stream is not supported with a precomputed payload hash",
+		}
+	}
+
+	ctx, err := v4.UseStreamingPayload(ctx, req, func(o *v4.StreamingPayloadOptions) {
+		o.TrailingChecksumHeader = AlgorithmHTTPHeader(algorithm)
+		o.TrailingChecksum = func() hash.Hash {
+			h, _ := NewAlgorithmHash(algorithm)
+			return h
+		}
+	})
+	if err != nil {
+		return ctx, computeInputHeaderChecksumError{
+			Msg: "failed to send checksum as trailing header",
+			Err: err,
+		}
+	}
+
+	return ctx, nil
+}
@@ -0,0 +1,177 @@
+package checksum
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+func TestComputeInputPayloadChecksum(t *testing.T) {
+	cases := map[string]struct {
+		algorithm         string
+		header            map[string]string
+		body              io.Reader
+		contentLength     int64
+		enableTrailer     bool
+		enableSHA256Hash  bool
+		expectHeader      map[string]string
+		expectPayloadHash string
+		expectPayload     []byte
+		expectErr         string
+	}{
+		"no algorithm": {
+			body:          strings.NewReader("hello world"),
+			expectHeader:  map[string]string{},
+			expectPayload: []byte("hello world"),
+		},
+		"seekable crc32": {
+			algorithm: "crc32",
+			body:      strings.NewReader("hello world"),
+			expectHeader: map[string]string{
+				"X-Amz-Checksum-Crc32": "DUoRhQ==",
+			},
+			expectPayload: []byte("hello world"),
+		},
+		"seekable crc32c": {
+			algorithm: "CRC32C",
+			body:      strings.NewReader("hello world"),
+			expectHeader: map[string]string{
+				"X-Amz-Checksum-Crc32c": "yZRlqg==",
+			},
+			expectPayload: []byte("hello world"),
+		},
+		"seekable sha256 payload hash": {
+			algorithm:        "SHA256",
+			body:             strings.NewReader("hello world"),
+			enableSHA256Hash: true,
+			expectHeader: map[string]string{
+				"X-Amz-Checksum-Sha256": "uU0nuZNNPgilLlLX2n2r+sSE7+N6U4DukIj3rOLvzek=",
+			},
+			expectPayloadHash: "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9",
+			expectPayload:     []byte("hello world"),
+		},
+		"no body": {
+			algorithm: "sha1",
+			expectHeader: map[string]string{
+				"X-Amz-Checksum-Sha1": "2jmj7l5rSw0yVb/vlWAYkK/YBwk=",
+			},
+		},
+		"checksum header provided": {
+			algorithm: "crc32",
+			header: map[string]string{
+				"X-Amz-Checksum-Sha256": "provided",
+			},
+			body: strings.NewReader("hello world"),
+			expectHeader: map[string]string{
+				"X-Amz-Checksum-Crc32":  "",
+				"X-Amz-Checksum-Sha256": "provided",
+			},
+			expectPayload: []byte("hello world"),
+		},
+		"unseekable trailing checksum": {
+			algorithm:     "crc32",
+			body:          ioutil.NopCloser(strings.NewReader("hello world")),
+			contentLength: 11,
+			enableTrailer: true,
+			expectHeader: map[string]string{
+				"X-Amz-Checksum-Crc32":         "",
+				"X-Amz-Trailer":                "x-amz-checksum-crc32",
+				"Content-Encoding":             "aws-chunked",
+				"X-Amz-Decoded-Content-Length": "11",
+			},
+			expectPayloadHash: v4.StreamingPayloadTrailer,
+			expectPayload:     []byte("hello world"),
+		},
+		"unseekable trailing checksum disabled": {
+			algorithm:     "crc32",
+			body:          ioutil.NopCloser(strings.NewReader("hello world")),
+			contentLength: 11,
+			expectErr:     "unseekable stream is not supported without trailing checksum",
+		},
+		"unseekable unknown length": {
+			algorithm:     "crc32",
+			body:          ioutil.NopCloser(strings.NewReader("hello world")),
+			contentLength: -1,
+			enableTrailer: true,
+			expectErr:     "requires the length of the payload to be known",
+		},
+		"unknown algorithm": {
+			algorithm: "md5",
+			body:      strings.NewReader("hello world"),
+			expectErr: "failed to parse algorithm",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := smithyhttp.NewStackRequest().(*smithyhttp.Request)
+			for k, v := range c.header {
+				req.Header.Set(k, v)
+			}
+			req.ContentLength = c.contentLength
+			if c.body != nil {
+				var err error
+				req, err = req.SetStream(c.body)
+				if err != nil {
+					t.Fatalf("expect no error, got %v", err)
+				}
+			}
+
+			ctx := context.Background()
+			if len(c.algorithm) != 0 {
+				ctx = setContextInputAlgorithm(ctx, c.algorithm)
+			}
+
+			m := &computeInputPayloadChecksum{
+				EnableTrailingChecksum:         c.enableTrailer,
+				EnableComputeSHA256PayloadHash: c.enableSHA256Hash,
+			}
+
+			var payloadHash string
+			var payload []byte
+			_, _, err := m.HandleBuild(ctx, middleware.BuildInput{Request: req},
+				middleware.BuildHandlerFunc(func(ctx context.Context, in middleware.BuildInput) (
+					out middleware.BuildOutput, metadata middleware.Metadata, err error,
+				) {
+					req := in.Request.(*smithyhttp.Request)
+					payloadHash = v4.GetPayloadHash(ctx)
+					if stream := req.GetStream(); stream != nil {
+						payload, err = ioutil.ReadAll(stream)
+					}
+					return out, metadata, err
+				}),
+			)
+			if len(c.expectErr) != 0 {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				if e, a := c.expectErr, err.Error(); !strings.Contains(a, e) {
+					t.Fatalf("expect error to contain %v, got %v", e, a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			for k, v := range c.expectHeader {
+				if e, a := v, req.Header.Get(k); e != a {
+					t.Errorf("expect %v header %q, got %q", k, e, a)
+				}
+			}
+			if e, a := c.expectPayloadHash, payloadHash; e != a {
+				t.Errorf("expect %v payload hash, got %v", e, a)
+			}
+			if e, a := c.expectPayload, payload; !bytes.Equal(e, a) {
+				t.Errorf("expect %q payload, got %q", e, a)
+			}
+		})
+	}
+}
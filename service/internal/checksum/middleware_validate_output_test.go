@@ -0,0 +1,199 @@
+package checksum
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+func TestValidateOutputPayloadChecksum(t *testing.T) {
+	cases := map[string]struct {
+		modifyContext    func(context.Context) context.Context
+		response         *smithyhttp.Response
+		expectAlgorithms []string
+		expectPayload    []byte
+		expectReadErr    string
+	}{
+		"success": {
+			modifyContext: func(ctx context.Context) context.Context {
+				return setContextOutputValidationMode(ctx, "ENABLED")
+			},
+			response: &smithyhttp.Response{
+				Response: &http.Response{
+					StatusCode: 200,
+					Header: func() http.Header {
+						h := http.Header{}
+						h.Set(AlgorithmHTTPHeader(AlgorithmCRC32), "DUoRhQ==")
+						return h
+					}(),
+					Body: ioutil.NopCloser(strings.NewReader("hello world")),
+				},
+			},
+			expectAlgorithms: []string{"CRC32"},
+			expectPayload:    []byte("hello world"),
+		},
+		"priority algorithm": {
+			modifyContext: func(ctx context.Context) context.Context {
+				return setContextOutputValidationMode(ctx, "ENABLED")
+			},
+			response: &smithyhttp.Response{
+				Response: &http.Response{
+					StatusCode: 200,
+					Header: func() http.Header {
+						h := http.Header{}
+						h.Set(AlgorithmHTTPHeader(AlgorithmSHA256), "uU0nuZNNPgilLlLX2n2r+sSE7+N6U4DukIj3rOLvzek=")
+						h.Set(AlgorithmHTTPHeader(AlgorithmCRC32C), "yZRlqg==")
+						return h
+					}(),
+					Body: ioutil.NopCloser(strings.NewReader("hello world")),
+				},
+			},
+			expectAlgorithms: []string{"CRC32C"},
+			expectPayload:    []byte("hello world"),
+		},
+		"failure": {
+			modifyContext: func(ctx context.Context) context.Context {
+				return setContextOutputValidationMode(ctx, "ENABLED")
+			},
+			response: &smithyhttp.Response{
+				Response: &http.Response{
+					StatusCode: 200,
+					Header: func() http.Header {
+						h := http.Header{}
+						h.Set(AlgorithmHTTPHeader(AlgorithmCRC32), "AAAAAA==")
+						return h
+					}(),
+					Body: ioutil.NopCloser(strings.NewReader("hello world")),
+				},
+			},
+			expectAlgorithms: []string{"CRC32"},
+			expectReadErr:    "checksum did not match",
+		},
+		"validation not enabled": {
+			response: &smithyhttp.Response{
+				Response: &http.Response{
+					StatusCode: 200,
+					Header: func() http.Header {
+						h := http.Header{}
+						h.Set(AlgorithmHTTPHeader(AlgorithmCRC32), "AAAAAA==")
+						return h
+					}(),
+					Body: ioutil.NopCloser(strings.NewReader("hello world")),
+				},
+			},
+			expectPayload: []byte("hello world"),
+		},
+		"no checksum header": {
+			modifyContext: func(ctx context.Context) context.Context {
+				return setContextOutputValidationMode(ctx, "ENABLED")
+			},
+			response: &smithyhttp.Response{
+				Response: &http.Response{
+					StatusCode: 200,
+					Header:     http.Header{},
+					Body:       ioutil.NopCloser(strings.NewReader("hello world")),
+				},
+			},
+			expectPayload: []byte("hello world"),
+		},
+		"multipart checksum": {
+			modifyContext: func(ctx context.Context) context.Context {
+				return setContextOutputValidationMode(ctx, "ENABLED")
+			},
+			response: &smithyhttp.Response{
+				Response: &http.Response{
+					StatusCode: 200,
+					Header: func() http.Header {
+						h := http.Header{}
+						h.Set(AlgorithmHTTPHeader(AlgorithmCRC32), "AAAAAA==-3")
+						return h
+					}(),
+					Body: ioutil.NopCloser(strings.NewReader("hello world")),
+				},
+			},
+			expectPayload: []byte("hello world"),
+		},
+		"error response": {
+			modifyContext: func(ctx context.Context) context.Context {
+				return setContextOutputValidationMode(ctx, "ENABLED")
+			},
+			response: &smithyhttp.Response{
+				Response: &http.Response{
+					StatusCode: 404,
+					Header: func() http.Header {
+						h := http.Header{}
+						h.Set(AlgorithmHTTPHeader(AlgorithmCRC32), "AAAAAA==")
+						return h
+					}(),
+					Body: ioutil.NopCloser(strings.NewReader("hello world")),
+				},
+			},
+			expectPayload: []byte("hello world"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &validateOutputPayloadChecksum{
+				Algorithms: []Algorithm{
+					AlgorithmCRC32C, AlgorithmCRC32, AlgorithmSHA1, AlgorithmSHA256,
+				},
+				IgnoreMultipartValidation: true,
+			}
+
+			ctx := context.Background()
+			if c.modifyContext != nil {
+				ctx = c.modifyContext(ctx)
+			}
+
+			out, metadata, err := m.HandleDeserialize(ctx, middleware.DeserializeInput{},
+				middleware.DeserializeHandlerFunc(func(ctx context.Context, in middleware.DeserializeInput) (
+					out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+				) {
+					out.RawResponse = c.response
+					return out, metadata, err
+				}),
+			)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			algorithms, _ := GetOutputValidationAlgorithmsUsed(metadata)
+			if e, a := len(c.expectAlgorithms), len(algorithms); e != a {
+				t.Fatalf("expect %v algorithms used, got %v", c.expectAlgorithms, algorithms)
+			}
+			for i := range c.expectAlgorithms {
+				if e, a := c.expectAlgorithms[i], algorithms[i]; e != a {
+					t.Errorf("expect %v algorithm used, got %v", e, a)
+				}
+			}
+
+			response := out.RawResponse.(*smithyhttp.Response)
+			payload, err := ioutil.ReadAll(response.Body)
+			if len(c.expectReadErr) != 0 {
+				if err == nil {
+					t.Fatalf("expect read error, got none")
+				}
+				if e, a := c.expectReadErr, err.Error(); !strings.Contains(a, e) {
+					t.Fatalf("expect read error to contain %v, got %v", e, a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no read error, got %v", err)
+			}
+			if e, a := c.expectPayload, payload; !bytes.Equal(e, a) {
+				t.Errorf("expect %q payload, got %q", e, a)
+			}
+			if err := response.Body.Close(); err != nil {
+				t.Errorf("expect no close error, got %v", err)
+			}
+		})
+	}
+}
@@ -266,6 +266,10 @@ replace github.com/aws/aws-sdk-go-v2/service/internal/s3shared => ../../../servi
 
 replace github.com/aws/aws-sdk-go-v2/service/internal/accept-encoding => ../../../service/internal/accept-encoding/
 
+replace github.com/aws/aws-sdk-go-v2/service/internal/checksum => ../../../service/internal/checksum/
+
 replace github.com/aws/aws-sdk-go-v2/service/internal/presigned-url => ../../../service/internal/presigned-url/
 
 replace github.com/aws/aws-sdk-go-v2/service/sso => ../../../service/sso/
+
+replace github.com/aws/aws-sdk-go-v2/service/ssooidc => ../../../service/ssooidc/
@@ -0,0 +1,590 @@
+package customizations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws/protocol/eventstream"
+	"github.com/aws/aws-sdk-go-v2/aws/protocol/eventstream/eventstreamapi"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis/types"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/aws/smithy-go/ptr"
+	smithytime "github.com/aws/smithy-go/time"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// SubscribeToShardEventStreamReader provides the interface for reading events
+// from a stream.
+//
+// The reader's Close method must allow multiple concurrent calls.
+type SubscribeToShardEventStreamReader interface {
+	Events() <-chan types.SubscribeToShardEventStream
+	Close() error
+	Err() error
+}
+
+// SubscribeToShardEventStream provides the event stream handling for the
+// SubscribeToShard operation. The client's HTTPClient must support HTTP/2, such
+// as the default awshttp.BuildableClient, to read the event stream.
+//
+// For testing and mocking the event stream this type should be initialized via
+// the NewSubscribeToShardEventStream constructor function. Using the functional
+// options to pass in nested mock behavior.
+type SubscribeToShardEventStream struct {
+	// SubscribeToShardEventStreamReader is the EventStream reader for the
+	// SubscribeToShardEventStream events. This value is automatically set by the
+	// SDK when the API call is made Use this member when unit testing your code with
+	// the SDK to mock out the EventStream Reader.
+	//
+	// Must not be nil.
+	Reader SubscribeToShardEventStreamReader
+}
+
+// NewSubscribeToShardEventStream initializes an SubscribeToShardEventStream.
+// This function should only be used for testing and mocking the
+// SubscribeToShardEventStream stream within your application.
+//
+// The Reader member must be set before reading events from the stream.
+func NewSubscribeToShardEventStream(optFns ...func(*SubscribeToShardEventStream)) *SubscribeToShardEventStream {
+	es := &SubscribeToShardEventStream{}
+	for _, fn := range optFns {
+		fn(es)
+	}
+	return es
+}
+
+// Events returns a channel to read events from. The channel is closed when the
+// stream ends, fails, is closed, or the operation's context is cancelled.
+func (es *SubscribeToShardEventStream) Events() <-chan types.SubscribeToShardEventStream {
+	return es.Reader.Events()
+}
+
+// Close closes the stream. Close must be called when done using the stream API.
+// Not calling Close may result in resource leaks.
+//
+// Will close the underlying EventStream reader, and no more events can be
+// received.
+func (es *SubscribeToShardEventStream) Close() error {
+	return es.Reader.Close()
+}
+
+// Err returns any error that occurred while reading EventStream Events from the
+// service API's response. Returns nil if there were no errors. Modeled
+// exceptions sent on the stream are returned as their types package error type,
+// and cancellation of the operation's context is returned as the context's
+// error.
+func (es *SubscribeToShardEventStream) Err() error {
+	return es.Reader.Err()
+}
+
+// subscribeToShardEventStreamKey is the metadata, and stack value, key of the
+// SubscribeToShard operation's event stream.
+type subscribeToShardEventStreamKey struct{}
+
+// GetSubscribeToShardEventStream returns the event stream of the
+// SubscribeToShard operation's response from the metadata, or nil if the
+// metadata has no event stream.
+func GetSubscribeToShardEventStream(metadata middleware.Metadata) *SubscribeToShardEventStream {
+	es, _ := metadata.Get(subscribeToShardEventStreamKey{}).(*SubscribeToShardEventStream)
+	return es
+}
+
+// AddSubscribeToShardEventStreamOptions provides the options for the
+// AddSubscribeToShardEventStream middleware setup.
+type AddSubscribeToShardEventStreamOptions struct {
+	// Logs the event messages read from the event stream.
+	LogEventStreamReads bool
+}
+
+// AddSubscribeToShardEventStream adds the middleware reading the initial
+// response, and events, of the SubscribeToShard operation's response from the
+// response body, and setting the event stream in the operation's result
+// metadata.
+//
+// The response body is the event stream, and is closed by the event stream
+// instead of once the operation's response is deserialized. The operation's
+// deserializer reads the output members from the initial response's payload.
+func AddSubscribeToShardEventStream(stack *middleware.Stack, options AddSubscribeToShardEventStreamOptions) error {
+	if _, ok := stack.Deserialize.Get(closeResponseBodyID); ok {
+		if _, err := stack.Deserialize.Remove(closeResponseBodyID); err != nil {
+			return err
+		}
+	}
+
+	if err := stack.Initialize.Add(&subscribeToShardEventStreamMetadata{}, middleware.Before); err != nil {
+		return err
+	}
+	return stack.Deserialize.Insert(&subscribeToShardEventStreamMiddleware{
+		logEventStreamReads: options.LogEventStreamReads,
+	}, "OperationDeserializer", middleware.After)
+}
+
+// closeResponseBodyID is the ID of the middleware closing the response body
+// after the response is deserialized.
+const closeResponseBodyID = "CloseResponseBody"
+
+// subscribeToShardEventStreamMetadata sets the event stream of the operation's
+// response in the operation's result metadata. The metadata of the deserialize
+// step is only retained for each attempt of the operation.
+type subscribeToShardEventStreamMetadata struct{}
+
+// ID returns the middleware identifier.
+func (*subscribeToShardEventStreamMetadata) ID() string {
+	return "OperationEventStreamMetadata"
+}
+
+func (m *subscribeToShardEventStreamMetadata) HandleInitialize(
+	ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler,
+) (
+	out middleware.InitializeOutput, metadata middleware.Metadata, err error,
+) {
+	var es *SubscribeToShardEventStream
+	ctx = middleware.WithStackValue(ctx, subscribeToShardEventStreamKey{}, &es)
+
+	out, metadata, err = next.HandleInitialize(ctx, in)
+	if es != nil {
+		if err != nil {
+			es.Close()
+		} else {
+			metadata.Set(subscribeToShardEventStreamKey{}, es)
+		}
+	}
+	return out, metadata, err
+}
+
+// subscribeToShardEventStreamMiddleware reads the initial response of the
+// SubscribeToShard operation's response, and creates the event stream reading
+// the response's events. The response body passed to the operation's
+// deserializer is replaced with the initial response's payload.
+type subscribeToShardEventStreamMiddleware struct {
+	logEventStreamReads bool
+}
+
+// ID returns the middleware identifier.
+func (*subscribeToShardEventStreamMiddleware) ID() string {
+	return "OperationEventStreamDeserializer"
+}
+
+func (m *subscribeToShardEventStreamMiddleware) HandleDeserialize(
+	ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler,
+) (
+	out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+) {
+	out, metadata, err = next.HandleDeserialize(ctx, in)
+	if err != nil {
+		return out, metadata, err
+	}
+
+	response, ok := out.RawResponse.(*smithyhttp.Response)
+	if !ok {
+		return out, metadata, fmt.Errorf("unknown transport type: %T", out.RawResponse)
+	}
+
+	// Error responses are not event streams, and are deserialized by the
+	// operation's deserializer.
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return out, metadata, err
+	}
+
+	es, ok := middleware.GetStackValue(ctx, subscribeToShardEventStreamKey{}).(**SubscribeToShardEventStream)
+	if !ok {
+		return out, metadata, fmt.Errorf("event stream metadata middleware not found")
+	}
+
+	logger := middleware.GetLogger(ctx)
+	decoder := eventstream.NewDecoder(func(options *eventstream.DecoderOptions) {
+		options.Logger = logger
+		options.LogMessages = m.logEventStreamReads
+	})
+
+	initialResponse, initialEvent, err := deserializeSubscribeToShardInitialResponse(decoder, response.Body)
+	if err != nil {
+		return out, metadata, err
+	}
+
+	eventStream := response.Body
+	response.Body = initialResponseBody{
+		Reader: bytes.NewReader(initialResponse),
+		Closer: eventStream,
+	}
+
+	*es = NewSubscribeToShardEventStream(func(stream *SubscribeToShardEventStream) {
+		stream.Reader = newSubscribeToShardEventStreamReader(ctx, eventStream, decoder, initialEvent)
+	})
+
+	return out, metadata, nil
+}
+
+// initialResponseBody is the response body read by the operation's
+// deserializer. Closing the body closes the event stream.
+type initialResponseBody struct {
+	io.Reader
+	io.Closer
+}
+
+// deserializeSubscribeToShardInitialResponse reads the first message of the
+// stream. The initial-response event carries the operation's response members
+// and its payload is returned. If the service skipped the initial response the
+// first event is returned to be delivered to the stream's reader. Exceptions
+// sent in place of the initial response are returned as the operation's error.
+func deserializeSubscribeToShardInitialResponse(decoder *eventstream.Decoder, body io.Reader) (
+	[]byte, types.SubscribeToShardEventStream, error,
+) {
+	msg, err := decoder.Decode(body, nil)
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, nil, &smithy.DeserializationError{
+			Err: fmt.Errorf("failed to read initial response event, %w", err),
+		}
+	}
+
+	messageType := msg.Headers.Get(eventstreamapi.MessageTypeHeader)
+	eventType := msg.Headers.Get(eventstreamapi.EventTypeHeader)
+	if messageType != nil && messageType.String() == eventstreamapi.EventMessageType &&
+		eventType != nil && eventType.String() == eventstreamapi.InitialResponseEventType {
+		return msg.Payload, nil, nil
+	}
+
+	event, err := deserializeSubscribeToShardMessage(&msg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, event, nil
+}
+
+// subscribeToShardEventStreamReader reads the events of the event stream from
+// the response body, until the operation's context is cancelled.
+type subscribeToShardEventStreamReader struct {
+	ctx          context.Context
+	stream       chan types.SubscribeToShardEventStream
+	decoder      *eventstream.Decoder
+	eventStream  io.ReadCloser
+	initialEvent types.SubscribeToShardEventStream
+	err          *onceErr
+	payloadBuf   []byte
+	done         chan struct{}
+	closeOnce    sync.Once
+}
+
+func newSubscribeToShardEventStreamReader(
+	ctx context.Context, readCloser io.ReadCloser, decoder *eventstream.Decoder,
+	initialEvent types.SubscribeToShardEventStream,
+) *subscribeToShardEventStreamReader {
+	r := &subscribeToShardEventStreamReader{
+		ctx:          ctx,
+		stream:       make(chan types.SubscribeToShardEventStream),
+		decoder:      decoder,
+		eventStream:  readCloser,
+		initialEvent: initialEvent,
+		err:          &onceErr{},
+		done:         make(chan struct{}),
+		payloadBuf:   make([]byte, 10*1024),
+	}
+
+	go r.readEventStream()
+	go r.watchContext()
+
+	return r
+}
+
+func (r *subscribeToShardEventStreamReader) Events() <-chan types.SubscribeToShardEventStream {
+	return r.stream
+}
+
+// watchContext closes the stream when the operation's context is cancelled,
+// unblocking any pending read of the response body.
+func (r *subscribeToShardEventStreamReader) watchContext() {
+	select {
+	case <-r.ctx.Done():
+		r.err.SetError(r.ctx.Err())
+		r.Close()
+	case <-r.done:
+	}
+}
+
+func (r *subscribeToShardEventStreamReader) readEventStream() {
+	defer r.Close()
+	defer close(r.stream)
+
+	if r.initialEvent != nil {
+		if !r.sendEvent(r.initialEvent) {
+			return
+		}
+		r.initialEvent = nil
+	}
+
+	for {
+		r.payloadBuf = r.payloadBuf[0:0]
+		msg, err := r.decoder.Decode(r.eventStream, r.payloadBuf)
+		if err != nil {
+			if err == io.EOF {
+				return
+			}
+			select {
+			case <-r.done:
+				return
+			default:
+			}
+			if ctxErr := r.ctx.Err(); ctxErr != nil {
+				err = ctxErr
+			}
+			r.err.SetError(err)
+			return
+		}
+
+		event, err := deserializeSubscribeToShardMessage(&msg)
+		if err != nil {
+			r.err.SetError(err)
+			return
+		}
+
+		if !r.sendEvent(event) {
+			return
+		}
+	}
+}
+
+func (r *subscribeToShardEventStreamReader) sendEvent(event types.SubscribeToShardEventStream) bool {
+	select {
+	case r.stream <- event:
+		return true
+	case <-r.done:
+		return false
+	}
+}
+
+func (r *subscribeToShardEventStreamReader) Close() error {
+	r.closeOnce.Do(r.safeClose)
+	return r.Err()
+}
+
+func (r *subscribeToShardEventStreamReader) safeClose() {
+	close(r.done)
+	r.eventStream.Close()
+}
+
+func (r *subscribeToShardEventStreamReader) Err() error {
+	return r.err.Err()
+}
+
+// deserializeSubscribeToShardMessage returns the event of the event stream
+// message, or the error of an exception or error message.
+func deserializeSubscribeToShardMessage(msg *eventstream.Message) (types.SubscribeToShardEventStream, error) {
+	messageType := msg.Headers.Get(eventstreamapi.MessageTypeHeader)
+	if messageType == nil {
+		return nil, fmt.Errorf("%s event header not present", eventstreamapi.MessageTypeHeader)
+	}
+
+	switch messageType.String() {
+	case eventstreamapi.EventMessageType:
+		return deserializeSubscribeToShardEvent(msg)
+
+	case eventstreamapi.ExceptionMessageType:
+		return nil, deserializeSubscribeToShardException(msg)
+
+	case eventstreamapi.ErrorMessageType:
+		errorCode := "UnknownError"
+		errorMessage := errorCode
+		if header := msg.Headers.Get(eventstreamapi.ErrorCodeHeader); header != nil {
+			errorCode = header.String()
+		}
+		if header := msg.Headers.Get(eventstreamapi.ErrorMessageHeader); header != nil {
+			errorMessage = header.String()
+		}
+		return nil, &smithy.GenericAPIError{
+			Code:    errorCode,
+			Message: errorMessage,
+		}
+
+	default:
+		return nil, &eventstreamapi.UnknownMessageTypeError{
+			Type:    messageType.String(),
+			Message: msg.Clone(),
+		}
+	}
+}
+
+// recordPayload is the JSON representation of a types.Record. The arrival
+// timestamp is sent as epoch seconds.
+type recordPayload struct {
+	ApproximateArrivalTimestamp *json.Number
+	Data                        []byte
+	EncryptionType              types.EncryptionType
+	PartitionKey                *string
+	SequenceNumber              *string
+}
+
+// subscribeToShardEventPayload is the JSON representation of a
+// types.SubscribeToShardEvent.
+type subscribeToShardEventPayload struct {
+	ChildShards                []types.ChildShard
+	ContinuationSequenceNumber *string
+	MillisBehindLatest         *int64
+	Records                    []recordPayload
+}
+
+// deserializeSubscribeToShardEvent returns the event of the event message.
+// Events of an unknown type are returned as an UnknownUnionMember with the
+// encoded message.
+func deserializeSubscribeToShardEvent(msg *eventstream.Message) (types.SubscribeToShardEventStream, error) {
+	eventType := msg.Headers.Get(eventstreamapi.EventTypeHeader)
+	if eventType == nil {
+		return nil, fmt.Errorf("%s event header not present", eventstreamapi.EventTypeHeader)
+	}
+
+	switch {
+	case strings.EqualFold("SubscribeToShardEvent", eventType.String()):
+		var payload subscribeToShardEventPayload
+		if err := unmarshalEventPayload(msg, &payload); err != nil {
+			return nil, err
+		}
+
+		v := &types.SubscribeToShardEventStreamMemberSubscribeToShardEvent{
+			Value: types.SubscribeToShardEvent{
+				ChildShards:                payload.ChildShards,
+				ContinuationSequenceNumber: payload.ContinuationSequenceNumber,
+				MillisBehindLatest:         payload.MillisBehindLatest,
+			},
+		}
+		for _, record := range payload.Records {
+			r := types.Record{
+				Data:           record.Data,
+				EncryptionType: record.EncryptionType,
+				PartitionKey:   record.PartitionKey,
+				SequenceNumber: record.SequenceNumber,
+			}
+			if record.ApproximateArrivalTimestamp != nil {
+				f64, err := record.ApproximateArrivalTimestamp.Float64()
+				if err != nil {
+					return nil, &smithy.DeserializationError{
+						Err:      fmt.Errorf("failed to decode ApproximateArrivalTimestamp, %w", err),
+						Snapshot: msg.Payload,
+					}
+				}
+				r.ApproximateArrivalTimestamp = ptr.Time(smithytime.ParseEpochSeconds(f64))
+			}
+			v.Value.Records = append(v.Value.Records, r)
+		}
+		return v, nil
+
+	default:
+		var buf bytes.Buffer
+		if err := eventstream.NewEncoder().Encode(&buf, *msg); err != nil {
+			return nil, err
+		}
+		return &types.UnknownUnionMember{
+			Tag:   eventType.String(),
+			Value: buf.Bytes(),
+		}, nil
+	}
+}
+
+// exceptionPayload is the JSON representation of the exceptions sent on the
+// event stream.
+type exceptionPayload struct {
+	Code    string `json:"__type"`
+	Message *string
+}
+
+// deserializeSubscribeToShardException returns the error of the exception
+// message. Modeled exceptions are returned as their types package error type.
+func deserializeSubscribeToShardException(msg *eventstream.Message) error {
+	exceptionType := msg.Headers.Get(eventstreamapi.ExceptionTypeHeader)
+	if exceptionType == nil {
+		return fmt.Errorf("%s event header not present", eventstreamapi.ExceptionTypeHeader)
+	}
+
+	var payload exceptionPayload
+	if err := unmarshalEventPayload(msg, &payload); err != nil {
+		return err
+	}
+
+	switch {
+	case strings.EqualFold("InternalFailureException", exceptionType.String()):
+		return &types.InternalFailureException{Message: payload.Message}
+
+	case strings.EqualFold("KMSAccessDeniedException", exceptionType.String()):
+		return &types.KMSAccessDeniedException{Message: payload.Message}
+
+	case strings.EqualFold("KMSDisabledException", exceptionType.String()):
+		return &types.KMSDisabledException{Message: payload.Message}
+
+	case strings.EqualFold("KMSInvalidStateException", exceptionType.String()):
+		return &types.KMSInvalidStateException{Message: payload.Message}
+
+	case strings.EqualFold("KMSNotFoundException", exceptionType.String()):
+		return &types.KMSNotFoundException{Message: payload.Message}
+
+	case strings.EqualFold("KMSOptInRequired", exceptionType.String()):
+		return &types.KMSOptInRequired{Message: payload.Message}
+
+	case strings.EqualFold("KMSThrottlingException", exceptionType.String()):
+		return &types.KMSThrottlingException{Message: payload.Message}
+
+	case strings.EqualFold("ResourceInUseException", exceptionType.String()):
+		return &types.ResourceInUseException{Message: payload.Message}
+
+	case strings.EqualFold("ResourceNotFoundException", exceptionType.String()):
+		return &types.ResourceNotFoundException{Message: payload.Message}
+
+	default:
+		errorCode := "UnknownError"
+		errorMessage := errorCode
+		if v := exceptionType.String(); len(v) > 0 {
+			errorCode = v
+		} else if v := payload.Code; len(v) > 0 {
+			errorCode = v
+		}
+		if v := payload.Message; v != nil && len(*v) > 0 {
+			errorMessage = *v
+		}
+		return &smithy.GenericAPIError{
+			Code:    errorCode,
+			Message: errorMessage,
+		}
+	}
+}
+
+// unmarshalEventPayload unmarshals the JSON payload of the event message into
+// the value. The value is left unmodified if the message has no payload.
+func unmarshalEventPayload(msg *eventstream.Message, v interface{}) error {
+	if len(msg.Payload) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(msg.Payload, v); err != nil {
+		return &smithy.DeserializationError{
+			Err:      fmt.Errorf("failed to decode event payload, %w", err),
+			Snapshot: msg.Payload,
+		}
+	}
+	return nil
+}
+
+// onceErr records the first error that occurred reading the event stream.
+type onceErr struct {
+	mu  sync.RWMutex
+	err error
+}
+
+func (e *onceErr) Err() error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.err
+}
+
+func (e *onceErr) SetError(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.err != nil {
+		return
+	}
+	e.err = err
+}
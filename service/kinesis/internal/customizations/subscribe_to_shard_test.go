@@ -0,0 +1,319 @@
+package customizations_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/protocol/eventstream"
+	"github.com/aws/aws-sdk-go-v2/aws/protocol/eventstream/eventstreamapi"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/internal/awstesting/unit"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis/types"
+)
+
+func eventMessage(eventType string, payload string) eventstream.Message {
+	return eventstream.Message{
+		Headers: eventstream.Headers{
+			{Name: eventstreamapi.MessageTypeHeader, Value: eventstream.StringValue(eventstreamapi.EventMessageType)},
+			{Name: eventstreamapi.EventTypeHeader, Value: eventstream.StringValue(eventType)},
+		},
+		Payload: []byte(payload),
+	}
+}
+
+func exceptionMessage(exceptionType string, payload string) eventstream.Message {
+	return eventstream.Message{
+		Headers: eventstream.Headers{
+			{Name: eventstreamapi.MessageTypeHeader, Value: eventstream.StringValue(eventstreamapi.ExceptionMessageType)},
+			{Name: eventstreamapi.ExceptionTypeHeader, Value: eventstream.StringValue(exceptionType)},
+		},
+		Payload: []byte(payload),
+	}
+}
+
+func writeMessages(t *testing.T, w http.ResponseWriter, msgs ...eventstream.Message) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	encoder := eventstream.NewEncoder()
+	for _, msg := range msgs {
+		if err := encoder.Encode(&buf, msg); err != nil {
+			t.Errorf("expect no encode error, got %v", err)
+			return
+		}
+	}
+	w.Write(buf.Bytes())
+	w.(http.Flusher).Flush()
+}
+
+// newSubscribeTestClient returns a client that communicates with a TLS HTTP/2
+// test server via the SDK's default BuildableClient.
+func newSubscribeTestClient(t *testing.T, handler http.HandlerFunc) (*kinesis.Client, func()) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if e, a := 2, r.ProtoMajor; e != a {
+			t.Errorf("expect HTTP/%v request, got HTTP/%v", e, a)
+		}
+		if e, a := "Kinesis_20131202.SubscribeToShard", r.Header.Get("X-Amz-Target"); e != a {
+			t.Errorf("expect %v target, got %v", e, a)
+		}
+		handler(w, r)
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+
+	certPool := x509.NewCertPool()
+	certPool.AddCert(server.Certificate())
+
+	client := kinesis.New(kinesis.Options{
+		Credentials: unit.StubCredentialsProvider{},
+		Retryer:     aws.NopRetryer{},
+		Region:      "mock-region",
+		HTTPClient: awshttp.NewBuildableClient().WithTransportOptions(func(tr *http.Transport) {
+			tr.TLSClientConfig = &tls.Config{RootCAs: certPool}
+		}),
+		EndpointResolver: kinesis.EndpointResolverFunc(func(region string, options kinesis.EndpointResolverOptions) (e aws.Endpoint, err error) {
+			e.URL = server.URL
+			e.SigningRegion = "us-west-2"
+			return e, err
+		}),
+	})
+
+	return client, server.Close
+}
+
+func subscribeInput() *kinesis.SubscribeToShardInput {
+	return &kinesis.SubscribeToShardInput{
+		ConsumerARN: aws.String("arn:aws:kinesis:us-west-2:123456789012:stream/stream/consumer/consumer:1"),
+		ShardId:     aws.String("shardId-000000000000"),
+		StartingPosition: &types.StartingPosition{
+			Type:           types.ShardIteratorTypeAfterSequenceNumber,
+			SequenceNumber: aws.String("123"),
+		},
+	}
+}
+
+const recordsEvent = `{"ContinuationSequenceNumber":"124","MillisBehindLatest":0,"Records":[{"Data":"aGVsbG8=","PartitionKey":"pk","SequenceNumber":"124"}]}`
+
+func TestSubscribeToShard_Events(t *testing.T) {
+	client, cleanup := newSubscribeTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		for _, s := range []string{
+			`"ConsumerARN":"arn:aws:kinesis:us-west-2:123456789012:stream/stream/consumer/consumer:1"`,
+			`"ShardId":"shardId-000000000000"`,
+			`"StartingPosition":{"SequenceNumber":"123","Type":"AFTER_SEQUENCE_NUMBER"}`,
+		} {
+			if !strings.Contains(string(body), s) {
+				t.Errorf("expect request body to contain %v, got %v", s, string(body))
+			}
+		}
+
+		w.WriteHeader(200)
+		writeMessages(t, w,
+			eventMessage(eventstreamapi.InitialResponseEventType, `{}`),
+			eventMessage("SubscribeToShardEvent", recordsEvent),
+			eventMessage("SubscribeToShardEvent", `{"ContinuationSequenceNumber":"125","MillisBehindLatest":10,"Records":[],"ChildShards":[{"ShardId":"shardId-000000000001","ParentShards":["shardId-000000000000"],"HashKeyRange":{"StartingHashKey":"0","EndingHashKey":"1"}}]}`),
+		)
+	})
+	defer cleanup()
+
+	resp, err := client.SubscribeToShard(context.Background(), subscribeInput())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	es := resp.GetStream()
+	defer es.Close()
+
+	var events []types.SubscribeToShardEvent
+	for event := range es.Events() {
+		v, ok := event.(*types.SubscribeToShardEventStreamMemberSubscribeToShardEvent)
+		if !ok {
+			t.Fatalf("expect SubscribeToShardEvent, got %T", event)
+		}
+		events = append(events, v.Value)
+	}
+	if err := es.Err(); err != nil {
+		t.Fatalf("expect no stream error, got %v", err)
+	}
+
+	if e, a := 2, len(events); e != a {
+		t.Fatalf("expect %v events, got %v", e, a)
+	}
+	if e, a := "124", aws.ToString(events[0].ContinuationSequenceNumber); e != a {
+		t.Errorf("expect %v continuation sequence number, got %v", e, a)
+	}
+	if e, a := 1, len(events[0].Records); e != a {
+		t.Fatalf("expect %v records, got %v", e, a)
+	}
+	if e, a := "hello", string(events[0].Records[0].Data); e != a {
+		t.Errorf("expect %v record data, got %v", e, a)
+	}
+	if e, a := int64(10), aws.ToInt64(events[1].MillisBehindLatest); e != a {
+		t.Errorf("expect %v millis behind latest, got %v", e, a)
+	}
+	if e, a := 1, len(events[1].ChildShards); e != a {
+		t.Fatalf("expect %v child shards, got %v", e, a)
+	}
+	if e, a := "shardId-000000000001", aws.ToString(events[1].ChildShards[0].ShardId); e != a {
+		t.Errorf("expect %v child shard, got %v", e, a)
+	}
+}
+
+func TestSubscribeToShard_NoInitialResponse(t *testing.T) {
+	client, cleanup := newSubscribeTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		writeMessages(t, w, eventMessage("SubscribeToShardEvent", recordsEvent))
+	})
+	defer cleanup()
+
+	resp, err := client.SubscribeToShard(context.Background(), subscribeInput())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	es := resp.GetStream()
+	defer es.Close()
+
+	var count int
+	for range es.Events() {
+		count++
+	}
+	if err := es.Err(); err != nil {
+		t.Fatalf("expect no stream error, got %v", err)
+	}
+	if e, a := 1, count; e != a {
+		t.Errorf("expect %v events, got %v", e, a)
+	}
+}
+
+func TestSubscribeToShard_StreamException(t *testing.T) {
+	client, cleanup := newSubscribeTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		writeMessages(t, w,
+			eventMessage(eventstreamapi.InitialResponseEventType, `{}`),
+			eventMessage("SubscribeToShardEvent", recordsEvent),
+			exceptionMessage("KMSThrottlingException", `{"message":"slow down"}`),
+		)
+	})
+	defer cleanup()
+
+	resp, err := client.SubscribeToShard(context.Background(), subscribeInput())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	es := resp.GetStream()
+	defer es.Close()
+
+	var count int
+	for range es.Events() {
+		count++
+	}
+	if e, a := 1, count; e != a {
+		t.Errorf("expect %v events, got %v", e, a)
+	}
+
+	var exception *types.KMSThrottlingException
+	if err := es.Err(); !errors.As(err, &exception) {
+		t.Fatalf("expect %T stream error, got %v", exception, err)
+	}
+	if e, a := "slow down", exception.ErrorMessage(); e != a {
+		t.Errorf("expect %v message, got %v", e, a)
+	}
+}
+
+func TestSubscribeToShard_InitialException(t *testing.T) {
+	client, cleanup := newSubscribeTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		writeMessages(t, w, exceptionMessage("ResourceNotFoundException", `{"message":"no such consumer"}`))
+	})
+	defer cleanup()
+
+	_, err := client.SubscribeToShard(context.Background(), subscribeInput())
+
+	var exception *types.ResourceNotFoundException
+	if !errors.As(err, &exception) {
+		t.Fatalf("expect %T error, got %v", exception, err)
+	}
+	if e, a := "no such consumer", exception.ErrorMessage(); e != a {
+		t.Errorf("expect %v message, got %v", e, a)
+	}
+}
+
+func TestSubscribeToShard_ResponseError(t *testing.T) {
+	client, cleanup := newSubscribeTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Amzn-ErrorType", "ResourceInUseException")
+		w.WriteHeader(400)
+		w.Write([]byte(`{"message":"consumer in use"}`))
+	})
+	defer cleanup()
+
+	_, err := client.SubscribeToShard(context.Background(), subscribeInput())
+
+	var exception *types.ResourceInUseException
+	if !errors.As(err, &exception) {
+		t.Fatalf("expect %T error, got %v", exception, err)
+	}
+}
+
+func TestSubscribeToShard_ContextCanceled(t *testing.T) {
+	client, cleanup := newSubscribeTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		writeMessages(t, w,
+			eventMessage(eventstreamapi.InitialResponseEventType, `{}`),
+			eventMessage("SubscribeToShardEvent", recordsEvent),
+		)
+		<-r.Context().Done()
+	})
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	resp, err := client.SubscribeToShard(ctx, subscribeInput())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	es := resp.GetStream()
+	defer es.Close()
+
+	select {
+	case _, ok := <-es.Events():
+		if !ok {
+			t.Fatalf("expect event, got closed stream, %v", es.Err())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expect event before timeout")
+	}
+
+	cancel()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-es.Events():
+			if ok {
+				continue
+			}
+		case <-timeout:
+			t.Fatalf("expect stream to be closed after context canceled")
+		}
+		break
+	}
+
+	if err := es.Err(); !errors.Is(err, context.Canceled) {
+		t.Errorf("expect %v stream error, got %v", context.Canceled, err)
+	}
+}
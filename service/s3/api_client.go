@@ -402,6 +402,197 @@ func NewPresignClient(c *Client, optFns ...func(*PresignOptions)) *PresignClient
 	}
 }
 
+// PresignOperation is used to generate a presigned HTTP Request for the operation
+// of the provided operation input parameters, such as *GetObjectInput. The
+// presigned request is built the same as the operation's Presign method. Returns
+// an error if the operation of the input cannot be presigned.
+func (c *PresignClient) PresignOperation(ctx context.Context, params interface{}, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	switch v := params.(type) {
+	case *AbortMultipartUploadInput:
+		return c.PresignAbortMultipartUpload(ctx, v, optFns...)
+	case *CompleteMultipartUploadInput:
+		return c.PresignCompleteMultipartUpload(ctx, v, optFns...)
+	case *CopyObjectInput:
+		return c.PresignCopyObject(ctx, v, optFns...)
+	case *CreateBucketInput:
+		return c.PresignCreateBucket(ctx, v, optFns...)
+	case *CreateMultipartUploadInput:
+		return c.PresignCreateMultipartUpload(ctx, v, optFns...)
+	case *DeleteBucketInput:
+		return c.PresignDeleteBucket(ctx, v, optFns...)
+	case *DeleteBucketAnalyticsConfigurationInput:
+		return c.PresignDeleteBucketAnalyticsConfiguration(ctx, v, optFns...)
+	case *DeleteBucketCorsInput:
+		return c.PresignDeleteBucketCors(ctx, v, optFns...)
+	case *DeleteBucketEncryptionInput:
+		return c.PresignDeleteBucketEncryption(ctx, v, optFns...)
+	case *DeleteBucketIntelligentTieringConfigurationInput:
+		return c.PresignDeleteBucketIntelligentTieringConfiguration(ctx, v, optFns...)
+	case *DeleteBucketInventoryConfigurationInput:
+		return c.PresignDeleteBucketInventoryConfiguration(ctx, v, optFns...)
+	case *DeleteBucketLifecycleInput:
+		return c.PresignDeleteBucketLifecycle(ctx, v, optFns...)
+	case *DeleteBucketMetricsConfigurationInput:
+		return c.PresignDeleteBucketMetricsConfiguration(ctx, v, optFns...)
+	case *DeleteBucketOwnershipControlsInput:
+		return c.PresignDeleteBucketOwnershipControls(ctx, v, optFns...)
+	case *DeleteBucketPolicyInput:
+		return c.PresignDeleteBucketPolicy(ctx, v, optFns...)
+	case *DeleteBucketReplicationInput:
+		return c.PresignDeleteBucketReplication(ctx, v, optFns...)
+	case *DeleteBucketTaggingInput:
+		return c.PresignDeleteBucketTagging(ctx, v, optFns...)
+	case *DeleteBucketWebsiteInput:
+		return c.PresignDeleteBucketWebsite(ctx, v, optFns...)
+	case *DeleteObjectInput:
+		return c.PresignDeleteObject(ctx, v, optFns...)
+	case *DeleteObjectTaggingInput:
+		return c.PresignDeleteObjectTagging(ctx, v, optFns...)
+	case *DeleteObjectsInput:
+		return c.PresignDeleteObjects(ctx, v, optFns...)
+	case *DeletePublicAccessBlockInput:
+		return c.PresignDeletePublicAccessBlock(ctx, v, optFns...)
+	case *GetBucketAccelerateConfigurationInput:
+		return c.PresignGetBucketAccelerateConfiguration(ctx, v, optFns...)
+	case *GetBucketAclInput:
+		return c.PresignGetBucketAcl(ctx, v, optFns...)
+	case *GetBucketAnalyticsConfigurationInput:
+		return c.PresignGetBucketAnalyticsConfiguration(ctx, v, optFns...)
+	case *GetBucketCorsInput:
+		return c.PresignGetBucketCors(ctx, v, optFns...)
+	case *GetBucketEncryptionInput:
+		return c.PresignGetBucketEncryption(ctx, v, optFns...)
+	case *GetBucketIntelligentTieringConfigurationInput:
+		return c.PresignGetBucketIntelligentTieringConfiguration(ctx, v, optFns...)
+	case *GetBucketInventoryConfigurationInput:
+		return c.PresignGetBucketInventoryConfiguration(ctx, v, optFns...)
+	case *GetBucketLifecycleConfigurationInput:
+		return c.PresignGetBucketLifecycleConfiguration(ctx, v, optFns...)
+	case *GetBucketLocationInput:
+		return c.PresignGetBucketLocation(ctx, v, optFns...)
+	case *GetBucketLoggingInput:
+		return c.PresignGetBucketLogging(ctx, v, optFns...)
+	case *GetBucketMetricsConfigurationInput:
+		return c.PresignGetBucketMetricsConfiguration(ctx, v, optFns...)
+	case *GetBucketNotificationConfigurationInput:
+		return c.PresignGetBucketNotificationConfiguration(ctx, v, optFns...)
+	case *GetBucketOwnershipControlsInput:
+		return c.PresignGetBucketOwnershipControls(ctx, v, optFns...)
+	case *GetBucketPolicyInput:
+		return c.PresignGetBucketPolicy(ctx, v, optFns...)
+	case *GetBucketPolicyStatusInput:
+		return c.PresignGetBucketPolicyStatus(ctx, v, optFns...)
+	case *GetBucketReplicationInput:
+		return c.PresignGetBucketReplication(ctx, v, optFns...)
+	case *GetBucketRequestPaymentInput:
+		return c.PresignGetBucketRequestPayment(ctx, v, optFns...)
+	case *GetBucketTaggingInput:
+		return c.PresignGetBucketTagging(ctx, v, optFns...)
+	case *GetBucketVersioningInput:
+		return c.PresignGetBucketVersioning(ctx, v, optFns...)
+	case *GetBucketWebsiteInput:
+		return c.PresignGetBucketWebsite(ctx, v, optFns...)
+	case *GetObjectInput:
+		return c.PresignGetObject(ctx, v, optFns...)
+	case *GetObjectAclInput:
+		return c.PresignGetObjectAcl(ctx, v, optFns...)
+	case *GetObjectLegalHoldInput:
+		return c.PresignGetObjectLegalHold(ctx, v, optFns...)
+	case *GetObjectLockConfigurationInput:
+		return c.PresignGetObjectLockConfiguration(ctx, v, optFns...)
+	case *GetObjectRetentionInput:
+		return c.PresignGetObjectRetention(ctx, v, optFns...)
+	case *GetObjectTaggingInput:
+		return c.PresignGetObjectTagging(ctx, v, optFns...)
+	case *GetObjectTorrentInput:
+		return c.PresignGetObjectTorrent(ctx, v, optFns...)
+	case *GetPublicAccessBlockInput:
+		return c.PresignGetPublicAccessBlock(ctx, v, optFns...)
+	case *HeadBucketInput:
+		return c.PresignHeadBucket(ctx, v, optFns...)
+	case *HeadObjectInput:
+		return c.PresignHeadObject(ctx, v, optFns...)
+	case *ListBucketAnalyticsConfigurationsInput:
+		return c.PresignListBucketAnalyticsConfigurations(ctx, v, optFns...)
+	case *ListBucketIntelligentTieringConfigurationsInput:
+		return c.PresignListBucketIntelligentTieringConfigurations(ctx, v, optFns...)
+	case *ListBucketInventoryConfigurationsInput:
+		return c.PresignListBucketInventoryConfigurations(ctx, v, optFns...)
+	case *ListBucketMetricsConfigurationsInput:
+		return c.PresignListBucketMetricsConfigurations(ctx, v, optFns...)
+	case *ListBucketsInput:
+		return c.PresignListBuckets(ctx, v, optFns...)
+	case *ListMultipartUploadsInput:
+		return c.PresignListMultipartUploads(ctx, v, optFns...)
+	case *ListObjectVersionsInput:
+		return c.PresignListObjectVersions(ctx, v, optFns...)
+	case *ListObjectsInput:
+		return c.PresignListObjects(ctx, v, optFns...)
+	case *ListObjectsV2Input:
+		return c.PresignListObjectsV2(ctx, v, optFns...)
+	case *ListPartsInput:
+		return c.PresignListParts(ctx, v, optFns...)
+	case *PutBucketAccelerateConfigurationInput:
+		return c.PresignPutBucketAccelerateConfiguration(ctx, v, optFns...)
+	case *PutBucketAclInput:
+		return c.PresignPutBucketAcl(ctx, v, optFns...)
+	case *PutBucketAnalyticsConfigurationInput:
+		return c.PresignPutBucketAnalyticsConfiguration(ctx, v, optFns...)
+	case *PutBucketCorsInput:
+		return c.PresignPutBucketCors(ctx, v, optFns...)
+	case *PutBucketEncryptionInput:
+		return c.PresignPutBucketEncryption(ctx, v, optFns...)
+	case *PutBucketIntelligentTieringConfigurationInput:
+		return c.PresignPutBucketIntelligentTieringConfiguration(ctx, v, optFns...)
+	case *PutBucketInventoryConfigurationInput:
+		return c.PresignPutBucketInventoryConfiguration(ctx, v, optFns...)
+	case *PutBucketLifecycleConfigurationInput:
+		return c.PresignPutBucketLifecycleConfiguration(ctx, v, optFns...)
+	case *PutBucketLoggingInput:
+		return c.PresignPutBucketLogging(ctx, v, optFns...)
+	case *PutBucketMetricsConfigurationInput:
+		return c.PresignPutBucketMetricsConfiguration(ctx, v, optFns...)
+	case *PutBucketNotificationConfigurationInput:
+		return c.PresignPutBucketNotificationConfiguration(ctx, v, optFns...)
+	case *PutBucketOwnershipControlsInput:
+		return c.PresignPutBucketOwnershipControls(ctx, v, optFns...)
+	case *PutBucketPolicyInput:
+		return c.PresignPutBucketPolicy(ctx, v, optFns...)
+	case *PutBucketReplicationInput:
+		return c.PresignPutBucketReplication(ctx, v, optFns...)
+	case *PutBucketRequestPaymentInput:
+		return c.PresignPutBucketRequestPayment(ctx, v, optFns...)
+	case *PutBucketTaggingInput:
+		return c.PresignPutBucketTagging(ctx, v, optFns...)
+	case *PutBucketVersioningInput:
+		return c.PresignPutBucketVersioning(ctx, v, optFns...)
+	case *PutBucketWebsiteInput:
+		return c.PresignPutBucketWebsite(ctx, v, optFns...)
+	case *PutObjectInput:
+		return c.PresignPutObject(ctx, v, optFns...)
+	case *PutObjectAclInput:
+		return c.PresignPutObjectAcl(ctx, v, optFns...)
+	case *PutObjectLegalHoldInput:
+		return c.PresignPutObjectLegalHold(ctx, v, optFns...)
+	case *PutObjectLockConfigurationInput:
+		return c.PresignPutObjectLockConfiguration(ctx, v, optFns...)
+	case *PutObjectRetentionInput:
+		return c.PresignPutObjectRetention(ctx, v, optFns...)
+	case *PutObjectTaggingInput:
+		return c.PresignPutObjectTagging(ctx, v, optFns...)
+	case *PutPublicAccessBlockInput:
+		return c.PresignPutPublicAccessBlock(ctx, v, optFns...)
+	case *RestoreObjectInput:
+		return c.PresignRestoreObject(ctx, v, optFns...)
+	case *UploadPartInput:
+		return c.PresignUploadPart(ctx, v, optFns...)
+	case *UploadPartCopyInput:
+		return c.PresignUploadPartCopy(ctx, v, optFns...)
+	default:
+		return nil, fmt.Errorf("presign not supported for %T input", params)
+	}
+}
+
 func withNopHTTPClientAPIOption(o *Options) {
 	o.HTTPClient = smithyhttp.NopClient{}
 }
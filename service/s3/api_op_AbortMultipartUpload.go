@@ -222,3 +222,34 @@ func addAbortMultipartUploadUpdateEndpoint(stack *middleware.Stack, options Opti
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignAbortMultipartUpload is used to generate a presigned HTTP Request which
+// contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignAbortMultipartUpload(ctx context.Context, params *AbortMultipartUploadInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &AbortMultipartUploadInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "AbortMultipartUpload", params, clientOptFns,
+		addOperationAbortMultipartUploadMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addAbortMultipartUploadPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addAbortMultipartUploadPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
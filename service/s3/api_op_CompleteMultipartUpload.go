@@ -168,6 +168,26 @@ type CompleteMultipartUploadOutput struct {
 	// encryption with AWS KMS (SSE-KMS).
 	BucketKeyEnabled bool
 
+	// The base64-encoded CRC32 checksum of the object. The checksum of a multipart
+	// upload is the checksum of the checksums of each part, followed by a hyphen and
+	// the number of parts.
+	ChecksumCRC32 *string
+
+	// The base64-encoded CRC32C checksum of the object. The checksum of a multipart
+	// upload is the checksum of the checksums of each part, followed by a hyphen and
+	// the number of parts.
+	ChecksumCRC32C *string
+
+	// The base64-encoded SHA1 checksum of the object. The checksum of a multipart
+	// upload is the checksum of the checksums of each part, followed by a hyphen and
+	// the number of parts.
+	ChecksumSHA1 *string
+
+	// The base64-encoded SHA256 checksum of the object. The checksum of a multipart
+	// upload is the checksum of the checksums of each part, followed by a hyphen and
+	// the number of parts.
+	ChecksumSHA256 *string
+
 	// Entity tag that identifies the newly created object's data. Objects with
 	// different object data will have different entity tags. The entity tag is an
 	// opaque string. The entity tag may or may not be an MD5 digest of the object
@@ -317,3 +337,34 @@ func addCompleteMultipartUploadUpdateEndpoint(stack *middleware.Stack, options O
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignCompleteMultipartUpload is used to generate a presigned HTTP Request
+// which contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignCompleteMultipartUpload(ctx context.Context, params *CompleteMultipartUploadInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &CompleteMultipartUploadInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "CompleteMultipartUpload", params, clientOptFns,
+		addOperationCompleteMultipartUploadMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addCompleteMultipartUploadPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addCompleteMultipartUploadPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
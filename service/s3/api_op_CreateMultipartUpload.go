@@ -281,6 +281,11 @@ type CreateMultipartUploadInput struct {
 	// Specifies caching behavior along the request/reply chain.
 	CacheControl *string
 
+	// Indicates the algorithm you want Amazon S3 to use to create the checksum of each
+	// part of the object. Each part must be uploaded with a checksum of the same
+	// algorithm.
+	ChecksumAlgorithm types.ChecksumAlgorithm
+
 	// Specifies presentational information for the object.
 	ContentDisposition *string
 
@@ -431,6 +436,9 @@ type CreateMultipartUploadOutput struct {
 	// encryption with AWS KMS (SSE-KMS).
 	BucketKeyEnabled bool
 
+	// The algorithm that was used to create a checksum of the object.
+	ChecksumAlgorithm types.ChecksumAlgorithm
+
 	// Object key for which the multipart upload was initiated.
 	Key *string
 
@@ -573,3 +581,34 @@ func addCreateMultipartUploadUpdateEndpoint(stack *middleware.Stack, options Opt
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignCreateMultipartUpload is used to generate a presigned HTTP Request which
+// contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignCreateMultipartUpload(ctx context.Context, params *CreateMultipartUploadInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &CreateMultipartUploadInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "CreateMultipartUpload", params, clientOptFns,
+		addOperationCreateMultipartUploadMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addCreateMultipartUploadPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addCreateMultipartUploadPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
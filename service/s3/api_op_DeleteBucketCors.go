@@ -161,3 +161,34 @@ func addDeleteBucketCorsUpdateEndpoint(stack *middleware.Stack, options Options)
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignDeleteBucketCors is used to generate a presigned HTTP Request which
+// contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignDeleteBucketCors(ctx context.Context, params *DeleteBucketCorsInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &DeleteBucketCorsInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "DeleteBucketCors", params, clientOptFns,
+		addOperationDeleteBucketCorsMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addDeleteBucketCorsPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addDeleteBucketCorsPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
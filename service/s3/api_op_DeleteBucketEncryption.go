@@ -170,3 +170,34 @@ func addDeleteBucketEncryptionUpdateEndpoint(stack *middleware.Stack, options Op
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignDeleteBucketEncryption is used to generate a presigned HTTP Request which
+// contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignDeleteBucketEncryption(ctx context.Context, params *DeleteBucketEncryptionInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &DeleteBucketEncryptionInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "DeleteBucketEncryption", params, clientOptFns,
+		addOperationDeleteBucketEncryptionMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addDeleteBucketEncryptionPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addDeleteBucketEncryptionPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
@@ -177,3 +177,35 @@ func addDeleteBucketIntelligentTieringConfigurationUpdateEndpoint(stack *middlew
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignDeleteBucketIntelligentTieringConfiguration is used to generate a
+// presigned HTTP Request which contains presigned URL, signed headers and HTTP
+// method used.
+func (c *PresignClient) PresignDeleteBucketIntelligentTieringConfiguration(ctx context.Context, params *DeleteBucketIntelligentTieringConfigurationInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &DeleteBucketIntelligentTieringConfigurationInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "DeleteBucketIntelligentTieringConfiguration", params, clientOptFns,
+		addOperationDeleteBucketIntelligentTieringConfigurationMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addDeleteBucketIntelligentTieringConfigurationPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addDeleteBucketIntelligentTieringConfigurationPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
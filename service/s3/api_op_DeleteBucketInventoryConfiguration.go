@@ -177,3 +177,34 @@ func addDeleteBucketInventoryConfigurationUpdateEndpoint(stack *middleware.Stack
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignDeleteBucketInventoryConfiguration is used to generate a presigned HTTP
+// Request which contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignDeleteBucketInventoryConfiguration(ctx context.Context, params *DeleteBucketInventoryConfigurationInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &DeleteBucketInventoryConfigurationInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "DeleteBucketInventoryConfiguration", params, clientOptFns,
+		addOperationDeleteBucketInventoryConfigurationMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addDeleteBucketInventoryConfigurationPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addDeleteBucketInventoryConfigurationPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
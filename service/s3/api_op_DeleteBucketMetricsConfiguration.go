@@ -183,3 +183,34 @@ func addDeleteBucketMetricsConfigurationUpdateEndpoint(stack *middleware.Stack,
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignDeleteBucketMetricsConfiguration is used to generate a presigned HTTP
+// Request which contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignDeleteBucketMetricsConfiguration(ctx context.Context, params *DeleteBucketMetricsConfigurationInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &DeleteBucketMetricsConfigurationInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "DeleteBucketMetricsConfiguration", params, clientOptFns,
+		addOperationDeleteBucketMetricsConfigurationMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addDeleteBucketMetricsConfigurationPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addDeleteBucketMetricsConfigurationPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
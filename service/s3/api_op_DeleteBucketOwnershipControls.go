@@ -160,3 +160,34 @@ func addDeleteBucketOwnershipControlsUpdateEndpoint(stack *middleware.Stack, opt
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignDeleteBucketOwnershipControls is used to generate a presigned HTTP
+// Request which contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignDeleteBucketOwnershipControls(ctx context.Context, params *DeleteBucketOwnershipControlsInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &DeleteBucketOwnershipControlsInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "DeleteBucketOwnershipControls", params, clientOptFns,
+		addOperationDeleteBucketOwnershipControlsMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addDeleteBucketOwnershipControlsPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addDeleteBucketOwnershipControlsPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
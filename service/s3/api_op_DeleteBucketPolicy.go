@@ -169,3 +169,34 @@ func addDeleteBucketPolicyUpdateEndpoint(stack *middleware.Stack, options Option
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignDeleteBucketPolicy is used to generate a presigned HTTP Request which
+// contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignDeleteBucketPolicy(ctx context.Context, params *DeleteBucketPolicyInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &DeleteBucketPolicyInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "DeleteBucketPolicy", params, clientOptFns,
+		addOperationDeleteBucketPolicyMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addDeleteBucketPolicyPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addDeleteBucketPolicyPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
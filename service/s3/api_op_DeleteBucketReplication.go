@@ -168,3 +168,34 @@ func addDeleteBucketReplicationUpdateEndpoint(stack *middleware.Stack, options O
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignDeleteBucketReplication is used to generate a presigned HTTP Request
+// which contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignDeleteBucketReplication(ctx context.Context, params *DeleteBucketReplicationInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &DeleteBucketReplicationInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "DeleteBucketReplication", params, clientOptFns,
+		addOperationDeleteBucketReplicationMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addDeleteBucketReplicationPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addDeleteBucketReplicationPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
@@ -168,3 +168,34 @@ func addDeleteBucketWebsiteUpdateEndpoint(stack *middleware.Stack, options Optio
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignDeleteBucketWebsite is used to generate a presigned HTTP Request which
+// contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignDeleteBucketWebsite(ctx context.Context, params *DeleteBucketWebsiteInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &DeleteBucketWebsiteInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "DeleteBucketWebsite", params, clientOptFns,
+		addOperationDeleteBucketWebsiteMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addDeleteBucketWebsitePayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addDeleteBucketWebsitePayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
@@ -191,3 +191,34 @@ func addDeleteObjectTaggingUpdateEndpoint(stack *middleware.Stack, options Optio
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignDeleteObjectTagging is used to generate a presigned HTTP Request which
+// contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignDeleteObjectTagging(ctx context.Context, params *DeleteObjectTaggingInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &DeleteObjectTaggingInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "DeleteObjectTagging", params, clientOptFns,
+		addOperationDeleteObjectTaggingMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addDeleteObjectTaggingPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addDeleteObjectTaggingPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
@@ -249,3 +249,34 @@ func addDeleteObjectsUpdateEndpoint(stack *middleware.Stack, options Options) er
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignDeleteObjects is used to generate a presigned HTTP Request which contains
+// presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignDeleteObjects(ctx context.Context, params *DeleteObjectsInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &DeleteObjectsInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "DeleteObjects", params, clientOptFns,
+		addOperationDeleteObjectsMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addDeleteObjectsPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addDeleteObjectsPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
@@ -172,3 +172,34 @@ func addDeletePublicAccessBlockUpdateEndpoint(stack *middleware.Stack, options O
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignDeletePublicAccessBlock is used to generate a presigned HTTP Request
+// which contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignDeletePublicAccessBlock(ctx context.Context, params *DeletePublicAccessBlockInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &DeletePublicAccessBlockInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "DeletePublicAccessBlock", params, clientOptFns,
+		addOperationDeletePublicAccessBlockMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addDeletePublicAccessBlockPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addDeletePublicAccessBlockPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
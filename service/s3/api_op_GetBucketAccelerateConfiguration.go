@@ -178,3 +178,34 @@ func addGetBucketAccelerateConfigurationUpdateEndpoint(stack *middleware.Stack,
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignGetBucketAccelerateConfiguration is used to generate a presigned HTTP
+// Request which contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignGetBucketAccelerateConfiguration(ctx context.Context, params *GetBucketAccelerateConfigurationInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &GetBucketAccelerateConfigurationInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "GetBucketAccelerateConfiguration", params, clientOptFns,
+		addOperationGetBucketAccelerateConfigurationMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addGetBucketAccelerateConfigurationPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addGetBucketAccelerateConfigurationPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
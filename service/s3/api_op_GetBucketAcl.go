@@ -164,3 +164,34 @@ func addGetBucketAclUpdateEndpoint(stack *middleware.Stack, options Options) err
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignGetBucketAcl is used to generate a presigned HTTP Request which contains
+// presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignGetBucketAcl(ctx context.Context, params *GetBucketAclInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &GetBucketAclInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "GetBucketAcl", params, clientOptFns,
+		addOperationGetBucketAclMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addGetBucketAclPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addGetBucketAclPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
@@ -167,3 +167,34 @@ func addGetBucketCorsUpdateEndpoint(stack *middleware.Stack, options Options) er
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignGetBucketCors is used to generate a presigned HTTP Request which contains
+// presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignGetBucketCors(ctx context.Context, params *GetBucketCorsInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &GetBucketCorsInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "GetBucketCors", params, clientOptFns,
+		addOperationGetBucketCorsMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addGetBucketCorsPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addGetBucketCorsPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
@@ -174,3 +174,34 @@ func addGetBucketEncryptionUpdateEndpoint(stack *middleware.Stack, options Optio
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignGetBucketEncryption is used to generate a presigned HTTP Request which
+// contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignGetBucketEncryption(ctx context.Context, params *GetBucketEncryptionInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &GetBucketEncryptionInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "GetBucketEncryption", params, clientOptFns,
+		addOperationGetBucketEncryptionMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addGetBucketEncryptionPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addGetBucketEncryptionPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
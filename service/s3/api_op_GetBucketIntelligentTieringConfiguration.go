@@ -182,3 +182,34 @@ func addGetBucketIntelligentTieringConfigurationUpdateEndpoint(stack *middleware
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignGetBucketIntelligentTieringConfiguration is used to generate a presigned
+// HTTP Request which contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignGetBucketIntelligentTieringConfiguration(ctx context.Context, params *GetBucketIntelligentTieringConfigurationInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &GetBucketIntelligentTieringConfigurationInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "GetBucketIntelligentTieringConfiguration", params, clientOptFns,
+		addOperationGetBucketIntelligentTieringConfigurationMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addGetBucketIntelligentTieringConfigurationPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addGetBucketIntelligentTieringConfigurationPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
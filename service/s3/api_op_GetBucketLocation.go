@@ -235,3 +235,34 @@ func addGetBucketLocationUpdateEndpoint(stack *middleware.Stack, options Options
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignGetBucketLocation is used to generate a presigned HTTP Request which
+// contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignGetBucketLocation(ctx context.Context, params *GetBucketLocationInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &GetBucketLocationInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "GetBucketLocation", params, clientOptFns,
+		addOperationGetBucketLocationMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addGetBucketLocationPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addGetBucketLocationPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
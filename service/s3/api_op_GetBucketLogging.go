@@ -166,3 +166,34 @@ func addGetBucketLoggingUpdateEndpoint(stack *middleware.Stack, options Options)
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignGetBucketLogging is used to generate a presigned HTTP Request which
+// contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignGetBucketLogging(ctx context.Context, params *GetBucketLoggingInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &GetBucketLoggingInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "GetBucketLogging", params, clientOptFns,
+		addOperationGetBucketLoggingMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addGetBucketLoggingPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addGetBucketLoggingPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
@@ -166,3 +166,34 @@ func addGetBucketOwnershipControlsUpdateEndpoint(stack *middleware.Stack, option
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignGetBucketOwnershipControls is used to generate a presigned HTTP Request
+// which contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignGetBucketOwnershipControls(ctx context.Context, params *GetBucketOwnershipControlsInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &GetBucketOwnershipControlsInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "GetBucketOwnershipControls", params, clientOptFns,
+		addOperationGetBucketOwnershipControlsMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addGetBucketOwnershipControlsPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addGetBucketOwnershipControlsPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
@@ -168,3 +168,34 @@ func addGetBucketPolicyUpdateEndpoint(stack *middleware.Stack, options Options)
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignGetBucketPolicy is used to generate a presigned HTTP Request which
+// contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignGetBucketPolicy(ctx context.Context, params *GetBucketPolicyInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &GetBucketPolicyInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "GetBucketPolicy", params, clientOptFns,
+		addOperationGetBucketPolicyMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addGetBucketPolicyPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addGetBucketPolicyPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
@@ -178,3 +178,34 @@ func addGetBucketPolicyStatusUpdateEndpoint(stack *middleware.Stack, options Opt
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignGetBucketPolicyStatus is used to generate a presigned HTTP Request which
+// contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignGetBucketPolicyStatus(ctx context.Context, params *GetBucketPolicyStatusInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &GetBucketPolicyStatusInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "GetBucketPolicyStatus", params, clientOptFns,
+		addOperationGetBucketPolicyStatusMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addGetBucketPolicyStatusPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addGetBucketPolicyStatusPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
@@ -177,3 +177,34 @@ func addGetBucketReplicationUpdateEndpoint(stack *middleware.Stack, options Opti
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignGetBucketReplication is used to generate a presigned HTTP Request which
+// contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignGetBucketReplication(ctx context.Context, params *GetBucketReplicationInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &GetBucketReplicationInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "GetBucketReplication", params, clientOptFns,
+		addOperationGetBucketReplicationMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addGetBucketReplicationPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addGetBucketReplicationPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
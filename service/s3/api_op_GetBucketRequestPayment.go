@@ -161,3 +161,34 @@ func addGetBucketRequestPaymentUpdateEndpoint(stack *middleware.Stack, options O
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignGetBucketRequestPayment is used to generate a presigned HTTP Request
+// which contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignGetBucketRequestPayment(ctx context.Context, params *GetBucketRequestPaymentInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &GetBucketRequestPaymentInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "GetBucketRequestPayment", params, clientOptFns,
+		addOperationGetBucketRequestPaymentMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addGetBucketRequestPaymentPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addGetBucketRequestPaymentPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
@@ -175,3 +175,34 @@ func addGetBucketTaggingUpdateEndpoint(stack *middleware.Stack, options Options)
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignGetBucketTagging is used to generate a presigned HTTP Request which
+// contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignGetBucketTagging(ctx context.Context, params *GetBucketTaggingInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &GetBucketTaggingInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "GetBucketTagging", params, clientOptFns,
+		addOperationGetBucketTaggingMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addGetBucketTaggingPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addGetBucketTaggingPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
@@ -175,3 +175,34 @@ func addGetBucketVersioningUpdateEndpoint(stack *middleware.Stack, options Optio
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignGetBucketVersioning is used to generate a presigned HTTP Request which
+// contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignGetBucketVersioning(ctx context.Context, params *GetBucketVersioningInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &GetBucketVersioningInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "GetBucketVersioning", params, clientOptFns,
+		addOperationGetBucketVersioningMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addGetBucketVersioningPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addGetBucketVersioningPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
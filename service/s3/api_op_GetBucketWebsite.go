@@ -179,3 +179,34 @@ func addGetBucketWebsiteUpdateEndpoint(stack *middleware.Stack, options Options)
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignGetBucketWebsite is used to generate a presigned HTTP Request which
+// contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignGetBucketWebsite(ctx context.Context, params *GetBucketWebsiteInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &GetBucketWebsiteInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "GetBucketWebsite", params, clientOptFns,
+		addOperationGetBucketWebsiteMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addGetBucketWebsitePayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addGetBucketWebsitePayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
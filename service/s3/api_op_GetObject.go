@@ -6,6 +6,7 @@ import (
 	"context"
 	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
 	"github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	internalChecksum "github.com/aws/aws-sdk-go-v2/service/internal/checksum"
 	s3cust "github.com/aws/aws-sdk-go-v2/service/s3/internal/customizations"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go/middleware"
@@ -176,6 +177,10 @@ type GetObjectInput struct {
 	// This member is required.
 	Key *string
 
+	// To retrieve the checksum of the object, set ChecksumMode to ENABLED. When
+	// enabled, the SDK validates the checksum of the object's payload as it is read.
+	ChecksumMode types.ChecksumMode
+
 	// The account id of the expected bucket owner. If the bucket is owned by a
 	// different account, the request will fail with an HTTP 403 (Access Denied) error.
 	ExpectedBucketOwner *string
@@ -269,6 +274,18 @@ type GetObjectOutput struct {
 	// Specifies caching behavior along the request/reply chain.
 	CacheControl *string
 
+	// The base64-encoded CRC32 checksum of the object.
+	ChecksumCRC32 *string
+
+	// The base64-encoded CRC32C checksum of the object.
+	ChecksumCRC32C *string
+
+	// The base64-encoded SHA1 checksum of the object.
+	ChecksumSHA1 *string
+
+	// The base64-encoded SHA256 checksum of the object.
+	ChecksumSHA256 *string
+
 	// Specifies presentational information for the object.
 	ContentDisposition *string
 
@@ -445,6 +462,9 @@ func addOperationGetObjectMiddlewares(stack *middleware.Stack, options Options)
 	if err = disableAcceptEncodingGzip(stack); err != nil {
 		return err
 	}
+	if err = addGetObjectOutputChecksumMiddlewares(stack, options); err != nil {
+		return err
+	}
 	if err = addRequestResponseLogging(stack, options); err != nil {
 		return err
 	}
@@ -484,6 +504,26 @@ func addGetObjectUpdateEndpoint(stack *middleware.Stack, options Options) error
 	})
 }
 
+// getGetObjectRequestValidationModeMember gets the request checksum validation
+// mode provided as input.
+func getGetObjectRequestValidationModeMember(input interface{}) (string, bool) {
+	in := input.(*GetObjectInput)
+	if len(in.ChecksumMode) == 0 {
+		return "", false
+	}
+	return string(in.ChecksumMode), true
+}
+
+func addGetObjectOutputChecksumMiddlewares(stack *middleware.Stack, options Options) error {
+	return internalChecksum.AddOutputMiddleware(stack, internalChecksum.OutputMiddlewareOptions{
+		GetValidationMode:             getGetObjectRequestValidationModeMember,
+		ValidationAlgorithms:          []string{"CRC32", "CRC32C", "SHA256", "SHA1"},
+		IgnoreMultipartValidation:     true,
+		LogValidationSkipped:          true,
+		LogMultipartValidationSkipped: true,
+	})
+}
+
 // PresignGetObject is used to generate a presigned HTTP Request which contains
 // presigned URL, signed headers and HTTP method used.
 func (c *PresignClient) PresignGetObject(ctx context.Context, params *GetObjectInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
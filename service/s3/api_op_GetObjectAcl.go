@@ -200,3 +200,34 @@ func addGetObjectAclUpdateEndpoint(stack *middleware.Stack, options Options) err
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignGetObjectAcl is used to generate a presigned HTTP Request which contains
+// presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignGetObjectAcl(ctx context.Context, params *GetObjectAclInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &GetObjectAclInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "GetObjectAcl", params, clientOptFns,
+		addOperationGetObjectAclMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addGetObjectAclPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addGetObjectAclPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
@@ -180,3 +180,34 @@ func addGetObjectLegalHoldUpdateEndpoint(stack *middleware.Stack, options Option
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignGetObjectLegalHold is used to generate a presigned HTTP Request which
+// contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignGetObjectLegalHold(ctx context.Context, params *GetObjectLegalHoldInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &GetObjectLegalHoldInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "GetObjectLegalHold", params, clientOptFns,
+		addOperationGetObjectLegalHoldMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addGetObjectLegalHoldPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addGetObjectLegalHoldPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
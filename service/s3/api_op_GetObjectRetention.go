@@ -180,3 +180,34 @@ func addGetObjectRetentionUpdateEndpoint(stack *middleware.Stack, options Option
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignGetObjectRetention is used to generate a presigned HTTP Request which
+// contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignGetObjectRetention(ctx context.Context, params *GetObjectRetentionInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &GetObjectRetentionInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "GetObjectRetention", params, clientOptFns,
+		addOperationGetObjectRetentionMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addGetObjectRetentionPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addGetObjectRetentionPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
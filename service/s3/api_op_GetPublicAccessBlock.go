@@ -185,3 +185,34 @@ func addGetPublicAccessBlockUpdateEndpoint(stack *middleware.Stack, options Opti
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignGetPublicAccessBlock is used to generate a presigned HTTP Request which
+// contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignGetPublicAccessBlock(ctx context.Context, params *GetPublicAccessBlockInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &GetPublicAccessBlockInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "GetPublicAccessBlock", params, clientOptFns,
+		addOperationGetPublicAccessBlockMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addGetPublicAccessBlockPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addGetPublicAccessBlockPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
@@ -203,3 +203,34 @@ func addListBucketAnalyticsConfigurationsUpdateEndpoint(stack *middleware.Stack,
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignListBucketAnalyticsConfigurations is used to generate a presigned HTTP
+// Request which contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignListBucketAnalyticsConfigurations(ctx context.Context, params *ListBucketAnalyticsConfigurationsInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &ListBucketAnalyticsConfigurationsInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "ListBucketAnalyticsConfigurations", params, clientOptFns,
+		addOperationListBucketAnalyticsConfigurationsMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addListBucketAnalyticsConfigurationsPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addListBucketAnalyticsConfigurationsPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
@@ -195,3 +195,35 @@ func addListBucketIntelligentTieringConfigurationsUpdateEndpoint(stack *middlewa
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignListBucketIntelligentTieringConfigurations is used to generate a
+// presigned HTTP Request which contains presigned URL, signed headers and HTTP
+// method used.
+func (c *PresignClient) PresignListBucketIntelligentTieringConfigurations(ctx context.Context, params *ListBucketIntelligentTieringConfigurationsInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &ListBucketIntelligentTieringConfigurationsInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "ListBucketIntelligentTieringConfigurations", params, clientOptFns,
+		addOperationListBucketIntelligentTieringConfigurationsMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addListBucketIntelligentTieringConfigurationsPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addListBucketIntelligentTieringConfigurationsPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
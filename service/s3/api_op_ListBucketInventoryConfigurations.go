@@ -204,3 +204,34 @@ func addListBucketInventoryConfigurationsUpdateEndpoint(stack *middleware.Stack,
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignListBucketInventoryConfigurations is used to generate a presigned HTTP
+// Request which contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignListBucketInventoryConfigurations(ctx context.Context, params *ListBucketInventoryConfigurationsInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &ListBucketInventoryConfigurationsInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "ListBucketInventoryConfigurations", params, clientOptFns,
+		addOperationListBucketInventoryConfigurationsMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addListBucketInventoryConfigurationsPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addListBucketInventoryConfigurationsPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
@@ -207,3 +207,34 @@ func addListBucketMetricsConfigurationsUpdateEndpoint(stack *middleware.Stack, o
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignListBucketMetricsConfigurations is used to generate a presigned HTTP
+// Request which contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignListBucketMetricsConfigurations(ctx context.Context, params *ListBucketMetricsConfigurationsInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &ListBucketMetricsConfigurationsInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "ListBucketMetricsConfigurations", params, clientOptFns,
+		addOperationListBucketMetricsConfigurationsMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addListBucketMetricsConfigurationsPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addListBucketMetricsConfigurationsPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
@@ -135,3 +135,34 @@ func addListBucketsUpdateEndpoint(stack *middleware.Stack, options Options) erro
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignListBuckets is used to generate a presigned HTTP Request which contains
+// presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignListBuckets(ctx context.Context, params *ListBucketsInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &ListBucketsInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "ListBuckets", params, clientOptFns,
+		addOperationListBucketsMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addListBucketsPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addListBucketsPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
@@ -298,3 +298,34 @@ func addListMultipartUploadsUpdateEndpoint(stack *middleware.Stack, options Opti
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignListMultipartUploads is used to generate a presigned HTTP Request which
+// contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignListMultipartUploads(ctx context.Context, params *ListMultipartUploadsInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &ListMultipartUploadsInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "ListMultipartUploads", params, clientOptFns,
+		addOperationListMultipartUploadsMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addListMultipartUploadsPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addListMultipartUploadsPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
@@ -263,3 +263,34 @@ func addListObjectVersionsUpdateEndpoint(stack *middleware.Stack, options Option
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignListObjectVersions is used to generate a presigned HTTP Request which
+// contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignListObjectVersions(ctx context.Context, params *ListObjectVersionsInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &ListObjectVersionsInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "ListObjectVersions", params, clientOptFns,
+		addOperationListObjectVersionsMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addListObjectVersionsPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addListObjectVersionsPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
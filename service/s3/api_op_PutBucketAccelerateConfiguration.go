@@ -191,3 +191,34 @@ func addPutBucketAccelerateConfigurationUpdateEndpoint(stack *middleware.Stack,
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignPutBucketAccelerateConfiguration is used to generate a presigned HTTP
+// Request which contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignPutBucketAccelerateConfiguration(ctx context.Context, params *PutBucketAccelerateConfigurationInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &PutBucketAccelerateConfigurationInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "PutBucketAccelerateConfiguration", params, clientOptFns,
+		addOperationPutBucketAccelerateConfigurationMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addPutBucketAccelerateConfigurationPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addPutBucketAccelerateConfigurationPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
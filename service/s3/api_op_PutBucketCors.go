@@ -209,3 +209,34 @@ func addPutBucketCorsUpdateEndpoint(stack *middleware.Stack, options Options) er
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignPutBucketCors is used to generate a presigned HTTP Request which contains
+// presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignPutBucketCors(ctx context.Context, params *PutBucketCorsInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &PutBucketCorsInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "PutBucketCors", params, clientOptFns,
+		addOperationPutBucketCorsMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addPutBucketCorsPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addPutBucketCorsPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
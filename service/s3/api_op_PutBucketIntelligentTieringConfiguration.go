@@ -183,3 +183,34 @@ func addPutBucketIntelligentTieringConfigurationUpdateEndpoint(stack *middleware
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignPutBucketIntelligentTieringConfiguration is used to generate a presigned
+// HTTP Request which contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignPutBucketIntelligentTieringConfiguration(ctx context.Context, params *PutBucketIntelligentTieringConfigurationInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &PutBucketIntelligentTieringConfigurationInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "PutBucketIntelligentTieringConfiguration", params, clientOptFns,
+		addOperationPutBucketIntelligentTieringConfigurationMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addPutBucketIntelligentTieringConfigurationPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addPutBucketIntelligentTieringConfigurationPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
@@ -175,3 +175,34 @@ func addPutBucketOwnershipControlsUpdateEndpoint(stack *middleware.Stack, option
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignPutBucketOwnershipControls is used to generate a presigned HTTP Request
+// which contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignPutBucketOwnershipControls(ctx context.Context, params *PutBucketOwnershipControlsInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &PutBucketOwnershipControlsInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "PutBucketOwnershipControls", params, clientOptFns,
+		addOperationPutBucketOwnershipControlsMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addPutBucketOwnershipControlsPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addPutBucketOwnershipControlsPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
@@ -219,3 +219,34 @@ func addPutBucketReplicationUpdateEndpoint(stack *middleware.Stack, options Opti
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignPutBucketReplication is used to generate a presigned HTTP Request which
+// contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignPutBucketReplication(ctx context.Context, params *PutBucketReplicationInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &PutBucketReplicationInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "PutBucketReplication", params, clientOptFns,
+		addOperationPutBucketReplicationMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addPutBucketReplicationPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addPutBucketReplicationPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
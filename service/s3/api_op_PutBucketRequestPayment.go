@@ -177,3 +177,34 @@ func addPutBucketRequestPaymentUpdateEndpoint(stack *middleware.Stack, options O
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignPutBucketRequestPayment is used to generate a presigned HTTP Request
+// which contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignPutBucketRequestPayment(ctx context.Context, params *PutBucketRequestPaymentInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &PutBucketRequestPaymentInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "PutBucketRequestPayment", params, clientOptFns,
+		addOperationPutBucketRequestPaymentMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addPutBucketRequestPaymentPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addPutBucketRequestPaymentPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
@@ -7,6 +7,7 @@ import (
 	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
 	"github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	internalChecksum "github.com/aws/aws-sdk-go-v2/service/internal/checksum"
 	s3cust "github.com/aws/aws-sdk-go-v2/service/s3/internal/customizations"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go/middleware"
@@ -137,6 +138,41 @@ type PutObjectInput struct {
 	// (http://www.w3.org/Protocols/rfc2616/rfc2616-sec14.html#sec14.9).
 	CacheControl *string
 
+	// Indicates the algorithm used to create the checksum for the object when using
+	// the SDK. The SDK computes the checksum, and sends it as the
+	// x-amz-checksum-algorithm header, or as a trailing header of the payload when the
+	// payload is not seekable. If you provide a checksum header for any algorithm, the
+	// SDK does not compute a checksum.
+	ChecksumAlgorithm types.ChecksumAlgorithm
+
+	// The base64-encoded CRC32 checksum of the object. This header can be used as a
+	// data integrity check to verify that the data received is the same data that was
+	// originally sent. For more information, see Checking object integrity
+	// (https://docs.aws.amazon.com/AmazonS3/latest/userguide/checking-object-integrity.html)
+	// in the Amazon S3 User Guide.
+	ChecksumCRC32 *string
+
+	// The base64-encoded CRC32C checksum of the object. This header can be used as a
+	// data integrity check to verify that the data received is the same data that was
+	// originally sent. For more information, see Checking object integrity
+	// (https://docs.aws.amazon.com/AmazonS3/latest/userguide/checking-object-integrity.html)
+	// in the Amazon S3 User Guide.
+	ChecksumCRC32C *string
+
+	// The base64-encoded SHA1 checksum of the object. This header can be used as a
+	// data integrity check to verify that the data received is the same data that was
+	// originally sent. For more information, see Checking object integrity
+	// (https://docs.aws.amazon.com/AmazonS3/latest/userguide/checking-object-integrity.html)
+	// in the Amazon S3 User Guide.
+	ChecksumSHA1 *string
+
+	// The base64-encoded SHA256 checksum of the object. This header can be used as a
+	// data integrity check to verify that the data received is the same data that was
+	// originally sent. For more information, see Checking object integrity
+	// (https://docs.aws.amazon.com/AmazonS3/latest/userguide/checking-object-integrity.html)
+	// in the Amazon S3 User Guide.
+	ChecksumSHA256 *string
+
 	// Specifies presentational information for the object. For more information, see
 	// http://www.w3.org/Protocols/rfc2616/rfc2616-sec19.html#sec19.5.1
 	// (http://www.w3.org/Protocols/rfc2616/rfc2616-sec19.html#sec19.5.1).
@@ -290,6 +326,18 @@ type PutObjectOutput struct {
 	// encryption with AWS KMS (SSE-KMS).
 	BucketKeyEnabled bool
 
+	// The base64-encoded CRC32 checksum of the object.
+	ChecksumCRC32 *string
+
+	// The base64-encoded CRC32C checksum of the object.
+	ChecksumCRC32C *string
+
+	// The base64-encoded SHA1 checksum of the object.
+	ChecksumSHA1 *string
+
+	// The base64-encoded SHA256 checksum of the object.
+	ChecksumSHA256 *string
+
 	// Entity tag for the uploaded object.
 	ETag *string
 
@@ -403,6 +451,9 @@ func addOperationPutObjectMiddlewares(stack *middleware.Stack, options Options)
 	if err = disableAcceptEncodingGzip(stack); err != nil {
 		return err
 	}
+	if err = addPutObjectInputChecksumMiddlewares(stack, options); err != nil {
+		return err
+	}
 	if err = addRequestResponseLogging(stack, options); err != nil {
 		return err
 	}
@@ -442,6 +493,24 @@ func addPutObjectUpdateEndpoint(stack *middleware.Stack, options Options) error
 	})
 }
 
+// getPutObjectRequestAlgorithmMember gets the request checksum algorithm value
+// provided as input.
+func getPutObjectRequestAlgorithmMember(input interface{}) (string, bool) {
+	in := input.(*PutObjectInput)
+	if len(in.ChecksumAlgorithm) == 0 {
+		return "", false
+	}
+	return string(in.ChecksumAlgorithm), true
+}
+
+func addPutObjectInputChecksumMiddlewares(stack *middleware.Stack, options Options) error {
+	return internalChecksum.AddInputMiddleware(stack, internalChecksum.InputMiddlewareOptions{
+		GetAlgorithm:                   getPutObjectRequestAlgorithmMember,
+		EnableTrailingChecksum:         true,
+		EnableComputeSHA256PayloadHash: true,
+	})
+}
+
 // PresignPutObject is used to generate a presigned HTTP Request which contains
 // presigned URL, signed headers and HTTP method used.
 func (c *PresignClient) PresignPutObject(ctx context.Context, params *PutObjectInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
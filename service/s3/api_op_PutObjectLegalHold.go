@@ -194,3 +194,34 @@ func addPutObjectLegalHoldUpdateEndpoint(stack *middleware.Stack, options Option
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignPutObjectLegalHold is used to generate a presigned HTTP Request which
+// contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignPutObjectLegalHold(ctx context.Context, params *PutObjectLegalHoldInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &PutObjectLegalHoldInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "PutObjectLegalHold", params, clientOptFns,
+		addOperationPutObjectLegalHoldMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addPutObjectLegalHoldPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addPutObjectLegalHoldPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
@@ -414,3 +414,34 @@ func addRestoreObjectUpdateEndpoint(stack *middleware.Stack, options Options) er
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignRestoreObject is used to generate a presigned HTTP Request which contains
+// presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignRestoreObject(ctx context.Context, params *RestoreObjectInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &RestoreObjectInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "RestoreObject", params, clientOptFns,
+		addOperationRestoreObjectMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addRestoreObjectPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addRestoreObjectPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
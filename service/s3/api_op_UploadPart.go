@@ -6,6 +6,8 @@ import (
 	"context"
 	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
 	"github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	internalChecksum "github.com/aws/aws-sdk-go-v2/service/internal/checksum"
 	s3cust "github.com/aws/aws-sdk-go-v2/service/s3/internal/customizations"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go/middleware"
@@ -166,6 +168,41 @@ type UploadPartInput struct {
 	// Object data.
 	Body io.Reader
 
+	// Indicates the algorithm used to create the checksum for the part when using the
+	// SDK. The SDK computes the checksum, and sends it as the x-amz-checksum-algorithm
+	// header, or as a trailing header of the payload when the payload is not seekable.
+	// If you provide a checksum header for any algorithm, the SDK does not compute a
+	// checksum.
+	ChecksumAlgorithm types.ChecksumAlgorithm
+
+	// The base64-encoded CRC32 checksum of the part. This header can be used as a data
+	// integrity check to verify that the data received is the same data that was
+	// originally sent. For more information, see Checking object integrity
+	// (https://docs.aws.amazon.com/AmazonS3/latest/userguide/checking-object-integrity.html)
+	// in the Amazon S3 User Guide.
+	ChecksumCRC32 *string
+
+	// The base64-encoded CRC32C checksum of the part. This header can be used as a
+	// data integrity check to verify that the data received is the same data that was
+	// originally sent. For more information, see Checking object integrity
+	// (https://docs.aws.amazon.com/AmazonS3/latest/userguide/checking-object-integrity.html)
+	// in the Amazon S3 User Guide.
+	ChecksumCRC32C *string
+
+	// The base64-encoded SHA1 checksum of the part. This header can be used as a data
+	// integrity check to verify that the data received is the same data that was
+	// originally sent. For more information, see Checking object integrity
+	// (https://docs.aws.amazon.com/AmazonS3/latest/userguide/checking-object-integrity.html)
+	// in the Amazon S3 User Guide.
+	ChecksumSHA1 *string
+
+	// The base64-encoded SHA256 checksum of the part. This header can be used as a
+	// data integrity check to verify that the data received is the same data that was
+	// originally sent. For more information, see Checking object integrity
+	// (https://docs.aws.amazon.com/AmazonS3/latest/userguide/checking-object-integrity.html)
+	// in the Amazon S3 User Guide.
+	ChecksumSHA256 *string
+
 	// Size of the body in bytes. This parameter is useful when the size of the body
 	// cannot be determined automatically.
 	ContentLength int64
@@ -211,6 +248,18 @@ type UploadPartOutput struct {
 	// encryption with AWS KMS (SSE-KMS).
 	BucketKeyEnabled bool
 
+	// The base64-encoded CRC32 checksum of the part.
+	ChecksumCRC32 *string
+
+	// The base64-encoded CRC32C checksum of the part.
+	ChecksumCRC32C *string
+
+	// The base64-encoded SHA1 checksum of the part.
+	ChecksumSHA1 *string
+
+	// The base64-encoded SHA256 checksum of the part.
+	ChecksumSHA256 *string
+
 	// Entity tag for the uploaded object.
 	ETag *string
 
@@ -305,6 +354,9 @@ func addOperationUploadPartMiddlewares(stack *middleware.Stack, options Options)
 	if err = disableAcceptEncodingGzip(stack); err != nil {
 		return err
 	}
+	if err = addUploadPartInputChecksumMiddlewares(stack, options); err != nil {
+		return err
+	}
 	if err = addRequestResponseLogging(stack, options); err != nil {
 		return err
 	}
@@ -343,3 +395,55 @@ func addUploadPartUpdateEndpoint(stack *middleware.Stack, options Options) error
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// getUploadPartRequestAlgorithmMember gets the request checksum algorithm value
+// provided as input.
+func getUploadPartRequestAlgorithmMember(input interface{}) (string, bool) {
+	in := input.(*UploadPartInput)
+	if len(in.ChecksumAlgorithm) == 0 {
+		return "", false
+	}
+	return string(in.ChecksumAlgorithm), true
+}
+
+func addUploadPartInputChecksumMiddlewares(stack *middleware.Stack, options Options) error {
+	return internalChecksum.AddInputMiddleware(stack, internalChecksum.InputMiddlewareOptions{
+		GetAlgorithm:                   getUploadPartRequestAlgorithmMember,
+		EnableTrailingChecksum:         true,
+		EnableComputeSHA256PayloadHash: true,
+	})
+}
+
+// PresignUploadPart is used to generate a presigned HTTP Request which contains
+// presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignUploadPart(ctx context.Context, params *UploadPartInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &UploadPartInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "UploadPart", params, clientOptFns,
+		addOperationUploadPartMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		func(stack *middleware.Stack, options Options) error {
+			return awshttp.RemoveContentTypeHeader(stack)
+		},
+		addUploadPartPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addUploadPartPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
@@ -430,3 +430,34 @@ func addUploadPartCopyUpdateEndpoint(stack *middleware.Stack, options Options) e
 		UseARNRegion:            options.UseARNRegion,
 	})
 }
+
+// PresignUploadPartCopy is used to generate a presigned HTTP Request which
+// contains presigned URL, signed headers and HTTP method used.
+func (c *PresignClient) PresignUploadPartCopy(ctx context.Context, params *UploadPartCopyInput, optFns ...func(*PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	if params == nil {
+		params = &UploadPartCopyInput{}
+	}
+	options := c.options.copy()
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	clientOptFns := append(options.ClientOptions, withNopHTTPClientAPIOption)
+
+	result, _, err := c.client.invokeOperation(ctx, "UploadPartCopy", params, clientOptFns,
+		addOperationUploadPartCopyMiddlewares,
+		presignConverter(options).convertToPresignMiddleware,
+		addUploadPartCopyPayloadAsUnsigned,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*v4.PresignedHTTPRequest)
+	return out, nil
+}
+
+func addUploadPartCopyPayloadAsUnsigned(stack *middleware.Stack, options Options) error {
+	v4.RemoveContentSHA256HeaderMiddleware(stack)
+	v4.RemoveComputePayloadSHA256Middleware(stack)
+	return v4.AddUnsignedPayloadMiddleware(stack)
+}
@@ -5,6 +5,7 @@ go 1.15
 require (
 	github.com/aws/aws-sdk-go-v2 v1.2.0
 	github.com/aws/aws-sdk-go-v2/service/internal/accept-encoding v1.0.0
+	github.com/aws/aws-sdk-go-v2/service/internal/checksum v1.0.0
 	github.com/aws/aws-sdk-go-v2/service/internal/presigned-url v1.0.0
 	github.com/aws/aws-sdk-go-v2/service/internal/s3shared v1.0.0
 	github.com/aws/smithy-go v1.1.0
@@ -15,6 +16,8 @@ replace github.com/aws/aws-sdk-go-v2 => ../../
 
 replace github.com/aws/aws-sdk-go-v2/service/internal/accept-encoding => ../../service/internal/accept-encoding/
 
+replace github.com/aws/aws-sdk-go-v2/service/internal/checksum => ../../service/internal/checksum/
+
 replace github.com/aws/aws-sdk-go-v2/service/internal/presigned-url => ../../service/internal/presigned-url/
 
 replace github.com/aws/aws-sdk-go-v2/service/internal/s3shared => ../../service/internal/s3shared/
@@ -11,6 +11,8 @@ This package provides support for following S3 customizations
 
     processResponseWith200Error Middleware: Deserializing response error with 200 status code
 
+    SelectObjectContent EventStream Middleware: reads the events of the SelectObjectContent response's event stream
+
 
 Virtual Host style url addressing
 
@@ -0,0 +1,247 @@
+package customizations_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/internal/awstesting/unit"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+func newChecksumTestClient(serverURL string) *s3.Client {
+	return s3.New(s3.Options{
+		Credentials: unit.StubCredentialsProvider{},
+		Retryer:     aws.NopRetryer{},
+		Region:      "mock-region",
+		EndpointResolver: EndpointResolverFunc(func(region string, options s3.EndpointResolverOptions) (e aws.Endpoint, err error) {
+			e.URL = serverURL
+			e.SigningRegion = "us-west-2"
+			return e, err
+		}),
+		UsePathStyle: true,
+	})
+}
+
+func TestPutObject_FlexibleChecksum(t *testing.T) {
+	cases := map[string]struct {
+		algorithm         types.ChecksumAlgorithm
+		checksumCRC32     *string
+		body              func() io.Reader
+		contentLength     int64
+		expectHeaders     map[string]string
+		expectPayloadHash string
+		expectTrailers    map[string]string
+	}{
+		"seekable crc32": {
+			algorithm: types.ChecksumAlgorithmCrc32,
+			body:      func() io.Reader { return strings.NewReader("hello world") },
+			expectHeaders: map[string]string{
+				"X-Amz-Sdk-Checksum-Algorithm": "CRC32",
+				"X-Amz-Checksum-Crc32":         "DUoRhQ==",
+			},
+			expectPayloadHash: "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9",
+		},
+		"seekable sha256": {
+			algorithm: types.ChecksumAlgorithmSha256,
+			body:      func() io.Reader { return strings.NewReader("hello world") },
+			expectHeaders: map[string]string{
+				"X-Amz-Sdk-Checksum-Algorithm": "SHA256",
+				"X-Amz-Checksum-Sha256":        "uU0nuZNNPgilLlLX2n2r+sSE7+N6U4DukIj3rOLvzek=",
+			},
+			expectPayloadHash: "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9",
+		},
+		"checksum provided": {
+			algorithm:     types.ChecksumAlgorithmCrc32,
+			checksumCRC32: aws.String("provided"),
+			body:          func() io.Reader { return strings.NewReader("hello world") },
+			expectHeaders: map[string]string{
+				"X-Amz-Checksum-Crc32": "provided",
+			},
+			expectPayloadHash: "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9",
+		},
+		"unseekable trailing crc32c": {
+			algorithm: types.ChecksumAlgorithmCrc32c,
+			body: func() io.Reader {
+				return ioutil.NopCloser(strings.NewReader("hello world"))
+			},
+			contentLength: 11,
+			expectHeaders: map[string]string{
+				"X-Amz-Sdk-Checksum-Algorithm": "CRC32C",
+				"X-Amz-Checksum-Crc32c":        "",
+				"X-Amz-Trailer":                "x-amz-checksum-crc32c",
+				"Content-Encoding":             "aws-chunked",
+				"X-Amz-Decoded-Content-Length": "11",
+			},
+			expectPayloadHash: v4.StreamingPayloadTrailer,
+			expectTrailers: map[string]string{
+				"x-amz-checksum-crc32c": "yZRlqg==",
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					for k, v := range c.expectHeaders {
+						if e, a := v, r.Header.Get(k); e != a {
+							t.Errorf("expect %v header %q, got %q", k, e, a)
+						}
+					}
+					if e, a := c.expectPayloadHash, r.Header.Get("X-Amz-Content-Sha256"); e != a {
+						t.Errorf("expect %v payload hash, got %v", e, a)
+					}
+
+					body, err := ioutil.ReadAll(r.Body)
+					if err != nil {
+						t.Errorf("expect no error reading body, got %v", err)
+					}
+
+					if c.expectTrailers == nil {
+						if e, a := "hello world", string(body); e != a {
+							t.Errorf("expect %q payload, got %q", e, a)
+						}
+						return
+					}
+
+					decoded, trailers, err := decodeAWSChunked(r, bytes.NewReader(body))
+					if err != nil {
+						t.Errorf("expect valid aws-chunked payload, got %v", err)
+					}
+					if e, a := "hello world", string(decoded); e != a {
+						t.Errorf("expect %q payload, got %q", e, a)
+					}
+					for k, v := range c.expectTrailers {
+						if e, a := v, trailers[k]; e != a {
+							t.Errorf("expect %v %v trailer, got %v", e, k, a)
+						}
+					}
+				}))
+			defer server.Close()
+
+			_, err := newChecksumTestClient(server.URL).PutObject(context.Background(), &s3.PutObjectInput{
+				Bucket:            aws.String("bucket"),
+				Key:               aws.String("key"),
+				Body:              c.body(),
+				ContentLength:     c.contentLength,
+				ChecksumAlgorithm: c.algorithm,
+				ChecksumCRC32:     c.checksumCRC32,
+			})
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestUploadPart_ResponseChecksum(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if e, a := "DUoRhQ==", r.Header.Get("X-Amz-Checksum-Crc32"); e != a {
+				t.Errorf("expect %v checksum, got %v", e, a)
+			}
+			w.Header().Set("ETag", "etag")
+			w.Header().Set("X-Amz-Checksum-Crc32", "DUoRhQ==")
+		}))
+	defer server.Close()
+
+	out, err := newChecksumTestClient(server.URL).UploadPart(context.Background(), &s3.UploadPartInput{
+		Bucket:            aws.String("bucket"),
+		Key:               aws.String("key"),
+		UploadId:          aws.String("upload-id"),
+		PartNumber:        1,
+		Body:              strings.NewReader("hello world"),
+		ChecksumAlgorithm: types.ChecksumAlgorithmCrc32,
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "DUoRhQ==", aws.ToString(out.ChecksumCRC32); e != a {
+		t.Errorf("expect %v checksum, got %v", e, a)
+	}
+}
+
+func TestGetObject_ValidateChecksum(t *testing.T) {
+	cases := map[string]struct {
+		checksumMode   types.ChecksumMode
+		responseHeader map[string]string
+		expectReadErr  string
+	}{
+		"valid checksum": {
+			checksumMode: types.ChecksumModeEnabled,
+			responseHeader: map[string]string{
+				"X-Amz-Checksum-Crc32": "DUoRhQ==",
+			},
+		},
+		"invalid checksum": {
+			checksumMode: types.ChecksumModeEnabled,
+			responseHeader: map[string]string{
+				"X-Amz-Checksum-Sha1": "AAAAAAAAAAAAAAAAAAAAAAAAAAA=",
+			},
+			expectReadErr: "checksum did not match",
+		},
+		"validation disabled": {
+			responseHeader: map[string]string{
+				"X-Amz-Checksum-Crc32": "AAAAAA==",
+			},
+		},
+		"multipart checksum": {
+			checksumMode: types.ChecksumModeEnabled,
+			responseHeader: map[string]string{
+				"X-Amz-Checksum-Crc32": "AAAAAA==-2",
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					if e, a := string(c.checksumMode), r.Header.Get("X-Amz-Checksum-Mode"); e != a {
+						t.Errorf("expect %q checksum mode, got %q", e, a)
+					}
+					for k, v := range c.responseHeader {
+						w.Header().Set(k, v)
+					}
+					w.Write([]byte("hello world"))
+				}))
+			defer server.Close()
+
+			out, err := newChecksumTestClient(server.URL).GetObject(context.Background(), &s3.GetObjectInput{
+				Bucket:       aws.String("bucket"),
+				Key:          aws.String("key"),
+				ChecksumMode: c.checksumMode,
+			})
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			defer out.Body.Close()
+
+			body, err := ioutil.ReadAll(out.Body)
+			if len(c.expectReadErr) != 0 {
+				if err == nil {
+					t.Fatalf("expect read error, got none")
+				}
+				if e, a := c.expectReadErr, err.Error(); !strings.Contains(a, e) {
+					t.Errorf("expect read error to contain %v, got %v", e, a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no read error, got %v", err)
+			}
+			if e, a := "hello world", string(body); e != a {
+				t.Errorf("expect %q body, got %q", e, a)
+			}
+		})
+	}
+}
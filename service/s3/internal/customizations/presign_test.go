@@ -6,10 +6,12 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	"github.com/aws/aws-sdk-go-v2/internal/awstesting/unit"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 )
@@ -179,3 +181,156 @@ func TestPutObject_PresignURL(t *testing.T) {
 		})
 	}
 }
+
+func TestPresignOperation(t *testing.T) {
+	cases := map[string]struct {
+		presign                func(context.Context, *s3.PresignClient) (*v4.PresignedHTTPRequest, error)
+		expectPresignedURLHost string
+		expectRequestURIQuery  []string
+		expectMethod           string
+		expectError            string
+	}{
+		"HeadObject": {
+			presign: func(ctx context.Context, c *s3.PresignClient) (*v4.PresignedHTTPRequest, error) {
+				return c.PresignHeadObject(ctx, &s3.HeadObjectInput{
+					Bucket: aws.String("mock-bucket"),
+					Key:    aws.String("mockkey"),
+				})
+			},
+			expectPresignedURLHost: "https://mock-bucket.s3.us-west-2.amazonaws.com/mockkey?",
+			expectRequestURIQuery: []string{
+				"X-Amz-Expires=900",
+				"X-Amz-Signature",
+			},
+			expectMethod: "HEAD",
+		},
+		"UploadPart": {
+			presign: func(ctx context.Context, c *s3.PresignClient) (*v4.PresignedHTTPRequest, error) {
+				return c.PresignUploadPart(ctx, &s3.UploadPartInput{
+					Bucket:     aws.String("mock-bucket"),
+					Key:        aws.String("mockkey"),
+					PartNumber: 2,
+					UploadId:   aws.String("mockUploadID"),
+				})
+			},
+			expectPresignedURLHost: "https://mock-bucket.s3.us-west-2.amazonaws.com/mockkey?",
+			expectRequestURIQuery: []string{
+				"partNumber=2",
+				"uploadId=mockUploadID",
+				"X-Amz-Signature",
+			},
+			expectMethod: "PUT",
+		},
+		"DeleteObject": {
+			presign: func(ctx context.Context, c *s3.PresignClient) (*v4.PresignedHTTPRequest, error) {
+				return c.PresignDeleteObject(ctx, &s3.DeleteObjectInput{
+					Bucket: aws.String("mock-bucket"),
+					Key:    aws.String("mockkey"),
+				})
+			},
+			expectPresignedURLHost: "https://mock-bucket.s3.us-west-2.amazonaws.com/mockkey?",
+			expectRequestURIQuery: []string{
+				"X-Amz-Signature",
+			},
+			expectMethod: "DELETE",
+		},
+		"CompleteMultipartUpload": {
+			presign: func(ctx context.Context, c *s3.PresignClient) (*v4.PresignedHTTPRequest, error) {
+				return c.PresignCompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+					Bucket:   aws.String("mock-bucket"),
+					Key:      aws.String("mockkey"),
+					UploadId: aws.String("mockUploadID"),
+				})
+			},
+			expectPresignedURLHost: "https://mock-bucket.s3.us-west-2.amazonaws.com/mockkey?",
+			expectRequestURIQuery: []string{
+				"uploadId=mockUploadID",
+				"X-Amz-Signature",
+			},
+			expectMethod: "POST",
+		},
+		"GetObjectTagging": {
+			presign: func(ctx context.Context, c *s3.PresignClient) (*v4.PresignedHTTPRequest, error) {
+				return c.PresignGetObjectTagging(ctx, &s3.GetObjectTaggingInput{
+					Bucket: aws.String("mock-bucket"),
+					Key:    aws.String("mockkey"),
+				})
+			},
+			expectPresignedURLHost: "https://mock-bucket.s3.us-west-2.amazonaws.com/mockkey?",
+			expectRequestURIQuery: []string{
+				"tagging=",
+				"X-Amz-Signature",
+			},
+			expectMethod: "GET",
+		},
+		"PresignOperation": {
+			presign: func(ctx context.Context, c *s3.PresignClient) (*v4.PresignedHTTPRequest, error) {
+				return c.PresignOperation(ctx, &s3.AbortMultipartUploadInput{
+					Bucket:   aws.String("mock-bucket"),
+					Key:      aws.String("mockkey"),
+					UploadId: aws.String("mockUploadID"),
+				}, s3.WithPresignExpires(time.Hour))
+			},
+			expectPresignedURLHost: "https://mock-bucket.s3.us-west-2.amazonaws.com/mockkey?",
+			expectRequestURIQuery: []string{
+				"X-Amz-Expires=3600",
+				"uploadId=mockUploadID",
+				"X-Amz-Signature",
+			},
+			expectMethod: "DELETE",
+		},
+		"PresignOperation unsupported input": {
+			presign: func(ctx context.Context, c *s3.PresignClient) (*v4.PresignedHTTPRequest, error) {
+				return c.PresignOperation(ctx, &struct{}{})
+			},
+			expectError: "presign not supported for *struct {} input",
+		},
+		"PresignOperation validation error": {
+			presign: func(ctx context.Context, c *s3.PresignClient) (*v4.PresignedHTTPRequest, error) {
+				return c.PresignOperation(ctx, &s3.HeadObjectInput{
+					Bucket: aws.String("mock-bucket"),
+				})
+			},
+			expectError: "Key",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := aws.Config{
+				Region:      "us-west-2",
+				Credentials: unit.StubCredentialsProvider{},
+				Retryer: func() aws.Retryer {
+					return aws.NopRetryer{}
+				},
+			}
+			presignClient := s3.NewPresignClient(s3.NewFromConfig(cfg))
+
+			req, err := c.presign(context.Background(), presignClient)
+			if len(c.expectError) != 0 {
+				if err == nil {
+					t.Fatalf("expected error %v, got none", c.expectError)
+				}
+				if e, a := c.expectError, err.Error(); !strings.Contains(a, e) {
+					t.Fatalf("expected error to contain %v, got %v", e, a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if e, a := c.expectPresignedURLHost, req.URL; !strings.Contains(a, e) {
+				t.Errorf("expected presigned url to contain host %s, got %s", e, a)
+			}
+			for _, label := range c.expectRequestURIQuery {
+				if e, a := label, req.URL; !strings.Contains(a, e) {
+					t.Errorf("expected presigned url to contain %v label in url: %v", label, req.URL)
+				}
+			}
+			if e, a := c.expectMethod, req.Method; !strings.EqualFold(e, a) {
+				t.Errorf("expected presigning Method to be %s, got %s", e, a)
+			}
+		})
+	}
+}
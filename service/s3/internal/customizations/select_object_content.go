@@ -0,0 +1,427 @@
+package customizations
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws/protocol/eventstream"
+	"github.com/aws/aws-sdk-go-v2/aws/protocol/eventstream/eventstreamapi"
+	"github.com/aws/aws-sdk-go-v2/service/internal/s3shared"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// SelectObjectContentEventStreamReader provides the interface for reading events
+// from a stream.
+//
+// The reader's Close method must allow multiple concurrent calls.
+type SelectObjectContentEventStreamReader interface {
+	Events() <-chan types.SelectObjectContentEventStream
+	Close() error
+	Err() error
+}
+
+// SelectObjectContentEventStream provides the event stream handling for the
+// SelectObjectContent operation.
+//
+// For testing and mocking the event stream this type should be initialized via
+// the NewSelectObjectContentEventStream constructor function. Using the
+// functional options to pass in nested mock behavior.
+type SelectObjectContentEventStream struct {
+	// SelectObjectContentEventStreamReader is the EventStream reader for the
+	// SelectObjectContentEventStream events. This value is automatically set by the
+	// SDK when the API call is made Use this member when unit testing your code with
+	// the SDK to mock out the EventStream Reader.
+	//
+	// Must not be nil.
+	Reader SelectObjectContentEventStreamReader
+}
+
+// NewSelectObjectContentEventStream initializes an
+// SelectObjectContentEventStream. This function should only be used for testing
+// and mocking the SelectObjectContentEventStream stream within your application.
+//
+// The Reader member must be set before reading events from the stream.
+func NewSelectObjectContentEventStream(optFns ...func(*SelectObjectContentEventStream)) *SelectObjectContentEventStream {
+	es := &SelectObjectContentEventStream{}
+	for _, fn := range optFns {
+		fn(es)
+	}
+	return es
+}
+
+// Events returns a channel to read events from. The channel is closed when the
+// stream ends, fails, or is closed.
+func (es *SelectObjectContentEventStream) Events() <-chan types.SelectObjectContentEventStream {
+	return es.Reader.Events()
+}
+
+// Close closes the stream. Close must be called when done using the stream API.
+// Not calling Close may result in resource leaks.
+//
+// Will close the underlying EventStream reader, and no more events can be
+// received.
+func (es *SelectObjectContentEventStream) Close() error {
+	return es.Reader.Close()
+}
+
+// Err returns any error that occurred while reading EventStream Events from the
+// service API's response. Returns nil if there were no errors.
+func (es *SelectObjectContentEventStream) Err() error {
+	return es.Reader.Err()
+}
+
+// selectObjectContentEventStreamKey is the metadata, and stack value, key of
+// the SelectObjectContent operation's event stream.
+type selectObjectContentEventStreamKey struct{}
+
+// GetSelectObjectContentEventStream returns the event stream of the
+// SelectObjectContent operation's response from the metadata, or nil if the
+// metadata has no event stream.
+func GetSelectObjectContentEventStream(metadata middleware.Metadata) *SelectObjectContentEventStream {
+	es, _ := metadata.Get(selectObjectContentEventStreamKey{}).(*SelectObjectContentEventStream)
+	return es
+}
+
+// AddSelectObjectContentEventStreamOptions provides the options for the
+// AddSelectObjectContentEventStream middleware setup.
+type AddSelectObjectContentEventStreamOptions struct {
+	// Logs the event messages read from the event stream.
+	LogEventStreamReads bool
+}
+
+// AddSelectObjectContentEventStream adds the middleware reading the events of
+// the SelectObjectContent operation's response from the response body, and
+// setting the event stream in the operation's result metadata.
+//
+// The response body is the event stream, and is closed by the event stream
+// instead of once the operation's response is deserialized.
+func AddSelectObjectContentEventStream(stack *middleware.Stack, options AddSelectObjectContentEventStreamOptions) error {
+	if _, ok := stack.Deserialize.Get(closeResponseBodyID); ok {
+		if _, err := stack.Deserialize.Remove(closeResponseBodyID); err != nil {
+			return err
+		}
+	}
+
+	if err := stack.Initialize.Add(&selectObjectContentEventStreamMetadata{}, middleware.Before); err != nil {
+		return err
+	}
+	return stack.Deserialize.Insert(&selectObjectContentEventStreamMiddleware{
+		logEventStreamReads: options.LogEventStreamReads,
+	}, "OperationDeserializer", middleware.Before)
+}
+
+// closeResponseBodyID is the ID of the middleware closing the response body
+// after the response is deserialized.
+const closeResponseBodyID = "CloseResponseBody"
+
+// selectObjectContentEventStreamMetadata sets the event stream of the
+// operation's response in the operation's result metadata. The metadata of
+// the deserialize step is only retained for each attempt of the operation.
+type selectObjectContentEventStreamMetadata struct{}
+
+// ID returns the middleware identifier.
+func (*selectObjectContentEventStreamMetadata) ID() string {
+	return "OperationEventStreamMetadata"
+}
+
+func (m *selectObjectContentEventStreamMetadata) HandleInitialize(
+	ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler,
+) (
+	out middleware.InitializeOutput, metadata middleware.Metadata, err error,
+) {
+	var es *SelectObjectContentEventStream
+	ctx = middleware.WithStackValue(ctx, selectObjectContentEventStreamKey{}, &es)
+
+	out, metadata, err = next.HandleInitialize(ctx, in)
+	if es != nil {
+		if err != nil {
+			es.Close()
+		} else {
+			metadata.Set(selectObjectContentEventStreamKey{}, es)
+		}
+	}
+	return out, metadata, err
+}
+
+// selectObjectContentEventStreamMiddleware creates the event stream reading
+// the events of the SelectObjectContent operation's response.
+type selectObjectContentEventStreamMiddleware struct {
+	logEventStreamReads bool
+}
+
+// ID returns the middleware identifier.
+func (*selectObjectContentEventStreamMiddleware) ID() string {
+	return "OperationEventStreamDeserializer"
+}
+
+func (m *selectObjectContentEventStreamMiddleware) HandleDeserialize(
+	ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler,
+) (
+	out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+) {
+	out, metadata, err = next.HandleDeserialize(ctx, in)
+	if err != nil {
+		return out, metadata, err
+	}
+
+	response, ok := out.RawResponse.(*smithyhttp.Response)
+	if !ok {
+		return out, metadata, fmt.Errorf("unknown transport type: %T", out.RawResponse)
+	}
+
+	es, ok := middleware.GetStackValue(ctx, selectObjectContentEventStreamKey{}).(**SelectObjectContentEventStream)
+	if !ok {
+		return out, metadata, fmt.Errorf("event stream metadata middleware not found")
+	}
+
+	logger := middleware.GetLogger(ctx)
+	*es = NewSelectObjectContentEventStream(func(stream *SelectObjectContentEventStream) {
+		stream.Reader = newSelectObjectContentEventStreamReader(
+			response.Body,
+			eventstream.NewDecoder(func(options *eventstream.DecoderOptions) {
+				options.Logger = logger
+				options.LogMessages = m.logEventStreamReads
+			}),
+		)
+	})
+
+	return out, metadata, nil
+}
+
+// selectObjectContentEventStreamReader reads the events of the event stream
+// from the response body.
+type selectObjectContentEventStreamReader struct {
+	stream      chan types.SelectObjectContentEventStream
+	decoder     *eventstream.Decoder
+	eventStream io.ReadCloser
+	err         *onceErr
+	payloadBuf  []byte
+	done        chan struct{}
+	closeOnce   sync.Once
+}
+
+func newSelectObjectContentEventStreamReader(readCloser io.ReadCloser, decoder *eventstream.Decoder) *selectObjectContentEventStreamReader {
+	r := &selectObjectContentEventStreamReader{
+		stream:      make(chan types.SelectObjectContentEventStream),
+		decoder:     decoder,
+		eventStream: readCloser,
+		err:         &onceErr{},
+		done:        make(chan struct{}),
+		payloadBuf:  make([]byte, 10*1024),
+	}
+
+	go r.readEventStream()
+
+	return r
+}
+
+func (r *selectObjectContentEventStreamReader) Events() <-chan types.SelectObjectContentEventStream {
+	return r.stream
+}
+
+func (r *selectObjectContentEventStreamReader) readEventStream() {
+	defer r.Close()
+	defer close(r.stream)
+
+	for {
+		r.payloadBuf = r.payloadBuf[0:0]
+		msg, err := r.decoder.Decode(r.eventStream, r.payloadBuf)
+		if err != nil {
+			if err == io.EOF {
+				return
+			}
+			select {
+			case <-r.done:
+				return
+			default:
+				r.err.SetError(err)
+				return
+			}
+		}
+
+		event, err := deserializeSelectObjectContentMessage(&msg)
+		if err != nil {
+			r.err.SetError(err)
+			return
+		}
+
+		select {
+		case r.stream <- event:
+		case <-r.done:
+			return
+		}
+	}
+}
+
+func (r *selectObjectContentEventStreamReader) Close() error {
+	r.closeOnce.Do(r.safeClose)
+	return r.Err()
+}
+
+func (r *selectObjectContentEventStreamReader) safeClose() {
+	close(r.done)
+	r.eventStream.Close()
+}
+
+func (r *selectObjectContentEventStreamReader) Err() error {
+	return r.err.Err()
+}
+
+// deserializeSelectObjectContentMessage returns the event of the event stream
+// message, or the error of an exception or error message.
+func deserializeSelectObjectContentMessage(msg *eventstream.Message) (types.SelectObjectContentEventStream, error) {
+	messageType := msg.Headers.Get(eventstreamapi.MessageTypeHeader)
+	if messageType == nil {
+		return nil, fmt.Errorf("%s event header not present", eventstreamapi.MessageTypeHeader)
+	}
+
+	switch messageType.String() {
+	case eventstreamapi.EventMessageType:
+		return deserializeSelectObjectContentEvent(msg)
+
+	case eventstreamapi.ExceptionMessageType:
+		return nil, deserializeSelectObjectContentException(msg)
+
+	case eventstreamapi.ErrorMessageType:
+		errorCode := "UnknownError"
+		errorMessage := errorCode
+		if header := msg.Headers.Get(eventstreamapi.ErrorCodeHeader); header != nil {
+			errorCode = header.String()
+		}
+		if header := msg.Headers.Get(eventstreamapi.ErrorMessageHeader); header != nil {
+			errorMessage = header.String()
+		}
+		return nil, &smithy.GenericAPIError{
+			Code:    errorCode,
+			Message: errorMessage,
+		}
+
+	default:
+		return nil, &eventstreamapi.UnknownMessageTypeError{
+			Type:    messageType.String(),
+			Message: msg.Clone(),
+		}
+	}
+}
+
+// deserializeSelectObjectContentEvent returns the event of the event message.
+// Events of an unknown type are returned as an UnknownUnionMember with the
+// encoded message.
+func deserializeSelectObjectContentEvent(msg *eventstream.Message) (types.SelectObjectContentEventStream, error) {
+	eventType := msg.Headers.Get(eventstreamapi.EventTypeHeader)
+	if eventType == nil {
+		return nil, fmt.Errorf("%s event header not present", eventstreamapi.EventTypeHeader)
+	}
+
+	switch {
+	case strings.EqualFold("Cont", eventType.String()):
+		return &types.SelectObjectContentEventStreamMemberCont{}, nil
+
+	case strings.EqualFold("End", eventType.String()):
+		return &types.SelectObjectContentEventStreamMemberEnd{}, nil
+
+	case strings.EqualFold("Progress", eventType.String()):
+		v := &types.SelectObjectContentEventStreamMemberProgress{}
+		if err := unmarshalEventPayload(msg, &v.Value.Details); err != nil {
+			return nil, err
+		}
+		return v, nil
+
+	case strings.EqualFold("Records", eventType.String()):
+		v := &types.SelectObjectContentEventStreamMemberRecords{}
+		if msg.Payload != nil {
+			v.Value.Payload = append([]byte{}, msg.Payload...)
+		}
+		return v, nil
+
+	case strings.EqualFold("Stats", eventType.String()):
+		v := &types.SelectObjectContentEventStreamMemberStats{}
+		if err := unmarshalEventPayload(msg, &v.Value.Details); err != nil {
+			return nil, err
+		}
+		return v, nil
+
+	default:
+		var buf bytes.Buffer
+		if err := eventstream.NewEncoder().Encode(&buf, *msg); err != nil {
+			return nil, err
+		}
+		return &types.UnknownUnionMember{
+			Tag:   eventType.String(),
+			Value: buf.Bytes(),
+		}, nil
+	}
+}
+
+// unmarshalEventPayload unmarshals the XML payload of the event message into
+// the value. The value is left unmodified if the message has no payload.
+func unmarshalEventPayload(msg *eventstream.Message, v interface{}) error {
+	if len(msg.Payload) == 0 {
+		return nil
+	}
+	if err := xml.Unmarshal(msg.Payload, v); err != nil {
+		return &smithy.DeserializationError{
+			Err:      fmt.Errorf("failed to decode event payload, %w", err),
+			Snapshot: msg.Payload,
+		}
+	}
+	return nil
+}
+
+// deserializeSelectObjectContentException returns the error of the exception
+// message.
+func deserializeSelectObjectContentException(msg *eventstream.Message) error {
+	exceptionType := msg.Headers.Get(eventstreamapi.ExceptionTypeHeader)
+	if exceptionType == nil {
+		return fmt.Errorf("%s event header not present", eventstreamapi.ExceptionTypeHeader)
+	}
+
+	errorComponents, err := s3shared.GetErrorResponseComponents(bytes.NewReader(msg.Payload),
+		s3shared.ErrorResponseDeserializerOptions{})
+	if err != nil {
+		return err
+	}
+	errorCode := "UnknownError"
+	errorMessage := errorCode
+	if v := exceptionType.String(); len(v) > 0 {
+		errorCode = v
+	} else if v := errorComponents.Code; len(v) > 0 {
+		errorCode = v
+	}
+	if v := errorComponents.Message; len(v) > 0 {
+		errorMessage = v
+	}
+	return &smithy.GenericAPIError{
+		Code:    errorCode,
+		Message: errorMessage,
+	}
+}
+
+// onceErr records the first error that occurred reading the event stream.
+type onceErr struct {
+	mu  sync.RWMutex
+	err error
+}
+
+func (e *onceErr) Err() error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.err
+}
+
+func (e *onceErr) SetError(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.err != nil {
+		return
+	}
+	e.err = err
+}
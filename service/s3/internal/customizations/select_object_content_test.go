@@ -0,0 +1,289 @@
+package customizations_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/protocol/eventstream"
+	"github.com/aws/aws-sdk-go-v2/aws/protocol/eventstream/eventstreamapi"
+	"github.com/aws/aws-sdk-go-v2/internal/awstesting/unit"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+func eventMessage(eventType string, payload []byte) eventstream.Message {
+	return eventstream.Message{
+		Headers: eventstream.Headers{
+			{Name: eventstreamapi.MessageTypeHeader, Value: eventstream.StringValue(eventstreamapi.EventMessageType)},
+			{Name: eventstreamapi.EventTypeHeader, Value: eventstream.StringValue(eventType)},
+		},
+		Payload: payload,
+	}
+}
+
+func encodeMessages(t *testing.T, msgs ...eventstream.Message) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	encoder := eventstream.NewEncoder()
+	for _, msg := range msgs {
+		if err := encoder.Encode(&buf, msg); err != nil {
+			t.Fatalf("expect no encode error, got %v", err)
+		}
+	}
+	return buf.Bytes()
+}
+
+func newSelectTestClient(t *testing.T, handler http.HandlerFunc) (*s3.Client, func()) {
+	server := httptest.NewServer(handler)
+
+	client := s3.New(s3.Options{
+		Credentials: unit.StubCredentialsProvider{},
+		Retryer:     aws.NopRetryer{},
+		Region:      "mock-region",
+		EndpointResolver: EndpointResolverFunc(func(region string, options s3.EndpointResolverOptions) (e aws.Endpoint, err error) {
+			e.URL = server.URL
+			e.SigningRegion = "us-west-2"
+			return e, err
+		}),
+		UsePathStyle: true,
+	})
+
+	return client, server.Close
+}
+
+func selectInput() *s3.SelectObjectContentInput {
+	return &s3.SelectObjectContentInput{
+		Bucket:         aws.String("bucket"),
+		Key:            aws.String("key.csv"),
+		Expression:     aws.String("SELECT * FROM S3Object"),
+		ExpressionType: types.ExpressionTypeSql,
+		InputSerialization: &types.InputSerialization{
+			CSV: &types.CSVInput{},
+		},
+		OutputSerialization: &types.OutputSerialization{
+			CSV: &types.CSVOutput{},
+		},
+		RequestProgress: &types.RequestProgress{Enabled: true},
+	}
+}
+
+func TestSelectObjectContent_Events(t *testing.T) {
+	stream := encodeMessages(t,
+		eventMessage("Records", []byte("a,b,c\n")),
+		eventMessage("Records", []byte("d,e,f\n")),
+		eventMessage("Cont", nil),
+		eventMessage("Progress", []byte(`<Progress><BytesScanned>10</BytesScanned><BytesProcessed>12</BytesProcessed><BytesReturned>6</BytesReturned></Progress>`)),
+		eventMessage("Stats", []byte(`<Stats><BytesScanned>20</BytesScanned><BytesProcessed>24</BytesProcessed><BytesReturned>12</BytesReturned></Stats>`)),
+		eventMessage("End", nil),
+	)
+
+	client, cleanup := newSelectTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if e, a := "POST", r.Method; e != a {
+			t.Errorf("expect %v method, got %v", e, a)
+		}
+		if e, a := "/bucket/key.csv", r.URL.Path; e != a {
+			t.Errorf("expect %v path, got %v", e, a)
+		}
+		if _, ok := r.URL.Query()["select"]; !ok {
+			t.Errorf("expect select query parameter, got %v", r.URL.RawQuery)
+		}
+		if e, a := "2", r.URL.Query().Get("select-type"); e != a {
+			t.Errorf("expect %v select-type, got %v", e, a)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		for _, s := range []string{
+			"<SelectObjectContentRequest",
+			"<Expression>SELECT * FROM S3Object</Expression>",
+			"<ExpressionType>SQL</ExpressionType>",
+			"<RequestProgress><Enabled>true</Enabled></RequestProgress>",
+		} {
+			if !strings.Contains(string(body), s) {
+				t.Errorf("expect request body to contain %v, got %v", s, string(body))
+			}
+		}
+
+		w.WriteHeader(200)
+		w.Write(stream)
+	})
+	defer cleanup()
+
+	resp, err := client.SelectObjectContent(context.Background(), selectInput())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	es := resp.GetStream()
+	defer es.Close()
+
+	var events []types.SelectObjectContentEventStream
+	for event := range es.Events() {
+		events = append(events, event)
+	}
+	if err := es.Err(); err != nil {
+		t.Fatalf("expect no stream error, got %v", err)
+	}
+
+	expect := []types.SelectObjectContentEventStream{
+		&types.SelectObjectContentEventStreamMemberRecords{Value: types.RecordsEvent{Payload: []byte("a,b,c\n")}},
+		&types.SelectObjectContentEventStreamMemberRecords{Value: types.RecordsEvent{Payload: []byte("d,e,f\n")}},
+		&types.SelectObjectContentEventStreamMemberCont{},
+		&types.SelectObjectContentEventStreamMemberProgress{Value: types.ProgressEvent{
+			Details: &types.Progress{BytesScanned: 10, BytesProcessed: 12, BytesReturned: 6},
+		}},
+		&types.SelectObjectContentEventStreamMemberStats{Value: types.StatsEvent{
+			Details: &types.Stats{BytesScanned: 20, BytesProcessed: 24, BytesReturned: 12},
+		}},
+		&types.SelectObjectContentEventStreamMemberEnd{},
+	}
+	if e, a := expect, events; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect events\n%#v\ngot\n%#v", e, a)
+	}
+}
+
+func TestSelectObjectContent_StreamErrors(t *testing.T) {
+	corrupt := encodeMessages(t, eventMessage("Records", []byte("a,b,c\n")))
+	corrupt[len(corrupt)-6] ^= 0xff
+
+	cases := map[string]struct {
+		Stream       []byte
+		ExpectEvents int
+		ExpectErr    func(*testing.T, error)
+	}{
+		"error message": {
+			Stream: encodeMessages(t,
+				eventMessage("Records", []byte("a,b,c\n")),
+				eventstream.Message{
+					Headers: eventstream.Headers{
+						{Name: eventstreamapi.MessageTypeHeader, Value: eventstream.StringValue(eventstreamapi.ErrorMessageType)},
+						{Name: eventstreamapi.ErrorCodeHeader, Value: eventstream.StringValue("InternalError")},
+						{Name: eventstreamapi.ErrorMessageHeader, Value: eventstream.StringValue("something failed")},
+					},
+				},
+			),
+			ExpectEvents: 1,
+			ExpectErr: func(t *testing.T, err error) {
+				var apiErr smithy.APIError
+				if !errors.As(err, &apiErr) {
+					t.Fatalf("expect API error, got %T, %v", err, err)
+				}
+				if e, a := "InternalError", apiErr.ErrorCode(); e != a {
+					t.Errorf("expect %v code, got %v", e, a)
+				}
+				if e, a := "something failed", apiErr.ErrorMessage(); e != a {
+					t.Errorf("expect %v message, got %v", e, a)
+				}
+			},
+		},
+		"checksum mismatch": {
+			Stream: corrupt,
+			ExpectErr: func(t *testing.T, err error) {
+				var csErr eventstream.ChecksumError
+				if !errors.As(err, &csErr) {
+					t.Fatalf("expect checksum error, got %T, %v", err, err)
+				}
+			},
+		},
+		"truncated stream": {
+			Stream: corrupt[:len(corrupt)-3],
+			ExpectErr: func(t *testing.T, err error) {
+				if e, a := "unexpected EOF", err.Error(); !strings.Contains(a, e) {
+					t.Errorf("expect %v error, got %v", e, a)
+				}
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			client, cleanup := newSelectTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(200)
+				w.Write(c.Stream)
+			})
+			defer cleanup()
+
+			resp, err := client.SelectObjectContent(context.Background(), selectInput())
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			es := resp.GetStream()
+			defer es.Close()
+
+			var count int
+			for range es.Events() {
+				count++
+			}
+			if e, a := c.ExpectEvents, count; e != a {
+				t.Errorf("expect %v events, got %v", e, a)
+			}
+
+			err = es.Err()
+			if err == nil {
+				t.Fatalf("expect stream error, got none")
+			}
+			c.ExpectErr(t, err)
+		})
+	}
+}
+
+func TestSelectObjectContent_Close(t *testing.T) {
+	var msgs []eventstream.Message
+	for i := 0; i < 100; i++ {
+		msgs = append(msgs, eventMessage("Records", []byte("a,b,c\n")))
+	}
+	stream := encodeMessages(t, msgs...)
+
+	client, cleanup := newSelectTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		w.Write(stream)
+	})
+	defer cleanup()
+
+	resp, err := client.SelectObjectContent(context.Background(), selectInput())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	es := resp.GetStream()
+	<-es.Events()
+	if err := es.Close(); err != nil {
+		t.Fatalf("expect no close error, got %v", err)
+	}
+
+	// The events channel must be closed once the stream is closed.
+	for range es.Events() {
+	}
+	if err := es.Err(); err != nil {
+		t.Errorf("expect no stream error after close, got %v", err)
+	}
+}
+
+func TestSelectObjectContent_ResponseError(t *testing.T) {
+	client, cleanup := newSelectTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+		w.Write([]byte(`<Error><Code>InvalidExpressionType</Code><Message>bad expression</Message></Error>`))
+	})
+	defer cleanup()
+
+	_, err := client.SelectObjectContent(context.Background(), selectInput())
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expect API error, got %T, %v", err, err)
+	}
+	if e, a := "InvalidExpressionType", apiErr.ErrorCode(); e != a {
+		t.Errorf("expect %v code, got %v", e, a)
+	}
+}
@@ -0,0 +1,246 @@
+package customizations_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/internal/awstesting/unit"
+	"github.com/aws/smithy-go/middleware"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// decodeAWSChunked decodes the aws-chunked encoded body, verifying the
+// signature of each chunk, and the trailing headers.
+func decodeAWSChunked(r *http.Request, body io.Reader) ([]byte, map[string]string, error) {
+	creds, _ := unit.StubCredentialsProvider{}.Retrieve(context.Background())
+
+	signingTime, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return nil, nil, err
+	}
+	authorization := r.Header.Get("Authorization")
+	seedSignature := authorization[strings.LastIndex(authorization, "Signature=")+len("Signature="):]
+	signer := v4.NewStreamSigner(creds, "s3", "us-west-2", signingTime, seedSignature)
+
+	var payload bytes.Buffer
+	reader := bufio.NewReader(body)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, nil, err
+		}
+		parts := strings.SplitN(strings.TrimSuffix(line, "\r\n"), ";chunk-signature=", 2)
+		if len(parts) != 2 {
+			return nil, nil, fmt.Errorf("invalid chunk header, %q", line)
+		}
+		size, err := strconv.ParseInt(parts[0], 16, 64)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		chunk := make([]byte, size)
+		if _, err := io.ReadFull(reader, chunk); err != nil {
+			return nil, nil, err
+		}
+		if e, a := signer.GetChunkSignature(chunk), parts[1]; e != a {
+			return nil, nil, fmt.Errorf("expect %v chunk signature, got %v", e, a)
+		}
+		payload.Write(chunk)
+
+		if size == 0 {
+			break
+		}
+		if crlf, err := reader.ReadString('\n'); err != nil || crlf != "\r\n" {
+			return nil, nil, fmt.Errorf("expect chunk to end with CRLF, %q, %v", crlf, err)
+		}
+	}
+
+	trailers := map[string]string{}
+	var trailer bytes.Buffer
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, nil, err
+		}
+		line = strings.TrimSuffix(line, "\r\n")
+		if len(line) == 0 {
+			break
+		}
+		kv := strings.SplitN(line, ":", 2)
+		if kv[0] == "x-amz-trailer-signature" {
+			if e, a := signer.GetTrailerSignature(trailer.Bytes()), kv[1]; e != a {
+				return nil, nil, fmt.Errorf("expect %v trailer signature, got %v", e, a)
+			}
+			continue
+		}
+		trailers[kv[0]] = kv[1]
+		trailer.WriteString(line + "\n")
+	}
+
+	return payload.Bytes(), trailers, nil
+}
+
+func TestPutObject_StreamingPayload(t *testing.T) {
+	cases := map[string]struct {
+		contentLength         int64
+		options               func(*v4.StreamingPayloadOptions)
+		expectContentEncoding string
+		expectPayloadHash     string
+		expectTrailers        map[string]string
+		expectErr             string
+	}{
+		"chunked": {
+			contentLength:         100 * 1024,
+			expectContentEncoding: "aws-chunked",
+			expectPayloadHash:     v4.StreamingPayload,
+			expectTrailers:        map[string]string{},
+		},
+		"trailing checksum": {
+			contentLength: 20 * 1024,
+			options: func(o *v4.StreamingPayloadOptions) {
+				o.ChunkSize = 8 * 1024
+				o.TrailingChecksumHeader = "x-amz-checksum-sha256"
+				o.TrailingChecksum = sha256.New
+			},
+			expectContentEncoding: "aws-chunked",
+			expectPayloadHash:     v4.StreamingPayloadTrailer,
+			expectTrailers: map[string]string{
+				"x-amz-checksum-sha256": "WIl8FBue/LLY0nBmBY1M+EVU9H3mntKhZaDweVzYGnM=",
+			},
+		},
+		"empty payload": {
+			contentLength:         0,
+			expectContentEncoding: "aws-chunked",
+			expectPayloadHash:     v4.StreamingPayload,
+			expectTrailers:        map[string]string{},
+		},
+		"unknown length": {
+			contentLength: -1,
+			expectErr:     "requires the length of the payload to be known",
+		},
+		"chunk size too small": {
+			contentLength: 1024,
+			options: func(o *v4.StreamingPayloadOptions) {
+				o.ChunkSize = 1024
+			},
+			expectErr: "chunk size must be at least",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			var payload []byte
+			if c.contentLength > 0 {
+				payload = bytes.Repeat([]byte("0123456789"), int(c.contentLength/10+1))[:c.contentLength]
+			}
+
+			server := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					if e, a := c.expectContentEncoding, r.Header.Get("Content-Encoding"); e != a {
+						t.Errorf("expect %v content encoding, got %v", e, a)
+					}
+					if e, a := c.expectPayloadHash, r.Header.Get("X-Amz-Content-Sha256"); e != a {
+						t.Errorf("expect %v payload hash, got %v", e, a)
+					}
+					if e, a := strconv.FormatInt(c.contentLength, 10), r.Header.Get("X-Amz-Decoded-Content-Length"); e != a {
+						t.Errorf("expect %v decoded content length, got %v", e, a)
+					}
+
+					body, err := ioutil.ReadAll(r.Body)
+					if err != nil {
+						t.Errorf("expect no error reading body, got %v", err)
+					}
+					if e, a := r.ContentLength, int64(len(body)); e != a {
+						t.Errorf("expect %v content length, got %v", e, a)
+					}
+
+					decoded, trailers, err := decodeAWSChunked(r, bytes.NewReader(body))
+					if err != nil {
+						t.Errorf("expect valid aws-chunked payload, got %v", err)
+					}
+					if !bytes.Equal(payload, decoded) {
+						t.Errorf("expect decoded payload to match")
+					}
+					if e, a := len(c.expectTrailers), len(trailers); e != a {
+						t.Errorf("expect %v trailers, got %v", e, a)
+					}
+					for k, v := range c.expectTrailers {
+						if e, a := v, trailers[k]; e != a {
+							t.Errorf("expect %v %v trailer, got %v", e, k, a)
+						}
+					}
+				}))
+			defer server.Close()
+
+			client := s3.New(s3.Options{
+				Credentials: unit.StubCredentialsProvider{},
+				Retryer:     aws.NopRetryer{},
+				Region:      "mock-region",
+				EndpointResolver: EndpointResolverFunc(func(region string, options s3.EndpointResolverOptions) (e aws.Endpoint, err error) {
+					e.URL = server.URL
+					e.SigningRegion = "us-west-2"
+					return e, err
+				}),
+				UsePathStyle: true,
+			})
+
+			// Upload the payload from a pipe, which is not seekable.
+			pr, pw := io.Pipe()
+			go func() {
+				pw.Write(payload)
+				pw.Close()
+			}()
+			defer pr.Close()
+
+			var optFns []func(*v4.StreamingPayloadOptions)
+			if c.options != nil {
+				optFns = append(optFns, c.options)
+			}
+
+			input := &s3.PutObjectInput{
+				Bucket: aws.String("bucket"),
+				Key:    aws.String("key"),
+				Body:   pr,
+			}
+			if c.contentLength >= 0 {
+				input.ContentLength = c.contentLength
+			}
+			// A zero length payload cannot be provided via the input's
+			// ContentLength member, use an empty body instead.
+			if c.contentLength == 0 {
+				input.Body = bytes.NewReader(nil)
+			}
+
+			_, err := client.PutObject(context.Background(), input, s3.WithAPIOptions(
+				func(stack *middleware.Stack) error {
+					return v4.AddStreamingPayloadMiddleware(stack, optFns...)
+				}))
+			if len(c.expectErr) != 0 {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				if e, a := c.expectErr, err.Error(); !strings.Contains(a, e) {
+					t.Errorf("expect error to contain %v, got %v", e, a)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,404 @@
+package s3
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/internal/sdk"
+	"github.com/aws/smithy-go/encoding/httpbinding"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+const (
+	// defaultPresignPostExpires is the duration a POST policy is valid for if
+	// no expiration was provided.
+	defaultPresignPostExpires = 900 * time.Second
+
+	postPolicyAlgorithm = "AWS4-HMAC-SHA256"
+
+	// filenameVariable is replaced by Amazon S3 with the name of the file
+	// uploaded by the user.
+	filenameVariable = "${filename}"
+)
+
+// PostPolicySignerV4 represents the signer interface used by the presign client
+// to sign POST policy documents.
+type PostPolicySignerV4 interface {
+	SignString(
+		ctx context.Context, credentials aws.Credentials, stringToSign string,
+		service string, region string, signingTime time.Time,
+		optFns ...func(*v4.SignerOptions),
+	) (string, error)
+}
+
+// PresignPostOptions represents the options of the PresignPostObject API.
+type PresignPostOptions struct {
+
+	// ClientOptions are list of functional options to mutate client options used by
+	// the presign client.
+	ClientOptions []func(*Options)
+
+	// PostPresigner is the signer used to sign the POST policy document. Defaults
+	// to the presign client's Presigner if it is able to sign POST policies,
+	// otherwise the SigV4 signer.
+	PostPresigner PostPolicySignerV4
+
+	// Expires sets the duration the POST policy is valid for. If not set or set to
+	// zero, the policy would default to expire after 900 seconds.
+	Expires time.Duration
+
+	// Fields are additional form fields the upload must be made with, such as
+	// "Content-Type", "acl", "success_action_status", or "x-amz-meta-*" fields.
+	// Each field is included in the returned form values, and added to the POST
+	// policy as an exact match condition.
+	Fields map[string]string
+
+	// Conditions are additional conditions of the POST policy, such as the
+	// allowed content length range of the upload, or a prefix a form field's
+	// value must start with.
+	Conditions []PostPolicyCondition
+}
+
+// PostPolicyCondition is a condition of a POST policy document that the form
+// fields of an upload must satisfy.
+//
+// Use the NewPostPolicy* functions to create conditions.
+type PostPolicyCondition struct {
+	value interface{}
+}
+
+// MarshalJSON returns the JSON encoding of the condition within the POST
+// policy document.
+func (c PostPolicyCondition) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.value)
+}
+
+// NewPostPolicyExactMatch returns a condition that the value of the form field
+// must exactly match value.
+func NewPostPolicyExactMatch(field, value string) PostPolicyCondition {
+	return PostPolicyCondition{value: map[string]string{field: value}}
+}
+
+// NewPostPolicyStartsWith returns a condition that the value of the form field
+// must start with prefix. An empty prefix allows any value for the field.
+func NewPostPolicyStartsWith(field, prefix string) PostPolicyCondition {
+	return PostPolicyCondition{value: []interface{}{"starts-with", "$" + field, prefix}}
+}
+
+// NewPostPolicyContentLengthRange returns a condition that the size of the
+// uploaded content must be between min and max bytes, inclusive.
+func NewPostPolicyContentLengthRange(min, max int64) PostPolicyCondition {
+	return PostPolicyCondition{value: []interface{}{"content-length-range", min, max}}
+}
+
+// PresignedPostRequest represents a presigned browser-based POST upload. The
+// upload is made by sending a multipart/form-data POST request to the URL
+// containing each of the form Values, followed by the "file" field.
+type PresignedPostRequest struct {
+	// URL is the endpoint the form must be posted to.
+	URL string
+
+	// Values are the form fields, including the signed POST policy, that must be
+	// included in the upload's form.
+	Values map[string]string
+}
+
+// PresignPostObject generates a presigned POST policy allowing a browser-based
+// form upload of an object to the bucket and key of the input. Only the Bucket
+// and Key members of the input are used. If the Key ends with "${filename}" any
+// key starting with the preceding prefix is allowed, and Amazon S3 replaces the
+// variable with the name of the uploaded file.
+//
+// Additional form fields and policy conditions can be provided with the
+// PresignPostOptions.
+//
+//	req, err := presignClient.PresignPostObject(ctx, &s3.PutObjectInput{
+//	    Bucket: aws.String("mybucket"),
+//	    Key:    aws.String("uploads/${filename}"),
+//	}, func(o *s3.PresignPostOptions) {
+//	    o.Fields = map[string]string{"success_action_status": "201"}
+//	    o.Conditions = []s3.PostPolicyCondition{
+//	        s3.NewPostPolicyContentLengthRange(1, 10*1024*1024),
+//	        s3.NewPostPolicyStartsWith("Content-Type", "image/"),
+//	    }
+//	})
+func (c *PresignClient) PresignPostObject(ctx context.Context, params *PutObjectInput, optFns ...func(*PresignPostOptions)) (*PresignedPostRequest, error) {
+	if params == nil {
+		params = &PutObjectInput{}
+	}
+	options := PresignPostOptions{
+		Expires: c.options.Expires,
+	}
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	if options.PostPresigner == nil {
+		if signer, ok := c.options.Presigner.(PostPolicySignerV4); ok {
+			options.PostPresigner = signer
+		} else {
+			options.PostPresigner = newDefaultV4Signer(c.client.options)
+		}
+	}
+
+	clientOptFns := make([]func(*Options), 0, len(c.options.ClientOptions)+len(options.ClientOptions)+1)
+	clientOptFns = append(clientOptFns, c.options.ClientOptions...)
+	clientOptFns = append(clientOptFns, options.ClientOptions...)
+	clientOptFns = append(clientOptFns, withNopHTTPClientAPIOption)
+
+	converter := presignPostConverter{
+		options: options,
+		bucket:  aws.ToString(params.Bucket),
+		key:     aws.ToString(params.Key),
+	}
+	result, _, err := c.client.invokeOperation(ctx, "PutObject", params, clientOptFns,
+		addOperationPutObjectMiddlewares,
+		converter.convertToPresignPostMiddleware,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*PresignedPostRequest)
+	return out, nil
+}
+
+type presignPostConverter struct {
+	options PresignPostOptions
+	bucket  string
+	key     string
+}
+
+func (c presignPostConverter) convertToPresignPostMiddleware(stack *middleware.Stack, options Options) (err error) {
+	if c.options.Expires < 0 {
+		return fmt.Errorf("presign POST duration must be 0 or greater, %v", c.options.Expires)
+	}
+
+	stack.Finalize.Clear()
+	stack.Deserialize.Clear()
+	stack.Build.Remove((*awsmiddleware.ClientRequestID)(nil).ID())
+
+	// The form is posted to the bucket's endpoint, not the object's. The
+	// serialized object path is replaced with the bucket's path, before the
+	// bucket is moved to the endpoint's host.
+	err = stack.Serialize.Insert(&presignPostEndpointPath{}, "OperationSerializer", middleware.Before)
+	if err != nil {
+		return err
+	}
+	err = stack.Serialize.Insert(&presignPostBucketPath{bucket: c.bucket}, "OperationSerializer", middleware.After)
+	if err != nil {
+		return err
+	}
+
+	return stack.Finalize.Add(&presignPostRequestMiddleware{
+		credentialsProvider: options.Credentials,
+		signer:              c.options.PostPresigner,
+		logSigning:          options.ClientLogMode.IsSigning(),
+		expires:             c.options.Expires,
+		fields:              c.options.Fields,
+		conditions:          c.options.Conditions,
+		bucket:              c.bucket,
+		key:                 c.key,
+	}, middleware.After)
+}
+
+// presignPostRequestMiddleware builds and signs the POST policy for the
+// serialized PutObject request, instead of sending the request.
+type presignPostRequestMiddleware struct {
+	credentialsProvider aws.CredentialsProvider
+	signer              PostPolicySignerV4
+	logSigning          bool
+
+	expires    time.Duration
+	fields     map[string]string
+	conditions []PostPolicyCondition
+
+	bucket string
+	key    string
+}
+
+func (*presignPostRequestMiddleware) ID() string { return "PresignPostRequest" }
+
+func (m *presignPostRequestMiddleware) HandleFinalize(
+	ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler,
+) (
+	out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+) {
+	req, ok := in.Request.(*smithyhttp.Request)
+	if !ok {
+		return out, metadata, &v4.SigningError{
+			Err: fmt.Errorf("unexpected request middleware type %T", in.Request),
+		}
+	}
+	if m.credentialsProvider == nil {
+		return out, metadata, &v4.SigningError{
+			Err: fmt.Errorf("credentials provider is required to presign POST policy"),
+		}
+	}
+
+	credentials, err := m.credentialsProvider.Retrieve(ctx)
+	if err != nil {
+		return out, metadata, &v4.SigningError{
+			Err: fmt.Errorf("failed to retrieve credentials: %w", err),
+		}
+	}
+
+	signingName := awsmiddleware.GetSigningName(ctx)
+	signingRegion := awsmiddleware.GetSigningRegion(ctx)
+	signingTime := sdk.NowTime().UTC()
+
+	expires := m.expires
+	if expires == 0 {
+		expires = defaultPresignPostExpires
+	}
+
+	values := map[string]string{}
+	conditions := []PostPolicyCondition{
+		NewPostPolicyExactMatch("bucket", m.bucket),
+	}
+
+	values["key"] = m.key
+	if strings.HasSuffix(m.key, filenameVariable) {
+		conditions = append(conditions,
+			NewPostPolicyStartsWith("key", strings.TrimSuffix(m.key, filenameVariable)))
+	} else {
+		conditions = append(conditions, NewPostPolicyExactMatch("key", m.key))
+	}
+
+	fieldNames := make([]string, 0, len(m.fields))
+	for name := range m.fields {
+		fieldNames = append(fieldNames, name)
+	}
+	sort.Strings(fieldNames)
+	for _, name := range fieldNames {
+		values[name] = m.fields[name]
+		conditions = append(conditions, NewPostPolicyExactMatch(name, m.fields[name]))
+	}
+
+	conditions = append(conditions, m.conditions...)
+
+	credentialScope := strings.Join([]string{
+		credentials.AccessKeyID,
+		signingTime.Format("20060102"),
+		signingRegion,
+		signingName,
+		"aws4_request",
+	}, "/")
+	amzDate := signingTime.Format("20060102T150405Z")
+
+	values["x-amz-algorithm"] = postPolicyAlgorithm
+	values["x-amz-credential"] = credentialScope
+	values["x-amz-date"] = amzDate
+	conditions = append(conditions,
+		NewPostPolicyExactMatch("x-amz-algorithm", postPolicyAlgorithm),
+		NewPostPolicyExactMatch("x-amz-credential", credentialScope),
+		NewPostPolicyExactMatch("x-amz-date", amzDate),
+	)
+	if len(credentials.SessionToken) != 0 {
+		values["x-amz-security-token"] = credentials.SessionToken
+		conditions = append(conditions,
+			NewPostPolicyExactMatch("x-amz-security-token", credentials.SessionToken))
+	}
+
+	document, err := json.Marshal(postPolicyDocument{
+		Expiration: signingTime.Add(expires).Format("2006-01-02T15:04:05.000Z"),
+		Conditions: conditions,
+	})
+	if err != nil {
+		return out, metadata, &v4.SigningError{
+			Err: fmt.Errorf("failed to encode POST policy, %w", err),
+		}
+	}
+	policy := base64.StdEncoding.EncodeToString(document)
+
+	signature, err := m.signer.SignString(ctx, credentials, policy, signingName, signingRegion, signingTime,
+		func(o *v4.SignerOptions) {
+			o.Logger = middleware.GetLogger(ctx)
+			o.LogSigning = m.logSigning
+		})
+	if err != nil {
+		return out, metadata, &v4.SigningError{
+			Err: fmt.Errorf("failed to sign POST policy, %w", err),
+		}
+	}
+	values["policy"] = policy
+	values["x-amz-signature"] = signature
+
+	u := *req.URL
+	u.RawQuery = ""
+
+	out.Result = &PresignedPostRequest{
+		URL:    u.String(),
+		Values: values,
+	}
+
+	return out, metadata, nil
+}
+
+// presignPostEndpointPathKey is the stack value key of the resolved endpoint's
+// URL path.
+type presignPostEndpointPathKey struct{}
+
+// presignPostEndpointPath records the URL path of the resolved endpoint,
+// before the PutObject operation's path is serialized.
+type presignPostEndpointPath struct{}
+
+func (*presignPostEndpointPath) ID() string { return "PresignPostEndpointPath" }
+
+func (m *presignPostEndpointPath) HandleSerialize(
+	ctx context.Context, in middleware.SerializeInput, next middleware.SerializeHandler,
+) (
+	out middleware.SerializeOutput, metadata middleware.Metadata, err error,
+) {
+	req, ok := in.Request.(*smithyhttp.Request)
+	if !ok {
+		return out, metadata, fmt.Errorf("unknown request type %T", in.Request)
+	}
+
+	endpointURL := url.URL{Path: req.URL.Path, RawPath: req.URL.RawPath}
+	ctx = middleware.WithStackValue(ctx, presignPostEndpointPathKey{}, endpointURL)
+	return next.HandleSerialize(ctx, in)
+}
+
+// presignPostBucketPath replaces the serialized URL path of the PutObject
+// operation, including the object's key, with the path of the bucket.
+type presignPostBucketPath struct {
+	bucket string
+}
+
+func (*presignPostBucketPath) ID() string { return "PresignPostBucketPath" }
+
+func (m *presignPostBucketPath) HandleSerialize(
+	ctx context.Context, in middleware.SerializeInput, next middleware.SerializeHandler,
+) (
+	out middleware.SerializeOutput, metadata middleware.Metadata, err error,
+) {
+	req, ok := in.Request.(*smithyhttp.Request)
+	if !ok {
+		return out, metadata, fmt.Errorf("unknown request type %T", in.Request)
+	}
+	endpointURL, ok := middleware.GetStackValue(ctx, presignPostEndpointPathKey{}).(url.URL)
+	if !ok {
+		return out, metadata, fmt.Errorf("presign POST endpoint path not found")
+	}
+
+	req.URL.Path = strings.TrimSuffix(endpointURL.Path, "/") + "/" + m.bucket + "/"
+	req.URL.RawPath = strings.TrimSuffix(endpointURL.EscapedPath(), "/") + "/" +
+		httpbinding.EscapePath(m.bucket, true) + "/"
+
+	return next.HandleSerialize(ctx, in)
+}
+
+type postPolicyDocument struct {
+	Expiration string                `json:"expiration"`
+	Conditions []PostPolicyCondition `json:"conditions"`
+}
@@ -206,6 +206,22 @@ type CompletedMultipartUpload struct {
 // Details of the parts that were uploaded.
 type CompletedPart struct {
 
+	// The base64-encoded CRC32 checksum of the part. Required if the multipart upload
+	// was created with the CRC32 checksum algorithm.
+	ChecksumCRC32 *string
+
+	// The base64-encoded CRC32C checksum of the part. Required if the multipart
+	// upload was created with the CRC32C checksum algorithm.
+	ChecksumCRC32C *string
+
+	// The base64-encoded SHA1 checksum of the part. Required if the multipart upload
+	// was created with the SHA1 checksum algorithm.
+	ChecksumSHA1 *string
+
+	// The base64-encoded SHA256 checksum of the part. Required if the multipart
+	// upload was created with the SHA256 checksum algorithm.
+	ChecksumSHA256 *string
+
 	// Entity tag returned when the part was uploaded.
 	ETag *string
 
@@ -2683,6 +2699,14 @@ type RequestPaymentConfiguration struct {
 	Payer Payer
 }
 
+// Container for specifying if periodic QueryProgress messages should be sent.
+type RequestProgress struct {
+
+	// Specifies whether periodic QueryProgress frames should be sent. Valid values:
+	// TRUE, FALSE. Default value: FALSE.
+	Enabled bool
+}
+
 // Container for restore job parameters.
 type RestoreRequest struct {
 
@@ -2772,6 +2796,26 @@ type S3Location struct {
 	UserMetadata []MetadataEntry
 }
 
+// Specifies the byte range of the object to get the records from. A record is
+// processed when its first byte is contained by the range. This parameter is
+// optional, but when specified, it must not be empty. See RFC 2616, Section
+// 14.35.1 about how to specify the start and end of the range.
+type ScanRange struct {
+
+	// Specifies the end of the byte range. This parameter is optional. Valid values:
+	// non-negative integers. The default value is one less than the size of the
+	// object being queried. If only the End parameter is supplied, it is interpreted
+	// to mean scan the last N bytes of the file. For example,
+	// 50 means scan the last 50 bytes.
+	End int64
+
+	// Specifies the start of the byte range. This parameter is optional. Valid
+	// values: non-negative integers. The default value is 0. If only start is
+	// supplied, it means scan from that point to the end of the file.For example;
+	// 50 means scan from byte 50 until the end of the file.
+	Start int64
+}
+
 // Describes the parameters for Select job types.
 type SelectParameters struct {
 
@@ -3079,3 +3123,108 @@ func (*UnknownUnionMember) isAnalyticsFilter()       {}
 func (*UnknownUnionMember) isLifecycleRuleFilter()   {}
 func (*UnknownUnionMember) isMetricsFilter()         {}
 func (*UnknownUnionMember) isReplicationRuleFilter() {}
+
+// The container for selecting objects from a content event stream.
+//
+// The following types satisfy this interface:
+//  SelectObjectContentEventStreamMemberRecords
+//  SelectObjectContentEventStreamMemberStats
+//  SelectObjectContentEventStreamMemberProgress
+//  SelectObjectContentEventStreamMemberCont
+//  SelectObjectContentEventStreamMemberEnd
+type SelectObjectContentEventStream interface {
+	isSelectObjectContentEventStream()
+}
+
+// The Records Event.
+type SelectObjectContentEventStreamMemberRecords struct {
+	Value RecordsEvent
+}
+
+func (*SelectObjectContentEventStreamMemberRecords) isSelectObjectContentEventStream() {}
+
+// The Stats Event.
+type SelectObjectContentEventStreamMemberStats struct {
+	Value StatsEvent
+}
+
+func (*SelectObjectContentEventStreamMemberStats) isSelectObjectContentEventStream() {}
+
+// The Progress Event.
+type SelectObjectContentEventStreamMemberProgress struct {
+	Value ProgressEvent
+}
+
+func (*SelectObjectContentEventStreamMemberProgress) isSelectObjectContentEventStream() {}
+
+// The Continuation Event.
+type SelectObjectContentEventStreamMemberCont struct {
+	Value ContinuationEvent
+}
+
+func (*SelectObjectContentEventStreamMemberCont) isSelectObjectContentEventStream() {}
+
+// The End Event.
+type SelectObjectContentEventStreamMemberEnd struct {
+	Value EndEvent
+}
+
+func (*SelectObjectContentEventStreamMemberEnd) isSelectObjectContentEventStream() {}
+
+func (*UnknownUnionMember) isSelectObjectContentEventStream() {}
+
+type ContinuationEvent struct {
+}
+
+// A message that indicates the request is complete and no more messages will be
+// sent. You should not assume that the request is complete until the client
+// receives an EndEvent.
+type EndEvent struct {
+}
+
+// This data type contains information about progress of an operation.
+type Progress struct {
+
+	// The current number of uncompressed object bytes processed.
+	BytesProcessed int64
+
+	// The current number of bytes of records payload data returned.
+	BytesReturned int64
+
+	// The current number of object bytes scanned.
+	BytesScanned int64
+}
+
+// This data type contains information about the progress event of an operation.
+type ProgressEvent struct {
+
+	// The Progress event details.
+	Details *Progress
+}
+
+// The container for the records event.
+type RecordsEvent struct {
+
+	// The byte array of partial, one or more result records.
+	Payload []byte
+}
+
+// Container for the stats details.
+type Stats struct {
+
+	// The total number of uncompressed object bytes processed.
+	BytesProcessed int64
+
+	// The total number of bytes of records payload data returned.
+	BytesReturned int64
+
+	// The total number of object bytes scanned.
+	BytesScanned int64
+}
+
+// Container for the Stats Event.
+type StatsEvent struct {
+
+	// The Stats event details.
+	Details *Stats
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws/retry"
 	"github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	sqscust "github.com/aws/aws-sdk-go-v2/service/sqs/internal/customizations"
 	smithy "github.com/aws/smithy-go"
 	"github.com/aws/smithy-go/logging"
 	"github.com/aws/smithy-go/middleware"
@@ -65,6 +66,10 @@ type Options struct {
 	// The credentials object to use when signing requests.
 	Credentials aws.CredentialsProvider
 
+	// Allows you to disable the client's validation of the MD5 checksums of the
+	// body and attributes of messages sent, and received. Enabled by default.
+	DisableMessageChecksumValidation bool
+
 	// The endpoint options to be used when attempting to resolve an endpoint.
 	EndpointOptions EndpointResolverOptions
 
@@ -249,6 +254,19 @@ func addResponseErrorMiddleware(stack *middleware.Stack) error {
 	return awshttp.AddResponseErrorMiddleware(stack)
 }
 
+// MessageChecksumError is returned by SendMessage, SendMessageBatch, and
+// ReceiveMessage when the MD5 checksums of the messages sent, or received, do
+// not match the checksums returned by the service. Lists the IDs of the
+// messages whose checksums did not match.
+type MessageChecksumError = sqscust.MessageChecksumError
+
+func addValidateMessageChecksum(stack *middleware.Stack, options Options, getMessages func(input, output interface{}) []sqscust.Message) error {
+	return sqscust.AddValidateMessageChecksum(stack, sqscust.AddValidateMessageChecksumOptions{
+		GetMessages: getMessages,
+		Disable:     options.DisableMessageChecksumValidation,
+	})
+}
+
 func addRequestResponseLogging(stack *middleware.Stack, o Options) error {
 	return stack.Deserialize.Add(&smithyhttp.RequestResponseLogger{
 		LogRequest:          o.ClientLogMode.IsRequest(),
@@ -6,6 +6,7 @@ import (
 	"context"
 	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
 	"github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	sqscust "github.com/aws/aws-sdk-go-v2/service/sqs/internal/customizations"
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
 	"github.com/aws/smithy-go/middleware"
 	smithyhttp "github.com/aws/smithy-go/transport/http"
@@ -291,6 +292,9 @@ func addOperationReceiveMessageMiddlewares(stack *middleware.Stack, options Opti
 	if err = addResponseErrorMiddleware(stack); err != nil {
 		return err
 	}
+	if err = addValidateMessageChecksum(stack, options, getReceiveMessageChecksumMessages); err != nil {
+		return err
+	}
 	if err = addRequestResponseLogging(stack, options); err != nil {
 		return err
 	}
@@ -305,3 +309,21 @@ func newServiceMetadataMiddleware_opReceiveMessage(region string) *awsmiddleware
 		OperationName: "ReceiveMessage",
 	}
 }
+
+// getReceiveMessageChecksumMessages returns the messages received, and the
+// checksums of the messages returned by the service.
+func getReceiveMessageChecksumMessages(input, output interface{}) []sqscust.Message {
+	out := output.(*ReceiveMessageOutput)
+
+	messages := make([]sqscust.Message, 0, len(out.Messages))
+	for _, msg := range out.Messages {
+		messages = append(messages, sqscust.Message{
+			MessageID:       msg.MessageId,
+			Body:            msg.Body,
+			MD5OfBody:       msg.MD5OfBody,
+			Attributes:      msg.MessageAttributes,
+			MD5OfAttributes: msg.MD5OfMessageAttributes,
+		})
+	}
+	return messages
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
 	"github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	sqscust "github.com/aws/aws-sdk-go-v2/service/sqs/internal/customizations"
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
 	"github.com/aws/smithy-go/middleware"
 	smithyhttp "github.com/aws/smithy-go/transport/http"
@@ -247,6 +248,9 @@ func addOperationSendMessageMiddlewares(stack *middleware.Stack, options Options
 	if err = addResponseErrorMiddleware(stack); err != nil {
 		return err
 	}
+	if err = addValidateMessageChecksum(stack, options, getSendMessageChecksumMessages); err != nil {
+		return err
+	}
 	if err = addRequestResponseLogging(stack, options); err != nil {
 		return err
 	}
@@ -261,3 +265,17 @@ func newServiceMetadataMiddleware_opSendMessage(region string) *awsmiddleware.Re
 		OperationName: "SendMessage",
 	}
 }
+
+// getSendMessageChecksumMessages returns the message sent, and the checksums
+// of the message returned by the service.
+func getSendMessageChecksumMessages(input, output interface{}) []sqscust.Message {
+	in := input.(*SendMessageInput)
+	out := output.(*SendMessageOutput)
+	return []sqscust.Message{{
+		MessageID:       out.MessageId,
+		Body:            in.MessageBody,
+		MD5OfBody:       out.MD5OfMessageBody,
+		Attributes:      in.MessageAttributes,
+		MD5OfAttributes: out.MD5OfMessageAttributes,
+	}}
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
 	"github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	sqscust "github.com/aws/aws-sdk-go-v2/service/sqs/internal/customizations"
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
 	"github.com/aws/smithy-go/middleware"
 	smithyhttp "github.com/aws/smithy-go/transport/http"
@@ -137,6 +138,9 @@ func addOperationSendMessageBatchMiddlewares(stack *middleware.Stack, options Op
 	if err = addResponseErrorMiddleware(stack); err != nil {
 		return err
 	}
+	if err = addValidateMessageChecksum(stack, options, getSendMessageBatchChecksumMessages); err != nil {
+		return err
+	}
 	if err = addRequestResponseLogging(stack, options); err != nil {
 		return err
 	}
@@ -151,3 +155,36 @@ func newServiceMetadataMiddleware_opSendMessageBatch(region string) *awsmiddlewa
 		OperationName: "SendMessageBatch",
 	}
 }
+
+// getSendMessageBatchChecksumMessages returns the messages sent successfully,
+// and the checksums of the messages returned by the service.
+func getSendMessageBatchChecksumMessages(input, output interface{}) []sqscust.Message {
+	in := input.(*SendMessageBatchInput)
+	out := output.(*SendMessageBatchOutput)
+
+	entries := make(map[string]types.SendMessageBatchRequestEntry, len(in.Entries))
+	for _, entry := range in.Entries {
+		if entry.Id != nil {
+			entries[*entry.Id] = entry
+		}
+	}
+
+	var messages []sqscust.Message
+	for _, result := range out.Successful {
+		if result.Id == nil {
+			continue
+		}
+		entry, ok := entries[*result.Id]
+		if !ok {
+			continue
+		}
+		messages = append(messages, sqscust.Message{
+			MessageID:       result.MessageId,
+			Body:            entry.MessageBody,
+			MD5OfBody:       result.MD5OfMessageBody,
+			Attributes:      entry.MessageAttributes,
+			MD5OfAttributes: result.MD5OfMessageAttributes,
+		})
+	}
+	return messages
+}
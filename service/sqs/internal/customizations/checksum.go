@@ -0,0 +1,200 @@
+package customizations
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// Transport type of the values of message attributes in their canonical
+// binary encoding.
+const (
+	stringTransportType     byte = 1
+	binaryTransportType     byte = 2
+	stringListTransportType byte = 3
+	binaryListTransportType byte = 4
+)
+
+// Message is a message sent, or received, and the MD5 digests of the
+// message returned by the service.
+type Message struct {
+	// The ID of the message assigned by the service.
+	MessageID *string
+
+	Body      *string
+	MD5OfBody *string
+
+	Attributes      map[string]types.MessageAttributeValue
+	MD5OfAttributes *string
+}
+
+// MessageChecksumError is returned when the MD5 digests of messages sent, or
+// received, do not match the digests returned by the service.
+type MessageChecksumError struct {
+	// The IDs of the messages whose checksums did not match.
+	MessageIDs []string
+}
+
+// Error returns the error message.
+func (e *MessageChecksumError) Error() string {
+	return fmt.Sprintf("message checksum mismatch, message IDs: %s",
+		strings.Join(e.MessageIDs, ", "))
+}
+
+// AddValidateMessageChecksumOptions provides the options for the
+// AddValidateMessageChecksum middleware setup.
+type AddValidateMessageChecksumOptions struct {
+	// Returns the messages of the operation's input and output to validate
+	// the checksums of.
+	GetMessages func(input, output interface{}) []Message
+
+	Disable bool
+}
+
+// AddValidateMessageChecksum adds the ValidateMessageChecksum to the
+// middleware stack if checksum validation is not disabled.
+func AddValidateMessageChecksum(stack *middleware.Stack, options AddValidateMessageChecksumOptions) error {
+	if options.Disable {
+		return nil
+	}
+
+	return stack.Initialize.Add(&ValidateMessageChecksum{
+		getMessages: options.GetMessages,
+	}, middleware.After)
+}
+
+// ValidateMessageChecksum provides a middleware to validate the integrity of
+// the messages sent, or received, by comparing the MD5 digests of the
+// messages with the digests returned by the service.
+type ValidateMessageChecksum struct {
+	getMessages func(input, output interface{}) []Message
+}
+
+// ID returns the middleware ID.
+func (*ValidateMessageChecksum) ID() string { return "SQS:ValidateMessageChecksum" }
+
+// HandleInitialize implements the Initialize middleware handle method.
+func (m *ValidateMessageChecksum) HandleInitialize(
+	ctx context.Context, input middleware.InitializeInput, next middleware.InitializeHandler,
+) (
+	output middleware.InitializeOutput, metadata middleware.Metadata, err error,
+) {
+	output, metadata, err = next.HandleInitialize(ctx, input)
+	if err != nil {
+		return output, metadata, err
+	}
+
+	err = ValidateMessageChecksums(m.getMessages(input.Parameters, output.Result))
+	return output, metadata, err
+}
+
+// ValidateMessageChecksums validates the MD5 digests of the messages' body
+// and attributes match the digests returned by the service. Digests not
+// returned by the service are not validated.
+//
+// Returns a MessageChecksumError listing the IDs of the messages whose
+// checksums did not match.
+func ValidateMessageChecksums(messages []Message) error {
+	var mismatched []string
+	for _, msg := range messages {
+		if !messageChecksumsMatch(msg) {
+			var id string
+			if msg.MessageID != nil {
+				id = *msg.MessageID
+			}
+			mismatched = append(mismatched, id)
+		}
+	}
+
+	if len(mismatched) != 0 {
+		return &MessageChecksumError{MessageIDs: mismatched}
+	}
+	return nil
+}
+
+func messageChecksumsMatch(msg Message) bool {
+	if msg.MD5OfBody != nil {
+		var body string
+		if msg.Body != nil {
+			body = *msg.Body
+		}
+		if !strings.EqualFold(*msg.MD5OfBody, md5OfBody(body)) {
+			return false
+		}
+	}
+
+	if msg.MD5OfAttributes != nil && len(msg.Attributes) != 0 {
+		if !strings.EqualFold(*msg.MD5OfAttributes, md5OfAttributes(msg.Attributes)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// md5OfBody returns the hex encoded MD5 digest of the message body.
+func md5OfBody(body string) string {
+	sum := md5.Sum([]byte(body))
+	return hex.EncodeToString(sum[:])
+}
+
+// md5OfAttributes returns the hex encoded MD5 digest of the message
+// attributes in their canonical binary encoding. The attributes are encoded
+// sorted by name, each as its name, data type, transport type, and value.
+// Names, data types, and values are encoded prefixed by their length as a
+// 4 byte big-endian integer.
+func md5OfAttributes(attributes map[string]types.MessageAttributeValue) string {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	h := md5.New()
+	for _, name := range names {
+		value := attributes[name]
+
+		writeLengthPrefixed(h, []byte(name))
+		var dataType string
+		if value.DataType != nil {
+			dataType = *value.DataType
+		}
+		writeLengthPrefixed(h, []byte(dataType))
+
+		switch {
+		case value.StringValue != nil:
+			h.Write([]byte{stringTransportType})
+			writeLengthPrefixed(h, []byte(*value.StringValue))
+		case value.BinaryValue != nil:
+			h.Write([]byte{binaryTransportType})
+			writeLengthPrefixed(h, value.BinaryValue)
+		case len(value.StringListValues) != 0:
+			h.Write([]byte{stringListTransportType})
+			for _, v := range value.StringListValues {
+				writeLengthPrefixed(h, []byte(v))
+			}
+		case len(value.BinaryListValues) != 0:
+			h.Write([]byte{binaryListTransportType})
+			for _, v := range value.BinaryListValues {
+				writeLengthPrefixed(h, v)
+			}
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func writeLengthPrefixed(h hash.Hash, v []byte) {
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(v)))
+	h.Write(length[:])
+	h.Write(v)
+}
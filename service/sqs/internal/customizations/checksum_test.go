@@ -0,0 +1,117 @@
+package customizations
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+func TestMD5OfAttributes(t *testing.T) {
+	cases := map[string]struct {
+		attributes map[string]types.MessageAttributeValue
+		expect     string
+	}{
+		"string number and binary": {
+			attributes: map[string]types.MessageAttributeValue{
+				"bin":   {DataType: aws.String("Binary"), BinaryValue: []byte{1, 2, 3}},
+				"attr2": {DataType: aws.String("Number"), StringValue: aws.String("123")},
+				"attr1": {DataType: aws.String("String"), StringValue: aws.String("value1")},
+			},
+			expect: "1224494623e2aecdb9c12451a03b00b7",
+		},
+		"string list": {
+			attributes: map[string]types.MessageAttributeValue{
+				"list": {DataType: aws.String("String"), StringListValues: []string{"a", "b"}},
+			},
+			expect: "11737c5b30053652ce9430fa5667cb84",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if e, a := c.expect, md5OfAttributes(c.attributes); e != a {
+				t.Errorf("expect %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestValidateMessageChecksums(t *testing.T) {
+	attributes := map[string]types.MessageAttributeValue{
+		"bin":   {DataType: aws.String("Binary"), BinaryValue: []byte{1, 2, 3}},
+		"attr2": {DataType: aws.String("Number"), StringValue: aws.String("123")},
+		"attr1": {DataType: aws.String("String"), StringValue: aws.String("value1")},
+	}
+
+	cases := map[string]struct {
+		messages  []Message
+		expectIDs []string
+	}{
+		"no messages": {},
+		"match": {
+			messages: []Message{
+				{
+					MessageID:       aws.String("id1"),
+					Body:            aws.String("hello world"),
+					MD5OfBody:       aws.String("5eb63bbbe01eeed093cb22bb8f5acdc3"),
+					Attributes:      attributes,
+					MD5OfAttributes: aws.String("1224494623E2AECDB9C12451A03B00B7"),
+				},
+			},
+		},
+		"no checksums": {
+			messages: []Message{
+				{
+					MessageID:  aws.String("id1"),
+					Body:       aws.String("hello world"),
+					Attributes: attributes,
+				},
+			},
+		},
+		"mismatch": {
+			messages: []Message{
+				{
+					MessageID: aws.String("id1"),
+					Body:      aws.String("hello world"),
+					MD5OfBody: aws.String("5eb63bbbe01eeed093cb22bb8f5acdc3"),
+				},
+				{
+					MessageID: aws.String("id2"),
+					Body:      aws.String("hello"),
+					MD5OfBody: aws.String("5eb63bbbe01eeed093cb22bb8f5acdc3"),
+				},
+				{
+					MessageID:       aws.String("id3"),
+					Body:            aws.String("hello world"),
+					MD5OfBody:       aws.String("5eb63bbbe01eeed093cb22bb8f5acdc3"),
+					Attributes:      attributes,
+					MD5OfAttributes: aws.String("11737c5b30053652ce9430fa5667cb84"),
+				},
+			},
+			expectIDs: []string{"id2", "id3"},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateMessageChecksums(c.messages)
+			if len(c.expectIDs) == 0 {
+				if err != nil {
+					t.Fatalf("expect no error, got %v", err)
+				}
+				return
+			}
+
+			var checksumErr *MessageChecksumError
+			if !errors.As(err, &checksumErr) {
+				t.Fatalf("expect MessageChecksumError, got %v", err)
+			}
+			if e, a := c.expectIDs, checksumErr.MessageIDs; !reflect.DeepEqual(e, a) {
+				t.Errorf("expect %v message IDs, got %v", e, a)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+/*
+Package customizations provides customizations for the Amazon SQS API client.
+
+The SQS API client uses one customization, message checksum validation.
+
+Message checksum validation
+
+The SendMessage, SendMessageBatch, and ReceiveMessage responses include the MD5
+digest of each message's body, and of the message's attributes if the message
+has any. The SDK computes the MD5 digest of the message body sent, or received,
+and of the message attributes encoded in the canonical binary encoding of SQS
+message attributes, and validates that the digests match the ones returned by
+the service.
+
+If any of the digests do not match, the operation returns a
+MessageChecksumError listing the IDs of the messages whose checksums did not
+match.
+
+Customization option:
+    DisableMessageChecksumValidation (Enabled by Default)
+
+*/
+package customizations
@@ -0,0 +1,188 @@
+package customizations_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/internal/awstesting/unit"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+const (
+	helloWorldMD5 = "5eb63bbbe01eeed093cb22bb8f5acdc3"
+	attributesMD5 = "3bc3f392bdd1097ba0b434f65d468d2e"
+)
+
+func newTestClient(response string, optFns ...func(*sqs.Options)) (*sqs.Client, func()) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/xml")
+			w.Write([]byte(response))
+		}))
+
+	client := sqs.New(sqs.Options{
+		Region:      "us-west-2",
+		Credentials: unit.StubCredentialsProvider{},
+		EndpointResolver: sqs.EndpointResolverFunc(func(region string, options sqs.EndpointResolverOptions) (aws.Endpoint, error) {
+			return aws.Endpoint{URL: server.URL, SigningName: "sqs"}, nil
+		}),
+		Retryer: aws.NopRetryer{},
+	}, optFns...)
+
+	return client, server.Close
+}
+
+func TestSendMessage_ChecksumValidation(t *testing.T) {
+	cases := map[string]struct {
+		md5OfBody string
+		disable   bool
+		expectIDs []string
+	}{
+		"match": {
+			md5OfBody: helloWorldMD5,
+		},
+		"mismatch": {
+			md5OfBody: "00000000000000000000000000000000",
+			expectIDs: []string{"message-id"},
+		},
+		"mismatch disabled": {
+			md5OfBody: "00000000000000000000000000000000",
+			disable:   true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			client, closeFn := newTestClient(`<SendMessageResponse><SendMessageResult>
+	<MD5OfMessageBody>`+c.md5OfBody+`</MD5OfMessageBody>
+	<MessageId>message-id</MessageId>
+</SendMessageResult></SendMessageResponse>`, func(o *sqs.Options) {
+				o.DisableMessageChecksumValidation = c.disable
+			})
+			defer closeFn()
+
+			ctx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancelFn()
+
+			_, err := client.SendMessage(ctx, &sqs.SendMessageInput{
+				QueueUrl:    aws.String("https://sqs.us-west-2.amazonaws.com/123456789012/queue"),
+				MessageBody: aws.String("hello world"),
+			})
+			assertMessageChecksumError(t, c.expectIDs, err)
+		})
+	}
+}
+
+func TestSendMessageBatch_ChecksumValidation(t *testing.T) {
+	client, closeFn := newTestClient(`<SendMessageBatchResponse><SendMessageBatchResult>
+	<SendMessageBatchResultEntry>
+		<Id>1</Id>
+		<MessageId>message-id-1</MessageId>
+		<MD5OfMessageBody>`+helloWorldMD5+`</MD5OfMessageBody>
+	</SendMessageBatchResultEntry>
+	<SendMessageBatchResultEntry>
+		<Id>2</Id>
+		<MessageId>message-id-2</MessageId>
+		<MD5OfMessageBody>`+helloWorldMD5+`</MD5OfMessageBody>
+	</SendMessageBatchResultEntry>
+	<BatchResultErrorEntry>
+		<Id>3</Id>
+		<Code>InternalError</Code>
+		<SenderFault>false</SenderFault>
+	</BatchResultErrorEntry>
+</SendMessageBatchResult></SendMessageBatchResponse>`)
+	defer closeFn()
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFn()
+
+	_, err := client.SendMessageBatch(ctx, &sqs.SendMessageBatchInput{
+		QueueUrl: aws.String("https://sqs.us-west-2.amazonaws.com/123456789012/queue"),
+		Entries: []types.SendMessageBatchRequestEntry{
+			{Id: aws.String("1"), MessageBody: aws.String("hello world")},
+			{Id: aws.String("2"), MessageBody: aws.String("hello")},
+			{Id: aws.String("3"), MessageBody: aws.String("failed")},
+		},
+	})
+	assertMessageChecksumError(t, []string{"message-id-2"}, err)
+}
+
+func TestReceiveMessage_ChecksumValidation(t *testing.T) {
+	cases := map[string]struct {
+		md5OfAttributes string
+		expectIDs       []string
+	}{
+		"match": {
+			md5OfAttributes: attributesMD5,
+		},
+		"mismatch": {
+			md5OfAttributes: "00000000000000000000000000000000",
+			expectIDs:       []string{"message-id-2"},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			client, closeFn := newTestClient(`<ReceiveMessageResponse><ReceiveMessageResult>
+	<Message>
+		<MessageId>message-id-1</MessageId>
+		<ReceiptHandle>handle-1</ReceiptHandle>
+		<MD5OfBody>`+helloWorldMD5+`</MD5OfBody>
+		<Body>hello world</Body>
+	</Message>
+	<Message>
+		<MessageId>message-id-2</MessageId>
+		<ReceiptHandle>handle-2</ReceiptHandle>
+		<MD5OfBody>`+helloWorldMD5+`</MD5OfBody>
+		<Body>hello world</Body>
+		<MessageAttribute>
+			<Name>attr1</Name>
+			<Value><StringValue>value1</StringValue><DataType>String</DataType></Value>
+		</MessageAttribute>
+		<MD5OfMessageAttributes>`+c.md5OfAttributes+`</MD5OfMessageAttributes>
+	</Message>
+</ReceiveMessageResult></ReceiveMessageResponse>`)
+			defer closeFn()
+
+			ctx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancelFn()
+
+			resp, err := client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+				QueueUrl:              aws.String("https://sqs.us-west-2.amazonaws.com/123456789012/queue"),
+				MessageAttributeNames: []string{"All"},
+			})
+			assertMessageChecksumError(t, c.expectIDs, err)
+			if err == nil {
+				if e, a := 2, len(resp.Messages); e != a {
+					t.Errorf("expect %v messages, got %v", e, a)
+				}
+			}
+		})
+	}
+}
+
+func assertMessageChecksumError(t *testing.T, expectIDs []string, err error) {
+	t.Helper()
+
+	if len(expectIDs) == 0 {
+		if err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		return
+	}
+
+	var checksumErr *sqs.MessageChecksumError
+	if !errors.As(err, &checksumErr) {
+		t.Fatalf("expect MessageChecksumError, got %v", err)
+	}
+	if e, a := expectIDs, checksumErr.MessageIDs; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v message IDs, got %v", e, a)
+	}
+}
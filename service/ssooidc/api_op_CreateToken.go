@@ -41,13 +41,6 @@ type CreateTokenInput struct {
 	// This member is required.
 	ClientSecret *string
 
-	// Used only when calling this API for the device code grant type. This short-term
-	// code is used to identify this authentication attempt. This should come from an
-	// in-memory reference to the result of the StartDeviceAuthorization API.
-	//
-	// This member is required.
-	DeviceCode *string
-
 	// Supports grant types for authorization code, refresh token, and device code
 	// request.
 	//
@@ -58,6 +51,11 @@ type CreateTokenInput struct {
 	// is required to perform an authorization grant request to get access to a token.
 	Code *string
 
+	// Used only when calling this API for the device code grant type. This short-term
+	// code is used to identify this authentication attempt. This should come from an
+	// in-memory reference to the result of the StartDeviceAuthorization API.
+	DeviceCode *string
+
 	// The location of the application that will receive the authorization code. Users
 	// authorize the service to send the request to this location.
 	RedirectUri *string